@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	activityv1 "github.com/mandacode-com/accounts-proto/go/auth/activity/v1"
+	invitev1 "github.com/mandacode-com/accounts-proto/go/auth/invite/v1"
+	providertokenv1 "github.com/mandacode-com/accounts-proto/go/auth/providertoken/v1"
+	sessionv1 "github.com/mandacode-com/accounts-proto/go/auth/session/v1"
+	"github.com/mandacode-com/golib/server"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	grpcmiddleware "mandacode.com/accounts/auth/internal/middleware/grpc"
+)
+
+type GRPCServer struct {
+	server               *grpc.Server
+	providerTokenHandler providertokenv1.ProviderTokenServiceServer
+	sessionHandler       sessionv1.SessionServiceServer
+	activityHandler      activityv1.AuthActivityServiceServer
+	inviteHandler        invitev1.InviteServiceServer
+	logger               *zap.Logger
+	port                 int
+}
+
+// NewGRPCServer creates the gRPC server for internal services, accepting
+// calls from the clients whose secrets are given by client name.
+func NewGRPCServer(port int, logger *zap.Logger, providerTokenHandler providertokenv1.ProviderTokenServiceServer, sessionHandler sessionv1.SessionServiceServer, activityHandler activityv1.AuthActivityServiceServer, inviteHandler invitev1.InviteServiceServer, clients map[string]string) (server.Server, error) {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcmiddleware.ErrorHandlerInterceptor(logger),
+			grpcmiddleware.Authenticate(clients),
+		),
+	)
+
+	// Register health check service
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	// Register the provider token handler
+	providertokenv1.RegisterProviderTokenServiceServer(server, providerTokenHandler)
+
+	// Register the session handler
+	sessionv1.RegisterSessionServiceServer(server, sessionHandler)
+
+	// Register the activity handler
+	activityv1.RegisterAuthActivityServiceServer(server, activityHandler)
+
+	// Register the invite handler
+	invitev1.RegisterInviteServiceServer(server, inviteHandler)
+
+	return &GRPCServer{
+		server:               server,
+		providerTokenHandler: providerTokenHandler,
+		sessionHandler:       sessionHandler,
+		activityHandler:      activityHandler,
+		inviteHandler:        inviteHandler,
+		logger:               logger,
+		port:                 port,
+	}, nil
+}
+
+func (g *GRPCServer) Start(ctx context.Context) error {
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(g.port))
+	if err != nil {
+		g.logger.Error("failed to listen on port", zap.Int("port", g.port), zap.Error(err))
+		return err
+	}
+
+	g.logger.Info("gRPC server is running", zap.String("address", ":"+strconv.Itoa(g.port)))
+	return g.server.Serve(lis)
+}
+
+func (g *GRPCServer) Stop(ctx context.Context) error {
+	g.logger.Info("stopping gRPC server")
+	g.server.GracefulStop()
+	g.logger.Info("gRPC server stopped gracefully")
+	return nil
+}
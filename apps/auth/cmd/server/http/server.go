@@ -19,6 +19,11 @@ type Server struct {
 	logger           *zap.Logger
 	localAuthHandler *httphandlerv1.LocalAuthHandler
 	oauthHandler     *httphandlerv1.OAuthHandler
+	accountHandler   *httphandlerv1.AccountHandler
+	passkeyHandler   *httphandlerv1.PasskeyHandler
+	sessionHandler   *httphandlerv1.SessionHandler
+	activityHandler  *httphandlerv1.ActivityHandler
+	authenticate     gin.HandlerFunc
 	port             int
 	sessionStore     sessions.Store
 }
@@ -28,6 +33,7 @@ func (s *Server) Start(ctx context.Context) error {
 	s.engine.Use(gin.Recovery())
 	s.engine.Use(sessions.Sessions("session", s.sessionStore))
 	s.engine.Use(httpmiddleware.ErrorHandler(s.logger))
+	s.engine.Use(httpmiddleware.RequestInfo())
 
 	localAuthGroup := s.engine.Group("/v1/auth/local")
 	s.localAuthHandler.RegisterRoutes(localAuthGroup)
@@ -35,6 +41,18 @@ func (s *Server) Start(ctx context.Context) error {
 	oauthGroup := s.engine.Group("/v1/auth/oauth")
 	s.oauthHandler.RegisterRoutes(oauthGroup)
 
+	passkeyGroup := s.engine.Group("/v1/auth/passkey")
+	s.passkeyHandler.RegisterRoutes(passkeyGroup)
+
+	authGroup := s.engine.Group("/v1/auth")
+	s.sessionHandler.RegisterRoutes(authGroup)
+
+	accountGroup := s.engine.Group("/v1/auth/account", s.authenticate)
+	s.accountHandler.RegisterRoutes(accountGroup)
+	s.passkeyHandler.RegisterAccountRoutes(accountGroup)
+	s.sessionHandler.RegisterAccountRoutes(accountGroup)
+	s.activityHandler.RegisterAccountRoutes(accountGroup)
+
 	s.logger.Info("starting HTTP server", zap.Int("port", s.port))
 	if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		s.logger.Error("failed to start HTTP server", zap.Error(err))
@@ -54,7 +72,18 @@ func (s *Server) Stop(ctx context.Context) error {
 	return nil
 }
 
-func NewServer(port int, logger *zap.Logger, localAuthHandler *httphandlerv1.LocalAuthHandler, oauthHandler *httphandlerv1.OAuthHandler, sessionStore sessions.Store) server.Server {
+func NewServer(
+	port int,
+	logger *zap.Logger,
+	localAuthHandler *httphandlerv1.LocalAuthHandler,
+	oauthHandler *httphandlerv1.OAuthHandler,
+	accountHandler *httphandlerv1.AccountHandler,
+	passkeyHandler *httphandlerv1.PasskeyHandler,
+	sessionHandler *httphandlerv1.SessionHandler,
+	activityHandler *httphandlerv1.ActivityHandler,
+	authenticate gin.HandlerFunc,
+	sessionStore sessions.Store,
+) server.Server {
 	engine := gin.Default()
 	return &Server{
 		http:             &http.Server{Addr: ":" + strconv.Itoa(port), Handler: engine},
@@ -63,6 +92,11 @@ func NewServer(port int, logger *zap.Logger, localAuthHandler *httphandlerv1.Loc
 		port:             port,
 		localAuthHandler: localAuthHandler,
 		oauthHandler:     oauthHandler,
+		accountHandler:   accountHandler,
+		passkeyHandler:   passkeyHandler,
+		sessionHandler:   sessionHandler,
+		activityHandler:  activityHandler,
+		authenticate:     authenticate,
 		sessionStore:     sessionStore,
 	}
 }
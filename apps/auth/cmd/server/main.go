@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 
 	sessionredis "github.com/gin-contrib/sessions/redis"
 	"github.com/go-playground/validator/v10"
@@ -11,23 +14,46 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
+	grpcserver "mandacode.com/accounts/auth/cmd/server/grpc"
 	httpserver "mandacode.com/accounts/auth/cmd/server/http"
 	kafkaserver "mandacode.com/accounts/auth/cmd/server/kafka"
 	"mandacode.com/accounts/auth/config"
-	"mandacode.com/accounts/auth/ent/authaccount"
 
 	_ "mandacode.com/accounts/auth/ent/runtime"
+	grpchandlerv1 "mandacode.com/accounts/auth/internal/handler/v1/grpc"
 	"mandacode.com/accounts/auth/internal/handler/v1/http"
 	kafkahandlerv1 "mandacode.com/accounts/auth/internal/handler/v1/kafka"
+	"mandacode.com/accounts/auth/internal/infra/captcha"
 	dbinfra "mandacode.com/accounts/auth/internal/infra/database"
 	"mandacode.com/accounts/auth/internal/infra/mailer"
 	"mandacode.com/accounts/auth/internal/infra/oauthapi"
 	tokeninfra "mandacode.com/accounts/auth/internal/infra/token"
+	userinfra "mandacode.com/accounts/auth/internal/infra/user"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	httpmiddleware "mandacode.com/accounts/auth/internal/middleware/http"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
 	coderepo "mandacode.com/accounts/auth/internal/repository/code"
 	dbrepository "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	rotationrepo "mandacode.com/accounts/auth/internal/repository/rotation"
 	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	userrepo "mandacode.com/accounts/auth/internal/repository/user"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+	activityusecase "mandacode.com/accounts/auth/internal/usecase/activity"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
+	"mandacode.com/accounts/auth/internal/usecase/emailauth"
 	"mandacode.com/accounts/auth/internal/usecase/localauth"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
 	oauthusecase "mandacode.com/accounts/auth/internal/usecase/oauthauth"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+	"mandacode.com/accounts/auth/internal/usecase/providertoken"
+	sessionusecase "mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
+	tokenusecase "mandacode.com/accounts/auth/internal/usecase/token"
 	"mandacode.com/accounts/auth/internal/usecase/userevent"
 	"mandacode.com/accounts/auth/internal/util"
 )
@@ -57,6 +83,61 @@ func main() {
 		Password: cfg.EmailCodeStore.Password,
 		DB:       cfg.EmailCodeStore.DB,
 	})
+	resetCodeStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.ResetCodeStore.Address,
+		Password: cfg.ResetCodeStore.Password,
+		DB:       cfg.ResetCodeStore.DB,
+	})
+	changeCodeStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.ChangeCodeStore.Address,
+		Password: cfg.ChangeCodeStore.Password,
+		DB:       cfg.ChangeCodeStore.DB,
+	})
+	magicLinkStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.MagicLinkStore.Address,
+		Password: cfg.MagicLinkStore.Password,
+		DB:       cfg.MagicLinkStore.DB,
+	})
+	verifyOTPStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.VerifyOTPStore.Address,
+		Password: cfg.VerifyOTPStore.Password,
+		DB:       cfg.VerifyOTPStore.DB,
+	})
+	loginOTPStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.LoginOTPStore.Address,
+		Password: cfg.LoginOTPStore.Password,
+		DB:       cfg.LoginOTPStore.DB,
+	})
+	mfaChallengeStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.ChallengeStore.Address,
+		Password: cfg.ChallengeStore.Password,
+		DB:       cfg.ChallengeStore.DB,
+	})
+	linkConfirmStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.LinkConfirmStore.Address,
+		Password: cfg.LinkConfirmStore.Password,
+		DB:       cfg.LinkConfirmStore.DB,
+	})
+	oauthStateStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.OAuthStateStore.Address,
+		Password: cfg.OAuthStateStore.Password,
+		DB:       cfg.OAuthStateStore.DB,
+	})
+	webauthnStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.WebauthnStore.Address,
+		Password: cfg.WebauthnStore.Password,
+		DB:       cfg.WebauthnStore.DB,
+	})
+	revocationStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.RevocationStore.Address,
+		Password: cfg.RevocationStore.Password,
+		DB:       cfg.RevocationStore.DB,
+	})
+	rateLimitStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.RateLimitStore.Address,
+		Password: cfg.RateLimitStore.Password,
+		DB:       cfg.RateLimitStore.DB,
+	})
 	sessionStore, err := sessionredis.NewStore(cfg.SessionStore.DB, "tcp", cfg.SessionStore.Address, "", cfg.SessionStore.Password, []byte(cfg.SessionStore.HashKey))
 	if err != nil {
 		logger.Fatal("failed to create session store", zap.Error(err))
@@ -71,6 +152,10 @@ func main() {
 	if err != nil {
 		logger.Fatal("failed to create token client", zap.Error(err))
 	}
+	userClient, _, err := userinfra.NewUserServiceClient(cfg.UserServiceAddr)
+	if err != nil {
+		logger.Fatal("failed to create user service client", zap.Error(err))
+	}
 
 	// Initialize mailer
 	mailWriter := &kafka.Writer{
@@ -79,7 +164,16 @@ func main() {
 		Balancer:               &kafka.Hash{},
 		AllowAutoTopicCreation: true,
 	}
-	mailer := mailer.NewMailer(mailWriter)
+	mailer := mailer.NewMailer(mailWriter, cfg.RevokeSessionURL)
+
+	// Initialize auth event emitter
+	authEventWriter := &kafka.Writer{
+		Addr:                   kafka.TCP(cfg.AuthEventWriter.Address),
+		Topic:                  cfg.AuthEventWriter.Topic,
+		Balancer:               &kafka.Hash{},
+		AllowAutoTopicCreation: true,
+	}
+	authEventEmitter := autheventrepo.NewAuthEventEmitter(authEventWriter)
 
 	userEventReader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers: []string{cfg.UserEventReader.Address},
@@ -88,45 +182,265 @@ func main() {
 	})
 
 	// Initialize OAuth APIs
-	googleApi, err := oauthapi.NewGoogleAPI(cfg.GoogleOAuth.ClientID, cfg.GoogleOAuth.ClientSecret, cfg.GoogleOAuth.RedirectURL, validator)
+	googleApi, err := oauthapi.NewGoogleAPI(cfg.GoogleOAuth.ClientID, cfg.GoogleOAuth.ClientSecret, cfg.GoogleOAuth.RedirectURL, cfg.GoogleOAuth.NativeClientIDs, validator)
 	if err != nil {
 		logger.Fatal("failed to create Google OAuth API", zap.Error(err))
 	}
-	naverApi, err := oauthapi.NewNaverAPI(cfg.NaverOAuth.ClientID, cfg.NaverOAuth.ClientSecret, cfg.NaverOAuth.RedirectURL, validator)
+	naverApi, err := oauthapi.NewNaverAPI(cfg.NaverOAuth.ClientID, cfg.NaverOAuth.ClientSecret, cfg.NaverOAuth.RedirectURL, cfg.NaverOAuth.NativeClientIDs, validator)
 	if err != nil {
 		logger.Fatal("failed to create Naver OAuth API", zap.Error(err))
 	}
-	kakaoApi, err := oauthapi.NewKakaoAPI(cfg.KakaoOAuth.ClientID, cfg.KakaoOAuth.ClientSecret, cfg.KakaoOAuth.RedirectURL, validator)
+	kakaoApi, err := oauthapi.NewKakaoAPI(cfg.KakaoOAuth.ClientID, cfg.KakaoOAuth.ClientSecret, cfg.KakaoOAuth.RedirectURL, cfg.KakaoOAuth.NativeClientIDs, cfg.KakaoOAuth.AppID, validator)
 	if err != nil {
 		logger.Fatal("failed to create Kakao OAuth API", zap.Error(err))
 	}
-	oauthApis := map[authaccount.Provider]oauthapi.OAuthAPI{
-		authaccount.ProviderGoogle: googleApi,
-		authaccount.ProviderNaver:  naverApi,
-		authaccount.ProviderKakao:  kakaoApi,
+	oauthApis := map[providermodels.Provider]oauthapi.OAuthAPI{
+		providermodels.ProviderGoogle: googleApi,
+		providermodels.ProviderNaver:  naverApi,
+		providermodels.ProviderKakao:  kakaoApi,
+	}
+	if cfg.AppleOAuth.ClientID != "" {
+		applePrivateKey, err := os.ReadFile(cfg.AppleOAuth.PrivateKeyPath)
+		if err != nil {
+			logger.Fatal("failed to read Apple private key", zap.Error(err))
+		}
+		appleApi, err := oauthapi.NewAppleAPI(cfg.AppleOAuth.ClientID, cfg.AppleOAuth.TeamID, cfg.AppleOAuth.KeyID, applePrivateKey, cfg.AppleOAuth.RedirectURL, cfg.AppleOAuth.BundleIDs, oauthapi.DefaultAppleEndpoints, validator)
+		if err != nil {
+			logger.Fatal("failed to create Apple OAuth API", zap.Error(err))
+		}
+		oauthApis[providermodels.ProviderApple] = appleApi
+	}
+	for _, oidcCfg := range cfg.OIDCProviders {
+		provider, err := util.ParseProvider(oidcCfg.Name)
+		if err != nil {
+			logger.Fatal("invalid OIDC provider name", zap.String("provider", oidcCfg.Name))
+		}
+		if _, ok := oauthApis[provider]; ok {
+			logger.Fatal("OIDC provider name is already registered", zap.String("provider", oidcCfg.Name))
+		}
+		oidcApi, err := oauthapi.NewOIDCAPI(
+			oidcCfg.Issuer,
+			oidcCfg.ClientID,
+			oidcCfg.ClientSecret,
+			oidcCfg.RedirectURL,
+			oidcCfg.Scopes,
+			oidcCfg.NativeIDs,
+			oauthapi.OIDCClaimPaths{
+				Subject:       oidcCfg.SubjectClaim,
+				Email:         oidcCfg.EmailClaim,
+				EmailVerified: oidcCfg.VerifiedClaim,
+				Name:          oidcCfg.NameClaim,
+			},
+			nil,
+			validator,
+		)
+		if err != nil {
+			logger.Fatal("failed to create OIDC OAuth API", zap.String("provider", oidcCfg.Name), zap.Error(err))
+		}
+		oauthApis[provider] = oidcApi
+	}
+
+	// Initialize provider token storage
+	tokenProviders := []providermodels.Provider{}
+	for _, name := range cfg.ProviderTokens.Providers {
+		provider, err := util.ParseProvider(name)
+		if err != nil {
+			logger.Fatal("invalid provider token provider", zap.String("provider", name))
+		}
+		if _, ok := oauthApis[provider]; !ok {
+			logger.Fatal("provider token provider is not registered", zap.String("provider", name))
+		}
+		tokenProviders = append(tokenProviders, provider)
+	}
+	var providerTokenKeyring *util.Keyring
+	if len(cfg.ProviderTokens.Keys) > 0 {
+		providerTokenKeys := map[string][]byte{}
+		for _, pair := range cfg.ProviderTokens.Keys {
+			keyID, encodedKey, _ := strings.Cut(pair, ":")
+			key, err := base64.StdEncoding.DecodeString(encodedKey)
+			if err != nil {
+				logger.Fatal("failed to decode provider token key", zap.String("key_id", keyID), zap.Error(err))
+			}
+			providerTokenKeys[keyID] = key
+		}
+		providerTokenKeyring, err = util.NewKeyring(cfg.ProviderTokens.CurrentKey, providerTokenKeys)
+		if err != nil {
+			logger.Fatal("failed to create provider token keyring", zap.Error(err))
+		}
+	}
+
+	// Initialize gRPC clients
+	grpcClients := map[string]string{}
+	for _, pair := range cfg.GRPCServer.Clients {
+		name, secret, ok := strings.Cut(pair, ":")
+		if !ok || name == "" || secret == "" {
+			logger.Fatal("gRPC client must be given as <service name>:<secret>", zap.String("client", name))
+		}
+		grpcClients[name] = secret
 	}
 
 	// Initialize random code generators
 	emailCodeGenerator := util.NewRandomGenerator(32)
 	loginCodeGenerator := util.NewRandomGenerator(32)
+	resetCodeGenerator := util.NewRandomGenerator(32)
+	changeCodeGenerator := util.NewRandomGenerator(32)
+	magicLinkGenerator := util.NewRandomGenerator(32)
+	mfaChallengeGenerator := util.NewRandomGenerator(32)
+	linkConfirmGenerator := util.NewRandomGenerator(32)
+	oauthStateGenerator := util.NewRandomGenerator(32)
+	webauthnChallengeGenerator := util.NewRandomGenerator(32)
+	recoveryCodeGenerator := util.NewRandomGenerator(8)
+	inviteCodeGenerator := util.NewRandomGenerator(16)
+
+	// Initialize secret encryption
+	totpKey, err := base64.StdEncoding.DecodeString(cfg.TotpKey)
+	if err != nil {
+		logger.Fatal("failed to decode TOTP key", zap.Error(err))
+	}
+	totpCipher, err := util.NewCipher(totpKey)
+	if err != nil {
+		logger.Fatal("failed to create TOTP cipher", zap.Error(err))
+	}
+
+	// Initialize WebAuthn relying party
+	relyingParty := &webauthn.RelyingParty{
+		ID:                      cfg.WebauthnRPID,
+		Name:                    cfg.WebauthnRPName,
+		Origins:                 cfg.WebauthnOrigins,
+		RequireUserVerification: true,
+		Timeout:                 cfg.WebauthnStore.Timeout,
+	}
+
+	// Initialize password hashing, keeping every algorithm verifiable so
+	// existing hashes are upgraded on login
+	argon2id := util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  cfg.PasswordHash.Argon2Memory,
+		Time:    cfg.PasswordHash.Argon2Time,
+		Threads: cfg.PasswordHash.Argon2Threads,
+		SaltLen: 16,
+		KeyLen:  32,
+	})
+	bcrypt := util.NewBcryptAlgorithm(cfg.PasswordHash.BcryptCost)
+	passwordHasher := util.NewPasswordHasher(argon2id, bcrypt)
+	if cfg.PasswordHash.Algorithm == "bcrypt" {
+		passwordHasher = util.NewPasswordHasher(bcrypt, argon2id)
+	}
+
+	// Initialize password policy
+	var breachedList *passwordpolicy.BreachedList
+	if cfg.BreachedListDir != "" {
+		breachedList, err = passwordpolicy.NewBreachedList(cfg.BreachedListDir)
+		if err != nil {
+			logger.Fatal("failed to load breached password list", zap.Error(err))
+		}
+	}
+	passwordPolicy := passwordpolicy.NewPolicy(passwordpolicy.Rules{
+		MinLength:     cfg.PasswordPolicy.MinLength,
+		RequireLower:  cfg.PasswordPolicy.RequireLower,
+		RequireUpper:  cfg.PasswordPolicy.RequireUpper,
+		RequireDigit:  cfg.PasswordPolicy.RequireDigit,
+		RequireSymbol: cfg.PasswordPolicy.RequireSymbol,
+		MinStrength:   passwordpolicy.Strength(cfg.PasswordPolicy.MinStrength),
+	}, breachedList)
+
+	// Initialize signup policy
+	signupPolicy, err := signuppolicy.NewPolicy(signuppolicy.Rules{
+		Mode:           signuppolicy.Mode(cfg.SignupPolicy.Mode),
+		AllowedDomains: cfg.SignupPolicy.AllowedDomains,
+		DeniedDomains:  cfg.SignupPolicy.DeniedDomains,
+	})
+	if err != nil {
+		logger.Fatal("failed to create signup policy", zap.Error(err))
+	}
 
 	// Initialize repositories
-	authAccountRepo := dbrepository.NewAuthAccountRepository(dbClient)
+	authAccountRepo := dbrepository.NewAuthAccountRepository(dbClient, passwordHasher, logger)
+	totpCredentialRepo := dbrepository.NewTotpCredentialRepository(dbClient, totpCipher)
+	webauthnCredentialRepo := dbrepository.NewWebauthnCredentialRepository(dbClient)
+	recoveryCodeRepo := dbrepository.NewRecoveryCodeRepository(dbClient)
+	providerTokenRepo := dbrepository.NewProviderTokenRepository(dbClient, providerTokenKeyring)
+	sessionRepo := dbrepository.NewSessionRepository(dbClient)
+	authActivityRepo := dbrepository.NewAuthActivityRepository(dbClient)
+	signupInviteRepo := dbrepository.NewSignupInviteRepository(dbClient)
 	tokenRepo := tokenrepo.NewTokenRepository(tokenClient)
+	userServiceRepo := userrepo.NewUserServiceRepository(userClient)
+	revocationRepo := revocationrepo.NewRevocationRepository(revocationStore, cfg.RevocationStore.Prefix, cfg.RevocationStore.Timeout)
+	rotationRepo := rotationrepo.NewRotationRepository(revocationStore, cfg.RevocationStore.Prefix+"rotation:", cfg.RevocationStore.Timeout)
 
 	// Initialize code managers
-	loginCodeManager := coderepo.NewCodeManager(loginCodeGenerator, cfg.LoginCodeStore.Timeout, loginCodeStore, cfg.LoginCodeStore.Prefix)
+	loginCodeManager := coderepo.NewLoginCodeManager(loginCodeGenerator, cfg.LoginCodeStore.Timeout, loginCodeStore, cfg.LoginCodeStore.Prefix)
 	emailCodeManager := coderepo.NewCodeManager(emailCodeGenerator, cfg.EmailCodeStore.Timeout, emailCodeStore, cfg.EmailCodeStore.Prefix)
+	resetCodeManager := coderepo.NewCodeManager(resetCodeGenerator, cfg.ResetCodeStore.Timeout, resetCodeStore, cfg.ResetCodeStore.Prefix)
+	resetCooldown := coderepo.NewCooldown(resetCodeStore, cfg.ResetCodeStore.Prefix+"cooldown:", cfg.ResetCodeStore.HashKey, cfg.MailCooldown)
+	changeCodeManager := coderepo.NewCodeManager(changeCodeGenerator, cfg.ChangeCodeStore.Timeout, changeCodeStore, cfg.ChangeCodeStore.Prefix)
+	magicLinkManager := coderepo.NewCodeManager(magicLinkGenerator, cfg.MagicLinkStore.Timeout, magicLinkStore, cfg.MagicLinkStore.Prefix)
+	magicLinkCooldown := coderepo.NewCooldown(magicLinkStore, cfg.MagicLinkStore.Prefix+"cooldown:", cfg.MagicLinkStore.HashKey, cfg.MailCooldown)
+	verifyOTPManager := coderepo.NewOTPManager(verifyOTPStore, cfg.VerifyOTPStore.Prefix, cfg.VerifyOTPStore.Timeout, cfg.VerifyOTPStore.HashKey, cfg.OTPMaxAttempts, cfg.MailCooldown)
+	loginOTPManager := coderepo.NewOTPManager(loginOTPStore, cfg.LoginOTPStore.Prefix, cfg.LoginOTPStore.Timeout, cfg.LoginOTPStore.HashKey, cfg.OTPMaxAttempts, cfg.MailCooldown)
+	mfaChallengeManager := coderepo.NewCodeManager(mfaChallengeGenerator, cfg.ChallengeStore.Timeout, mfaChallengeStore, cfg.ChallengeStore.Prefix)
+	pendingLinkManager := coderepo.NewPendingLinkManager(linkConfirmGenerator, cfg.LinkConfirmStore.Timeout, linkConfirmStore, cfg.LinkConfirmStore.Prefix)
+	loginAttemptManager := coderepo.NewLoginAttemptManager(oauthStateGenerator, cfg.OAuthStateStore.Timeout, oauthStateStore, cfg.OAuthStateStore.Prefix)
+	webauthnChallengeManager := coderepo.NewCodeManager(webauthnChallengeGenerator, cfg.WebauthnStore.Timeout, webauthnStore, cfg.WebauthnStore.Prefix)
+
+	// Initialize attempt limiters
+	loginLimiter := ratelimitrepo.NewLimiter(rateLimitStore, cfg.RateLimitStore.Prefix+"login:", ratelimitrepo.Policy(cfg.LoginLimit))
+	loginCodeLimiter := ratelimitrepo.NewLimiter(rateLimitStore, cfg.RateLimitStore.Prefix+"login_code:", ratelimitrepo.Policy(cfg.LoginCodeLimit))
+	verifyCodeLimiter := ratelimitrepo.NewLimiter(rateLimitStore, cfg.RateLimitStore.Prefix+"verify_code:", ratelimitrepo.Policy(cfg.VerifyCodeLimit))
+	mfaLimiter := ratelimitrepo.NewLimiter(rateLimitStore, cfg.RateLimitStore.Prefix+"mfa:", ratelimitrepo.Policy(cfg.MFALimit))
+	otpLimiter := ratelimitrepo.NewLimiter(rateLimitStore, cfg.RateLimitStore.Prefix+"otp:", ratelimitrepo.Policy(cfg.OTPLimit))
+
+	// Initialize bot challenge, asked from clients once they look risky
+	var challengeVerifier captcha.ChallengeVerifier
+	captchaHTTPClient := &http.Client{Timeout: cfg.Captcha.Timeout}
+	switch cfg.Captcha.Provider {
+	case captcha.ProviderHCaptcha:
+		challengeVerifier, err = captcha.NewHCaptchaVerifier(captcha.HCaptchaEndpoint, cfg.Captcha.SiteKey, cfg.Captcha.Secret, captchaHTTPClient)
+	case captcha.ProviderTurnstile:
+		challengeVerifier, err = captcha.NewTurnstileVerifier(captcha.TurnstileEndpoint, cfg.Captcha.SiteKey, cfg.Captcha.Secret, captchaHTTPClient)
+	case captcha.ProviderRecaptcha:
+		challengeVerifier, err = captcha.NewRecaptchaVerifier(captcha.RecaptchaEndpoint, cfg.Captcha.SiteKey, cfg.Captcha.Secret, cfg.Captcha.MinScore, captchaHTTPClient)
+	case captcha.ProviderStub:
+		challengeVerifier = captcha.NewStubVerifier(cfg.Captcha.StubToken)
+	}
+	if err != nil {
+		logger.Fatal("failed to create challenge verifier", zap.Error(err))
+	}
+	riskCounter := ratelimitrepo.NewCounter(rateLimitStore, cfg.RateLimitStore.Prefix+"risk:", cfg.Captcha.RiskWindow)
+	challengeGuard := challenge.NewGuard(challengeVerifier, riskCounter, cfg.Captcha.RiskThreshold)
 
 	// Initialize use cases
-	localLoginUsecase := localauth.NewLoginUsecase(authAccountRepo, tokenRepo, loginCodeManager)
-	localSignupUsecase := localauth.NewSignupUsecase(authAccountRepo, tokenRepo, mailer, emailCodeManager, cfg.VerifyEmailURL)
-	oauthLoginUsecase := oauthusecase.NewLoginUsecase(authAccountRepo, tokenRepo, loginCodeManager, oauthApis)
+	securityNotifier := notice.NewSecurityNotifier(authAccountRepo, authActivityRepo, mailer, logger)
+	sessionIssuer := sessionusecase.NewIssuer(tokenRepo, sessionRepo, authActivityRepo, securityNotifier)
+	signupGate := signup.NewGate(signupPolicy, signupInviteRepo)
+	signupInviteUsecase := signup.NewInviteUsecase(signupInviteRepo, inviteCodeGenerator, cfg.SignupPolicy.InviteTTL)
+	passkeyVerifier := passkeyauth.NewAssertionVerifier(webauthnCredentialRepo, webauthnChallengeManager, relyingParty)
+	mfaChallengeUsecase := mfa.NewChallengeUsecase(totpCredentialRepo, webauthnCredentialRepo, recoveryCodeRepo, authEventEmitter, securityNotifier, passkeyVerifier, mfaChallengeManager, mfaLimiter)
+	localLoginUsecase := localauth.NewLoginUsecase(authAccountRepo, sessionIssuer, loginCodeManager, mfaChallengeUsecase, loginLimiter, loginCodeLimiter, verifyCodeLimiter, challengeGuard)
+	magicLinkUsecase := emailauth.NewMagicLinkUsecase(authAccountRepo, tokenRepo, sessionIssuer, mailer, magicLinkManager, magicLinkCooldown, mfaChallengeUsecase, cfg.MagicLinkURL)
+	loginOTPUsecase := emailauth.NewOTPUsecase(authAccountRepo, sessionIssuer, mailer, loginOTPManager, mfaChallengeUsecase, loginLimiter)
+	localSignupUsecase := localauth.NewSignupUsecase(authAccountRepo, userServiceRepo, tokenRepo, authActivityRepo, mailer, emailCodeManager, verifyOTPManager, otpLimiter, passwordPolicy, challengeGuard, signupGate, cfg.VerifyEmailURL)
+	passwordResetUsecase := localauth.NewPasswordResetUsecase(authAccountRepo, tokenRepo, revocationRepo, sessionRepo, mailer, securityNotifier, resetCodeManager, resetCooldown, passwordPolicy, cfg.ResetPasswordURL)
+	passwordChangeUsecase := localauth.NewPasswordChangeUsecase(authAccountRepo, sessionIssuer, revocationRepo, sessionRepo, passwordPolicy, securityNotifier)
+	emailChangeUsecase := localauth.NewEmailChangeUsecase(authAccountRepo, tokenRepo, mailer, securityNotifier, changeCodeManager, signupPolicy, cfg.ChangeEmailURL)
+	totpUsecase := localauth.NewTotpUsecase(authAccountRepo, totpCredentialRepo, securityNotifier, cfg.TotpIssuer)
+	mfaFactorUsecase := mfa.NewFactorUsecase(totpCredentialRepo, webauthnCredentialRepo, recoveryCodeRepo, authEventEmitter, securityNotifier)
+	recoveryCodeUsecase := mfa.NewRecoveryCodeUsecase(totpCredentialRepo, webauthnCredentialRepo, recoveryCodeRepo, authEventEmitter, securityNotifier, recoveryCodeGenerator, logger)
+	passkeyLoginUsecase := passkeyauth.NewLoginUsecase(passkeyVerifier, sessionIssuer, loginCodeManager, verifyCodeLimiter)
+	passkeyRegistrationUsecase := passkeyauth.NewRegistrationUsecase(authAccountRepo, webauthnCredentialRepo, webauthnChallengeManager, securityNotifier, relyingParty)
+	oauthLoginUsecase := oauthusecase.NewLoginUsecase(authAccountRepo, userServiceRepo, sessionIssuer, mfaChallengeUsecase, authEventEmitter, securityNotifier, signupGate, loginCodeManager, pendingLinkManager, loginAttemptManager, loginLimiter, verifyCodeLimiter, providerTokenRepo, tokenProviders, oauthusecase.LinkPolicy(cfg.OAuthLinkPolicy), cfg.OAuthReturnURLs, oauthApis)
+	oauthLinkUsecase := oauthusecase.NewLinkUsecase(authAccountRepo, webauthnCredentialRepo, providerTokenRepo, securityNotifier, tokenProviders, oauthApis)
+	providerTokenFetchUsecase := providertoken.NewFetchUsecase(providerTokenRepo, authEventEmitter, oauthApis)
 
-	userEventUsecase := userevent.NewUserEventUsecase(authAccountRepo)
+	tokenVerifyUsecase := tokenusecase.NewVerifyUsecase(tokenRepo)
+	refreshUsecase := tokenusecase.NewRefreshUsecase(tokenRepo, revocationRepo, rotationRepo, sessionRepo, authActivityRepo, authEventEmitter)
+	logoutUsecase := tokenusecase.NewLogoutUsecase(tokenRepo, revocationRepo, sessionRepo)
+	sessionManageUsecase := sessionusecase.NewManageUsecase(sessionRepo, revocationRepo, tokenRepo)
+	activityHistoryUsecase := activityusecase.NewHistoryUsecase(authActivityRepo)
+
+	userEventUsecase := userevent.NewUserEventUsecase(authAccountRepo, totpCredentialRepo, webauthnCredentialRepo, recoveryCodeRepo, providerTokenRepo, sessionRepo, authActivityRepo)
 
 	// Initialize handlers
-	localAuthHandler, err := httphandlerv1.NewLocalAuthHandler(localLoginUsecase, localSignupUsecase, logger, validator)
+	localAuthHandler, err := httphandlerv1.NewLocalAuthHandler(localLoginUsecase, mfaChallengeUsecase, magicLinkUsecase, loginOTPUsecase, localSignupUsecase, passwordResetUsecase, emailChangeUsecase, challengeGuard, logger, validator)
 	if err != nil {
 		logger.Fatal("failed to create local auth handler", zap.Error(err))
 	}
@@ -134,10 +448,35 @@ func main() {
 	if err != nil {
 		logger.Fatal("failed to create OAuth handler", zap.Error(err))
 	}
+	accountHandler, err := httphandlerv1.NewAccountHandler(passwordChangeUsecase, emailChangeUsecase, totpUsecase, mfaFactorUsecase, recoveryCodeUsecase, oauthLinkUsecase, logger, validator)
+	if err != nil {
+		logger.Fatal("failed to create account handler", zap.Error(err))
+	}
+	passkeyHandler, err := httphandlerv1.NewPasskeyHandler(passkeyLoginUsecase, passkeyRegistrationUsecase, logger, validator)
+	if err != nil {
+		logger.Fatal("failed to create passkey handler", zap.Error(err))
+	}
+	sessionHandler, err := httphandlerv1.NewSessionHandler(refreshUsecase, logoutUsecase, sessionManageUsecase, logger)
+	if err != nil {
+		logger.Fatal("failed to create session handler", zap.Error(err))
+	}
+	activityHandler, err := httphandlerv1.NewActivityHandler(activityHistoryUsecase, logger)
+	if err != nil {
+		logger.Fatal("failed to create activity handler", zap.Error(err))
+	}
 	userEventHandler := kafkahandlerv1.NewUserEventHandler(userEventUsecase)
+	providerTokenHandler := grpchandlerv1.NewProviderTokenHandler(providerTokenFetchUsecase, logger)
+	sessionGRPCHandler := grpchandlerv1.NewSessionHandler(sessionManageUsecase, cfg.GRPCServer.AdminClients, logger)
+	activityGRPCHandler := grpchandlerv1.NewActivityHandler(activityHistoryUsecase, cfg.GRPCServer.AdminClients, logger)
+	inviteGRPCHandler := grpchandlerv1.NewInviteHandler(signupInviteUsecase, cfg.GRPCServer.AdminClients, logger)
 
 	// Initialize servers
-	httpServer := httpserver.NewServer(cfg.Port, logger, localAuthHandler, oauthHandler, sessionStore)
+	authenticate := httpmiddleware.Authenticate(tokenVerifyUsecase)
+	httpServer := httpserver.NewServer(cfg.Port, logger, localAuthHandler, oauthHandler, accountHandler, passkeyHandler, sessionHandler, activityHandler, authenticate, sessionStore)
+	grpcServer, err := grpcserver.NewGRPCServer(cfg.GRPCServer.Port, logger, providerTokenHandler, sessionGRPCHandler, activityGRPCHandler, inviteGRPCHandler, grpcClients)
+	if err != nil {
+		logger.Fatal("failed to create gRPC server", zap.Error(err))
+	}
 	kafkaServer := kafkaserver.NewKafkaServer(logger, []*kafkaserver.ReaderHandler{
 		{
 			Reader:  userEventReader,
@@ -146,11 +485,23 @@ func main() {
 	})
 
 	manager := server.NewServerManager(
-		[]server.Server{httpServer, kafkaServer},
+		[]server.Server{httpServer, grpcServer, kafkaServer},
 	)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Re-encrypt provider tokens still encrypted with a retired key
+	go func() {
+		rotated, err := providerTokenRepo.RotateProviderTokens(ctx)
+		if err != nil {
+			logger.Error("failed to rotate provider tokens", zap.Error(err))
+			return
+		}
+		if rotated > 0 {
+			logger.Info("rotated provider tokens", zap.Int("count", rotated))
+		}
+	}()
+
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, os.Kill)
 	go func() {
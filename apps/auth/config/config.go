@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -12,9 +13,51 @@ import (
 )
 
 type OAuthProviderConfig struct {
-	ClientID     string `validate:"required"`
-	ClientSecret string `validate:"required"`
-	RedirectURL  string `validate:"required,url"`
+	ClientID        string   `validate:"required"`
+	ClientSecret    string   `validate:"required"`
+	RedirectURL     string   `validate:"required,url"`
+	NativeClientIDs []string `validate:"omitempty"` // Client IDs of the mobile apps, accepted as token audiences
+	AppID           int64    `validate:"min=0"`     // Numeric app ID checked by Kakao token info, 0 to accept only ID tokens
+}
+
+// AppleProviderConfig configures Sign in with Apple, which is disabled when ClientID is empty
+type AppleProviderConfig struct {
+	ClientID       string   `validate:"omitempty"` // Services ID
+	TeamID         string   `validate:"required_with=ClientID"`
+	KeyID          string   `validate:"required_with=ClientID"`
+	PrivateKeyPath string   `validate:"required_with=ClientID,omitempty,file"` // .p8 key file
+	RedirectURL    string   `validate:"required_with=ClientID,omitempty,url"`
+	BundleIDs      []string `validate:"omitempty"` // Bundle IDs of the iOS apps, accepted as token audiences
+}
+
+// OIDCConfig configures a generic OpenID Connect provider registered under Name
+type OIDCConfig struct {
+	Name          string   `validate:"required"`
+	Issuer        string   `validate:"required,url"`
+	ClientID      string   `validate:"required"`
+	ClientSecret  string   `validate:"required"`
+	RedirectURL   string   `validate:"required,url"`
+	Scopes        []string `validate:"required,min=1"`
+	SubjectClaim  string   `validate:"omitempty"` // Dotted claim paths, empty for the standard claim
+	EmailClaim    string   `validate:"omitempty"`
+	VerifiedClaim string   `validate:"omitempty"`
+	NameClaim     string   `validate:"omitempty"`
+	NativeIDs     []string `validate:"omitempty"` // Client IDs of the mobile apps, accepted as token audiences
+}
+
+// ProviderTokenConfig configures the storage of provider tokens for internal
+// services, which is disabled when Providers is empty
+type ProviderTokenConfig struct {
+	Providers  []string `validate:"omitempty"`                                         // Providers whose tokens are stored
+	Keys       []string `validate:"required_with=Providers,omitempty,dive,contains=:"` // <key ID>:<base64 AES key> pairs
+	CurrentKey string   `validate:"required_with=Providers"`                           // ID of the key encrypting new tokens
+}
+
+// GRPCServerConfig configures the gRPC server for internal services
+type GRPCServerConfig struct {
+	Port         int      `validate:"required,min=1,max=65535"`
+	Clients      []string `validate:"omitempty,dive,contains=:"` // <service name>:<secret> pairs of the allowed callers
+	AdminClients []string `validate:"omitempty"`                 // Names of the callers allowed to manage the sessions of any user
 }
 
 type KafkaWriterConfig struct {
@@ -37,20 +80,110 @@ type RedisStoreConfig struct {
 	Timeout  time.Duration `validate:"omitempty,min=1"`
 }
 
+// RateLimitConfig limits failed attempts on a route, see ratelimitrepo.Policy
+type RateLimitConfig struct {
+	Window       time.Duration `validate:"required,min=1"`
+	FreeAttempts int           `validate:"min=0"`
+	MaxAttempts  int           `validate:"required,min=1,gtfield=FreeAttempts"`
+	BaseDelay    time.Duration `validate:"min=0"`
+	MaxDelay     time.Duration `validate:"gtefield=BaseDelay"`
+	Lockout      time.Duration `validate:"required,min=1"`
+}
+
+// CaptchaConfig configures the bot challenge asked from risky clients, which
+// is disabled when Provider is none
+type CaptchaConfig struct {
+	Provider      string        `validate:"required,oneof=none hcaptcha turnstile recaptcha stub"`
+	SiteKey       string        `validate:"omitempty"` // Checked when the verifier is created
+	Secret        string        `validate:"omitempty"`
+	MinScore      float64       `validate:"min=0,max=1"`                   // Minimum reCAPTCHA v3 score
+	StubToken     string        `validate:"required_if=Provider stub"`     // Token the stub accepts, for development only
+	RiskThreshold int64         `validate:"min=0"`                         // Risky events of an IP before a challenge is required
+	RiskWindow    time.Duration `validate:"required_unless=Provider none"` // Period over which risky events are counted
+	Timeout       time.Duration `validate:"required_unless=Provider none"` // Timeout of a call to the siteverify endpoint
+}
+
+// SignupPolicyConfig decides who may create an account, see signuppolicy.Rules
+type SignupPolicyConfig struct {
+	Mode           string        `validate:"required,oneof=open closed invite domain"`
+	AllowedDomains []string      `validate:"omitempty,dive,fqdn"` // Email domains allowed in domain mode, empty to allow any not denied
+	DeniedDomains  []string      `validate:"omitempty,dive,fqdn"` // Email domains denied in domain mode
+	InviteTTL      time.Duration `validate:"required,min=1"`      // Default lifetime of invite codes
+}
+
+// PasswordConfig sets the rules new passwords must follow, see passwordpolicy.Rules
+type PasswordConfig struct {
+	MinLength     int  `validate:"min=1,max=64"`
+	RequireLower  bool `validate:"omitempty"`
+	RequireUpper  bool `validate:"omitempty"`
+	RequireDigit  bool `validate:"omitempty"`
+	RequireSymbol bool `validate:"omitempty"`
+	MinStrength   int  `validate:"min=0,max=4"` // Minimum estimated strength, from 0 (very weak) to 4 (very strong)
+}
+
+// PasswordHashConfig sets the algorithm and cost of new password hashes
+type PasswordHashConfig struct {
+	Algorithm     string `validate:"required,oneof=argon2id bcrypt"`
+	Argon2Memory  uint32 `validate:"required,min=8192"` // KiB
+	Argon2Time    uint32 `validate:"required,min=1"`
+	Argon2Threads uint8  `validate:"required,min=1"`
+	BcryptCost    int    `validate:"required,min=10,max=31"`
+}
+
 type Config struct {
 	Env              string              `validate:"required,oneof=dev prod"`
 	Port             int                 `validate:"required,min=1,max=65535"`
 	TokenServiceAddr string              `validate:"required"`
+	UserServiceAddr  string              `validate:"required"`
 	DatabaseURL      string              `validate:"required"`
 	VerifyEmailURL   string              `validate:"required,url"`
+	ResetPasswordURL string              `validate:"required,url"`
+	ChangeEmailURL   string              `validate:"required,url"`
+	MagicLinkURL     string              `validate:"required,url"` // Page consuming passwordless login links
+	RevokeSessionURL string              `validate:"required,url"` // Page listing sessions to revoke, linked from security notifications
+	TotpIssuer       string              `validate:"required"`
+	TotpKey          string              `validate:"required,base64"` // AES key encrypting stored TOTP secrets
+	WebauthnRPID     string              `validate:"required,hostname"`
+	WebauthnRPName   string              `validate:"required"`
+	WebauthnOrigins  []string            `validate:"required,min=1,dive,url"` // Origins allowed to perform passkey ceremonies
+	PasswordPolicy   PasswordConfig      `validate:"required"`
+	SignupPolicy     SignupPolicyConfig  `validate:"required"`
+	PasswordHash     PasswordHashConfig  `validate:"required"`
+	BreachedListDir  string              `validate:"omitempty,dir"` // Directory of SHA-1 range files of breached passwords, empty to skip the check
 	LoginCodeStore   RedisStoreConfig    `validate:"required"`
 	EmailCodeStore   RedisStoreConfig    `validate:"required"` // Store for email verification codes
+	ResetCodeStore   RedisStoreConfig    `validate:"required"` // Store for password reset codes
+	ChangeCodeStore  RedisStoreConfig    `validate:"required"` // Store for email change codes
+	MagicLinkStore   RedisStoreConfig    `validate:"required"` // Store for passwordless login link codes
+	VerifyOTPStore   RedisStoreConfig    `validate:"required"` // Store for email verification passcodes
+	LoginOTPStore    RedisStoreConfig    `validate:"required"` // Store for email login passcodes
+	ChallengeStore   RedisStoreConfig    `validate:"required"` // Store for pending MFA login challenges
+	LinkConfirmStore RedisStoreConfig    `validate:"required"` // Store for OAuth links awaiting password confirmation
+	OAuthStateStore  RedisStoreConfig    `validate:"required"` // Store for OAuth web logins awaiting the provider callback
+	WebauthnStore    RedisStoreConfig    `validate:"required"` // Store for pending passkey challenges
+	RevocationStore  RedisStoreConfig    `validate:"required"` // Store for revoked refresh tokens, shared with the token service
 	SessionStore     RedisStoreConfig    `validate:"required"`
+	OTPMaxAttempts   int                 `validate:"required,min=1"`
+	OAuthLinkPolicy  string              `validate:"required,oneof=never verified confirm"`
+	OAuthReturnURLs  []string            `validate:"omitempty,dive,url"`
+	RateLimitStore   RedisStoreConfig    `validate:"required"` // Store for failed login attempt counters
+	LoginLimit       RateLimitConfig     `validate:"required"` // Limits failed password logins
+	LoginCodeLimit   RateLimitConfig     `validate:"required"` // Limits failed password logins issuing a login code
+	VerifyCodeLimit  RateLimitConfig     `validate:"required"` // Limits failed login code verifications
+	MFALimit         RateLimitConfig     `validate:"required"` // Limits wrong answers to MFA challenges
+	OTPLimit         RateLimitConfig     `validate:"required"` // Limits wrong email verification passcodes
+	Captcha          CaptchaConfig       `validate:"required"`
 	MailWriter       KafkaWriterConfig   `validate:"required"`
+	MailCooldown     time.Duration       `validate:"required,min=1"` // Least time between two mails of a flow to the same email
+	AuthEventWriter  KafkaWriterConfig   `validate:"required"`
 	UserEventReader  KafkaReaderConfig   `validate:"required"`
 	GoogleOAuth      OAuthProviderConfig `validate:"required"`
 	NaverOAuth       OAuthProviderConfig `validate:"required"`
 	KakaoOAuth       OAuthProviderConfig `validate:"required"`
+	AppleOAuth       AppleProviderConfig `validate:"required"`
+	OIDCProviders    []OIDCConfig        `validate:"omitempty,dive"`
+	ProviderTokens   ProviderTokenConfig `validate:"required"`
+	GRPCServer       GRPCServerConfig    `validate:"required"`
 }
 
 // LoadConfig loads env vars from .env (if exists) and returns structured config
@@ -63,6 +196,10 @@ func LoadConfig(validator *validator.Validate) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	grpcPort, err := strconv.Atoi(getEnv("GRPC_PORT", "50051"))
+	if err != nil {
+		return nil, err
+	}
 	sessionStoreDB, err := strconv.Atoi(getEnv("SESSION_STORE_DB", "0"))
 	if err != nil {
 		return nil, err
@@ -79,13 +216,126 @@ func LoadConfig(validator *validator.Validate) (*Config, error) {
 	if err != nil {
 		return nil, errors.New("Invalid EMAIL_CODE_TTL format", "Failed to parse email code TTL", errcode.ErrInvalidInput)
 	}
+	resetCodeTTL, err := time.ParseDuration(getEnv("RESET_CODE_TTL", "15m"))
+	if err != nil {
+		return nil, errors.New("Invalid RESET_CODE_TTL format", "Failed to parse reset code TTL", errcode.ErrInvalidInput)
+	}
+	mailCooldown, err := time.ParseDuration(getEnv("MAIL_COOLDOWN", "1m"))
+	if err != nil {
+		return nil, errors.New("Invalid MAIL_COOLDOWN format", "Failed to parse mail cooldown", errcode.ErrInvalidInput)
+	}
+	changeCodeTTL, err := time.ParseDuration(getEnv("CHANGE_CODE_TTL", "1h"))
+	if err != nil {
+		return nil, errors.New("Invalid CHANGE_CODE_TTL format", "Failed to parse email change code TTL", errcode.ErrInvalidInput)
+	}
+	magicLinkTTL, err := time.ParseDuration(getEnv("MAGIC_LINK_TTL", "15m"))
+	if err != nil {
+		return nil, errors.New("Invalid MAGIC_LINK_TTL format", "Failed to parse magic link TTL", errcode.ErrInvalidInput)
+	}
+	otpTTL, err := time.ParseDuration(getEnv("OTP_TTL", "10m"))
+	if err != nil {
+		return nil, errors.New("Invalid OTP_TTL format", "Failed to parse OTP TTL", errcode.ErrInvalidInput)
+	}
+	otpMaxAttempts, err := strconv.Atoi(getEnv("OTP_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		return nil, errors.New("Invalid OTP_MAX_ATTEMPTS format", "Failed to parse OTP max attempts", errcode.ErrInvalidInput)
+	}
+	mfaChallengeTTL, err := time.ParseDuration(getEnv("MFA_CHALLENGE_TTL", "5m"))
+	if err != nil {
+		return nil, errors.New("Invalid MFA_CHALLENGE_TTL format", "Failed to parse MFA challenge TTL", errcode.ErrInvalidInput)
+	}
+	linkConfirmTTL, err := time.ParseDuration(getEnv("LINK_CONFIRM_TTL", "10m"))
+	if err != nil {
+		return nil, errors.New("Invalid LINK_CONFIRM_TTL format", "Failed to parse link confirmation TTL", errcode.ErrInvalidInput)
+	}
+	kakaoAppID, err := strconv.ParseInt(getEnv("KAKAO_APP_ID", "0"), 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid KAKAO_APP_ID format", "Failed to parse Kakao app ID", errcode.ErrInvalidInput)
+	}
+	oauthStateTTL, err := time.ParseDuration(getEnv("OAUTH_STATE_TTL", "10m"))
+	if err != nil {
+		return nil, errors.New("Invalid OAUTH_STATE_TTL format", "Failed to parse OAuth state TTL", errcode.ErrInvalidInput)
+	}
+	webauthnChallengeTTL, err := time.ParseDuration(getEnv("WEBAUTHN_CHALLENGE_TTL", "5m"))
+	if err != nil {
+		return nil, errors.New("Invalid WEBAUTHN_CHALLENGE_TTL format", "Failed to parse WebAuthn challenge TTL", errcode.ErrInvalidInput)
+	}
+	revocationStoreDB, err := strconv.Atoi(getEnv("REVOCATION_STORE_DB", "0"))
+	if err != nil {
+		return nil, err
+	}
+	// Revocation entries only need to outlive the refresh tokens they revoke
+	refreshTokenTTL, err := time.ParseDuration(getEnv("REFRESH_TOKEN_TTL", "720h"))
+	if err != nil {
+		return nil, errors.New("Invalid REFRESH_TOKEN_TTL format", "Failed to parse refresh token TTL", errcode.ErrInvalidInput)
+	}
+
+	passwordPolicy, err := loadPasswordConfig()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := loadPasswordHashConfig()
+	if err != nil {
+		return nil, err
+	}
+	inviteTTL, err := time.ParseDuration(getEnv("SIGNUP_INVITE_TTL", "168h"))
+	if err != nil {
+		return nil, errors.New("Invalid SIGNUP_INVITE_TTL format", "Failed to parse signup invite TTL", errcode.ErrInvalidInput)
+	}
+	rateLimitStoreDB, err := strconv.Atoi(getEnv("RATE_LIMIT_STORE_DB", "0"))
+	if err != nil {
+		return nil, err
+	}
+	loginLimit, err := loadRateLimitConfig("LOGIN_LIMIT")
+	if err != nil {
+		return nil, err
+	}
+	loginCodeLimit, err := loadRateLimitConfig("LOGIN_CODE_LIMIT")
+	if err != nil {
+		return nil, err
+	}
+	verifyCodeLimit, err := loadRateLimitConfig("VERIFY_CODE_LIMIT")
+	if err != nil {
+		return nil, err
+	}
+	mfaLimit, err := loadRateLimitConfig("MFA_LIMIT")
+	if err != nil {
+		return nil, err
+	}
+	otpLimit, err := loadRateLimitConfig("OTP_LIMIT")
+	if err != nil {
+		return nil, err
+	}
+	captcha, err := loadCaptchaConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	config := &Config{
 		Env:              getEnv("ENV", "dev"),
 		Port:             port,
 		TokenServiceAddr: getEnv("TOKEN_SERVICE_ADDR", ""),
+		UserServiceAddr:  getEnv("USER_SERVICE_ADDR", ""),
 		DatabaseURL:      getEnv("DATABASE_URL", ""),
 		VerifyEmailURL:   getEnv("VERIFY_EMAIL_URL", ""),
+		ResetPasswordURL: getEnv("RESET_PASSWORD_URL", ""),
+		ChangeEmailURL:   getEnv("CHANGE_EMAIL_URL", ""),
+		MagicLinkURL:     getEnv("MAGIC_LINK_URL", ""),
+		RevokeSessionURL: getEnv("REVOKE_SESSION_URL", ""),
+		TotpIssuer:       getEnv("TOTP_ISSUER", "mandacode"),
+		TotpKey:          getEnv("TOTP_KEY", ""),
+		WebauthnRPID:     getEnv("WEBAUTHN_RP_ID", ""),
+		WebauthnRPName:   getEnv("WEBAUTHN_RP_NAME", "mandacode"),
+		WebauthnOrigins:  getEnvList("WEBAUTHN_ORIGINS"),
+		PasswordPolicy:   passwordPolicy,
+		PasswordHash:     passwordHash,
+		BreachedListDir:  getEnv("BREACHED_LIST_DIR", ""),
+		SignupPolicy: SignupPolicyConfig{
+			Mode:           getEnv("SIGNUP_MODE", "open"),
+			AllowedDomains: getEnvList("SIGNUP_ALLOWED_DOMAINS"),
+			DeniedDomains:  getEnvList("SIGNUP_DENIED_DOMAINS"),
+			InviteTTL:      inviteTTL,
+		},
 		LoginCodeStore: RedisStoreConfig{
 			Address:  getEnv("LOGIN_CODE_STORE_ADDRESS", ""),
 			Password: getEnv("LOGIN_CODE_STORE_PASSWORD", ""),
@@ -102,6 +352,89 @@ func LoadConfig(validator *validator.Validate) (*Config, error) {
 			HashKey:  getEnv("EMAIL_CODE_STORE_HASH_KEY", "default_email_code_hash_key"),
 			Timeout:  emailCodeTTL,
 		},
+		ResetCodeStore: RedisStoreConfig{
+			Address:  getEnv("RESET_CODE_STORE_ADDRESS", ""),
+			Password: getEnv("RESET_CODE_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("RESET_CODE_STORE_PREFIX", "reset_code:"),
+			HashKey:  getEnv("RESET_CODE_STORE_HASH_KEY", "default_reset_code_hash_key"),
+			Timeout:  resetCodeTTL,
+		},
+		ChangeCodeStore: RedisStoreConfig{
+			Address:  getEnv("CHANGE_CODE_STORE_ADDRESS", ""),
+			Password: getEnv("CHANGE_CODE_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("CHANGE_CODE_STORE_PREFIX", "change_code:"),
+			HashKey:  getEnv("CHANGE_CODE_STORE_HASH_KEY", "default_change_code_hash_key"),
+			Timeout:  changeCodeTTL,
+		},
+		MagicLinkStore: RedisStoreConfig{
+			Address:  getEnv("MAGIC_LINK_STORE_ADDRESS", ""),
+			Password: getEnv("MAGIC_LINK_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("MAGIC_LINK_STORE_PREFIX", "magic_link:"),
+			HashKey:  getEnv("MAGIC_LINK_STORE_HASH_KEY", "default_magic_link_hash_key"),
+			Timeout:  magicLinkTTL,
+		},
+		VerifyOTPStore: RedisStoreConfig{
+			Address:  getEnv("VERIFY_OTP_STORE_ADDRESS", ""),
+			Password: getEnv("VERIFY_OTP_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("VERIFY_OTP_STORE_PREFIX", "verify_otp:"),
+			HashKey:  getEnv("VERIFY_OTP_STORE_HASH_KEY", "default_verify_otp_hash_key"),
+			Timeout:  otpTTL,
+		},
+		LoginOTPStore: RedisStoreConfig{
+			Address:  getEnv("LOGIN_OTP_STORE_ADDRESS", ""),
+			Password: getEnv("LOGIN_OTP_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("LOGIN_OTP_STORE_PREFIX", "login_otp:"),
+			HashKey:  getEnv("LOGIN_OTP_STORE_HASH_KEY", "default_login_otp_hash_key"),
+			Timeout:  otpTTL,
+		},
+		OTPMaxAttempts:  otpMaxAttempts,
+		OAuthLinkPolicy: getEnv("OAUTH_LINK_POLICY", "never"),
+		OAuthReturnURLs: getEnvList("OAUTH_RETURN_URLS"),
+		ChallengeStore: RedisStoreConfig{
+			Address:  getEnv("MFA_CHALLENGE_STORE_ADDRESS", ""),
+			Password: getEnv("MFA_CHALLENGE_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("MFA_CHALLENGE_STORE_PREFIX", "mfa_challenge:"),
+			HashKey:  getEnv("MFA_CHALLENGE_STORE_HASH_KEY", "default_mfa_challenge_hash_key"),
+			Timeout:  mfaChallengeTTL,
+		},
+		LinkConfirmStore: RedisStoreConfig{
+			Address:  getEnv("LINK_CONFIRM_STORE_ADDRESS", ""),
+			Password: getEnv("LINK_CONFIRM_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("LINK_CONFIRM_STORE_PREFIX", "link_confirm:"),
+			HashKey:  getEnv("LINK_CONFIRM_STORE_HASH_KEY", "default_link_confirm_hash_key"),
+			Timeout:  linkConfirmTTL,
+		},
+		OAuthStateStore: RedisStoreConfig{
+			Address:  getEnv("OAUTH_STATE_STORE_ADDRESS", ""),
+			Password: getEnv("OAUTH_STATE_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("OAUTH_STATE_STORE_PREFIX", "oauth_state:"),
+			HashKey:  getEnv("OAUTH_STATE_STORE_HASH_KEY", "default_oauth_state_hash_key"),
+			Timeout:  oauthStateTTL,
+		},
+		WebauthnStore: RedisStoreConfig{
+			Address:  getEnv("WEBAUTHN_STORE_ADDRESS", ""),
+			Password: getEnv("WEBAUTHN_STORE_PASSWORD", ""),
+			DB:       codeStoreDB,
+			Prefix:   getEnv("WEBAUTHN_STORE_PREFIX", "webauthn_challenge:"),
+			HashKey:  getEnv("WEBAUTHN_STORE_HASH_KEY", "default_webauthn_challenge_hash_key"),
+			Timeout:  webauthnChallengeTTL,
+		},
+		RevocationStore: RedisStoreConfig{
+			Address:  getEnv("REVOCATION_STORE_ADDRESS", ""),
+			Password: getEnv("REVOCATION_STORE_PASSWORD", ""),
+			DB:       revocationStoreDB,
+			Prefix:   getEnv("REVOCATION_STORE_PREFIX", "revoked:"),
+			HashKey:  getEnv("REVOCATION_STORE_HASH_KEY", "default_revocation_hash_key"),
+			Timeout:  refreshTokenTTL,
+		},
 		SessionStore: RedisStoreConfig{
 			Address:  getEnv("SESSION_STORE_ADDRESS", ""),
 			Password: getEnv("SESSION_STORE_PASSWORD", ""),
@@ -109,29 +442,70 @@ func LoadConfig(validator *validator.Validate) (*Config, error) {
 			Prefix:   getEnv("SESSION_STORE_PREFIX", "session:"),
 			HashKey:  getEnv("SESSION_STORE_HASH_KEY", "default_session_hash_key"),
 		},
+		RateLimitStore: RedisStoreConfig{
+			Address:  getEnv("RATE_LIMIT_STORE_ADDRESS", ""),
+			Password: getEnv("RATE_LIMIT_STORE_PASSWORD", ""),
+			DB:       rateLimitStoreDB,
+			Prefix:   getEnv("RATE_LIMIT_STORE_PREFIX", "rate_limit:"),
+			HashKey:  getEnv("RATE_LIMIT_STORE_HASH_KEY", "default_rate_limit_hash_key"),
+		},
+		LoginLimit:      loginLimit,
+		LoginCodeLimit:  loginCodeLimit,
+		VerifyCodeLimit: verifyCodeLimit,
+		MFALimit:        mfaLimit,
+		OTPLimit:        otpLimit,
+		Captcha:         captcha,
 		MailWriter: KafkaWriterConfig{
 			Address: getEnv("MAIL_WRITER_ADDRESS", ""),
 			Topic:   getEnv("MAIL_WRITER_TOPIC", "mail"),
 		},
+		MailCooldown: mailCooldown,
+		AuthEventWriter: KafkaWriterConfig{
+			Address: getEnv("AUTH_EVENT_WRITER_ADDRESS", ""),
+			Topic:   getEnv("AUTH_EVENT_WRITER_TOPIC", "auth_event"),
+		},
 		UserEventReader: KafkaReaderConfig{
 			Address: getEnv("USER_EVENT_READER_ADDRESS", ""),
 			Topic:   getEnv("USER_EVENT_READER_TOPIC", "user_event"),
 			GroupID: getEnv("USER_EVENT_READER_GROUP_ID", "user_event_group"),
 		},
 		GoogleOAuth: OAuthProviderConfig{
-			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
-			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", ""),
+			ClientID:        getEnv("GOOGLE_CLIENT_ID", ""),
+			ClientSecret:    getEnv("GOOGLE_CLIENT_SECRET", ""),
+			RedirectURL:     getEnv("GOOGLE_REDIRECT_URL", ""),
+			NativeClientIDs: getEnvList("GOOGLE_NATIVE_CLIENT_IDS"),
 		},
 		NaverOAuth: OAuthProviderConfig{
-			ClientID:     getEnv("NAVER_CLIENT_ID", ""),
-			ClientSecret: getEnv("NAVER_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("NAVER_REDIRECT_URL", ""),
+			ClientID:        getEnv("NAVER_CLIENT_ID", ""),
+			ClientSecret:    getEnv("NAVER_CLIENT_SECRET", ""),
+			RedirectURL:     getEnv("NAVER_REDIRECT_URL", ""),
+			NativeClientIDs: getEnvList("NAVER_NATIVE_CLIENT_IDS"),
 		},
 		KakaoOAuth: OAuthProviderConfig{
-			ClientID:     getEnv("KAKAO_CLIENT_ID", ""),
-			ClientSecret: getEnv("KAKAO_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("KAKAO_REDIRECT_URL", ""),
+			ClientID:        getEnv("KAKAO_CLIENT_ID", ""),
+			ClientSecret:    getEnv("KAKAO_CLIENT_SECRET", ""),
+			RedirectURL:     getEnv("KAKAO_REDIRECT_URL", ""),
+			NativeClientIDs: getEnvList("KAKAO_NATIVE_CLIENT_IDS"),
+			AppID:           kakaoAppID,
+		},
+		AppleOAuth: AppleProviderConfig{
+			ClientID:       getEnv("APPLE_CLIENT_ID", ""),
+			TeamID:         getEnv("APPLE_TEAM_ID", ""),
+			KeyID:          getEnv("APPLE_KEY_ID", ""),
+			PrivateKeyPath: getEnv("APPLE_PRIVATE_KEY_PATH", ""),
+			RedirectURL:    getEnv("APPLE_REDIRECT_URL", ""),
+			BundleIDs:      getEnvList("APPLE_BUNDLE_IDS"),
+		},
+		OIDCProviders: loadOIDCConfigs(),
+		ProviderTokens: ProviderTokenConfig{
+			Providers:  getEnvList("PROVIDER_TOKEN_PROVIDERS"),
+			Keys:       getEnvList("PROVIDER_TOKEN_KEYS"),
+			CurrentKey: getEnv("PROVIDER_TOKEN_CURRENT_KEY", ""),
+		},
+		GRPCServer: GRPCServerConfig{
+			Port:         grpcPort,
+			Clients:      getEnvList("GRPC_CLIENTS"),
+			AdminClients: getEnvList("GRPC_ADMIN_CLIENTS"),
 		},
 	}
 
@@ -141,6 +515,164 @@ func LoadConfig(validator *validator.Validate) (*Config, error) {
 	return config, nil
 }
 
+// loadPasswordConfig loads the password rules from PASSWORD_* env vars
+func loadPasswordConfig() (PasswordConfig, error) {
+	minLength, err := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	if err != nil {
+		return PasswordConfig{}, errors.New("Invalid PASSWORD_MIN_LENGTH format", "Failed to parse password min length", errcode.ErrInvalidInput)
+	}
+	requireLower, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_LOWER", "true"))
+	if err != nil {
+		return PasswordConfig{}, errors.New("Invalid PASSWORD_REQUIRE_LOWER format", "Failed to parse password policy", errcode.ErrInvalidInput)
+	}
+	requireUpper, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_UPPER", "false"))
+	if err != nil {
+		return PasswordConfig{}, errors.New("Invalid PASSWORD_REQUIRE_UPPER format", "Failed to parse password policy", errcode.ErrInvalidInput)
+	}
+	requireDigit, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_DIGIT", "true"))
+	if err != nil {
+		return PasswordConfig{}, errors.New("Invalid PASSWORD_REQUIRE_DIGIT format", "Failed to parse password policy", errcode.ErrInvalidInput)
+	}
+	requireSymbol, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_SYMBOL", "false"))
+	if err != nil {
+		return PasswordConfig{}, errors.New("Invalid PASSWORD_REQUIRE_SYMBOL format", "Failed to parse password policy", errcode.ErrInvalidInput)
+	}
+	minStrength, err := strconv.Atoi(getEnv("PASSWORD_MIN_STRENGTH", "2"))
+	if err != nil {
+		return PasswordConfig{}, errors.New("Invalid PASSWORD_MIN_STRENGTH format", "Failed to parse password min strength", errcode.ErrInvalidInput)
+	}
+
+	return PasswordConfig{
+		MinLength:     minLength,
+		RequireLower:  requireLower,
+		RequireUpper:  requireUpper,
+		RequireDigit:  requireDigit,
+		RequireSymbol: requireSymbol,
+		MinStrength:   minStrength,
+	}, nil
+}
+
+// loadPasswordHashConfig loads the password hashing parameters from PASSWORD_HASH_* env vars
+func loadPasswordHashConfig() (PasswordHashConfig, error) {
+	argon2Memory, err := strconv.ParseUint(getEnv("PASSWORD_HASH_ARGON2_MEMORY", "19456"), 10, 32)
+	if err != nil {
+		return PasswordHashConfig{}, errors.New("Invalid PASSWORD_HASH_ARGON2_MEMORY format", "Failed to parse Argon2 memory", errcode.ErrInvalidInput)
+	}
+	argon2Time, err := strconv.ParseUint(getEnv("PASSWORD_HASH_ARGON2_TIME", "2"), 10, 32)
+	if err != nil {
+		return PasswordHashConfig{}, errors.New("Invalid PASSWORD_HASH_ARGON2_TIME format", "Failed to parse Argon2 time", errcode.ErrInvalidInput)
+	}
+	argon2Threads, err := strconv.ParseUint(getEnv("PASSWORD_HASH_ARGON2_THREADS", "1"), 10, 8)
+	if err != nil {
+		return PasswordHashConfig{}, errors.New("Invalid PASSWORD_HASH_ARGON2_THREADS format", "Failed to parse Argon2 threads", errcode.ErrInvalidInput)
+	}
+	bcryptCost, err := strconv.Atoi(getEnv("PASSWORD_HASH_BCRYPT_COST", "10"))
+	if err != nil {
+		return PasswordHashConfig{}, errors.New("Invalid PASSWORD_HASH_BCRYPT_COST format", "Failed to parse bcrypt cost", errcode.ErrInvalidInput)
+	}
+
+	return PasswordHashConfig{
+		Algorithm:     getEnv("PASSWORD_HASH_ALGORITHM", "argon2id"),
+		Argon2Memory:  uint32(argon2Memory),
+		Argon2Time:    uint32(argon2Time),
+		Argon2Threads: uint8(argon2Threads),
+		BcryptCost:    bcryptCost,
+	}, nil
+}
+
+// loadRateLimitConfig loads the limits of a route from env vars starting with prefix
+func loadRateLimitConfig(prefix string) (RateLimitConfig, error) {
+	window, err := time.ParseDuration(getEnv(prefix+"_WINDOW", "15m"))
+	if err != nil {
+		return RateLimitConfig{}, errors.New("Invalid "+prefix+"_WINDOW format", "Failed to parse rate limit window", errcode.ErrInvalidInput)
+	}
+	freeAttempts, err := strconv.Atoi(getEnv(prefix+"_FREE_ATTEMPTS", "3"))
+	if err != nil {
+		return RateLimitConfig{}, errors.New("Invalid "+prefix+"_FREE_ATTEMPTS format", "Failed to parse rate limit free attempts", errcode.ErrInvalidInput)
+	}
+	maxAttempts, err := strconv.Atoi(getEnv(prefix+"_MAX_ATTEMPTS", "10"))
+	if err != nil {
+		return RateLimitConfig{}, errors.New("Invalid "+prefix+"_MAX_ATTEMPTS format", "Failed to parse rate limit max attempts", errcode.ErrInvalidInput)
+	}
+	baseDelay, err := time.ParseDuration(getEnv(prefix+"_BASE_DELAY", "1s"))
+	if err != nil {
+		return RateLimitConfig{}, errors.New("Invalid "+prefix+"_BASE_DELAY format", "Failed to parse rate limit base delay", errcode.ErrInvalidInput)
+	}
+	maxDelay, err := time.ParseDuration(getEnv(prefix+"_MAX_DELAY", "30s"))
+	if err != nil {
+		return RateLimitConfig{}, errors.New("Invalid "+prefix+"_MAX_DELAY format", "Failed to parse rate limit max delay", errcode.ErrInvalidInput)
+	}
+	lockout, err := time.ParseDuration(getEnv(prefix+"_LOCKOUT", "15m"))
+	if err != nil {
+		return RateLimitConfig{}, errors.New("Invalid "+prefix+"_LOCKOUT format", "Failed to parse rate limit lockout", errcode.ErrInvalidInput)
+	}
+
+	return RateLimitConfig{
+		Window:       window,
+		FreeAttempts: freeAttempts,
+		MaxAttempts:  maxAttempts,
+		BaseDelay:    baseDelay,
+		MaxDelay:     maxDelay,
+		Lockout:      lockout,
+	}, nil
+}
+
+// loadCaptchaConfig loads the bot challenge settings from CAPTCHA_* env vars
+func loadCaptchaConfig() (CaptchaConfig, error) {
+	minScore, err := strconv.ParseFloat(getEnv("CAPTCHA_MIN_SCORE", "0.5"), 64)
+	if err != nil {
+		return CaptchaConfig{}, errors.New("Invalid CAPTCHA_MIN_SCORE format", "Failed to parse captcha min score", errcode.ErrInvalidInput)
+	}
+	riskThreshold, err := strconv.ParseInt(getEnv("CAPTCHA_RISK_THRESHOLD", "5"), 10, 64)
+	if err != nil {
+		return CaptchaConfig{}, errors.New("Invalid CAPTCHA_RISK_THRESHOLD format", "Failed to parse captcha risk threshold", errcode.ErrInvalidInput)
+	}
+	riskWindow, err := time.ParseDuration(getEnv("CAPTCHA_RISK_WINDOW", "1h"))
+	if err != nil {
+		return CaptchaConfig{}, errors.New("Invalid CAPTCHA_RISK_WINDOW format", "Failed to parse captcha risk window", errcode.ErrInvalidInput)
+	}
+	timeout, err := time.ParseDuration(getEnv("CAPTCHA_TIMEOUT", "5s"))
+	if err != nil {
+		return CaptchaConfig{}, errors.New("Invalid CAPTCHA_TIMEOUT format", "Failed to parse captcha timeout", errcode.ErrInvalidInput)
+	}
+
+	return CaptchaConfig{
+		Provider:      getEnv("CAPTCHA_PROVIDER", "none"),
+		SiteKey:       getEnv("CAPTCHA_SITE_KEY", ""),
+		Secret:        getEnv("CAPTCHA_SECRET", ""),
+		MinScore:      minScore,
+		StubToken:     getEnv("CAPTCHA_STUB_TOKEN", ""),
+		RiskThreshold: riskThreshold,
+		RiskWindow:    riskWindow,
+		Timeout:       timeout,
+	}, nil
+}
+
+// loadOIDCConfigs loads the OpenID Connect providers named in OIDC_PROVIDERS
+// from OIDC_<NAME>_* env vars
+func loadOIDCConfigs() []OIDCConfig {
+	providers := []OIDCConfig{}
+	for _, name := range getEnvList("OIDC_PROVIDERS") {
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		scopes := strings.Fields(getEnv(prefix+"_SCOPES", "openid email profile"))
+
+		providers = append(providers, OIDCConfig{
+			Name:          name,
+			Issuer:        getEnv(prefix+"_ISSUER", ""),
+			ClientID:      getEnv(prefix+"_CLIENT_ID", ""),
+			ClientSecret:  getEnv(prefix+"_CLIENT_SECRET", ""),
+			RedirectURL:   getEnv(prefix+"_REDIRECT_URL", ""),
+			Scopes:        scopes,
+			SubjectClaim:  getEnv(prefix+"_CLAIM_SUBJECT", ""),
+			EmailClaim:    getEnv(prefix+"_CLAIM_EMAIL", ""),
+			VerifiedClaim: getEnv(prefix+"_CLAIM_EMAIL_VERIFIED", ""),
+			NameClaim:     getEnv(prefix+"_CLAIM_NAME", ""),
+			NativeIDs:     getEnvList(prefix + "_NATIVE_CLIENT_IDS"),
+		})
+	}
+	return providers
+}
+
 // getEnv returns env value or fallback
 func getEnv(key, fallback string) string {
 	val := os.Getenv(key)
@@ -149,3 +681,14 @@ func getEnv(key, fallback string) string {
 	}
 	return val
 }
+
+// getEnvList returns a comma separated env value as a list, skipping empty items
+func getEnvList(key string) []string {
+	values := []string{}
+	for _, val := range strings.Split(os.Getenv(key), ",") {
+		if val = strings.TrimSpace(val); val != "" {
+			values = append(values, val)
+		}
+	}
+	return values
+}
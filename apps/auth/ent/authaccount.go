@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
 	"mandacode.com/accounts/auth/ent/authaccount"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 // AuthAccount is the model entity for the AuthAccount schema.
@@ -21,8 +22,8 @@ type AuthAccount struct {
 	ID uuid.UUID `json:"id,omitempty"`
 	// The unique identifier for the user associated with this authentication account
 	UserID uuid.UUID `json:"user_id,omitempty"`
-	// The OAuth provider used for authentication
-	Provider authaccount.Provider `json:"provider,omitempty"`
+	// The name of the provider used for authentication, local or an OAuth provider
+	Provider providermodels.Provider `json:"provider,omitempty"`
 	// The unique identifier provided by the OAuth provider for the user
 	ProviderID *string `json:"provider_id,omitempty"`
 	// Indicates if the authentication account has verified the email address
@@ -82,7 +83,7 @@ func (aa *AuthAccount) assignValues(columns []string, values []any) error {
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field provider", values[i])
 			} else if value.Valid {
-				aa.Provider = authaccount.Provider(value.String)
+				aa.Provider = providermodels.Provider(value.String)
 			}
 		case authaccount.FieldProviderID:
 			if value, ok := values[i].(*sql.NullString); !ok {
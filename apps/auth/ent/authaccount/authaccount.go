@@ -3,7 +3,6 @@
 package authaccount
 
 import (
-	"fmt"
 	"time"
 
 	"entgo.io/ent"
@@ -66,6 +65,8 @@ func ValidColumn(column string) bool {
 //	import _ "mandacode.com/accounts/auth/ent/runtime"
 var (
 	Hooks [2]ent.Hook
+	// ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	ProviderValidator func(string) error
 	// DefaultIsVerified holds the default value on creation for the "is_verified" field.
 	DefaultIsVerified bool
 	// EmailValidator is a validator for the "email" field. It is called by the builders before save.
@@ -80,32 +81,6 @@ var (
 	DefaultID func() uuid.UUID
 )
 
-// Provider defines the type for the "provider" enum field.
-type Provider string
-
-// Provider values.
-const (
-	ProviderLocal  Provider = "local"
-	ProviderGoogle Provider = "google"
-	ProviderKakao  Provider = "kakao"
-	ProviderNaver  Provider = "naver"
-	ProviderApple  Provider = "apple"
-)
-
-func (pr Provider) String() string {
-	return string(pr)
-}
-
-// ProviderValidator is a validator for the "provider" field enum values. It is called by the builders before save.
-func ProviderValidator(pr Provider) error {
-	switch pr {
-	case ProviderLocal, ProviderGoogle, ProviderKakao, ProviderNaver, ProviderApple:
-		return nil
-	default:
-		return fmt.Errorf("authaccount: invalid enum value for provider field: %q", pr)
-	}
-}
-
 // OrderOption defines the ordering options for the AuthAccount queries.
 type OrderOption func(*sql.Selector)
 
@@ -8,6 +8,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
 	"mandacode.com/accounts/auth/ent/predicate"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 // ID filters vertices based on their ID field.
@@ -60,6 +61,12 @@ func UserID(v uuid.UUID) predicate.AuthAccount {
 	return predicate.AuthAccount(sql.FieldEQ(FieldUserID, v))
 }
 
+// Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
+func Provider(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldEQ(FieldProvider, vc))
+}
+
 // ProviderID applies equality check predicate on the "provider_id" field. It's identical to ProviderIDEQ.
 func ProviderID(v string) predicate.AuthAccount {
 	return predicate.AuthAccount(sql.FieldEQ(FieldProviderID, v))
@@ -131,23 +138,87 @@ func UserIDLTE(v uuid.UUID) predicate.AuthAccount {
 }
 
 // ProviderEQ applies the EQ predicate on the "provider" field.
-func ProviderEQ(v Provider) predicate.AuthAccount {
-	return predicate.AuthAccount(sql.FieldEQ(FieldProvider, v))
+func ProviderEQ(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldEQ(FieldProvider, vc))
 }
 
 // ProviderNEQ applies the NEQ predicate on the "provider" field.
-func ProviderNEQ(v Provider) predicate.AuthAccount {
-	return predicate.AuthAccount(sql.FieldNEQ(FieldProvider, v))
+func ProviderNEQ(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldNEQ(FieldProvider, vc))
 }
 
 // ProviderIn applies the In predicate on the "provider" field.
-func ProviderIn(vs ...Provider) predicate.AuthAccount {
-	return predicate.AuthAccount(sql.FieldIn(FieldProvider, vs...))
+func ProviderIn(vs ...providermodels.Provider) predicate.AuthAccount {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.AuthAccount(sql.FieldIn(FieldProvider, v...))
 }
 
 // ProviderNotIn applies the NotIn predicate on the "provider" field.
-func ProviderNotIn(vs ...Provider) predicate.AuthAccount {
-	return predicate.AuthAccount(sql.FieldNotIn(FieldProvider, vs...))
+func ProviderNotIn(vs ...providermodels.Provider) predicate.AuthAccount {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.AuthAccount(sql.FieldNotIn(FieldProvider, v...))
+}
+
+// ProviderGT applies the GT predicate on the "provider" field.
+func ProviderGT(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldGT(FieldProvider, vc))
+}
+
+// ProviderGTE applies the GTE predicate on the "provider" field.
+func ProviderGTE(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldGTE(FieldProvider, vc))
+}
+
+// ProviderLT applies the LT predicate on the "provider" field.
+func ProviderLT(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldLT(FieldProvider, vc))
+}
+
+// ProviderLTE applies the LTE predicate on the "provider" field.
+func ProviderLTE(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldLTE(FieldProvider, vc))
+}
+
+// ProviderContains applies the Contains predicate on the "provider" field.
+func ProviderContains(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldContains(FieldProvider, vc))
+}
+
+// ProviderHasPrefix applies the HasPrefix predicate on the "provider" field.
+func ProviderHasPrefix(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldHasPrefix(FieldProvider, vc))
+}
+
+// ProviderHasSuffix applies the HasSuffix predicate on the "provider" field.
+func ProviderHasSuffix(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldHasSuffix(FieldProvider, vc))
+}
+
+// ProviderEqualFold applies the EqualFold predicate on the "provider" field.
+func ProviderEqualFold(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldEqualFold(FieldProvider, vc))
+}
+
+// ProviderContainsFold applies the ContainsFold predicate on the "provider" field.
+func ProviderContainsFold(v providermodels.Provider) predicate.AuthAccount {
+	vc := string(v)
+	return predicate.AuthAccount(sql.FieldContainsFold(FieldProvider, vc))
 }
 
 // ProviderIDEQ applies the EQ predicate on the "provider_id" field.
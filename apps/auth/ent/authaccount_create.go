@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
 	"mandacode.com/accounts/auth/ent/authaccount"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 // AuthAccountCreate is the builder for creating a AuthAccount entity.
@@ -28,8 +29,8 @@ func (aac *AuthAccountCreate) SetUserID(u uuid.UUID) *AuthAccountCreate {
 }
 
 // SetProvider sets the "provider" field.
-func (aac *AuthAccountCreate) SetProvider(a authaccount.Provider) *AuthAccountCreate {
-	aac.mutation.SetProvider(a)
+func (aac *AuthAccountCreate) SetProvider(pr providermodels.Provider) *AuthAccountCreate {
+	aac.mutation.SetProvider(pr)
 	return aac
 }
 
@@ -197,7 +198,7 @@ func (aac *AuthAccountCreate) check() error {
 		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "AuthAccount.provider"`)}
 	}
 	if v, ok := aac.mutation.Provider(); ok {
-		if err := authaccount.ProviderValidator(v); err != nil {
+		if err := authaccount.ProviderValidator(string(v)); err != nil {
 			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "AuthAccount.provider": %w`, err)}
 		}
 	}
@@ -258,7 +259,7 @@ func (aac *AuthAccountCreate) createSpec() (*AuthAccount, *sqlgraph.CreateSpec)
 		_node.UserID = value
 	}
 	if value, ok := aac.mutation.Provider(); ok {
-		_spec.SetField(authaccount.FieldProvider, field.TypeEnum, value)
+		_spec.SetField(authaccount.FieldProvider, field.TypeString, value)
 		_node.Provider = value
 	}
 	if value, ok := aac.mutation.ProviderID(); ok {
@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"mandacode.com/accounts/auth/ent/authaccount"
 	"mandacode.com/accounts/auth/ent/predicate"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 // AuthAccountUpdate is the builder for updating AuthAccount entities.
@@ -44,15 +45,15 @@ func (aau *AuthAccountUpdate) SetNillableUserID(u *uuid.UUID) *AuthAccountUpdate
 }
 
 // SetProvider sets the "provider" field.
-func (aau *AuthAccountUpdate) SetProvider(a authaccount.Provider) *AuthAccountUpdate {
-	aau.mutation.SetProvider(a)
+func (aau *AuthAccountUpdate) SetProvider(pr providermodels.Provider) *AuthAccountUpdate {
+	aau.mutation.SetProvider(pr)
 	return aau
 }
 
 // SetNillableProvider sets the "provider" field if the given value is not nil.
-func (aau *AuthAccountUpdate) SetNillableProvider(a *authaccount.Provider) *AuthAccountUpdate {
-	if a != nil {
-		aau.SetProvider(*a)
+func (aau *AuthAccountUpdate) SetNillableProvider(pr *providermodels.Provider) *AuthAccountUpdate {
+	if pr != nil {
+		aau.SetProvider(*pr)
 	}
 	return aau
 }
@@ -181,7 +182,7 @@ func (aau *AuthAccountUpdate) defaults() error {
 // check runs all checks and user-defined validators on the builder.
 func (aau *AuthAccountUpdate) check() error {
 	if v, ok := aau.mutation.Provider(); ok {
-		if err := authaccount.ProviderValidator(v); err != nil {
+		if err := authaccount.ProviderValidator(string(v)); err != nil {
 			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "AuthAccount.provider": %w`, err)}
 		}
 	}
@@ -209,7 +210,7 @@ func (aau *AuthAccountUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		_spec.SetField(authaccount.FieldUserID, field.TypeUUID, value)
 	}
 	if value, ok := aau.mutation.Provider(); ok {
-		_spec.SetField(authaccount.FieldProvider, field.TypeEnum, value)
+		_spec.SetField(authaccount.FieldProvider, field.TypeString, value)
 	}
 	if value, ok := aau.mutation.ProviderID(); ok {
 		_spec.SetField(authaccount.FieldProviderID, field.TypeString, value)
@@ -267,15 +268,15 @@ func (aauo *AuthAccountUpdateOne) SetNillableUserID(u *uuid.UUID) *AuthAccountUp
 }
 
 // SetProvider sets the "provider" field.
-func (aauo *AuthAccountUpdateOne) SetProvider(a authaccount.Provider) *AuthAccountUpdateOne {
-	aauo.mutation.SetProvider(a)
+func (aauo *AuthAccountUpdateOne) SetProvider(pr providermodels.Provider) *AuthAccountUpdateOne {
+	aauo.mutation.SetProvider(pr)
 	return aauo
 }
 
 // SetNillableProvider sets the "provider" field if the given value is not nil.
-func (aauo *AuthAccountUpdateOne) SetNillableProvider(a *authaccount.Provider) *AuthAccountUpdateOne {
-	if a != nil {
-		aauo.SetProvider(*a)
+func (aauo *AuthAccountUpdateOne) SetNillableProvider(pr *providermodels.Provider) *AuthAccountUpdateOne {
+	if pr != nil {
+		aauo.SetProvider(*pr)
 	}
 	return aauo
 }
@@ -417,7 +418,7 @@ func (aauo *AuthAccountUpdateOne) defaults() error {
 // check runs all checks and user-defined validators on the builder.
 func (aauo *AuthAccountUpdateOne) check() error {
 	if v, ok := aauo.mutation.Provider(); ok {
-		if err := authaccount.ProviderValidator(v); err != nil {
+		if err := authaccount.ProviderValidator(string(v)); err != nil {
 			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "AuthAccount.provider": %w`, err)}
 		}
 	}
@@ -462,7 +463,7 @@ func (aauo *AuthAccountUpdateOne) sqlSave(ctx context.Context) (_node *AuthAccou
 		_spec.SetField(authaccount.FieldUserID, field.TypeUUID, value)
 	}
 	if value, ok := aauo.mutation.Provider(); ok {
-		_spec.SetField(authaccount.FieldProvider, field.TypeEnum, value)
+		_spec.SetField(authaccount.FieldProvider, field.TypeString, value)
 	}
 	if value, ok := aauo.mutation.ProviderID(); ok {
 		_spec.SetField(authaccount.FieldProviderID, field.TypeString, value)
@@ -0,0 +1,191 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+)
+
+// AuthActivity is the model entity for the AuthActivity schema.
+type AuthActivity struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The unique identifier for the authentication attempt
+	ID uuid.UUID `json:"id,omitempty"`
+	// The unique identifier for the user of the attempt, nil if unknown
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	// The way the user tried to authenticate
+	Method activitymodels.Method `json:"method,omitempty"`
+	// The OAuth provider of the attempt, empty for other methods
+	Provider string `json:"provider,omitempty"`
+	// The IP address the attempt came from
+	IPAddress string `json:"ip_address,omitempty"`
+	// The user agent of the client
+	UserAgent string `json:"user_agent,omitempty"`
+	// The result of the attempt
+	Outcome activitymodels.Outcome `json:"outcome,omitempty"`
+	// The reason the attempt failed, empty unless it did
+	FailureReason string `json:"failure_reason,omitempty"`
+	// The time of the attempt
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuthActivity) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case authactivity.FieldUserID:
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case authactivity.FieldMethod, authactivity.FieldProvider, authactivity.FieldIPAddress, authactivity.FieldUserAgent, authactivity.FieldOutcome, authactivity.FieldFailureReason:
+			values[i] = new(sql.NullString)
+		case authactivity.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case authactivity.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuthActivity fields.
+func (aa *AuthActivity) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case authactivity.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				aa.ID = *value
+			}
+		case authactivity.FieldUserID:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				aa.UserID = new(uuid.UUID)
+				*aa.UserID = *value.S.(*uuid.UUID)
+			}
+		case authactivity.FieldMethod:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field method", values[i])
+			} else if value.Valid {
+				aa.Method = activitymodels.Method(value.String)
+			}
+		case authactivity.FieldProvider:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field provider", values[i])
+			} else if value.Valid {
+				aa.Provider = value.String
+			}
+		case authactivity.FieldIPAddress:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip_address", values[i])
+			} else if value.Valid {
+				aa.IPAddress = value.String
+			}
+		case authactivity.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				aa.UserAgent = value.String
+			}
+		case authactivity.FieldOutcome:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field outcome", values[i])
+			} else if value.Valid {
+				aa.Outcome = activitymodels.Outcome(value.String)
+			}
+		case authactivity.FieldFailureReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field failure_reason", values[i])
+			} else if value.Valid {
+				aa.FailureReason = value.String
+			}
+		case authactivity.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				aa.CreatedAt = value.Time
+			}
+		default:
+			aa.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AuthActivity.
+// This includes values selected through modifiers, order, etc.
+func (aa *AuthActivity) Value(name string) (ent.Value, error) {
+	return aa.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this AuthActivity.
+// Note that you need to call AuthActivity.Unwrap() before calling this method if this AuthActivity
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (aa *AuthActivity) Update() *AuthActivityUpdateOne {
+	return NewAuthActivityClient(aa.config).UpdateOne(aa)
+}
+
+// Unwrap unwraps the AuthActivity entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (aa *AuthActivity) Unwrap() *AuthActivity {
+	_tx, ok := aa.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuthActivity is not a transactional entity")
+	}
+	aa.config.driver = _tx.drv
+	return aa
+}
+
+// String implements the fmt.Stringer.
+func (aa *AuthActivity) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuthActivity(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", aa.ID))
+	if v := aa.UserID; v != nil {
+		builder.WriteString("user_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("method=")
+	builder.WriteString(fmt.Sprintf("%v", aa.Method))
+	builder.WriteString(", ")
+	builder.WriteString("provider=")
+	builder.WriteString(aa.Provider)
+	builder.WriteString(", ")
+	builder.WriteString("ip_address=")
+	builder.WriteString(aa.IPAddress)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(aa.UserAgent)
+	builder.WriteString(", ")
+	builder.WriteString("outcome=")
+	builder.WriteString(fmt.Sprintf("%v", aa.Outcome))
+	builder.WriteString(", ")
+	builder.WriteString("failure_reason=")
+	builder.WriteString(aa.FailureReason)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(aa.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuthActivities is a parsable slice of AuthActivity.
+type AuthActivities []*AuthActivity
@@ -0,0 +1,632 @@
+// Code generated by ent, DO NOT EDIT.
+
+package authactivity
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/predicate"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldUserID, v))
+}
+
+// Method applies equality check predicate on the "method" field. It's identical to MethodEQ.
+func Method(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldEQ(FieldMethod, vc))
+}
+
+// Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
+func Provider(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldProvider, v))
+}
+
+// IPAddress applies equality check predicate on the "ip_address" field. It's identical to IPAddressEQ.
+func IPAddress(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldIPAddress, v))
+}
+
+// UserAgent applies equality check predicate on the "user_agent" field. It's identical to UserAgentEQ.
+func UserAgent(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldUserAgent, v))
+}
+
+// Outcome applies equality check predicate on the "outcome" field. It's identical to OutcomeEQ.
+func Outcome(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldEQ(FieldOutcome, vc))
+}
+
+// FailureReason applies equality check predicate on the "failure_reason" field. It's identical to FailureReasonEQ.
+func FailureReason(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldFailureReason, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v uuid.UUID) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldUserID, v))
+}
+
+// UserIDIsNil applies the IsNil predicate on the "user_id" field.
+func UserIDIsNil() predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIsNull(FieldUserID))
+}
+
+// UserIDNotNil applies the NotNil predicate on the "user_id" field.
+func UserIDNotNil() predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotNull(FieldUserID))
+}
+
+// MethodEQ applies the EQ predicate on the "method" field.
+func MethodEQ(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldEQ(FieldMethod, vc))
+}
+
+// MethodNEQ applies the NEQ predicate on the "method" field.
+func MethodNEQ(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldNEQ(FieldMethod, vc))
+}
+
+// MethodIn applies the In predicate on the "method" field.
+func MethodIn(vs ...activitymodels.Method) predicate.AuthActivity {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.AuthActivity(sql.FieldIn(FieldMethod, v...))
+}
+
+// MethodNotIn applies the NotIn predicate on the "method" field.
+func MethodNotIn(vs ...activitymodels.Method) predicate.AuthActivity {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.AuthActivity(sql.FieldNotIn(FieldMethod, v...))
+}
+
+// MethodGT applies the GT predicate on the "method" field.
+func MethodGT(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldGT(FieldMethod, vc))
+}
+
+// MethodGTE applies the GTE predicate on the "method" field.
+func MethodGTE(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldGTE(FieldMethod, vc))
+}
+
+// MethodLT applies the LT predicate on the "method" field.
+func MethodLT(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldLT(FieldMethod, vc))
+}
+
+// MethodLTE applies the LTE predicate on the "method" field.
+func MethodLTE(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldLTE(FieldMethod, vc))
+}
+
+// MethodContains applies the Contains predicate on the "method" field.
+func MethodContains(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldContains(FieldMethod, vc))
+}
+
+// MethodHasPrefix applies the HasPrefix predicate on the "method" field.
+func MethodHasPrefix(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldHasPrefix(FieldMethod, vc))
+}
+
+// MethodHasSuffix applies the HasSuffix predicate on the "method" field.
+func MethodHasSuffix(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldHasSuffix(FieldMethod, vc))
+}
+
+// MethodEqualFold applies the EqualFold predicate on the "method" field.
+func MethodEqualFold(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldEqualFold(FieldMethod, vc))
+}
+
+// MethodContainsFold applies the ContainsFold predicate on the "method" field.
+func MethodContainsFold(v activitymodels.Method) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldContainsFold(FieldMethod, vc))
+}
+
+// ProviderEQ applies the EQ predicate on the "provider" field.
+func ProviderEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldProvider, v))
+}
+
+// ProviderNEQ applies the NEQ predicate on the "provider" field.
+func ProviderNEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldProvider, v))
+}
+
+// ProviderIn applies the In predicate on the "provider" field.
+func ProviderIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldProvider, vs...))
+}
+
+// ProviderNotIn applies the NotIn predicate on the "provider" field.
+func ProviderNotIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldProvider, vs...))
+}
+
+// ProviderGT applies the GT predicate on the "provider" field.
+func ProviderGT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldProvider, v))
+}
+
+// ProviderGTE applies the GTE predicate on the "provider" field.
+func ProviderGTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldProvider, v))
+}
+
+// ProviderLT applies the LT predicate on the "provider" field.
+func ProviderLT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldProvider, v))
+}
+
+// ProviderLTE applies the LTE predicate on the "provider" field.
+func ProviderLTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldProvider, v))
+}
+
+// ProviderContains applies the Contains predicate on the "provider" field.
+func ProviderContains(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContains(FieldProvider, v))
+}
+
+// ProviderHasPrefix applies the HasPrefix predicate on the "provider" field.
+func ProviderHasPrefix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasPrefix(FieldProvider, v))
+}
+
+// ProviderHasSuffix applies the HasSuffix predicate on the "provider" field.
+func ProviderHasSuffix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasSuffix(FieldProvider, v))
+}
+
+// ProviderEqualFold applies the EqualFold predicate on the "provider" field.
+func ProviderEqualFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEqualFold(FieldProvider, v))
+}
+
+// ProviderContainsFold applies the ContainsFold predicate on the "provider" field.
+func ProviderContainsFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContainsFold(FieldProvider, v))
+}
+
+// IPAddressEQ applies the EQ predicate on the "ip_address" field.
+func IPAddressEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldIPAddress, v))
+}
+
+// IPAddressNEQ applies the NEQ predicate on the "ip_address" field.
+func IPAddressNEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldIPAddress, v))
+}
+
+// IPAddressIn applies the In predicate on the "ip_address" field.
+func IPAddressIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldIPAddress, vs...))
+}
+
+// IPAddressNotIn applies the NotIn predicate on the "ip_address" field.
+func IPAddressNotIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldIPAddress, vs...))
+}
+
+// IPAddressGT applies the GT predicate on the "ip_address" field.
+func IPAddressGT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldIPAddress, v))
+}
+
+// IPAddressGTE applies the GTE predicate on the "ip_address" field.
+func IPAddressGTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldIPAddress, v))
+}
+
+// IPAddressLT applies the LT predicate on the "ip_address" field.
+func IPAddressLT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldIPAddress, v))
+}
+
+// IPAddressLTE applies the LTE predicate on the "ip_address" field.
+func IPAddressLTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldIPAddress, v))
+}
+
+// IPAddressContains applies the Contains predicate on the "ip_address" field.
+func IPAddressContains(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContains(FieldIPAddress, v))
+}
+
+// IPAddressHasPrefix applies the HasPrefix predicate on the "ip_address" field.
+func IPAddressHasPrefix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasPrefix(FieldIPAddress, v))
+}
+
+// IPAddressHasSuffix applies the HasSuffix predicate on the "ip_address" field.
+func IPAddressHasSuffix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasSuffix(FieldIPAddress, v))
+}
+
+// IPAddressEqualFold applies the EqualFold predicate on the "ip_address" field.
+func IPAddressEqualFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEqualFold(FieldIPAddress, v))
+}
+
+// IPAddressContainsFold applies the ContainsFold predicate on the "ip_address" field.
+func IPAddressContainsFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContainsFold(FieldIPAddress, v))
+}
+
+// UserAgentEQ applies the EQ predicate on the "user_agent" field.
+func UserAgentEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldUserAgent, v))
+}
+
+// UserAgentNEQ applies the NEQ predicate on the "user_agent" field.
+func UserAgentNEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldUserAgent, v))
+}
+
+// UserAgentIn applies the In predicate on the "user_agent" field.
+func UserAgentIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldUserAgent, vs...))
+}
+
+// UserAgentNotIn applies the NotIn predicate on the "user_agent" field.
+func UserAgentNotIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldUserAgent, vs...))
+}
+
+// UserAgentGT applies the GT predicate on the "user_agent" field.
+func UserAgentGT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldUserAgent, v))
+}
+
+// UserAgentGTE applies the GTE predicate on the "user_agent" field.
+func UserAgentGTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldUserAgent, v))
+}
+
+// UserAgentLT applies the LT predicate on the "user_agent" field.
+func UserAgentLT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldUserAgent, v))
+}
+
+// UserAgentLTE applies the LTE predicate on the "user_agent" field.
+func UserAgentLTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldUserAgent, v))
+}
+
+// UserAgentContains applies the Contains predicate on the "user_agent" field.
+func UserAgentContains(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContains(FieldUserAgent, v))
+}
+
+// UserAgentHasPrefix applies the HasPrefix predicate on the "user_agent" field.
+func UserAgentHasPrefix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasPrefix(FieldUserAgent, v))
+}
+
+// UserAgentHasSuffix applies the HasSuffix predicate on the "user_agent" field.
+func UserAgentHasSuffix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasSuffix(FieldUserAgent, v))
+}
+
+// UserAgentEqualFold applies the EqualFold predicate on the "user_agent" field.
+func UserAgentEqualFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEqualFold(FieldUserAgent, v))
+}
+
+// UserAgentContainsFold applies the ContainsFold predicate on the "user_agent" field.
+func UserAgentContainsFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContainsFold(FieldUserAgent, v))
+}
+
+// OutcomeEQ applies the EQ predicate on the "outcome" field.
+func OutcomeEQ(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldEQ(FieldOutcome, vc))
+}
+
+// OutcomeNEQ applies the NEQ predicate on the "outcome" field.
+func OutcomeNEQ(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldNEQ(FieldOutcome, vc))
+}
+
+// OutcomeIn applies the In predicate on the "outcome" field.
+func OutcomeIn(vs ...activitymodels.Outcome) predicate.AuthActivity {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.AuthActivity(sql.FieldIn(FieldOutcome, v...))
+}
+
+// OutcomeNotIn applies the NotIn predicate on the "outcome" field.
+func OutcomeNotIn(vs ...activitymodels.Outcome) predicate.AuthActivity {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.AuthActivity(sql.FieldNotIn(FieldOutcome, v...))
+}
+
+// OutcomeGT applies the GT predicate on the "outcome" field.
+func OutcomeGT(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldGT(FieldOutcome, vc))
+}
+
+// OutcomeGTE applies the GTE predicate on the "outcome" field.
+func OutcomeGTE(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldGTE(FieldOutcome, vc))
+}
+
+// OutcomeLT applies the LT predicate on the "outcome" field.
+func OutcomeLT(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldLT(FieldOutcome, vc))
+}
+
+// OutcomeLTE applies the LTE predicate on the "outcome" field.
+func OutcomeLTE(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldLTE(FieldOutcome, vc))
+}
+
+// OutcomeContains applies the Contains predicate on the "outcome" field.
+func OutcomeContains(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldContains(FieldOutcome, vc))
+}
+
+// OutcomeHasPrefix applies the HasPrefix predicate on the "outcome" field.
+func OutcomeHasPrefix(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldHasPrefix(FieldOutcome, vc))
+}
+
+// OutcomeHasSuffix applies the HasSuffix predicate on the "outcome" field.
+func OutcomeHasSuffix(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldHasSuffix(FieldOutcome, vc))
+}
+
+// OutcomeEqualFold applies the EqualFold predicate on the "outcome" field.
+func OutcomeEqualFold(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldEqualFold(FieldOutcome, vc))
+}
+
+// OutcomeContainsFold applies the ContainsFold predicate on the "outcome" field.
+func OutcomeContainsFold(v activitymodels.Outcome) predicate.AuthActivity {
+	vc := string(v)
+	return predicate.AuthActivity(sql.FieldContainsFold(FieldOutcome, vc))
+}
+
+// FailureReasonEQ applies the EQ predicate on the "failure_reason" field.
+func FailureReasonEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldFailureReason, v))
+}
+
+// FailureReasonNEQ applies the NEQ predicate on the "failure_reason" field.
+func FailureReasonNEQ(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldFailureReason, v))
+}
+
+// FailureReasonIn applies the In predicate on the "failure_reason" field.
+func FailureReasonIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldFailureReason, vs...))
+}
+
+// FailureReasonNotIn applies the NotIn predicate on the "failure_reason" field.
+func FailureReasonNotIn(vs ...string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldFailureReason, vs...))
+}
+
+// FailureReasonGT applies the GT predicate on the "failure_reason" field.
+func FailureReasonGT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldFailureReason, v))
+}
+
+// FailureReasonGTE applies the GTE predicate on the "failure_reason" field.
+func FailureReasonGTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldFailureReason, v))
+}
+
+// FailureReasonLT applies the LT predicate on the "failure_reason" field.
+func FailureReasonLT(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldFailureReason, v))
+}
+
+// FailureReasonLTE applies the LTE predicate on the "failure_reason" field.
+func FailureReasonLTE(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldFailureReason, v))
+}
+
+// FailureReasonContains applies the Contains predicate on the "failure_reason" field.
+func FailureReasonContains(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContains(FieldFailureReason, v))
+}
+
+// FailureReasonHasPrefix applies the HasPrefix predicate on the "failure_reason" field.
+func FailureReasonHasPrefix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasPrefix(FieldFailureReason, v))
+}
+
+// FailureReasonHasSuffix applies the HasSuffix predicate on the "failure_reason" field.
+func FailureReasonHasSuffix(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldHasSuffix(FieldFailureReason, v))
+}
+
+// FailureReasonEqualFold applies the EqualFold predicate on the "failure_reason" field.
+func FailureReasonEqualFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEqualFold(FieldFailureReason, v))
+}
+
+// FailureReasonContainsFold applies the ContainsFold predicate on the "failure_reason" field.
+func FailureReasonContainsFold(v string) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldContainsFold(FieldFailureReason, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.AuthActivity) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.AuthActivity) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.AuthActivity) predicate.AuthActivity {
+	return predicate.AuthActivity(sql.NotPredicates(p))
+}
@@ -0,0 +1,390 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+)
+
+// AuthActivityCreate is the builder for creating a AuthActivity entity.
+type AuthActivityCreate struct {
+	config
+	mutation *AuthActivityMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (aac *AuthActivityCreate) SetUserID(u uuid.UUID) *AuthActivityCreate {
+	aac.mutation.SetUserID(u)
+	return aac
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableUserID(u *uuid.UUID) *AuthActivityCreate {
+	if u != nil {
+		aac.SetUserID(*u)
+	}
+	return aac
+}
+
+// SetMethod sets the "method" field.
+func (aac *AuthActivityCreate) SetMethod(a activitymodels.Method) *AuthActivityCreate {
+	aac.mutation.SetMethod(a)
+	return aac
+}
+
+// SetProvider sets the "provider" field.
+func (aac *AuthActivityCreate) SetProvider(s string) *AuthActivityCreate {
+	aac.mutation.SetProvider(s)
+	return aac
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableProvider(s *string) *AuthActivityCreate {
+	if s != nil {
+		aac.SetProvider(*s)
+	}
+	return aac
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (aac *AuthActivityCreate) SetIPAddress(s string) *AuthActivityCreate {
+	aac.mutation.SetIPAddress(s)
+	return aac
+}
+
+// SetNillableIPAddress sets the "ip_address" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableIPAddress(s *string) *AuthActivityCreate {
+	if s != nil {
+		aac.SetIPAddress(*s)
+	}
+	return aac
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (aac *AuthActivityCreate) SetUserAgent(s string) *AuthActivityCreate {
+	aac.mutation.SetUserAgent(s)
+	return aac
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableUserAgent(s *string) *AuthActivityCreate {
+	if s != nil {
+		aac.SetUserAgent(*s)
+	}
+	return aac
+}
+
+// SetOutcome sets the "outcome" field.
+func (aac *AuthActivityCreate) SetOutcome(a activitymodels.Outcome) *AuthActivityCreate {
+	aac.mutation.SetOutcome(a)
+	return aac
+}
+
+// SetFailureReason sets the "failure_reason" field.
+func (aac *AuthActivityCreate) SetFailureReason(s string) *AuthActivityCreate {
+	aac.mutation.SetFailureReason(s)
+	return aac
+}
+
+// SetNillableFailureReason sets the "failure_reason" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableFailureReason(s *string) *AuthActivityCreate {
+	if s != nil {
+		aac.SetFailureReason(*s)
+	}
+	return aac
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (aac *AuthActivityCreate) SetCreatedAt(t time.Time) *AuthActivityCreate {
+	aac.mutation.SetCreatedAt(t)
+	return aac
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableCreatedAt(t *time.Time) *AuthActivityCreate {
+	if t != nil {
+		aac.SetCreatedAt(*t)
+	}
+	return aac
+}
+
+// SetID sets the "id" field.
+func (aac *AuthActivityCreate) SetID(u uuid.UUID) *AuthActivityCreate {
+	aac.mutation.SetID(u)
+	return aac
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (aac *AuthActivityCreate) SetNillableID(u *uuid.UUID) *AuthActivityCreate {
+	if u != nil {
+		aac.SetID(*u)
+	}
+	return aac
+}
+
+// Mutation returns the AuthActivityMutation object of the builder.
+func (aac *AuthActivityCreate) Mutation() *AuthActivityMutation {
+	return aac.mutation
+}
+
+// Save creates the AuthActivity in the database.
+func (aac *AuthActivityCreate) Save(ctx context.Context) (*AuthActivity, error) {
+	if err := aac.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, aac.sqlSave, aac.mutation, aac.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (aac *AuthActivityCreate) SaveX(ctx context.Context) *AuthActivity {
+	v, err := aac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aac *AuthActivityCreate) Exec(ctx context.Context) error {
+	_, err := aac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aac *AuthActivityCreate) ExecX(ctx context.Context) {
+	if err := aac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (aac *AuthActivityCreate) defaults() error {
+	if _, ok := aac.mutation.Provider(); !ok {
+		v := authactivity.DefaultProvider
+		aac.mutation.SetProvider(v)
+	}
+	if _, ok := aac.mutation.IPAddress(); !ok {
+		v := authactivity.DefaultIPAddress
+		aac.mutation.SetIPAddress(v)
+	}
+	if _, ok := aac.mutation.UserAgent(); !ok {
+		v := authactivity.DefaultUserAgent
+		aac.mutation.SetUserAgent(v)
+	}
+	if _, ok := aac.mutation.FailureReason(); !ok {
+		v := authactivity.DefaultFailureReason
+		aac.mutation.SetFailureReason(v)
+	}
+	if _, ok := aac.mutation.CreatedAt(); !ok {
+		if authactivity.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized authactivity.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := authactivity.DefaultCreatedAt()
+		aac.mutation.SetCreatedAt(v)
+	}
+	if _, ok := aac.mutation.ID(); !ok {
+		if authactivity.DefaultID == nil {
+			return fmt.Errorf("ent: uninitialized authactivity.DefaultID (forgotten import ent/runtime?)")
+		}
+		v := authactivity.DefaultID()
+		aac.mutation.SetID(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aac *AuthActivityCreate) check() error {
+	if _, ok := aac.mutation.Method(); !ok {
+		return &ValidationError{Name: "method", err: errors.New(`ent: missing required field "AuthActivity.method"`)}
+	}
+	if v, ok := aac.mutation.Method(); ok {
+		if err := authactivity.MethodValidator(string(v)); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "AuthActivity.method": %w`, err)}
+		}
+	}
+	if _, ok := aac.mutation.Provider(); !ok {
+		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "AuthActivity.provider"`)}
+	}
+	if _, ok := aac.mutation.IPAddress(); !ok {
+		return &ValidationError{Name: "ip_address", err: errors.New(`ent: missing required field "AuthActivity.ip_address"`)}
+	}
+	if _, ok := aac.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`ent: missing required field "AuthActivity.user_agent"`)}
+	}
+	if _, ok := aac.mutation.Outcome(); !ok {
+		return &ValidationError{Name: "outcome", err: errors.New(`ent: missing required field "AuthActivity.outcome"`)}
+	}
+	if v, ok := aac.mutation.Outcome(); ok {
+		if err := authactivity.OutcomeValidator(string(v)); err != nil {
+			return &ValidationError{Name: "outcome", err: fmt.Errorf(`ent: validator failed for field "AuthActivity.outcome": %w`, err)}
+		}
+	}
+	if _, ok := aac.mutation.FailureReason(); !ok {
+		return &ValidationError{Name: "failure_reason", err: errors.New(`ent: missing required field "AuthActivity.failure_reason"`)}
+	}
+	if _, ok := aac.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AuthActivity.created_at"`)}
+	}
+	return nil
+}
+
+func (aac *AuthActivityCreate) sqlSave(ctx context.Context) (*AuthActivity, error) {
+	if err := aac.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := aac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, aac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	aac.mutation.id = &_node.ID
+	aac.mutation.done = true
+	return _node, nil
+}
+
+func (aac *AuthActivityCreate) createSpec() (*AuthActivity, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuthActivity{config: aac.config}
+		_spec = sqlgraph.NewCreateSpec(authactivity.Table, sqlgraph.NewFieldSpec(authactivity.FieldID, field.TypeUUID))
+	)
+	if id, ok := aac.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := aac.mutation.UserID(); ok {
+		_spec.SetField(authactivity.FieldUserID, field.TypeUUID, value)
+		_node.UserID = &value
+	}
+	if value, ok := aac.mutation.Method(); ok {
+		_spec.SetField(authactivity.FieldMethod, field.TypeString, value)
+		_node.Method = value
+	}
+	if value, ok := aac.mutation.Provider(); ok {
+		_spec.SetField(authactivity.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := aac.mutation.IPAddress(); ok {
+		_spec.SetField(authactivity.FieldIPAddress, field.TypeString, value)
+		_node.IPAddress = value
+	}
+	if value, ok := aac.mutation.UserAgent(); ok {
+		_spec.SetField(authactivity.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := aac.mutation.Outcome(); ok {
+		_spec.SetField(authactivity.FieldOutcome, field.TypeString, value)
+		_node.Outcome = value
+	}
+	if value, ok := aac.mutation.FailureReason(); ok {
+		_spec.SetField(authactivity.FieldFailureReason, field.TypeString, value)
+		_node.FailureReason = value
+	}
+	if value, ok := aac.mutation.CreatedAt(); ok {
+		_spec.SetField(authactivity.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AuthActivityCreateBulk is the builder for creating many AuthActivity entities in bulk.
+type AuthActivityCreateBulk struct {
+	config
+	err      error
+	builders []*AuthActivityCreate
+}
+
+// Save creates the AuthActivity entities in the database.
+func (aacb *AuthActivityCreateBulk) Save(ctx context.Context) ([]*AuthActivity, error) {
+	if aacb.err != nil {
+		return nil, aacb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(aacb.builders))
+	nodes := make([]*AuthActivity, len(aacb.builders))
+	mutators := make([]Mutator, len(aacb.builders))
+	for i := range aacb.builders {
+		func(i int, root context.Context) {
+			builder := aacb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuthActivityMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, aacb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, aacb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, aacb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aacb *AuthActivityCreateBulk) SaveX(ctx context.Context) []*AuthActivity {
+	v, err := aacb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aacb *AuthActivityCreateBulk) Exec(ctx context.Context) error {
+	_, err := aacb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aacb *AuthActivityCreateBulk) ExecX(ctx context.Context) {
+	if err := aacb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	"mandacode.com/accounts/auth/ent/predicate"
+)
+
+// AuthActivityDelete is the builder for deleting a AuthActivity entity.
+type AuthActivityDelete struct {
+	config
+	hooks    []Hook
+	mutation *AuthActivityMutation
+}
+
+// Where appends a list predicates to the AuthActivityDelete builder.
+func (aad *AuthActivityDelete) Where(ps ...predicate.AuthActivity) *AuthActivityDelete {
+	aad.mutation.Where(ps...)
+	return aad
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (aad *AuthActivityDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, aad.sqlExec, aad.mutation, aad.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aad *AuthActivityDelete) ExecX(ctx context.Context) int {
+	n, err := aad.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (aad *AuthActivityDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(authactivity.Table, sqlgraph.NewFieldSpec(authactivity.FieldID, field.TypeUUID))
+	if ps := aad.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, aad.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	aad.mutation.done = true
+	return affected, err
+}
+
+// AuthActivityDeleteOne is the builder for deleting a single AuthActivity entity.
+type AuthActivityDeleteOne struct {
+	aad *AuthActivityDelete
+}
+
+// Where appends a list predicates to the AuthActivityDelete builder.
+func (aado *AuthActivityDeleteOne) Where(ps ...predicate.AuthActivity) *AuthActivityDeleteOne {
+	aado.aad.mutation.Where(ps...)
+	return aado
+}
+
+// Exec executes the deletion query.
+func (aado *AuthActivityDeleteOne) Exec(ctx context.Context) error {
+	n, err := aado.aad.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{authactivity.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aado *AuthActivityDeleteOne) ExecX(ctx context.Context) {
+	if err := aado.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
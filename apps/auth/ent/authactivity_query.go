@@ -0,0 +1,528 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	"mandacode.com/accounts/auth/ent/predicate"
+)
+
+// AuthActivityQuery is the builder for querying AuthActivity entities.
+type AuthActivityQuery struct {
+	config
+	ctx        *QueryContext
+	order      []authactivity.OrderOption
+	inters     []Interceptor
+	predicates []predicate.AuthActivity
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the AuthActivityQuery builder.
+func (aaq *AuthActivityQuery) Where(ps ...predicate.AuthActivity) *AuthActivityQuery {
+	aaq.predicates = append(aaq.predicates, ps...)
+	return aaq
+}
+
+// Limit the number of records to be returned by this query.
+func (aaq *AuthActivityQuery) Limit(limit int) *AuthActivityQuery {
+	aaq.ctx.Limit = &limit
+	return aaq
+}
+
+// Offset to start from.
+func (aaq *AuthActivityQuery) Offset(offset int) *AuthActivityQuery {
+	aaq.ctx.Offset = &offset
+	return aaq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (aaq *AuthActivityQuery) Unique(unique bool) *AuthActivityQuery {
+	aaq.ctx.Unique = &unique
+	return aaq
+}
+
+// Order specifies how the records should be ordered.
+func (aaq *AuthActivityQuery) Order(o ...authactivity.OrderOption) *AuthActivityQuery {
+	aaq.order = append(aaq.order, o...)
+	return aaq
+}
+
+// First returns the first AuthActivity entity from the query.
+// Returns a *NotFoundError when no AuthActivity was found.
+func (aaq *AuthActivityQuery) First(ctx context.Context) (*AuthActivity, error) {
+	nodes, err := aaq.Limit(1).All(setContextOp(ctx, aaq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{authactivity.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (aaq *AuthActivityQuery) FirstX(ctx context.Context) *AuthActivity {
+	node, err := aaq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first AuthActivity ID from the query.
+// Returns a *NotFoundError when no AuthActivity ID was found.
+func (aaq *AuthActivityQuery) FirstID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = aaq.Limit(1).IDs(setContextOp(ctx, aaq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{authactivity.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (aaq *AuthActivityQuery) FirstIDX(ctx context.Context) uuid.UUID {
+	id, err := aaq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single AuthActivity entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one AuthActivity entity is found.
+// Returns a *NotFoundError when no AuthActivity entities are found.
+func (aaq *AuthActivityQuery) Only(ctx context.Context) (*AuthActivity, error) {
+	nodes, err := aaq.Limit(2).All(setContextOp(ctx, aaq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{authactivity.Label}
+	default:
+		return nil, &NotSingularError{authactivity.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (aaq *AuthActivityQuery) OnlyX(ctx context.Context) *AuthActivity {
+	node, err := aaq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only AuthActivity ID in the query.
+// Returns a *NotSingularError when more than one AuthActivity ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (aaq *AuthActivityQuery) OnlyID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = aaq.Limit(2).IDs(setContextOp(ctx, aaq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{authactivity.Label}
+	default:
+		err = &NotSingularError{authactivity.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (aaq *AuthActivityQuery) OnlyIDX(ctx context.Context) uuid.UUID {
+	id, err := aaq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of AuthActivities.
+func (aaq *AuthActivityQuery) All(ctx context.Context) ([]*AuthActivity, error) {
+	ctx = setContextOp(ctx, aaq.ctx, ent.OpQueryAll)
+	if err := aaq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*AuthActivity, *AuthActivityQuery]()
+	return withInterceptors[[]*AuthActivity](ctx, aaq, qr, aaq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (aaq *AuthActivityQuery) AllX(ctx context.Context) []*AuthActivity {
+	nodes, err := aaq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of AuthActivity IDs.
+func (aaq *AuthActivityQuery) IDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	if aaq.ctx.Unique == nil && aaq.path != nil {
+		aaq.Unique(true)
+	}
+	ctx = setContextOp(ctx, aaq.ctx, ent.OpQueryIDs)
+	if err = aaq.Select(authactivity.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (aaq *AuthActivityQuery) IDsX(ctx context.Context) []uuid.UUID {
+	ids, err := aaq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (aaq *AuthActivityQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, aaq.ctx, ent.OpQueryCount)
+	if err := aaq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, aaq, querierCount[*AuthActivityQuery](), aaq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (aaq *AuthActivityQuery) CountX(ctx context.Context) int {
+	count, err := aaq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (aaq *AuthActivityQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, aaq.ctx, ent.OpQueryExist)
+	switch _, err := aaq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (aaq *AuthActivityQuery) ExistX(ctx context.Context) bool {
+	exist, err := aaq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the AuthActivityQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (aaq *AuthActivityQuery) Clone() *AuthActivityQuery {
+	if aaq == nil {
+		return nil
+	}
+	return &AuthActivityQuery{
+		config:     aaq.config,
+		ctx:        aaq.ctx.Clone(),
+		order:      append([]authactivity.OrderOption{}, aaq.order...),
+		inters:     append([]Interceptor{}, aaq.inters...),
+		predicates: append([]predicate.AuthActivity{}, aaq.predicates...),
+		// clone intermediate query.
+		sql:  aaq.sql.Clone(),
+		path: aaq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID uuid.UUID `json:"user_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.AuthActivity.Query().
+//		GroupBy(authactivity.FieldUserID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (aaq *AuthActivityQuery) GroupBy(field string, fields ...string) *AuthActivityGroupBy {
+	aaq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &AuthActivityGroupBy{build: aaq}
+	grbuild.flds = &aaq.ctx.Fields
+	grbuild.label = authactivity.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID uuid.UUID `json:"user_id,omitempty"`
+//	}
+//
+//	client.AuthActivity.Query().
+//		Select(authactivity.FieldUserID).
+//		Scan(ctx, &v)
+func (aaq *AuthActivityQuery) Select(fields ...string) *AuthActivitySelect {
+	aaq.ctx.Fields = append(aaq.ctx.Fields, fields...)
+	sbuild := &AuthActivitySelect{AuthActivityQuery: aaq}
+	sbuild.label = authactivity.Label
+	sbuild.flds, sbuild.scan = &aaq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a AuthActivitySelect configured with the given aggregations.
+func (aaq *AuthActivityQuery) Aggregate(fns ...AggregateFunc) *AuthActivitySelect {
+	return aaq.Select().Aggregate(fns...)
+}
+
+func (aaq *AuthActivityQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range aaq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, aaq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range aaq.ctx.Fields {
+		if !authactivity.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if aaq.path != nil {
+		prev, err := aaq.path(ctx)
+		if err != nil {
+			return err
+		}
+		aaq.sql = prev
+	}
+	return nil
+}
+
+func (aaq *AuthActivityQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*AuthActivity, error) {
+	var (
+		nodes = []*AuthActivity{}
+		_spec = aaq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*AuthActivity).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &AuthActivity{config: aaq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, aaq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (aaq *AuthActivityQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := aaq.querySpec()
+	_spec.Node.Columns = aaq.ctx.Fields
+	if len(aaq.ctx.Fields) > 0 {
+		_spec.Unique = aaq.ctx.Unique != nil && *aaq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, aaq.driver, _spec)
+}
+
+func (aaq *AuthActivityQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(authactivity.Table, authactivity.Columns, sqlgraph.NewFieldSpec(authactivity.FieldID, field.TypeUUID))
+	_spec.From = aaq.sql
+	if unique := aaq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if aaq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := aaq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, authactivity.FieldID)
+		for i := range fields {
+			if fields[i] != authactivity.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := aaq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := aaq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := aaq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := aaq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (aaq *AuthActivityQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(aaq.driver.Dialect())
+	t1 := builder.Table(authactivity.Table)
+	columns := aaq.ctx.Fields
+	if len(columns) == 0 {
+		columns = authactivity.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if aaq.sql != nil {
+		selector = aaq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if aaq.ctx.Unique != nil && *aaq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range aaq.predicates {
+		p(selector)
+	}
+	for _, p := range aaq.order {
+		p(selector)
+	}
+	if offset := aaq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := aaq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// AuthActivityGroupBy is the group-by builder for AuthActivity entities.
+type AuthActivityGroupBy struct {
+	selector
+	build *AuthActivityQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (aagb *AuthActivityGroupBy) Aggregate(fns ...AggregateFunc) *AuthActivityGroupBy {
+	aagb.fns = append(aagb.fns, fns...)
+	return aagb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (aagb *AuthActivityGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, aagb.build.ctx, ent.OpQueryGroupBy)
+	if err := aagb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*AuthActivityQuery, *AuthActivityGroupBy](ctx, aagb.build, aagb, aagb.build.inters, v)
+}
+
+func (aagb *AuthActivityGroupBy) sqlScan(ctx context.Context, root *AuthActivityQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(aagb.fns))
+	for _, fn := range aagb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*aagb.flds)+len(aagb.fns))
+		for _, f := range *aagb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*aagb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := aagb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// AuthActivitySelect is the builder for selecting fields of AuthActivity entities.
+type AuthActivitySelect struct {
+	*AuthActivityQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (aas *AuthActivitySelect) Aggregate(fns ...AggregateFunc) *AuthActivitySelect {
+	aas.fns = append(aas.fns, fns...)
+	return aas
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (aas *AuthActivitySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, aas.ctx, ent.OpQuerySelect)
+	if err := aas.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*AuthActivityQuery, *AuthActivitySelect](ctx, aas.AuthActivityQuery, aas, aas.inters, v)
+}
+
+func (aas *AuthActivitySelect) sqlScan(ctx context.Context, root *AuthActivityQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(aas.fns))
+	for _, fn := range aas.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*aas.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := aas.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
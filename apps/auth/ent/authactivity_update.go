@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	"mandacode.com/accounts/auth/ent/predicate"
+)
+
+// AuthActivityUpdate is the builder for updating AuthActivity entities.
+type AuthActivityUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuthActivityMutation
+}
+
+// Where appends a list predicates to the AuthActivityUpdate builder.
+func (aau *AuthActivityUpdate) Where(ps ...predicate.AuthActivity) *AuthActivityUpdate {
+	aau.mutation.Where(ps...)
+	return aau
+}
+
+// Mutation returns the AuthActivityMutation object of the builder.
+func (aau *AuthActivityUpdate) Mutation() *AuthActivityMutation {
+	return aau.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (aau *AuthActivityUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, aau.sqlSave, aau.mutation, aau.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aau *AuthActivityUpdate) SaveX(ctx context.Context) int {
+	affected, err := aau.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (aau *AuthActivityUpdate) Exec(ctx context.Context) error {
+	_, err := aau.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aau *AuthActivityUpdate) ExecX(ctx context.Context) {
+	if err := aau.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (aau *AuthActivityUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(authactivity.Table, authactivity.Columns, sqlgraph.NewFieldSpec(authactivity.FieldID, field.TypeUUID))
+	if ps := aau.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if aau.mutation.UserIDCleared() {
+		_spec.ClearField(authactivity.FieldUserID, field.TypeUUID)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, aau.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{authactivity.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	aau.mutation.done = true
+	return n, nil
+}
+
+// AuthActivityUpdateOne is the builder for updating a single AuthActivity entity.
+type AuthActivityUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuthActivityMutation
+}
+
+// Mutation returns the AuthActivityMutation object of the builder.
+func (aauo *AuthActivityUpdateOne) Mutation() *AuthActivityMutation {
+	return aauo.mutation
+}
+
+// Where appends a list predicates to the AuthActivityUpdate builder.
+func (aauo *AuthActivityUpdateOne) Where(ps ...predicate.AuthActivity) *AuthActivityUpdateOne {
+	aauo.mutation.Where(ps...)
+	return aauo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aauo *AuthActivityUpdateOne) Select(field string, fields ...string) *AuthActivityUpdateOne {
+	aauo.fields = append([]string{field}, fields...)
+	return aauo
+}
+
+// Save executes the query and returns the updated AuthActivity entity.
+func (aauo *AuthActivityUpdateOne) Save(ctx context.Context) (*AuthActivity, error) {
+	return withHooks(ctx, aauo.sqlSave, aauo.mutation, aauo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aauo *AuthActivityUpdateOne) SaveX(ctx context.Context) *AuthActivity {
+	node, err := aauo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aauo *AuthActivityUpdateOne) Exec(ctx context.Context) error {
+	_, err := aauo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aauo *AuthActivityUpdateOne) ExecX(ctx context.Context) {
+	if err := aauo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (aauo *AuthActivityUpdateOne) sqlSave(ctx context.Context) (_node *AuthActivity, err error) {
+	_spec := sqlgraph.NewUpdateSpec(authactivity.Table, authactivity.Columns, sqlgraph.NewFieldSpec(authactivity.FieldID, field.TypeUUID))
+	id, ok := aauo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuthActivity.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aauo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, authactivity.FieldID)
+		for _, f := range fields {
+			if !authactivity.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != authactivity.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aauo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if aauo.mutation.UserIDCleared() {
+		_spec.ClearField(authactivity.FieldUserID, field.TypeUUID)
+	}
+	_node = &AuthActivity{config: aauo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aauo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{authactivity.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	aauo.mutation.done = true
+	return _node, nil
+}
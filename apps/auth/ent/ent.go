@@ -13,6 +13,13 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"mandacode.com/accounts/auth/ent/authaccount"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	"mandacode.com/accounts/auth/ent/providertoken"
+	"mandacode.com/accounts/auth/ent/recoverycode"
+	"mandacode.com/accounts/auth/ent/session"
+	"mandacode.com/accounts/auth/ent/signupinvite"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+	"mandacode.com/accounts/auth/ent/webauthncredential"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -73,7 +80,14 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			authaccount.Table: authaccount.ValidColumn,
+			authaccount.Table:        authaccount.ValidColumn,
+			authactivity.Table:       authactivity.ValidColumn,
+			providertoken.Table:      providertoken.ValidColumn,
+			recoverycode.Table:       recoverycode.ValidColumn,
+			session.Table:            session.ValidColumn,
+			signupinvite.Table:       signupinvite.ValidColumn,
+			totpcredential.Table:     totpcredential.ValidColumn,
+			webauthncredential.Table: webauthncredential.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -0,0 +1,13 @@
+-- Create "totp_credentials" table
+CREATE TABLE "public"."totp_credentials" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "encrypted_secret" character varying NOT NULL,
+  "is_confirmed" boolean NOT NULL DEFAULT false,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "totpcredential_user_id" to table: "totp_credentials"
+CREATE UNIQUE INDEX "totpcredential_user_id" ON "public"."totp_credentials" ("user_id");
@@ -0,0 +1,21 @@
+-- Create "webauthn_credentials" table
+CREATE TABLE "public"."webauthn_credentials" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "credential_id" bytea NOT NULL,
+  "public_key" bytea NOT NULL,
+  "sign_count" bigint NOT NULL DEFAULT 0,
+  "aaguid" uuid NOT NULL,
+  "transports" jsonb NULL,
+  "name" character varying NOT NULL DEFAULT '',
+  "backup_eligible" boolean NOT NULL DEFAULT false,
+  "backup_state" boolean NOT NULL DEFAULT false,
+  "last_used_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "webauthn_credentials_credential_id_key" to table: "webauthn_credentials"
+CREATE UNIQUE INDEX "webauthn_credentials_credential_id_key" ON "public"."webauthn_credentials" ("credential_id");
+-- Create index "webauthncredential_user_id" to table: "webauthn_credentials"
+CREATE INDEX "webauthncredential_user_id" ON "public"."webauthn_credentials" ("user_id");
@@ -0,0 +1,13 @@
+-- Modify "totp_credentials" table
+ALTER TABLE "public"."totp_credentials" ADD COLUMN "name" character varying(64) NOT NULL DEFAULT '';
+-- Create "recovery_codes" table
+CREATE TABLE "public"."recovery_codes" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "code_hash" character varying NOT NULL,
+  "used_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "recoverycode_user_id_code_hash" to table: "recovery_codes"
+CREATE UNIQUE INDEX "recoverycode_user_id_code_hash" ON "public"."recovery_codes" ("user_id", "code_hash");
@@ -0,0 +1,20 @@
+-- Create "provider_tokens" table
+CREATE TABLE "public"."provider_tokens" (
+  "id" uuid NOT NULL,
+  "auth_account_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "provider" character varying NOT NULL,
+  "key_id" character varying NOT NULL,
+  "encrypted_access_token" character varying NOT NULL,
+  "encrypted_refresh_token" character varying NULL,
+  "token_type" character varying NOT NULL DEFAULT '',
+  "scope" character varying NOT NULL DEFAULT '',
+  "expires_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "providertoken_auth_account_id" to table: "provider_tokens"
+CREATE UNIQUE INDEX "providertoken_auth_account_id" ON "public"."provider_tokens" ("auth_account_id");
+-- Create index "providertoken_user_id_provider" to table: "provider_tokens"
+CREATE UNIQUE INDEX "providertoken_user_id_provider" ON "public"."provider_tokens" ("user_id", "provider");
@@ -0,0 +1,17 @@
+-- Create "sessions" table
+CREATE TABLE "public"."sessions" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "family_id" character varying NOT NULL,
+  "device_name" character varying NOT NULL DEFAULT '',
+  "user_agent" character varying NOT NULL DEFAULT '',
+  "ip_address" character varying NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL,
+  "last_used_at" timestamptz NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "sessions_family_id_key" to table: "sessions"
+CREATE UNIQUE INDEX "sessions_family_id_key" ON "public"."sessions" ("family_id");
+-- Create index "session_user_id" to table: "sessions"
+CREATE INDEX "session_user_id" ON "public"."sessions" ("user_id");
@@ -0,0 +1,19 @@
+-- Create "auth_activities" table
+CREATE TABLE "public"."auth_activities" (
+  "id" uuid NOT NULL,
+  "user_id" uuid NULL,
+  "method" character varying NOT NULL,
+  "provider" character varying NOT NULL DEFAULT '',
+  "ip_address" character varying NOT NULL DEFAULT '',
+  "user_agent" character varying NOT NULL DEFAULT '',
+  "outcome" character varying NOT NULL,
+  "failure_reason" character varying NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "authactivity_user_id_created_at" to table: "auth_activities"
+CREATE INDEX "authactivity_user_id_created_at" ON "public"."auth_activities" ("user_id", "created_at");
+-- Create index "authactivity_ip_address_created_at" to table: "auth_activities"
+CREATE INDEX "authactivity_ip_address_created_at" ON "public"."auth_activities" ("ip_address", "created_at");
+-- Create index "authactivity_created_at" to table: "auth_activities"
+CREATE INDEX "authactivity_created_at" ON "public"."auth_activities" ("created_at");
@@ -0,0 +1,14 @@
+-- Create "signup_invites" table
+CREATE TABLE "public"."signup_invites" (
+  "id" uuid NOT NULL,
+  "code_hash" character varying NOT NULL,
+  "email" character varying NOT NULL DEFAULT '',
+  "created_by" character varying NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz NULL,
+  "used_by" uuid NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "signup_invites_code_hash_key" to table: "signup_invites"
+CREATE UNIQUE INDEX "signup_invites_code_hash_key" ON "public"."signup_invites" ("code_hash");
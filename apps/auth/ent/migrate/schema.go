@@ -12,7 +12,7 @@ var (
 	AuthAccountsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID, Unique: true},
 		{Name: "user_id", Type: field.TypeUUID},
-		{Name: "provider", Type: field.TypeEnum, Enums: []string{"local", "google", "kakao", "naver", "apple"}},
+		{Name: "provider", Type: field.TypeString},
 		{Name: "provider_id", Type: field.TypeString, Nullable: true},
 		{Name: "is_verified", Type: field.TypeBool, Default: false},
 		{Name: "email", Type: field.TypeString},
@@ -43,9 +43,200 @@ var (
 			},
 		},
 	}
+	// AuthActivitiesColumns holds the columns for the "auth_activities" table.
+	AuthActivitiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "user_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "method", Type: field.TypeString},
+		{Name: "provider", Type: field.TypeString, Default: ""},
+		{Name: "ip_address", Type: field.TypeString, Default: ""},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "outcome", Type: field.TypeString},
+		{Name: "failure_reason", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// AuthActivitiesTable holds the schema information for the "auth_activities" table.
+	AuthActivitiesTable = &schema.Table{
+		Name:       "auth_activities",
+		Columns:    AuthActivitiesColumns,
+		PrimaryKey: []*schema.Column{AuthActivitiesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "authactivity_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuthActivitiesColumns[1], AuthActivitiesColumns[8]},
+			},
+			{
+				Name:    "authactivity_ip_address_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuthActivitiesColumns[4], AuthActivitiesColumns[8]},
+			},
+			{
+				Name:    "authactivity_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuthActivitiesColumns[8]},
+			},
+		},
+	}
+	// ProviderTokensColumns holds the columns for the "provider_tokens" table.
+	ProviderTokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "auth_account_id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "key_id", Type: field.TypeString},
+		{Name: "encrypted_access_token", Type: field.TypeString},
+		{Name: "encrypted_refresh_token", Type: field.TypeString, Nullable: true},
+		{Name: "token_type", Type: field.TypeString, Default: ""},
+		{Name: "scope", Type: field.TypeString, Default: ""},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// ProviderTokensTable holds the schema information for the "provider_tokens" table.
+	ProviderTokensTable = &schema.Table{
+		Name:       "provider_tokens",
+		Columns:    ProviderTokensColumns,
+		PrimaryKey: []*schema.Column{ProviderTokensColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "providertoken_auth_account_id",
+				Unique:  true,
+				Columns: []*schema.Column{ProviderTokensColumns[1]},
+			},
+			{
+				Name:    "providertoken_user_id_provider",
+				Unique:  true,
+				Columns: []*schema.Column{ProviderTokensColumns[2], ProviderTokensColumns[3]},
+			},
+		},
+	}
+	// RecoveryCodesColumns holds the columns for the "recovery_codes" table.
+	RecoveryCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "code_hash", Type: field.TypeString},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// RecoveryCodesTable holds the schema information for the "recovery_codes" table.
+	RecoveryCodesTable = &schema.Table{
+		Name:       "recovery_codes",
+		Columns:    RecoveryCodesColumns,
+		PrimaryKey: []*schema.Column{RecoveryCodesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "recoverycode_user_id_code_hash",
+				Unique:  true,
+				Columns: []*schema.Column{RecoveryCodesColumns[1], RecoveryCodesColumns[2]},
+			},
+		},
+	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "family_id", Type: field.TypeString, Unique: true},
+		{Name: "device_name", Type: field.TypeString, Default: ""},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "ip_address", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "session_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[1]},
+			},
+		},
+	}
+	// SignupInvitesColumns holds the columns for the "signup_invites" table.
+	SignupInvitesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "code_hash", Type: field.TypeString, Unique: true},
+		{Name: "email", Type: field.TypeString, Default: ""},
+		{Name: "created_by", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "used_by", Type: field.TypeUUID, Nullable: true},
+	}
+	// SignupInvitesTable holds the schema information for the "signup_invites" table.
+	SignupInvitesTable = &schema.Table{
+		Name:       "signup_invites",
+		Columns:    SignupInvitesColumns,
+		PrimaryKey: []*schema.Column{SignupInvitesColumns[0]},
+	}
+	// TotpCredentialsColumns holds the columns for the "totp_credentials" table.
+	TotpCredentialsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "encrypted_secret", Type: field.TypeString},
+		{Name: "is_confirmed", Type: field.TypeBool, Default: false},
+		{Name: "name", Type: field.TypeString, Size: 64, Default: ""},
+		{Name: "last_used_step", Type: field.TypeInt64, Default: 0},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// TotpCredentialsTable holds the schema information for the "totp_credentials" table.
+	TotpCredentialsTable = &schema.Table{
+		Name:       "totp_credentials",
+		Columns:    TotpCredentialsColumns,
+		PrimaryKey: []*schema.Column{TotpCredentialsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "totpcredential_user_id",
+				Unique:  true,
+				Columns: []*schema.Column{TotpCredentialsColumns[1]},
+			},
+		},
+	}
+	// WebauthnCredentialsColumns holds the columns for the "webauthn_credentials" table.
+	WebauthnCredentialsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "credential_id", Type: field.TypeBytes, Unique: true},
+		{Name: "public_key", Type: field.TypeBytes},
+		{Name: "sign_count", Type: field.TypeUint32, Default: 0},
+		{Name: "aaguid", Type: field.TypeUUID},
+		{Name: "transports", Type: field.TypeJSON, Nullable: true},
+		{Name: "name", Type: field.TypeString, Default: ""},
+		{Name: "backup_eligible", Type: field.TypeBool, Default: false},
+		{Name: "backup_state", Type: field.TypeBool, Default: false},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// WebauthnCredentialsTable holds the schema information for the "webauthn_credentials" table.
+	WebauthnCredentialsTable = &schema.Table{
+		Name:       "webauthn_credentials",
+		Columns:    WebauthnCredentialsColumns,
+		PrimaryKey: []*schema.Column{WebauthnCredentialsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webauthncredential_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{WebauthnCredentialsColumns[1]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		AuthAccountsTable,
+		AuthActivitiesTable,
+		ProviderTokensTable,
+		RecoveryCodesTable,
+		SessionsTable,
+		SignupInvitesTable,
+		TotpCredentialsTable,
+		WebauthnCredentialsTable,
 	}
 )
 
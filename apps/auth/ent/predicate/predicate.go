@@ -8,3 +8,24 @@ import (
 
 // AuthAccount is the predicate function for authaccount builders.
 type AuthAccount func(*sql.Selector)
+
+// AuthActivity is the predicate function for authactivity builders.
+type AuthActivity func(*sql.Selector)
+
+// ProviderToken is the predicate function for providertoken builders.
+type ProviderToken func(*sql.Selector)
+
+// RecoveryCode is the predicate function for recoverycode builders.
+type RecoveryCode func(*sql.Selector)
+
+// Session is the predicate function for session builders.
+type Session func(*sql.Selector)
+
+// SignupInvite is the predicate function for signupinvite builders.
+type SignupInvite func(*sql.Selector)
+
+// TotpCredential is the predicate function for totpcredential builders.
+type TotpCredential func(*sql.Selector)
+
+// WebauthnCredential is the predicate function for webauthncredential builders.
+type WebauthnCredential func(*sql.Selector)
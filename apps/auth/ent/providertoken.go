@@ -0,0 +1,221 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/providertoken"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+)
+
+// ProviderToken is the model entity for the ProviderToken schema.
+type ProviderToken struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The unique identifier for the provider token
+	ID uuid.UUID `json:"id,omitempty"`
+	// The unique identifier for the OAuth authentication account the tokens were issued for
+	AuthAccountID uuid.UUID `json:"auth_account_id,omitempty"`
+	// The unique identifier for the user owning the authentication account
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// The name of the OAuth provider that issued the tokens
+	Provider providermodels.Provider `json:"provider,omitempty"`
+	// The ID of the key the tokens are encrypted with
+	KeyID string `json:"key_id,omitempty"`
+	// The access token of the provider, encrypted with the key of key_id
+	EncryptedAccessToken string `json:"-"`
+	// The refresh token of the provider, encrypted with the key of key_id, nil if none was issued
+	EncryptedRefreshToken *string `json:"-"`
+	// The type of the access token, usually Bearer
+	TokenType string `json:"token_type,omitempty"`
+	// The scopes granted to the access token, separated by spaces
+	Scope string `json:"scope,omitempty"`
+	// The time when the access token expires, nil if the provider did not say
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// The time when the provider token was first stored
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// The time when the provider token was last updated
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ProviderToken) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case providertoken.FieldProvider, providertoken.FieldKeyID, providertoken.FieldEncryptedAccessToken, providertoken.FieldEncryptedRefreshToken, providertoken.FieldTokenType, providertoken.FieldScope:
+			values[i] = new(sql.NullString)
+		case providertoken.FieldExpiresAt, providertoken.FieldCreatedAt, providertoken.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case providertoken.FieldID, providertoken.FieldAuthAccountID, providertoken.FieldUserID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ProviderToken fields.
+func (pt *ProviderToken) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case providertoken.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				pt.ID = *value
+			}
+		case providertoken.FieldAuthAccountID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field auth_account_id", values[i])
+			} else if value != nil {
+				pt.AuthAccountID = *value
+			}
+		case providertoken.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				pt.UserID = *value
+			}
+		case providertoken.FieldProvider:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field provider", values[i])
+			} else if value.Valid {
+				pt.Provider = providermodels.Provider(value.String)
+			}
+		case providertoken.FieldKeyID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key_id", values[i])
+			} else if value.Valid {
+				pt.KeyID = value.String
+			}
+		case providertoken.FieldEncryptedAccessToken:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted_access_token", values[i])
+			} else if value.Valid {
+				pt.EncryptedAccessToken = value.String
+			}
+		case providertoken.FieldEncryptedRefreshToken:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted_refresh_token", values[i])
+			} else if value.Valid {
+				pt.EncryptedRefreshToken = new(string)
+				*pt.EncryptedRefreshToken = value.String
+			}
+		case providertoken.FieldTokenType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_type", values[i])
+			} else if value.Valid {
+				pt.TokenType = value.String
+			}
+		case providertoken.FieldScope:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field scope", values[i])
+			} else if value.Valid {
+				pt.Scope = value.String
+			}
+		case providertoken.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				pt.ExpiresAt = new(time.Time)
+				*pt.ExpiresAt = value.Time
+			}
+		case providertoken.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pt.CreatedAt = value.Time
+			}
+		case providertoken.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				pt.UpdatedAt = value.Time
+			}
+		default:
+			pt.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ProviderToken.
+// This includes values selected through modifiers, order, etc.
+func (pt *ProviderToken) Value(name string) (ent.Value, error) {
+	return pt.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ProviderToken.
+// Note that you need to call ProviderToken.Unwrap() before calling this method if this ProviderToken
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pt *ProviderToken) Update() *ProviderTokenUpdateOne {
+	return NewProviderTokenClient(pt.config).UpdateOne(pt)
+}
+
+// Unwrap unwraps the ProviderToken entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pt *ProviderToken) Unwrap() *ProviderToken {
+	_tx, ok := pt.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ProviderToken is not a transactional entity")
+	}
+	pt.config.driver = _tx.drv
+	return pt
+}
+
+// String implements the fmt.Stringer.
+func (pt *ProviderToken) String() string {
+	var builder strings.Builder
+	builder.WriteString("ProviderToken(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pt.ID))
+	builder.WriteString("auth_account_id=")
+	builder.WriteString(fmt.Sprintf("%v", pt.AuthAccountID))
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", pt.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("provider=")
+	builder.WriteString(fmt.Sprintf("%v", pt.Provider))
+	builder.WriteString(", ")
+	builder.WriteString("key_id=")
+	builder.WriteString(pt.KeyID)
+	builder.WriteString(", ")
+	builder.WriteString("encrypted_access_token=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("encrypted_refresh_token=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("token_type=")
+	builder.WriteString(pt.TokenType)
+	builder.WriteString(", ")
+	builder.WriteString("scope=")
+	builder.WriteString(pt.Scope)
+	builder.WriteString(", ")
+	if v := pt.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(pt.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(pt.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ProviderTokens is a parsable slice of ProviderToken.
+type ProviderTokens []*ProviderToken
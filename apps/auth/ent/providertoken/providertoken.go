@@ -0,0 +1,151 @@
+// Code generated by ent, DO NOT EDIT.
+
+package providertoken
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the providertoken type in the database.
+	Label = "provider_token"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldAuthAccountID holds the string denoting the auth_account_id field in the database.
+	FieldAuthAccountID = "auth_account_id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldProvider holds the string denoting the provider field in the database.
+	FieldProvider = "provider"
+	// FieldKeyID holds the string denoting the key_id field in the database.
+	FieldKeyID = "key_id"
+	// FieldEncryptedAccessToken holds the string denoting the encrypted_access_token field in the database.
+	FieldEncryptedAccessToken = "encrypted_access_token"
+	// FieldEncryptedRefreshToken holds the string denoting the encrypted_refresh_token field in the database.
+	FieldEncryptedRefreshToken = "encrypted_refresh_token"
+	// FieldTokenType holds the string denoting the token_type field in the database.
+	FieldTokenType = "token_type"
+	// FieldScope holds the string denoting the scope field in the database.
+	FieldScope = "scope"
+	// FieldExpiresAt holds the string denoting the expires_at field in the database.
+	FieldExpiresAt = "expires_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the providertoken in the database.
+	Table = "provider_tokens"
+)
+
+// Columns holds all SQL columns for providertoken fields.
+var Columns = []string{
+	FieldID,
+	FieldAuthAccountID,
+	FieldUserID,
+	FieldProvider,
+	FieldKeyID,
+	FieldEncryptedAccessToken,
+	FieldEncryptedRefreshToken,
+	FieldTokenType,
+	FieldScope,
+	FieldExpiresAt,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	ProviderValidator func(string) error
+	// KeyIDValidator is a validator for the "key_id" field. It is called by the builders before save.
+	KeyIDValidator func(string) error
+	// EncryptedAccessTokenValidator is a validator for the "encrypted_access_token" field. It is called by the builders before save.
+	EncryptedAccessTokenValidator func(string) error
+	// DefaultTokenType holds the default value on creation for the "token_type" field.
+	DefaultTokenType string
+	// DefaultScope holds the default value on creation for the "scope" field.
+	DefaultScope string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the ProviderToken queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByAuthAccountID orders the results by the auth_account_id field.
+func ByAuthAccountID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAuthAccountID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByProvider orders the results by the provider field.
+func ByProvider(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProvider, opts...).ToFunc()
+}
+
+// ByKeyID orders the results by the key_id field.
+func ByKeyID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKeyID, opts...).ToFunc()
+}
+
+// ByEncryptedAccessToken orders the results by the encrypted_access_token field.
+func ByEncryptedAccessToken(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEncryptedAccessToken, opts...).ToFunc()
+}
+
+// ByEncryptedRefreshToken orders the results by the encrypted_refresh_token field.
+func ByEncryptedRefreshToken(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEncryptedRefreshToken, opts...).ToFunc()
+}
+
+// ByTokenType orders the results by the token_type field.
+func ByTokenType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTokenType, opts...).ToFunc()
+}
+
+// ByScope orders the results by the scope field.
+func ByScope(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScope, opts...).ToFunc()
+}
+
+// ByExpiresAt orders the results by the expires_at field.
+func ByExpiresAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExpiresAt, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
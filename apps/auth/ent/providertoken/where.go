@@ -0,0 +1,757 @@
+// Code generated by ent, DO NOT EDIT.
+
+package providertoken
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/predicate"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldID, id))
+}
+
+// AuthAccountID applies equality check predicate on the "auth_account_id" field. It's identical to AuthAccountIDEQ.
+func AuthAccountID(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldAuthAccountID, v))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldUserID, v))
+}
+
+// Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
+func Provider(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldEQ(FieldProvider, vc))
+}
+
+// KeyID applies equality check predicate on the "key_id" field. It's identical to KeyIDEQ.
+func KeyID(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldKeyID, v))
+}
+
+// EncryptedAccessToken applies equality check predicate on the "encrypted_access_token" field. It's identical to EncryptedAccessTokenEQ.
+func EncryptedAccessToken(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedRefreshToken applies equality check predicate on the "encrypted_refresh_token" field. It's identical to EncryptedRefreshTokenEQ.
+func EncryptedRefreshToken(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldEncryptedRefreshToken, v))
+}
+
+// TokenType applies equality check predicate on the "token_type" field. It's identical to TokenTypeEQ.
+func TokenType(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldTokenType, v))
+}
+
+// Scope applies equality check predicate on the "scope" field. It's identical to ScopeEQ.
+func Scope(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldScope, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// AuthAccountIDEQ applies the EQ predicate on the "auth_account_id" field.
+func AuthAccountIDEQ(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldAuthAccountID, v))
+}
+
+// AuthAccountIDNEQ applies the NEQ predicate on the "auth_account_id" field.
+func AuthAccountIDNEQ(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldAuthAccountID, v))
+}
+
+// AuthAccountIDIn applies the In predicate on the "auth_account_id" field.
+func AuthAccountIDIn(vs ...uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldAuthAccountID, vs...))
+}
+
+// AuthAccountIDNotIn applies the NotIn predicate on the "auth_account_id" field.
+func AuthAccountIDNotIn(vs ...uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldAuthAccountID, vs...))
+}
+
+// AuthAccountIDGT applies the GT predicate on the "auth_account_id" field.
+func AuthAccountIDGT(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldAuthAccountID, v))
+}
+
+// AuthAccountIDGTE applies the GTE predicate on the "auth_account_id" field.
+func AuthAccountIDGTE(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldAuthAccountID, v))
+}
+
+// AuthAccountIDLT applies the LT predicate on the "auth_account_id" field.
+func AuthAccountIDLT(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldAuthAccountID, v))
+}
+
+// AuthAccountIDLTE applies the LTE predicate on the "auth_account_id" field.
+func AuthAccountIDLTE(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldAuthAccountID, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v uuid.UUID) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldUserID, v))
+}
+
+// ProviderEQ applies the EQ predicate on the "provider" field.
+func ProviderEQ(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldEQ(FieldProvider, vc))
+}
+
+// ProviderNEQ applies the NEQ predicate on the "provider" field.
+func ProviderNEQ(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldNEQ(FieldProvider, vc))
+}
+
+// ProviderIn applies the In predicate on the "provider" field.
+func ProviderIn(vs ...providermodels.Provider) predicate.ProviderToken {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.ProviderToken(sql.FieldIn(FieldProvider, v...))
+}
+
+// ProviderNotIn applies the NotIn predicate on the "provider" field.
+func ProviderNotIn(vs ...providermodels.Provider) predicate.ProviderToken {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.ProviderToken(sql.FieldNotIn(FieldProvider, v...))
+}
+
+// ProviderGT applies the GT predicate on the "provider" field.
+func ProviderGT(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldGT(FieldProvider, vc))
+}
+
+// ProviderGTE applies the GTE predicate on the "provider" field.
+func ProviderGTE(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldGTE(FieldProvider, vc))
+}
+
+// ProviderLT applies the LT predicate on the "provider" field.
+func ProviderLT(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldLT(FieldProvider, vc))
+}
+
+// ProviderLTE applies the LTE predicate on the "provider" field.
+func ProviderLTE(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldLTE(FieldProvider, vc))
+}
+
+// ProviderContains applies the Contains predicate on the "provider" field.
+func ProviderContains(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldContains(FieldProvider, vc))
+}
+
+// ProviderHasPrefix applies the HasPrefix predicate on the "provider" field.
+func ProviderHasPrefix(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldHasPrefix(FieldProvider, vc))
+}
+
+// ProviderHasSuffix applies the HasSuffix predicate on the "provider" field.
+func ProviderHasSuffix(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldHasSuffix(FieldProvider, vc))
+}
+
+// ProviderEqualFold applies the EqualFold predicate on the "provider" field.
+func ProviderEqualFold(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldEqualFold(FieldProvider, vc))
+}
+
+// ProviderContainsFold applies the ContainsFold predicate on the "provider" field.
+func ProviderContainsFold(v providermodels.Provider) predicate.ProviderToken {
+	vc := string(v)
+	return predicate.ProviderToken(sql.FieldContainsFold(FieldProvider, vc))
+}
+
+// KeyIDEQ applies the EQ predicate on the "key_id" field.
+func KeyIDEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldKeyID, v))
+}
+
+// KeyIDNEQ applies the NEQ predicate on the "key_id" field.
+func KeyIDNEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldKeyID, v))
+}
+
+// KeyIDIn applies the In predicate on the "key_id" field.
+func KeyIDIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldKeyID, vs...))
+}
+
+// KeyIDNotIn applies the NotIn predicate on the "key_id" field.
+func KeyIDNotIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldKeyID, vs...))
+}
+
+// KeyIDGT applies the GT predicate on the "key_id" field.
+func KeyIDGT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldKeyID, v))
+}
+
+// KeyIDGTE applies the GTE predicate on the "key_id" field.
+func KeyIDGTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldKeyID, v))
+}
+
+// KeyIDLT applies the LT predicate on the "key_id" field.
+func KeyIDLT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldKeyID, v))
+}
+
+// KeyIDLTE applies the LTE predicate on the "key_id" field.
+func KeyIDLTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldKeyID, v))
+}
+
+// KeyIDContains applies the Contains predicate on the "key_id" field.
+func KeyIDContains(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContains(FieldKeyID, v))
+}
+
+// KeyIDHasPrefix applies the HasPrefix predicate on the "key_id" field.
+func KeyIDHasPrefix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasPrefix(FieldKeyID, v))
+}
+
+// KeyIDHasSuffix applies the HasSuffix predicate on the "key_id" field.
+func KeyIDHasSuffix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasSuffix(FieldKeyID, v))
+}
+
+// KeyIDEqualFold applies the EqualFold predicate on the "key_id" field.
+func KeyIDEqualFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEqualFold(FieldKeyID, v))
+}
+
+// KeyIDContainsFold applies the ContainsFold predicate on the "key_id" field.
+func KeyIDContainsFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContainsFold(FieldKeyID, v))
+}
+
+// EncryptedAccessTokenEQ applies the EQ predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenNEQ applies the NEQ predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenNEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenIn applies the In predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldEncryptedAccessToken, vs...))
+}
+
+// EncryptedAccessTokenNotIn applies the NotIn predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenNotIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldEncryptedAccessToken, vs...))
+}
+
+// EncryptedAccessTokenGT applies the GT predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenGT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenGTE applies the GTE predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenGTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenLT applies the LT predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenLT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenLTE applies the LTE predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenLTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenContains applies the Contains predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenContains(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContains(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenHasPrefix applies the HasPrefix predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenHasPrefix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasPrefix(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenHasSuffix applies the HasSuffix predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenHasSuffix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasSuffix(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenEqualFold applies the EqualFold predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenEqualFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEqualFold(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedAccessTokenContainsFold applies the ContainsFold predicate on the "encrypted_access_token" field.
+func EncryptedAccessTokenContainsFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContainsFold(FieldEncryptedAccessToken, v))
+}
+
+// EncryptedRefreshTokenEQ applies the EQ predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenNEQ applies the NEQ predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenNEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenIn applies the In predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldEncryptedRefreshToken, vs...))
+}
+
+// EncryptedRefreshTokenNotIn applies the NotIn predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenNotIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldEncryptedRefreshToken, vs...))
+}
+
+// EncryptedRefreshTokenGT applies the GT predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenGT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenGTE applies the GTE predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenGTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenLT applies the LT predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenLT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenLTE applies the LTE predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenLTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenContains applies the Contains predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenContains(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContains(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenHasPrefix applies the HasPrefix predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenHasPrefix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasPrefix(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenHasSuffix applies the HasSuffix predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenHasSuffix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasSuffix(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenIsNil applies the IsNil predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenIsNil() predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIsNull(FieldEncryptedRefreshToken))
+}
+
+// EncryptedRefreshTokenNotNil applies the NotNil predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenNotNil() predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotNull(FieldEncryptedRefreshToken))
+}
+
+// EncryptedRefreshTokenEqualFold applies the EqualFold predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenEqualFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEqualFold(FieldEncryptedRefreshToken, v))
+}
+
+// EncryptedRefreshTokenContainsFold applies the ContainsFold predicate on the "encrypted_refresh_token" field.
+func EncryptedRefreshTokenContainsFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContainsFold(FieldEncryptedRefreshToken, v))
+}
+
+// TokenTypeEQ applies the EQ predicate on the "token_type" field.
+func TokenTypeEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldTokenType, v))
+}
+
+// TokenTypeNEQ applies the NEQ predicate on the "token_type" field.
+func TokenTypeNEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldTokenType, v))
+}
+
+// TokenTypeIn applies the In predicate on the "token_type" field.
+func TokenTypeIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldTokenType, vs...))
+}
+
+// TokenTypeNotIn applies the NotIn predicate on the "token_type" field.
+func TokenTypeNotIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldTokenType, vs...))
+}
+
+// TokenTypeGT applies the GT predicate on the "token_type" field.
+func TokenTypeGT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldTokenType, v))
+}
+
+// TokenTypeGTE applies the GTE predicate on the "token_type" field.
+func TokenTypeGTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldTokenType, v))
+}
+
+// TokenTypeLT applies the LT predicate on the "token_type" field.
+func TokenTypeLT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldTokenType, v))
+}
+
+// TokenTypeLTE applies the LTE predicate on the "token_type" field.
+func TokenTypeLTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldTokenType, v))
+}
+
+// TokenTypeContains applies the Contains predicate on the "token_type" field.
+func TokenTypeContains(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContains(FieldTokenType, v))
+}
+
+// TokenTypeHasPrefix applies the HasPrefix predicate on the "token_type" field.
+func TokenTypeHasPrefix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasPrefix(FieldTokenType, v))
+}
+
+// TokenTypeHasSuffix applies the HasSuffix predicate on the "token_type" field.
+func TokenTypeHasSuffix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasSuffix(FieldTokenType, v))
+}
+
+// TokenTypeEqualFold applies the EqualFold predicate on the "token_type" field.
+func TokenTypeEqualFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEqualFold(FieldTokenType, v))
+}
+
+// TokenTypeContainsFold applies the ContainsFold predicate on the "token_type" field.
+func TokenTypeContainsFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContainsFold(FieldTokenType, v))
+}
+
+// ScopeEQ applies the EQ predicate on the "scope" field.
+func ScopeEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldScope, v))
+}
+
+// ScopeNEQ applies the NEQ predicate on the "scope" field.
+func ScopeNEQ(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldScope, v))
+}
+
+// ScopeIn applies the In predicate on the "scope" field.
+func ScopeIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldScope, vs...))
+}
+
+// ScopeNotIn applies the NotIn predicate on the "scope" field.
+func ScopeNotIn(vs ...string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldScope, vs...))
+}
+
+// ScopeGT applies the GT predicate on the "scope" field.
+func ScopeGT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldScope, v))
+}
+
+// ScopeGTE applies the GTE predicate on the "scope" field.
+func ScopeGTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldScope, v))
+}
+
+// ScopeLT applies the LT predicate on the "scope" field.
+func ScopeLT(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldScope, v))
+}
+
+// ScopeLTE applies the LTE predicate on the "scope" field.
+func ScopeLTE(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldScope, v))
+}
+
+// ScopeContains applies the Contains predicate on the "scope" field.
+func ScopeContains(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContains(FieldScope, v))
+}
+
+// ScopeHasPrefix applies the HasPrefix predicate on the "scope" field.
+func ScopeHasPrefix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasPrefix(FieldScope, v))
+}
+
+// ScopeHasSuffix applies the HasSuffix predicate on the "scope" field.
+func ScopeHasSuffix(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldHasSuffix(FieldScope, v))
+}
+
+// ScopeEqualFold applies the EqualFold predicate on the "scope" field.
+func ScopeEqualFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEqualFold(FieldScope, v))
+}
+
+// ScopeContainsFold applies the ContainsFold predicate on the "scope" field.
+func ScopeContainsFold(v string) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldContainsFold(FieldScope, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtIsNil applies the IsNil predicate on the "expires_at" field.
+func ExpiresAtIsNil() predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIsNull(FieldExpiresAt))
+}
+
+// ExpiresAtNotNil applies the NotNil predicate on the "expires_at" field.
+func ExpiresAtNotNil() predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotNull(FieldExpiresAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ProviderToken) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ProviderToken) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ProviderToken) predicate.ProviderToken {
+	return predicate.ProviderToken(sql.NotPredicates(p))
+}
@@ -0,0 +1,418 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/providertoken"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+)
+
+// ProviderTokenCreate is the builder for creating a ProviderToken entity.
+type ProviderTokenCreate struct {
+	config
+	mutation *ProviderTokenMutation
+	hooks    []Hook
+}
+
+// SetAuthAccountID sets the "auth_account_id" field.
+func (ptc *ProviderTokenCreate) SetAuthAccountID(u uuid.UUID) *ProviderTokenCreate {
+	ptc.mutation.SetAuthAccountID(u)
+	return ptc
+}
+
+// SetUserID sets the "user_id" field.
+func (ptc *ProviderTokenCreate) SetUserID(u uuid.UUID) *ProviderTokenCreate {
+	ptc.mutation.SetUserID(u)
+	return ptc
+}
+
+// SetProvider sets the "provider" field.
+func (ptc *ProviderTokenCreate) SetProvider(pr providermodels.Provider) *ProviderTokenCreate {
+	ptc.mutation.SetProvider(pr)
+	return ptc
+}
+
+// SetKeyID sets the "key_id" field.
+func (ptc *ProviderTokenCreate) SetKeyID(s string) *ProviderTokenCreate {
+	ptc.mutation.SetKeyID(s)
+	return ptc
+}
+
+// SetEncryptedAccessToken sets the "encrypted_access_token" field.
+func (ptc *ProviderTokenCreate) SetEncryptedAccessToken(s string) *ProviderTokenCreate {
+	ptc.mutation.SetEncryptedAccessToken(s)
+	return ptc
+}
+
+// SetEncryptedRefreshToken sets the "encrypted_refresh_token" field.
+func (ptc *ProviderTokenCreate) SetEncryptedRefreshToken(s string) *ProviderTokenCreate {
+	ptc.mutation.SetEncryptedRefreshToken(s)
+	return ptc
+}
+
+// SetNillableEncryptedRefreshToken sets the "encrypted_refresh_token" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableEncryptedRefreshToken(s *string) *ProviderTokenCreate {
+	if s != nil {
+		ptc.SetEncryptedRefreshToken(*s)
+	}
+	return ptc
+}
+
+// SetTokenType sets the "token_type" field.
+func (ptc *ProviderTokenCreate) SetTokenType(s string) *ProviderTokenCreate {
+	ptc.mutation.SetTokenType(s)
+	return ptc
+}
+
+// SetNillableTokenType sets the "token_type" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableTokenType(s *string) *ProviderTokenCreate {
+	if s != nil {
+		ptc.SetTokenType(*s)
+	}
+	return ptc
+}
+
+// SetScope sets the "scope" field.
+func (ptc *ProviderTokenCreate) SetScope(s string) *ProviderTokenCreate {
+	ptc.mutation.SetScope(s)
+	return ptc
+}
+
+// SetNillableScope sets the "scope" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableScope(s *string) *ProviderTokenCreate {
+	if s != nil {
+		ptc.SetScope(*s)
+	}
+	return ptc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ptc *ProviderTokenCreate) SetExpiresAt(t time.Time) *ProviderTokenCreate {
+	ptc.mutation.SetExpiresAt(t)
+	return ptc
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableExpiresAt(t *time.Time) *ProviderTokenCreate {
+	if t != nil {
+		ptc.SetExpiresAt(*t)
+	}
+	return ptc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ptc *ProviderTokenCreate) SetCreatedAt(t time.Time) *ProviderTokenCreate {
+	ptc.mutation.SetCreatedAt(t)
+	return ptc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableCreatedAt(t *time.Time) *ProviderTokenCreate {
+	if t != nil {
+		ptc.SetCreatedAt(*t)
+	}
+	return ptc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ptc *ProviderTokenCreate) SetUpdatedAt(t time.Time) *ProviderTokenCreate {
+	ptc.mutation.SetUpdatedAt(t)
+	return ptc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableUpdatedAt(t *time.Time) *ProviderTokenCreate {
+	if t != nil {
+		ptc.SetUpdatedAt(*t)
+	}
+	return ptc
+}
+
+// SetID sets the "id" field.
+func (ptc *ProviderTokenCreate) SetID(u uuid.UUID) *ProviderTokenCreate {
+	ptc.mutation.SetID(u)
+	return ptc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (ptc *ProviderTokenCreate) SetNillableID(u *uuid.UUID) *ProviderTokenCreate {
+	if u != nil {
+		ptc.SetID(*u)
+	}
+	return ptc
+}
+
+// Mutation returns the ProviderTokenMutation object of the builder.
+func (ptc *ProviderTokenCreate) Mutation() *ProviderTokenMutation {
+	return ptc.mutation
+}
+
+// Save creates the ProviderToken in the database.
+func (ptc *ProviderTokenCreate) Save(ctx context.Context) (*ProviderToken, error) {
+	ptc.defaults()
+	return withHooks(ctx, ptc.sqlSave, ptc.mutation, ptc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ptc *ProviderTokenCreate) SaveX(ctx context.Context) *ProviderToken {
+	v, err := ptc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ptc *ProviderTokenCreate) Exec(ctx context.Context) error {
+	_, err := ptc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptc *ProviderTokenCreate) ExecX(ctx context.Context) {
+	if err := ptc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ptc *ProviderTokenCreate) defaults() {
+	if _, ok := ptc.mutation.TokenType(); !ok {
+		v := providertoken.DefaultTokenType
+		ptc.mutation.SetTokenType(v)
+	}
+	if _, ok := ptc.mutation.Scope(); !ok {
+		v := providertoken.DefaultScope
+		ptc.mutation.SetScope(v)
+	}
+	if _, ok := ptc.mutation.CreatedAt(); !ok {
+		v := providertoken.DefaultCreatedAt()
+		ptc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := ptc.mutation.UpdatedAt(); !ok {
+		v := providertoken.DefaultUpdatedAt()
+		ptc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := ptc.mutation.ID(); !ok {
+		v := providertoken.DefaultID()
+		ptc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ptc *ProviderTokenCreate) check() error {
+	if _, ok := ptc.mutation.AuthAccountID(); !ok {
+		return &ValidationError{Name: "auth_account_id", err: errors.New(`ent: missing required field "ProviderToken.auth_account_id"`)}
+	}
+	if _, ok := ptc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "ProviderToken.user_id"`)}
+	}
+	if _, ok := ptc.mutation.Provider(); !ok {
+		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "ProviderToken.provider"`)}
+	}
+	if v, ok := ptc.mutation.Provider(); ok {
+		if err := providertoken.ProviderValidator(string(v)); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.provider": %w`, err)}
+		}
+	}
+	if _, ok := ptc.mutation.KeyID(); !ok {
+		return &ValidationError{Name: "key_id", err: errors.New(`ent: missing required field "ProviderToken.key_id"`)}
+	}
+	if v, ok := ptc.mutation.KeyID(); ok {
+		if err := providertoken.KeyIDValidator(v); err != nil {
+			return &ValidationError{Name: "key_id", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.key_id": %w`, err)}
+		}
+	}
+	if _, ok := ptc.mutation.EncryptedAccessToken(); !ok {
+		return &ValidationError{Name: "encrypted_access_token", err: errors.New(`ent: missing required field "ProviderToken.encrypted_access_token"`)}
+	}
+	if v, ok := ptc.mutation.EncryptedAccessToken(); ok {
+		if err := providertoken.EncryptedAccessTokenValidator(v); err != nil {
+			return &ValidationError{Name: "encrypted_access_token", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.encrypted_access_token": %w`, err)}
+		}
+	}
+	if _, ok := ptc.mutation.TokenType(); !ok {
+		return &ValidationError{Name: "token_type", err: errors.New(`ent: missing required field "ProviderToken.token_type"`)}
+	}
+	if _, ok := ptc.mutation.Scope(); !ok {
+		return &ValidationError{Name: "scope", err: errors.New(`ent: missing required field "ProviderToken.scope"`)}
+	}
+	if _, ok := ptc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ProviderToken.created_at"`)}
+	}
+	if _, ok := ptc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ProviderToken.updated_at"`)}
+	}
+	return nil
+}
+
+func (ptc *ProviderTokenCreate) sqlSave(ctx context.Context) (*ProviderToken, error) {
+	if err := ptc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ptc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ptc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	ptc.mutation.id = &_node.ID
+	ptc.mutation.done = true
+	return _node, nil
+}
+
+func (ptc *ProviderTokenCreate) createSpec() (*ProviderToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ProviderToken{config: ptc.config}
+		_spec = sqlgraph.NewCreateSpec(providertoken.Table, sqlgraph.NewFieldSpec(providertoken.FieldID, field.TypeUUID))
+	)
+	if id, ok := ptc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := ptc.mutation.AuthAccountID(); ok {
+		_spec.SetField(providertoken.FieldAuthAccountID, field.TypeUUID, value)
+		_node.AuthAccountID = value
+	}
+	if value, ok := ptc.mutation.UserID(); ok {
+		_spec.SetField(providertoken.FieldUserID, field.TypeUUID, value)
+		_node.UserID = value
+	}
+	if value, ok := ptc.mutation.Provider(); ok {
+		_spec.SetField(providertoken.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := ptc.mutation.KeyID(); ok {
+		_spec.SetField(providertoken.FieldKeyID, field.TypeString, value)
+		_node.KeyID = value
+	}
+	if value, ok := ptc.mutation.EncryptedAccessToken(); ok {
+		_spec.SetField(providertoken.FieldEncryptedAccessToken, field.TypeString, value)
+		_node.EncryptedAccessToken = value
+	}
+	if value, ok := ptc.mutation.EncryptedRefreshToken(); ok {
+		_spec.SetField(providertoken.FieldEncryptedRefreshToken, field.TypeString, value)
+		_node.EncryptedRefreshToken = &value
+	}
+	if value, ok := ptc.mutation.TokenType(); ok {
+		_spec.SetField(providertoken.FieldTokenType, field.TypeString, value)
+		_node.TokenType = value
+	}
+	if value, ok := ptc.mutation.Scope(); ok {
+		_spec.SetField(providertoken.FieldScope, field.TypeString, value)
+		_node.Scope = value
+	}
+	if value, ok := ptc.mutation.ExpiresAt(); ok {
+		_spec.SetField(providertoken.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if value, ok := ptc.mutation.CreatedAt(); ok {
+		_spec.SetField(providertoken.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := ptc.mutation.UpdatedAt(); ok {
+		_spec.SetField(providertoken.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// ProviderTokenCreateBulk is the builder for creating many ProviderToken entities in bulk.
+type ProviderTokenCreateBulk struct {
+	config
+	err      error
+	builders []*ProviderTokenCreate
+}
+
+// Save creates the ProviderToken entities in the database.
+func (ptcb *ProviderTokenCreateBulk) Save(ctx context.Context) ([]*ProviderToken, error) {
+	if ptcb.err != nil {
+		return nil, ptcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ptcb.builders))
+	nodes := make([]*ProviderToken, len(ptcb.builders))
+	mutators := make([]Mutator, len(ptcb.builders))
+	for i := range ptcb.builders {
+		func(i int, root context.Context) {
+			builder := ptcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ProviderTokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ptcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ptcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ptcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ptcb *ProviderTokenCreateBulk) SaveX(ctx context.Context) []*ProviderToken {
+	v, err := ptcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ptcb *ProviderTokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := ptcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptcb *ProviderTokenCreateBulk) ExecX(ctx context.Context) {
+	if err := ptcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
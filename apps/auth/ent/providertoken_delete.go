@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mandacode.com/accounts/auth/ent/predicate"
+	"mandacode.com/accounts/auth/ent/providertoken"
+)
+
+// ProviderTokenDelete is the builder for deleting a ProviderToken entity.
+type ProviderTokenDelete struct {
+	config
+	hooks    []Hook
+	mutation *ProviderTokenMutation
+}
+
+// Where appends a list predicates to the ProviderTokenDelete builder.
+func (ptd *ProviderTokenDelete) Where(ps ...predicate.ProviderToken) *ProviderTokenDelete {
+	ptd.mutation.Where(ps...)
+	return ptd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ptd *ProviderTokenDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ptd.sqlExec, ptd.mutation, ptd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptd *ProviderTokenDelete) ExecX(ctx context.Context) int {
+	n, err := ptd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ptd *ProviderTokenDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(providertoken.Table, sqlgraph.NewFieldSpec(providertoken.FieldID, field.TypeUUID))
+	if ps := ptd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ptd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ptd.mutation.done = true
+	return affected, err
+}
+
+// ProviderTokenDeleteOne is the builder for deleting a single ProviderToken entity.
+type ProviderTokenDeleteOne struct {
+	ptd *ProviderTokenDelete
+}
+
+// Where appends a list predicates to the ProviderTokenDelete builder.
+func (ptdo *ProviderTokenDeleteOne) Where(ps ...predicate.ProviderToken) *ProviderTokenDeleteOne {
+	ptdo.ptd.mutation.Where(ps...)
+	return ptdo
+}
+
+// Exec executes the deletion query.
+func (ptdo *ProviderTokenDeleteOne) Exec(ctx context.Context) error {
+	n, err := ptdo.ptd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{providertoken.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptdo *ProviderTokenDeleteOne) ExecX(ctx context.Context) {
+	if err := ptdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,488 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mandacode.com/accounts/auth/ent/predicate"
+	"mandacode.com/accounts/auth/ent/providertoken"
+)
+
+// ProviderTokenUpdate is the builder for updating ProviderToken entities.
+type ProviderTokenUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProviderTokenMutation
+}
+
+// Where appends a list predicates to the ProviderTokenUpdate builder.
+func (ptu *ProviderTokenUpdate) Where(ps ...predicate.ProviderToken) *ProviderTokenUpdate {
+	ptu.mutation.Where(ps...)
+	return ptu
+}
+
+// SetKeyID sets the "key_id" field.
+func (ptu *ProviderTokenUpdate) SetKeyID(s string) *ProviderTokenUpdate {
+	ptu.mutation.SetKeyID(s)
+	return ptu
+}
+
+// SetNillableKeyID sets the "key_id" field if the given value is not nil.
+func (ptu *ProviderTokenUpdate) SetNillableKeyID(s *string) *ProviderTokenUpdate {
+	if s != nil {
+		ptu.SetKeyID(*s)
+	}
+	return ptu
+}
+
+// SetEncryptedAccessToken sets the "encrypted_access_token" field.
+func (ptu *ProviderTokenUpdate) SetEncryptedAccessToken(s string) *ProviderTokenUpdate {
+	ptu.mutation.SetEncryptedAccessToken(s)
+	return ptu
+}
+
+// SetNillableEncryptedAccessToken sets the "encrypted_access_token" field if the given value is not nil.
+func (ptu *ProviderTokenUpdate) SetNillableEncryptedAccessToken(s *string) *ProviderTokenUpdate {
+	if s != nil {
+		ptu.SetEncryptedAccessToken(*s)
+	}
+	return ptu
+}
+
+// SetEncryptedRefreshToken sets the "encrypted_refresh_token" field.
+func (ptu *ProviderTokenUpdate) SetEncryptedRefreshToken(s string) *ProviderTokenUpdate {
+	ptu.mutation.SetEncryptedRefreshToken(s)
+	return ptu
+}
+
+// SetNillableEncryptedRefreshToken sets the "encrypted_refresh_token" field if the given value is not nil.
+func (ptu *ProviderTokenUpdate) SetNillableEncryptedRefreshToken(s *string) *ProviderTokenUpdate {
+	if s != nil {
+		ptu.SetEncryptedRefreshToken(*s)
+	}
+	return ptu
+}
+
+// ClearEncryptedRefreshToken clears the value of the "encrypted_refresh_token" field.
+func (ptu *ProviderTokenUpdate) ClearEncryptedRefreshToken() *ProviderTokenUpdate {
+	ptu.mutation.ClearEncryptedRefreshToken()
+	return ptu
+}
+
+// SetTokenType sets the "token_type" field.
+func (ptu *ProviderTokenUpdate) SetTokenType(s string) *ProviderTokenUpdate {
+	ptu.mutation.SetTokenType(s)
+	return ptu
+}
+
+// SetNillableTokenType sets the "token_type" field if the given value is not nil.
+func (ptu *ProviderTokenUpdate) SetNillableTokenType(s *string) *ProviderTokenUpdate {
+	if s != nil {
+		ptu.SetTokenType(*s)
+	}
+	return ptu
+}
+
+// SetScope sets the "scope" field.
+func (ptu *ProviderTokenUpdate) SetScope(s string) *ProviderTokenUpdate {
+	ptu.mutation.SetScope(s)
+	return ptu
+}
+
+// SetNillableScope sets the "scope" field if the given value is not nil.
+func (ptu *ProviderTokenUpdate) SetNillableScope(s *string) *ProviderTokenUpdate {
+	if s != nil {
+		ptu.SetScope(*s)
+	}
+	return ptu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ptu *ProviderTokenUpdate) SetExpiresAt(t time.Time) *ProviderTokenUpdate {
+	ptu.mutation.SetExpiresAt(t)
+	return ptu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (ptu *ProviderTokenUpdate) SetNillableExpiresAt(t *time.Time) *ProviderTokenUpdate {
+	if t != nil {
+		ptu.SetExpiresAt(*t)
+	}
+	return ptu
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (ptu *ProviderTokenUpdate) ClearExpiresAt() *ProviderTokenUpdate {
+	ptu.mutation.ClearExpiresAt()
+	return ptu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ptu *ProviderTokenUpdate) SetUpdatedAt(t time.Time) *ProviderTokenUpdate {
+	ptu.mutation.SetUpdatedAt(t)
+	return ptu
+}
+
+// Mutation returns the ProviderTokenMutation object of the builder.
+func (ptu *ProviderTokenUpdate) Mutation() *ProviderTokenMutation {
+	return ptu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ptu *ProviderTokenUpdate) Save(ctx context.Context) (int, error) {
+	ptu.defaults()
+	return withHooks(ctx, ptu.sqlSave, ptu.mutation, ptu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ptu *ProviderTokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := ptu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ptu *ProviderTokenUpdate) Exec(ctx context.Context) error {
+	_, err := ptu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptu *ProviderTokenUpdate) ExecX(ctx context.Context) {
+	if err := ptu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ptu *ProviderTokenUpdate) defaults() {
+	if _, ok := ptu.mutation.UpdatedAt(); !ok {
+		v := providertoken.UpdateDefaultUpdatedAt()
+		ptu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ptu *ProviderTokenUpdate) check() error {
+	if v, ok := ptu.mutation.KeyID(); ok {
+		if err := providertoken.KeyIDValidator(v); err != nil {
+			return &ValidationError{Name: "key_id", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.key_id": %w`, err)}
+		}
+	}
+	if v, ok := ptu.mutation.EncryptedAccessToken(); ok {
+		if err := providertoken.EncryptedAccessTokenValidator(v); err != nil {
+			return &ValidationError{Name: "encrypted_access_token", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.encrypted_access_token": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ptu *ProviderTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ptu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(providertoken.Table, providertoken.Columns, sqlgraph.NewFieldSpec(providertoken.FieldID, field.TypeUUID))
+	if ps := ptu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ptu.mutation.KeyID(); ok {
+		_spec.SetField(providertoken.FieldKeyID, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.EncryptedAccessToken(); ok {
+		_spec.SetField(providertoken.FieldEncryptedAccessToken, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.EncryptedRefreshToken(); ok {
+		_spec.SetField(providertoken.FieldEncryptedRefreshToken, field.TypeString, value)
+	}
+	if ptu.mutation.EncryptedRefreshTokenCleared() {
+		_spec.ClearField(providertoken.FieldEncryptedRefreshToken, field.TypeString)
+	}
+	if value, ok := ptu.mutation.TokenType(); ok {
+		_spec.SetField(providertoken.FieldTokenType, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.Scope(); ok {
+		_spec.SetField(providertoken.FieldScope, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.ExpiresAt(); ok {
+		_spec.SetField(providertoken.FieldExpiresAt, field.TypeTime, value)
+	}
+	if ptu.mutation.ExpiresAtCleared() {
+		_spec.ClearField(providertoken.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := ptu.mutation.UpdatedAt(); ok {
+		_spec.SetField(providertoken.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ptu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{providertoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ptu.mutation.done = true
+	return n, nil
+}
+
+// ProviderTokenUpdateOne is the builder for updating a single ProviderToken entity.
+type ProviderTokenUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProviderTokenMutation
+}
+
+// SetKeyID sets the "key_id" field.
+func (ptuo *ProviderTokenUpdateOne) SetKeyID(s string) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetKeyID(s)
+	return ptuo
+}
+
+// SetNillableKeyID sets the "key_id" field if the given value is not nil.
+func (ptuo *ProviderTokenUpdateOne) SetNillableKeyID(s *string) *ProviderTokenUpdateOne {
+	if s != nil {
+		ptuo.SetKeyID(*s)
+	}
+	return ptuo
+}
+
+// SetEncryptedAccessToken sets the "encrypted_access_token" field.
+func (ptuo *ProviderTokenUpdateOne) SetEncryptedAccessToken(s string) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetEncryptedAccessToken(s)
+	return ptuo
+}
+
+// SetNillableEncryptedAccessToken sets the "encrypted_access_token" field if the given value is not nil.
+func (ptuo *ProviderTokenUpdateOne) SetNillableEncryptedAccessToken(s *string) *ProviderTokenUpdateOne {
+	if s != nil {
+		ptuo.SetEncryptedAccessToken(*s)
+	}
+	return ptuo
+}
+
+// SetEncryptedRefreshToken sets the "encrypted_refresh_token" field.
+func (ptuo *ProviderTokenUpdateOne) SetEncryptedRefreshToken(s string) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetEncryptedRefreshToken(s)
+	return ptuo
+}
+
+// SetNillableEncryptedRefreshToken sets the "encrypted_refresh_token" field if the given value is not nil.
+func (ptuo *ProviderTokenUpdateOne) SetNillableEncryptedRefreshToken(s *string) *ProviderTokenUpdateOne {
+	if s != nil {
+		ptuo.SetEncryptedRefreshToken(*s)
+	}
+	return ptuo
+}
+
+// ClearEncryptedRefreshToken clears the value of the "encrypted_refresh_token" field.
+func (ptuo *ProviderTokenUpdateOne) ClearEncryptedRefreshToken() *ProviderTokenUpdateOne {
+	ptuo.mutation.ClearEncryptedRefreshToken()
+	return ptuo
+}
+
+// SetTokenType sets the "token_type" field.
+func (ptuo *ProviderTokenUpdateOne) SetTokenType(s string) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetTokenType(s)
+	return ptuo
+}
+
+// SetNillableTokenType sets the "token_type" field if the given value is not nil.
+func (ptuo *ProviderTokenUpdateOne) SetNillableTokenType(s *string) *ProviderTokenUpdateOne {
+	if s != nil {
+		ptuo.SetTokenType(*s)
+	}
+	return ptuo
+}
+
+// SetScope sets the "scope" field.
+func (ptuo *ProviderTokenUpdateOne) SetScope(s string) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetScope(s)
+	return ptuo
+}
+
+// SetNillableScope sets the "scope" field if the given value is not nil.
+func (ptuo *ProviderTokenUpdateOne) SetNillableScope(s *string) *ProviderTokenUpdateOne {
+	if s != nil {
+		ptuo.SetScope(*s)
+	}
+	return ptuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ptuo *ProviderTokenUpdateOne) SetExpiresAt(t time.Time) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetExpiresAt(t)
+	return ptuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (ptuo *ProviderTokenUpdateOne) SetNillableExpiresAt(t *time.Time) *ProviderTokenUpdateOne {
+	if t != nil {
+		ptuo.SetExpiresAt(*t)
+	}
+	return ptuo
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (ptuo *ProviderTokenUpdateOne) ClearExpiresAt() *ProviderTokenUpdateOne {
+	ptuo.mutation.ClearExpiresAt()
+	return ptuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ptuo *ProviderTokenUpdateOne) SetUpdatedAt(t time.Time) *ProviderTokenUpdateOne {
+	ptuo.mutation.SetUpdatedAt(t)
+	return ptuo
+}
+
+// Mutation returns the ProviderTokenMutation object of the builder.
+func (ptuo *ProviderTokenUpdateOne) Mutation() *ProviderTokenMutation {
+	return ptuo.mutation
+}
+
+// Where appends a list predicates to the ProviderTokenUpdate builder.
+func (ptuo *ProviderTokenUpdateOne) Where(ps ...predicate.ProviderToken) *ProviderTokenUpdateOne {
+	ptuo.mutation.Where(ps...)
+	return ptuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ptuo *ProviderTokenUpdateOne) Select(field string, fields ...string) *ProviderTokenUpdateOne {
+	ptuo.fields = append([]string{field}, fields...)
+	return ptuo
+}
+
+// Save executes the query and returns the updated ProviderToken entity.
+func (ptuo *ProviderTokenUpdateOne) Save(ctx context.Context) (*ProviderToken, error) {
+	ptuo.defaults()
+	return withHooks(ctx, ptuo.sqlSave, ptuo.mutation, ptuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ptuo *ProviderTokenUpdateOne) SaveX(ctx context.Context) *ProviderToken {
+	node, err := ptuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ptuo *ProviderTokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := ptuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptuo *ProviderTokenUpdateOne) ExecX(ctx context.Context) {
+	if err := ptuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ptuo *ProviderTokenUpdateOne) defaults() {
+	if _, ok := ptuo.mutation.UpdatedAt(); !ok {
+		v := providertoken.UpdateDefaultUpdatedAt()
+		ptuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ptuo *ProviderTokenUpdateOne) check() error {
+	if v, ok := ptuo.mutation.KeyID(); ok {
+		if err := providertoken.KeyIDValidator(v); err != nil {
+			return &ValidationError{Name: "key_id", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.key_id": %w`, err)}
+		}
+	}
+	if v, ok := ptuo.mutation.EncryptedAccessToken(); ok {
+		if err := providertoken.EncryptedAccessTokenValidator(v); err != nil {
+			return &ValidationError{Name: "encrypted_access_token", err: fmt.Errorf(`ent: validator failed for field "ProviderToken.encrypted_access_token": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ptuo *ProviderTokenUpdateOne) sqlSave(ctx context.Context) (_node *ProviderToken, err error) {
+	if err := ptuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(providertoken.Table, providertoken.Columns, sqlgraph.NewFieldSpec(providertoken.FieldID, field.TypeUUID))
+	id, ok := ptuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ProviderToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ptuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, providertoken.FieldID)
+		for _, f := range fields {
+			if !providertoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != providertoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ptuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ptuo.mutation.KeyID(); ok {
+		_spec.SetField(providertoken.FieldKeyID, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.EncryptedAccessToken(); ok {
+		_spec.SetField(providertoken.FieldEncryptedAccessToken, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.EncryptedRefreshToken(); ok {
+		_spec.SetField(providertoken.FieldEncryptedRefreshToken, field.TypeString, value)
+	}
+	if ptuo.mutation.EncryptedRefreshTokenCleared() {
+		_spec.ClearField(providertoken.FieldEncryptedRefreshToken, field.TypeString)
+	}
+	if value, ok := ptuo.mutation.TokenType(); ok {
+		_spec.SetField(providertoken.FieldTokenType, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.Scope(); ok {
+		_spec.SetField(providertoken.FieldScope, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(providertoken.FieldExpiresAt, field.TypeTime, value)
+	}
+	if ptuo.mutation.ExpiresAtCleared() {
+		_spec.ClearField(providertoken.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := ptuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(providertoken.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &ProviderToken{config: ptuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ptuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{providertoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ptuo.mutation.done = true
+	return _node, nil
+}
@@ -7,7 +7,14 @@ import (
 
 	"github.com/google/uuid"
 	"mandacode.com/accounts/auth/ent/authaccount"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	"mandacode.com/accounts/auth/ent/providertoken"
+	"mandacode.com/accounts/auth/ent/recoverycode"
 	"mandacode.com/accounts/auth/ent/schema"
+	"mandacode.com/accounts/auth/ent/session"
+	"mandacode.com/accounts/auth/ent/signupinvite"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+	"mandacode.com/accounts/auth/ent/webauthncredential"
 )
 
 // The init function reads all schema descriptors with runtime code
@@ -19,6 +26,10 @@ func init() {
 	authaccount.Hooks[1] = authaccountHooks[1]
 	authaccountFields := schema.AuthAccount{}.Fields()
 	_ = authaccountFields
+	// authaccountDescProvider is the schema descriptor for provider field.
+	authaccountDescProvider := authaccountFields[2].Descriptor()
+	// authaccount.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	authaccount.ProviderValidator = authaccountDescProvider.Validators[0].(func(string) error)
 	// authaccountDescIsVerified is the schema descriptor for is_verified field.
 	authaccountDescIsVerified := authaccountFields[4].Descriptor()
 	// authaccount.DefaultIsVerified holds the default value on creation for the is_verified field.
@@ -41,6 +52,218 @@ func init() {
 	authaccountDescID := authaccountFields[0].Descriptor()
 	// authaccount.DefaultID holds the default value on creation for the id field.
 	authaccount.DefaultID = authaccountDescID.Default.(func() uuid.UUID)
+	authactivityHooks := schema.AuthActivity{}.Hooks()
+	authactivity.Hooks[0] = authactivityHooks[0]
+	authactivityFields := schema.AuthActivity{}.Fields()
+	_ = authactivityFields
+	// authactivityDescMethod is the schema descriptor for method field.
+	authactivityDescMethod := authactivityFields[2].Descriptor()
+	// authactivity.MethodValidator is a validator for the "method" field. It is called by the builders before save.
+	authactivity.MethodValidator = authactivityDescMethod.Validators[0].(func(string) error)
+	// authactivityDescProvider is the schema descriptor for provider field.
+	authactivityDescProvider := authactivityFields[3].Descriptor()
+	// authactivity.DefaultProvider holds the default value on creation for the provider field.
+	authactivity.DefaultProvider = authactivityDescProvider.Default.(string)
+	// authactivityDescIPAddress is the schema descriptor for ip_address field.
+	authactivityDescIPAddress := authactivityFields[4].Descriptor()
+	// authactivity.DefaultIPAddress holds the default value on creation for the ip_address field.
+	authactivity.DefaultIPAddress = authactivityDescIPAddress.Default.(string)
+	// authactivityDescUserAgent is the schema descriptor for user_agent field.
+	authactivityDescUserAgent := authactivityFields[5].Descriptor()
+	// authactivity.DefaultUserAgent holds the default value on creation for the user_agent field.
+	authactivity.DefaultUserAgent = authactivityDescUserAgent.Default.(string)
+	// authactivityDescOutcome is the schema descriptor for outcome field.
+	authactivityDescOutcome := authactivityFields[6].Descriptor()
+	// authactivity.OutcomeValidator is a validator for the "outcome" field. It is called by the builders before save.
+	authactivity.OutcomeValidator = authactivityDescOutcome.Validators[0].(func(string) error)
+	// authactivityDescFailureReason is the schema descriptor for failure_reason field.
+	authactivityDescFailureReason := authactivityFields[7].Descriptor()
+	// authactivity.DefaultFailureReason holds the default value on creation for the failure_reason field.
+	authactivity.DefaultFailureReason = authactivityDescFailureReason.Default.(string)
+	// authactivityDescCreatedAt is the schema descriptor for created_at field.
+	authactivityDescCreatedAt := authactivityFields[8].Descriptor()
+	// authactivity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	authactivity.DefaultCreatedAt = authactivityDescCreatedAt.Default.(func() time.Time)
+	// authactivityDescID is the schema descriptor for id field.
+	authactivityDescID := authactivityFields[0].Descriptor()
+	// authactivity.DefaultID holds the default value on creation for the id field.
+	authactivity.DefaultID = authactivityDescID.Default.(func() uuid.UUID)
+	providertokenFields := schema.ProviderToken{}.Fields()
+	_ = providertokenFields
+	// providertokenDescProvider is the schema descriptor for provider field.
+	providertokenDescProvider := providertokenFields[3].Descriptor()
+	// providertoken.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	providertoken.ProviderValidator = providertokenDescProvider.Validators[0].(func(string) error)
+	// providertokenDescKeyID is the schema descriptor for key_id field.
+	providertokenDescKeyID := providertokenFields[4].Descriptor()
+	// providertoken.KeyIDValidator is a validator for the "key_id" field. It is called by the builders before save.
+	providertoken.KeyIDValidator = providertokenDescKeyID.Validators[0].(func(string) error)
+	// providertokenDescEncryptedAccessToken is the schema descriptor for encrypted_access_token field.
+	providertokenDescEncryptedAccessToken := providertokenFields[5].Descriptor()
+	// providertoken.EncryptedAccessTokenValidator is a validator for the "encrypted_access_token" field. It is called by the builders before save.
+	providertoken.EncryptedAccessTokenValidator = providertokenDescEncryptedAccessToken.Validators[0].(func(string) error)
+	// providertokenDescTokenType is the schema descriptor for token_type field.
+	providertokenDescTokenType := providertokenFields[7].Descriptor()
+	// providertoken.DefaultTokenType holds the default value on creation for the token_type field.
+	providertoken.DefaultTokenType = providertokenDescTokenType.Default.(string)
+	// providertokenDescScope is the schema descriptor for scope field.
+	providertokenDescScope := providertokenFields[8].Descriptor()
+	// providertoken.DefaultScope holds the default value on creation for the scope field.
+	providertoken.DefaultScope = providertokenDescScope.Default.(string)
+	// providertokenDescCreatedAt is the schema descriptor for created_at field.
+	providertokenDescCreatedAt := providertokenFields[10].Descriptor()
+	// providertoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	providertoken.DefaultCreatedAt = providertokenDescCreatedAt.Default.(func() time.Time)
+	// providertokenDescUpdatedAt is the schema descriptor for updated_at field.
+	providertokenDescUpdatedAt := providertokenFields[11].Descriptor()
+	// providertoken.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	providertoken.DefaultUpdatedAt = providertokenDescUpdatedAt.Default.(func() time.Time)
+	// providertoken.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	providertoken.UpdateDefaultUpdatedAt = providertokenDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// providertokenDescID is the schema descriptor for id field.
+	providertokenDescID := providertokenFields[0].Descriptor()
+	// providertoken.DefaultID holds the default value on creation for the id field.
+	providertoken.DefaultID = providertokenDescID.Default.(func() uuid.UUID)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	recoverycodeDescCodeHash := recoverycodeFields[2].Descriptor()
+	// recoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	recoverycode.CodeHashValidator = recoverycodeDescCodeHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[4].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	// recoverycodeDescID is the schema descriptor for id field.
+	recoverycodeDescID := recoverycodeFields[0].Descriptor()
+	// recoverycode.DefaultID holds the default value on creation for the id field.
+	recoverycode.DefaultID = recoverycodeDescID.Default.(func() uuid.UUID)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescFamilyID is the schema descriptor for family_id field.
+	sessionDescFamilyID := sessionFields[2].Descriptor()
+	// session.FamilyIDValidator is a validator for the "family_id" field. It is called by the builders before save.
+	session.FamilyIDValidator = sessionDescFamilyID.Validators[0].(func(string) error)
+	// sessionDescDeviceName is the schema descriptor for device_name field.
+	sessionDescDeviceName := sessionFields[3].Descriptor()
+	// session.DefaultDeviceName holds the default value on creation for the device_name field.
+	session.DefaultDeviceName = sessionDescDeviceName.Default.(string)
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[4].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescIPAddress is the schema descriptor for ip_address field.
+	sessionDescIPAddress := sessionFields[5].Descriptor()
+	// session.DefaultIPAddress holds the default value on creation for the ip_address field.
+	session.DefaultIPAddress = sessionDescIPAddress.Default.(string)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[6].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescLastUsedAt is the schema descriptor for last_used_at field.
+	sessionDescLastUsedAt := sessionFields[7].Descriptor()
+	// session.DefaultLastUsedAt holds the default value on creation for the last_used_at field.
+	session.DefaultLastUsedAt = sessionDescLastUsedAt.Default.(func() time.Time)
+	// sessionDescID is the schema descriptor for id field.
+	sessionDescID := sessionFields[0].Descriptor()
+	// session.DefaultID holds the default value on creation for the id field.
+	session.DefaultID = sessionDescID.Default.(func() uuid.UUID)
+	signupinviteFields := schema.SignupInvite{}.Fields()
+	_ = signupinviteFields
+	// signupinviteDescCodeHash is the schema descriptor for code_hash field.
+	signupinviteDescCodeHash := signupinviteFields[1].Descriptor()
+	// signupinvite.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	signupinvite.CodeHashValidator = signupinviteDescCodeHash.Validators[0].(func(string) error)
+	// signupinviteDescEmail is the schema descriptor for email field.
+	signupinviteDescEmail := signupinviteFields[2].Descriptor()
+	// signupinvite.DefaultEmail holds the default value on creation for the email field.
+	signupinvite.DefaultEmail = signupinviteDescEmail.Default.(string)
+	// signupinviteDescCreatedBy is the schema descriptor for created_by field.
+	signupinviteDescCreatedBy := signupinviteFields[3].Descriptor()
+	// signupinvite.CreatedByValidator is a validator for the "created_by" field. It is called by the builders before save.
+	signupinvite.CreatedByValidator = signupinviteDescCreatedBy.Validators[0].(func(string) error)
+	// signupinviteDescCreatedAt is the schema descriptor for created_at field.
+	signupinviteDescCreatedAt := signupinviteFields[4].Descriptor()
+	// signupinvite.DefaultCreatedAt holds the default value on creation for the created_at field.
+	signupinvite.DefaultCreatedAt = signupinviteDescCreatedAt.Default.(func() time.Time)
+	// signupinviteDescID is the schema descriptor for id field.
+	signupinviteDescID := signupinviteFields[0].Descriptor()
+	// signupinvite.DefaultID holds the default value on creation for the id field.
+	signupinvite.DefaultID = signupinviteDescID.Default.(func() uuid.UUID)
+	totpcredentialFields := schema.TotpCredential{}.Fields()
+	_ = totpcredentialFields
+	// totpcredentialDescEncryptedSecret is the schema descriptor for encrypted_secret field.
+	totpcredentialDescEncryptedSecret := totpcredentialFields[2].Descriptor()
+	// totpcredential.EncryptedSecretValidator is a validator for the "encrypted_secret" field. It is called by the builders before save.
+	totpcredential.EncryptedSecretValidator = totpcredentialDescEncryptedSecret.Validators[0].(func(string) error)
+	// totpcredentialDescIsConfirmed is the schema descriptor for is_confirmed field.
+	totpcredentialDescIsConfirmed := totpcredentialFields[3].Descriptor()
+	// totpcredential.DefaultIsConfirmed holds the default value on creation for the is_confirmed field.
+	totpcredential.DefaultIsConfirmed = totpcredentialDescIsConfirmed.Default.(bool)
+	// totpcredentialDescName is the schema descriptor for name field.
+	totpcredentialDescName := totpcredentialFields[4].Descriptor()
+	// totpcredential.DefaultName holds the default value on creation for the name field.
+	totpcredential.DefaultName = totpcredentialDescName.Default.(string)
+	// totpcredential.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	totpcredential.NameValidator = totpcredentialDescName.Validators[0].(func(string) error)
+	// totpcredentialDescLastUsedStep is the schema descriptor for last_used_step field.
+	totpcredentialDescLastUsedStep := totpcredentialFields[5].Descriptor()
+	// totpcredential.DefaultLastUsedStep holds the default value on creation for the last_used_step field.
+	totpcredential.DefaultLastUsedStep = totpcredentialDescLastUsedStep.Default.(int64)
+	// totpcredentialDescCreatedAt is the schema descriptor for created_at field.
+	totpcredentialDescCreatedAt := totpcredentialFields[6].Descriptor()
+	// totpcredential.DefaultCreatedAt holds the default value on creation for the created_at field.
+	totpcredential.DefaultCreatedAt = totpcredentialDescCreatedAt.Default.(func() time.Time)
+	// totpcredentialDescUpdatedAt is the schema descriptor for updated_at field.
+	totpcredentialDescUpdatedAt := totpcredentialFields[7].Descriptor()
+	// totpcredential.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	totpcredential.DefaultUpdatedAt = totpcredentialDescUpdatedAt.Default.(func() time.Time)
+	// totpcredential.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	totpcredential.UpdateDefaultUpdatedAt = totpcredentialDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// totpcredentialDescID is the schema descriptor for id field.
+	totpcredentialDescID := totpcredentialFields[0].Descriptor()
+	// totpcredential.DefaultID holds the default value on creation for the id field.
+	totpcredential.DefaultID = totpcredentialDescID.Default.(func() uuid.UUID)
+	webauthncredentialFields := schema.WebauthnCredential{}.Fields()
+	_ = webauthncredentialFields
+	// webauthncredentialDescCredentialID is the schema descriptor for credential_id field.
+	webauthncredentialDescCredentialID := webauthncredentialFields[2].Descriptor()
+	// webauthncredential.CredentialIDValidator is a validator for the "credential_id" field. It is called by the builders before save.
+	webauthncredential.CredentialIDValidator = webauthncredentialDescCredentialID.Validators[0].(func([]byte) error)
+	// webauthncredentialDescPublicKey is the schema descriptor for public_key field.
+	webauthncredentialDescPublicKey := webauthncredentialFields[3].Descriptor()
+	// webauthncredential.PublicKeyValidator is a validator for the "public_key" field. It is called by the builders before save.
+	webauthncredential.PublicKeyValidator = webauthncredentialDescPublicKey.Validators[0].(func([]byte) error)
+	// webauthncredentialDescSignCount is the schema descriptor for sign_count field.
+	webauthncredentialDescSignCount := webauthncredentialFields[4].Descriptor()
+	// webauthncredential.DefaultSignCount holds the default value on creation for the sign_count field.
+	webauthncredential.DefaultSignCount = webauthncredentialDescSignCount.Default.(uint32)
+	// webauthncredentialDescName is the schema descriptor for name field.
+	webauthncredentialDescName := webauthncredentialFields[7].Descriptor()
+	// webauthncredential.DefaultName holds the default value on creation for the name field.
+	webauthncredential.DefaultName = webauthncredentialDescName.Default.(string)
+	// webauthncredentialDescBackupEligible is the schema descriptor for backup_eligible field.
+	webauthncredentialDescBackupEligible := webauthncredentialFields[8].Descriptor()
+	// webauthncredential.DefaultBackupEligible holds the default value on creation for the backup_eligible field.
+	webauthncredential.DefaultBackupEligible = webauthncredentialDescBackupEligible.Default.(bool)
+	// webauthncredentialDescBackupState is the schema descriptor for backup_state field.
+	webauthncredentialDescBackupState := webauthncredentialFields[9].Descriptor()
+	// webauthncredential.DefaultBackupState holds the default value on creation for the backup_state field.
+	webauthncredential.DefaultBackupState = webauthncredentialDescBackupState.Default.(bool)
+	// webauthncredentialDescCreatedAt is the schema descriptor for created_at field.
+	webauthncredentialDescCreatedAt := webauthncredentialFields[11].Descriptor()
+	// webauthncredential.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webauthncredential.DefaultCreatedAt = webauthncredentialDescCreatedAt.Default.(func() time.Time)
+	// webauthncredentialDescUpdatedAt is the schema descriptor for updated_at field.
+	webauthncredentialDescUpdatedAt := webauthncredentialFields[12].Descriptor()
+	// webauthncredential.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webauthncredential.DefaultUpdatedAt = webauthncredentialDescUpdatedAt.Default.(func() time.Time)
+	// webauthncredential.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webauthncredential.UpdateDefaultUpdatedAt = webauthncredentialDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webauthncredentialDescID is the schema descriptor for id field.
+	webauthncredentialDescID := webauthncredentialFields[0].Descriptor()
+	// webauthncredential.DefaultID holds the default value on creation for the id field.
+	webauthncredential.DefaultID = webauthncredentialDescID.Default.(func() uuid.UUID)
 }
 
 const (
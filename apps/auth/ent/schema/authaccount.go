@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	gen "mandacode.com/accounts/auth/ent"
 	"mandacode.com/accounts/auth/ent/hook"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 // AuthAccount holds the schema definition for the AuthAccount entity.
@@ -33,9 +34,10 @@ func (AuthAccount) Fields() []ent.Field {
 			Comment("The unique identifier for the user associated with this authentication account"),
 
 		// Provider
-		field.Enum("provider").
-			Values("local", "google", "kakao", "naver", "apple").
-			Comment("The OAuth provider used for authentication"),
+		field.String("provider").
+			GoType(providermodels.Provider("")).
+			NotEmpty().
+			Comment("The name of the provider used for authentication, local or an OAuth provider"),
 
 		// ProviderID
 		field.String("provider_id").
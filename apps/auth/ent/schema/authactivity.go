@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/hook"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+)
+
+// AuthActivity holds the schema definition for the AuthActivity entity.
+type AuthActivity struct {
+	ent.Schema
+}
+
+// Fields of the AuthActivity.
+func (AuthActivity) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the authentication attempt"),
+
+		// User ID
+		field.UUID("user_id", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Immutable().
+			Comment("The unique identifier for the user of the attempt, nil if unknown"),
+
+		// Method
+		field.String("method").
+			GoType(activitymodels.Method("")).
+			NotEmpty().
+			Immutable().
+			Comment("The way the user tried to authenticate"),
+
+		// Provider
+		field.String("provider").
+			Default("").
+			Immutable().
+			Comment("The OAuth provider of the attempt, empty for other methods"),
+
+		// IPAddress
+		field.String("ip_address").
+			Default("").
+			Immutable().
+			Comment("The IP address the attempt came from"),
+
+		// UserAgent
+		field.String("user_agent").
+			Default("").
+			Immutable().
+			Comment("The user agent of the client"),
+
+		// Outcome
+		field.String("outcome").
+			GoType(activitymodels.Outcome("")).
+			NotEmpty().
+			Immutable().
+			Comment("The result of the attempt"),
+
+		// FailureReason
+		field.String("failure_reason").
+			Default("").
+			Immutable().
+			Comment("The reason the attempt failed, empty unless it did"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time of the attempt"),
+	}
+}
+
+// Indexes of the AuthActivity.
+func (AuthActivity) Indexes() []ent.Index {
+	return []ent.Index{
+		// History is read newest first, by user, by IP address or overall
+		index.Fields("user_id", "created_at"),
+		index.Fields("ip_address", "created_at"),
+		index.Fields("created_at"),
+	}
+}
+
+// Edges of the AuthActivity.
+func (AuthActivity) Edges() []ent.Edge {
+	return nil
+}
+
+// Hooks of the AuthActivity.
+func (AuthActivity) Hooks() []ent.Hook {
+	return []ent.Hook{
+		// Recorded attempts are never changed
+		hook.Reject(ent.OpUpdate | ent.OpUpdateOne),
+	}
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+)
+
+// ProviderToken holds the schema definition for the ProviderToken entity.
+type ProviderToken struct {
+	ent.Schema
+}
+
+// Fields of the ProviderToken.
+func (ProviderToken) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the provider token"),
+
+		// AuthAccount ID
+		field.UUID("auth_account_id", uuid.UUID{}).
+			Immutable().
+			Comment("The unique identifier for the OAuth authentication account the tokens were issued for"),
+
+		// User ID
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable().
+			Comment("The unique identifier for the user owning the authentication account"),
+
+		// Provider
+		field.String("provider").
+			GoType(providermodels.Provider("")).
+			NotEmpty().
+			Immutable().
+			Comment("The name of the OAuth provider that issued the tokens"),
+
+		// KeyID
+		field.String("key_id").
+			NotEmpty().
+			Comment("The ID of the key the tokens are encrypted with"),
+
+		// EncryptedAccessToken
+		field.String("encrypted_access_token").
+			NotEmpty().
+			Sensitive().
+			Comment("The access token of the provider, encrypted with the key of key_id"),
+
+		// EncryptedRefreshToken
+		field.String("encrypted_refresh_token").
+			Optional().
+			Nillable().
+			Sensitive().
+			Comment("The refresh token of the provider, encrypted with the key of key_id, nil if none was issued"),
+
+		// TokenType
+		field.String("token_type").
+			Default("").
+			Comment("The type of the access token, usually Bearer"),
+
+		// Scope
+		field.String("scope").
+			Default("").
+			Comment("The scopes granted to the access token, separated by spaces"),
+
+		// ExpiresAt
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Comment("The time when the access token expires, nil if the provider did not say"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time when the provider token was first stored"),
+
+		// UpdatedAt
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("The time when the provider token was last updated"),
+	}
+}
+
+// Indexes of the ProviderToken.
+func (ProviderToken) Indexes() []ent.Index {
+	return []ent.Index{
+		// An authentication account has at most one token set
+		index.Fields("auth_account_id").Unique(),
+		// Tokens are looked up by user and provider
+		index.Fields("user_id", "provider").Unique(),
+	}
+}
+
+// Edges of the ProviderToken.
+func (ProviderToken) Edges() []ent.Edge {
+	return nil
+}
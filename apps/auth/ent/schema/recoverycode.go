@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// RecoveryCode holds the schema definition for the RecoveryCode entity.
+type RecoveryCode struct {
+	ent.Schema
+}
+
+// Fields of the RecoveryCode.
+func (RecoveryCode) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the recovery code"),
+
+		// User ID
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable().
+			Comment("The unique identifier for the user owning this recovery code"),
+
+		// CodeHash
+		field.String("code_hash").
+			NotEmpty().
+			Immutable().
+			Sensitive().
+			Comment("The SHA-256 hash of the recovery code"),
+
+		// UsedAt
+		field.Time("used_at").
+			Optional().
+			Nillable().
+			Comment("The time when the recovery code was used, nil while unused"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time when the recovery code was generated"),
+	}
+}
+
+// Indexes of the RecoveryCode.
+func (RecoveryCode) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "code_hash").Unique(),
+	}
+}
+
+// Edges of the RecoveryCode.
+func (RecoveryCode) Edges() []ent.Edge {
+	return nil
+}
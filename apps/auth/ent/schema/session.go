@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Session holds the schema definition for the Session entity.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the session"),
+
+		// User ID
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable().
+			Comment("The unique identifier for the user signed in by this session"),
+
+		// FamilyID
+		field.String("family_id").
+			NotEmpty().
+			Immutable().
+			Unique().
+			Comment("The family of the refresh tokens of the session, kept across rotations"),
+
+		// DeviceName
+		field.String("device_name").
+			Default("").
+			Comment("The device name sent by the client at login, empty if none"),
+
+		// UserAgent
+		field.String("user_agent").
+			Default("").
+			Comment("The user agent of the client at login"),
+
+		// IPAddress
+		field.String("ip_address").
+			Default("").
+			Comment("The IP address the session was last used from"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time when the user signed in"),
+
+		// LastUsedAt
+		field.Time("last_used_at").
+			Default(time.Now).
+			Comment("The time when the refresh token of the session was last rotated"),
+
+		// ExpiresAt
+		field.Time("expires_at").
+			Comment("The time when the current refresh token of the session expires"),
+	}
+}
+
+// Indexes of the Session.
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		// Sessions are listed by user
+		index.Fields("user_id"),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return nil
+}
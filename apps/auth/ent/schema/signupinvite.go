@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// SignupInvite holds the schema definition for the SignupInvite entity.
+type SignupInvite struct {
+	ent.Schema
+}
+
+// Fields of the SignupInvite.
+func (SignupInvite) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the invite"),
+
+		// CodeHash
+		field.String("code_hash").
+			NotEmpty().
+			Immutable().
+			Unique().
+			Sensitive().
+			Comment("The SHA-256 hash of the invite code"),
+
+		// Email
+		field.String("email").
+			Default("").
+			Immutable().
+			Comment("The only email the invite admits, empty to admit any email"),
+
+		// CreatedBy
+		field.String("created_by").
+			NotEmpty().
+			Immutable().
+			Comment("The name of the admin client that created the invite"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time when the invite was created"),
+
+		// ExpiresAt
+		field.Time("expires_at").
+			Immutable().
+			Comment("The time after which the invite can no longer be used"),
+
+		// UsedAt
+		field.Time("used_at").
+			Optional().
+			Nillable().
+			Comment("The time when the invite was used, nil while unused"),
+
+		// UsedBy
+		field.UUID("used_by", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Comment("The unique identifier for the user who signed up with the invite"),
+	}
+}
+
+// Edges of the SignupInvite.
+func (SignupInvite) Edges() []ent.Edge {
+	return nil
+}
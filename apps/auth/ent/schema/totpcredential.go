@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// TotpCredential holds the schema definition for the TotpCredential entity.
+type TotpCredential struct {
+	ent.Schema
+}
+
+// Fields of the TotpCredential.
+func (TotpCredential) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the TOTP credential"),
+
+		// User ID
+		field.UUID("user_id", uuid.UUID{}).
+			Comment("The unique identifier for the user owning this TOTP credential"),
+
+		// EncryptedSecret
+		field.String("encrypted_secret").
+			NotEmpty().
+			Sensitive().
+			Comment("The shared TOTP secret, encrypted with the application key"),
+
+		// IsConfirmed
+		field.Bool("is_confirmed").
+			Default(false).
+			Comment("Indicates if the enrollment has been confirmed with a valid code"),
+
+		// Name
+		field.String("name").
+			Default("").
+			MaxLen(64).
+			Comment("The display name chosen by the user"),
+
+		// LastUsedStep
+		field.Int64("last_used_step").
+			Default(0).
+			Comment("The last time step accepted for this credential, used to reject replayed codes"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time when the TOTP credential was created"),
+
+		// UpdatedAt
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("The time when the TOTP credential was last updated"),
+	}
+}
+
+// Indexes of the TotpCredential.
+func (TotpCredential) Indexes() []ent.Index {
+	return []ent.Index{
+		// A user has at most one TOTP credential
+		index.Fields("user_id").Unique(),
+	}
+}
+
+// Edges of the TotpCredential.
+func (TotpCredential) Edges() []ent.Edge {
+	return nil
+}
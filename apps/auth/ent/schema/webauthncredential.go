@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// WebauthnCredential holds the schema definition for the WebauthnCredential entity.
+type WebauthnCredential struct {
+	ent.Schema
+}
+
+// Fields of the WebauthnCredential.
+func (WebauthnCredential) Fields() []ent.Field {
+	return []ent.Field{
+		// Internal PK
+		field.UUID("id", uuid.UUID{}).
+			Immutable().
+			Unique().
+			Default(uuid.New).
+			Comment("The unique identifier for the WebAuthn credential"),
+
+		// User ID
+		field.UUID("user_id", uuid.UUID{}).
+			Comment("The unique identifier for the user owning this WebAuthn credential"),
+
+		// CredentialID
+		field.Bytes("credential_id").
+			NotEmpty().
+			Immutable().
+			Unique().
+			Comment("The credential ID chosen by the authenticator"),
+
+		// PublicKey
+		field.Bytes("public_key").
+			NotEmpty().
+			Immutable().
+			Comment("The COSE encoded credential public key"),
+
+		// SignCount
+		field.Uint32("sign_count").
+			Default(0).
+			Comment("The last signature counter reported by the authenticator"),
+
+		// AAGUID
+		field.UUID("aaguid", uuid.UUID{}).
+			Immutable().
+			Comment("The AAGUID identifying the authenticator model"),
+
+		// Transports
+		field.Strings("transports").
+			Optional().
+			Comment("The transports the authenticator supports, as reported by the client"),
+
+		// Name
+		field.String("name").
+			Default("").
+			Comment("A user chosen label for the credential"),
+
+		// BackupEligible
+		field.Bool("backup_eligible").
+			Default(false).
+			Comment("Indicates if the credential can be synced to other devices"),
+
+		// BackupState
+		field.Bool("backup_state").
+			Default(false).
+			Comment("Indicates if the credential is currently backed up"),
+
+		// LastUsedAt
+		field.Time("last_used_at").
+			Optional().
+			Nillable().
+			Comment("The time when the credential was last used to sign in"),
+
+		// CreatedAt
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("The time when the WebAuthn credential was created"),
+
+		// UpdatedAt
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("The time when the WebAuthn credential was last updated"),
+	}
+}
+
+// Indexes of the WebauthnCredential.
+func (WebauthnCredential) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for listing the credentials of a user
+		index.Fields("user_id"),
+	}
+}
+
+// Edges of the WebauthnCredential.
+func (WebauthnCredential) Edges() []ent.Edge {
+	return nil
+}
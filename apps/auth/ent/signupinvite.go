@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/signupinvite"
+)
+
+// SignupInvite is the model entity for the SignupInvite schema.
+type SignupInvite struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The unique identifier for the invite
+	ID uuid.UUID `json:"id,omitempty"`
+	// The SHA-256 hash of the invite code
+	CodeHash string `json:"-"`
+	// The only email the invite admits, empty to admit any email
+	Email string `json:"email,omitempty"`
+	// The name of the admin client that created the invite
+	CreatedBy string `json:"created_by,omitempty"`
+	// The time when the invite was created
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// The time after which the invite can no longer be used
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// The time when the invite was used, nil while unused
+	UsedAt *time.Time `json:"used_at,omitempty"`
+	// The unique identifier for the user who signed up with the invite
+	UsedBy       *uuid.UUID `json:"used_by,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SignupInvite) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case signupinvite.FieldUsedBy:
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case signupinvite.FieldCodeHash, signupinvite.FieldEmail, signupinvite.FieldCreatedBy:
+			values[i] = new(sql.NullString)
+		case signupinvite.FieldCreatedAt, signupinvite.FieldExpiresAt, signupinvite.FieldUsedAt:
+			values[i] = new(sql.NullTime)
+		case signupinvite.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SignupInvite fields.
+func (si *SignupInvite) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case signupinvite.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				si.ID = *value
+			}
+		case signupinvite.FieldCodeHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code_hash", values[i])
+			} else if value.Valid {
+				si.CodeHash = value.String
+			}
+		case signupinvite.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				si.Email = value.String
+			}
+		case signupinvite.FieldCreatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field created_by", values[i])
+			} else if value.Valid {
+				si.CreatedBy = value.String
+			}
+		case signupinvite.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				si.CreatedAt = value.Time
+			}
+		case signupinvite.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				si.ExpiresAt = value.Time
+			}
+		case signupinvite.FieldUsedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field used_at", values[i])
+			} else if value.Valid {
+				si.UsedAt = new(time.Time)
+				*si.UsedAt = value.Time
+			}
+		case signupinvite.FieldUsedBy:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field used_by", values[i])
+			} else if value.Valid {
+				si.UsedBy = new(uuid.UUID)
+				*si.UsedBy = *value.S.(*uuid.UUID)
+			}
+		default:
+			si.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SignupInvite.
+// This includes values selected through modifiers, order, etc.
+func (si *SignupInvite) Value(name string) (ent.Value, error) {
+	return si.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this SignupInvite.
+// Note that you need to call SignupInvite.Unwrap() before calling this method if this SignupInvite
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (si *SignupInvite) Update() *SignupInviteUpdateOne {
+	return NewSignupInviteClient(si.config).UpdateOne(si)
+}
+
+// Unwrap unwraps the SignupInvite entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (si *SignupInvite) Unwrap() *SignupInvite {
+	_tx, ok := si.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SignupInvite is not a transactional entity")
+	}
+	si.config.driver = _tx.drv
+	return si
+}
+
+// String implements the fmt.Stringer.
+func (si *SignupInvite) String() string {
+	var builder strings.Builder
+	builder.WriteString("SignupInvite(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", si.ID))
+	builder.WriteString("code_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("email=")
+	builder.WriteString(si.Email)
+	builder.WriteString(", ")
+	builder.WriteString("created_by=")
+	builder.WriteString(si.CreatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(si.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(si.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := si.UsedAt; v != nil {
+		builder.WriteString("used_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := si.UsedBy; v != nil {
+		builder.WriteString("used_by=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SignupInvites is a parsable slice of SignupInvite.
+type SignupInvites []*SignupInvite
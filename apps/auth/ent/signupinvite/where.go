@@ -0,0 +1,481 @@
+// Code generated by ent, DO NOT EDIT.
+
+package signupinvite
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldID, id))
+}
+
+// CodeHash applies equality check predicate on the "code_hash" field. It's identical to CodeHashEQ.
+func CodeHash(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldCodeHash, v))
+}
+
+// Email applies equality check predicate on the "email" field. It's identical to EmailEQ.
+func Email(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldEmail, v))
+}
+
+// CreatedBy applies equality check predicate on the "created_by" field. It's identical to CreatedByEQ.
+func CreatedBy(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// UsedAt applies equality check predicate on the "used_at" field. It's identical to UsedAtEQ.
+func UsedAt(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldUsedAt, v))
+}
+
+// UsedBy applies equality check predicate on the "used_by" field. It's identical to UsedByEQ.
+func UsedBy(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldUsedBy, v))
+}
+
+// CodeHashEQ applies the EQ predicate on the "code_hash" field.
+func CodeHashEQ(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldCodeHash, v))
+}
+
+// CodeHashNEQ applies the NEQ predicate on the "code_hash" field.
+func CodeHashNEQ(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldCodeHash, v))
+}
+
+// CodeHashIn applies the In predicate on the "code_hash" field.
+func CodeHashIn(vs ...string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldCodeHash, vs...))
+}
+
+// CodeHashNotIn applies the NotIn predicate on the "code_hash" field.
+func CodeHashNotIn(vs ...string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldCodeHash, vs...))
+}
+
+// CodeHashGT applies the GT predicate on the "code_hash" field.
+func CodeHashGT(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldCodeHash, v))
+}
+
+// CodeHashGTE applies the GTE predicate on the "code_hash" field.
+func CodeHashGTE(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldCodeHash, v))
+}
+
+// CodeHashLT applies the LT predicate on the "code_hash" field.
+func CodeHashLT(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldCodeHash, v))
+}
+
+// CodeHashLTE applies the LTE predicate on the "code_hash" field.
+func CodeHashLTE(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldCodeHash, v))
+}
+
+// CodeHashContains applies the Contains predicate on the "code_hash" field.
+func CodeHashContains(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldContains(FieldCodeHash, v))
+}
+
+// CodeHashHasPrefix applies the HasPrefix predicate on the "code_hash" field.
+func CodeHashHasPrefix(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldHasPrefix(FieldCodeHash, v))
+}
+
+// CodeHashHasSuffix applies the HasSuffix predicate on the "code_hash" field.
+func CodeHashHasSuffix(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldHasSuffix(FieldCodeHash, v))
+}
+
+// CodeHashEqualFold applies the EqualFold predicate on the "code_hash" field.
+func CodeHashEqualFold(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEqualFold(FieldCodeHash, v))
+}
+
+// CodeHashContainsFold applies the ContainsFold predicate on the "code_hash" field.
+func CodeHashContainsFold(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldContainsFold(FieldCodeHash, v))
+}
+
+// EmailEQ applies the EQ predicate on the "email" field.
+func EmailEQ(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldEmail, v))
+}
+
+// EmailNEQ applies the NEQ predicate on the "email" field.
+func EmailNEQ(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldEmail, v))
+}
+
+// EmailIn applies the In predicate on the "email" field.
+func EmailIn(vs ...string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldEmail, vs...))
+}
+
+// EmailNotIn applies the NotIn predicate on the "email" field.
+func EmailNotIn(vs ...string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldEmail, vs...))
+}
+
+// EmailGT applies the GT predicate on the "email" field.
+func EmailGT(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldEmail, v))
+}
+
+// EmailGTE applies the GTE predicate on the "email" field.
+func EmailGTE(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldEmail, v))
+}
+
+// EmailLT applies the LT predicate on the "email" field.
+func EmailLT(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldEmail, v))
+}
+
+// EmailLTE applies the LTE predicate on the "email" field.
+func EmailLTE(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldEmail, v))
+}
+
+// EmailContains applies the Contains predicate on the "email" field.
+func EmailContains(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldContains(FieldEmail, v))
+}
+
+// EmailHasPrefix applies the HasPrefix predicate on the "email" field.
+func EmailHasPrefix(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldHasPrefix(FieldEmail, v))
+}
+
+// EmailHasSuffix applies the HasSuffix predicate on the "email" field.
+func EmailHasSuffix(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldHasSuffix(FieldEmail, v))
+}
+
+// EmailEqualFold applies the EqualFold predicate on the "email" field.
+func EmailEqualFold(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEqualFold(FieldEmail, v))
+}
+
+// EmailContainsFold applies the ContainsFold predicate on the "email" field.
+func EmailContainsFold(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldContainsFold(FieldEmail, v))
+}
+
+// CreatedByEQ applies the EQ predicate on the "created_by" field.
+func CreatedByEQ(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// CreatedByNEQ applies the NEQ predicate on the "created_by" field.
+func CreatedByNEQ(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldCreatedBy, v))
+}
+
+// CreatedByIn applies the In predicate on the "created_by" field.
+func CreatedByIn(vs ...string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByNotIn applies the NotIn predicate on the "created_by" field.
+func CreatedByNotIn(vs ...string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByGT applies the GT predicate on the "created_by" field.
+func CreatedByGT(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldCreatedBy, v))
+}
+
+// CreatedByGTE applies the GTE predicate on the "created_by" field.
+func CreatedByGTE(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldCreatedBy, v))
+}
+
+// CreatedByLT applies the LT predicate on the "created_by" field.
+func CreatedByLT(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldCreatedBy, v))
+}
+
+// CreatedByLTE applies the LTE predicate on the "created_by" field.
+func CreatedByLTE(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldCreatedBy, v))
+}
+
+// CreatedByContains applies the Contains predicate on the "created_by" field.
+func CreatedByContains(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldContains(FieldCreatedBy, v))
+}
+
+// CreatedByHasPrefix applies the HasPrefix predicate on the "created_by" field.
+func CreatedByHasPrefix(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldHasPrefix(FieldCreatedBy, v))
+}
+
+// CreatedByHasSuffix applies the HasSuffix predicate on the "created_by" field.
+func CreatedByHasSuffix(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldHasSuffix(FieldCreatedBy, v))
+}
+
+// CreatedByEqualFold applies the EqualFold predicate on the "created_by" field.
+func CreatedByEqualFold(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEqualFold(FieldCreatedBy, v))
+}
+
+// CreatedByContainsFold applies the ContainsFold predicate on the "created_by" field.
+func CreatedByContainsFold(v string) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldContainsFold(FieldCreatedBy, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// UsedAtEQ applies the EQ predicate on the "used_at" field.
+func UsedAtEQ(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldUsedAt, v))
+}
+
+// UsedAtNEQ applies the NEQ predicate on the "used_at" field.
+func UsedAtNEQ(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldUsedAt, v))
+}
+
+// UsedAtIn applies the In predicate on the "used_at" field.
+func UsedAtIn(vs ...time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldUsedAt, vs...))
+}
+
+// UsedAtNotIn applies the NotIn predicate on the "used_at" field.
+func UsedAtNotIn(vs ...time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldUsedAt, vs...))
+}
+
+// UsedAtGT applies the GT predicate on the "used_at" field.
+func UsedAtGT(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldUsedAt, v))
+}
+
+// UsedAtGTE applies the GTE predicate on the "used_at" field.
+func UsedAtGTE(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldUsedAt, v))
+}
+
+// UsedAtLT applies the LT predicate on the "used_at" field.
+func UsedAtLT(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldUsedAt, v))
+}
+
+// UsedAtLTE applies the LTE predicate on the "used_at" field.
+func UsedAtLTE(v time.Time) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldUsedAt, v))
+}
+
+// UsedAtIsNil applies the IsNil predicate on the "used_at" field.
+func UsedAtIsNil() predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIsNull(FieldUsedAt))
+}
+
+// UsedAtNotNil applies the NotNil predicate on the "used_at" field.
+func UsedAtNotNil() predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotNull(FieldUsedAt))
+}
+
+// UsedByEQ applies the EQ predicate on the "used_by" field.
+func UsedByEQ(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldEQ(FieldUsedBy, v))
+}
+
+// UsedByNEQ applies the NEQ predicate on the "used_by" field.
+func UsedByNEQ(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNEQ(FieldUsedBy, v))
+}
+
+// UsedByIn applies the In predicate on the "used_by" field.
+func UsedByIn(vs ...uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIn(FieldUsedBy, vs...))
+}
+
+// UsedByNotIn applies the NotIn predicate on the "used_by" field.
+func UsedByNotIn(vs ...uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotIn(FieldUsedBy, vs...))
+}
+
+// UsedByGT applies the GT predicate on the "used_by" field.
+func UsedByGT(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGT(FieldUsedBy, v))
+}
+
+// UsedByGTE applies the GTE predicate on the "used_by" field.
+func UsedByGTE(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldGTE(FieldUsedBy, v))
+}
+
+// UsedByLT applies the LT predicate on the "used_by" field.
+func UsedByLT(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLT(FieldUsedBy, v))
+}
+
+// UsedByLTE applies the LTE predicate on the "used_by" field.
+func UsedByLTE(v uuid.UUID) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldLTE(FieldUsedBy, v))
+}
+
+// UsedByIsNil applies the IsNil predicate on the "used_by" field.
+func UsedByIsNil() predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldIsNull(FieldUsedBy))
+}
+
+// UsedByNotNil applies the NotNil predicate on the "used_by" field.
+func UsedByNotNil() predicate.SignupInvite {
+	return predicate.SignupInvite(sql.FieldNotNull(FieldUsedBy))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SignupInvite) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SignupInvite) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SignupInvite) predicate.SignupInvite {
+	return predicate.SignupInvite(sql.NotPredicates(p))
+}
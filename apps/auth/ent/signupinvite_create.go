@@ -0,0 +1,336 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/signupinvite"
+)
+
+// SignupInviteCreate is the builder for creating a SignupInvite entity.
+type SignupInviteCreate struct {
+	config
+	mutation *SignupInviteMutation
+	hooks    []Hook
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (sic *SignupInviteCreate) SetCodeHash(s string) *SignupInviteCreate {
+	sic.mutation.SetCodeHash(s)
+	return sic
+}
+
+// SetEmail sets the "email" field.
+func (sic *SignupInviteCreate) SetEmail(s string) *SignupInviteCreate {
+	sic.mutation.SetEmail(s)
+	return sic
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (sic *SignupInviteCreate) SetNillableEmail(s *string) *SignupInviteCreate {
+	if s != nil {
+		sic.SetEmail(*s)
+	}
+	return sic
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (sic *SignupInviteCreate) SetCreatedBy(s string) *SignupInviteCreate {
+	sic.mutation.SetCreatedBy(s)
+	return sic
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sic *SignupInviteCreate) SetCreatedAt(t time.Time) *SignupInviteCreate {
+	sic.mutation.SetCreatedAt(t)
+	return sic
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sic *SignupInviteCreate) SetNillableCreatedAt(t *time.Time) *SignupInviteCreate {
+	if t != nil {
+		sic.SetCreatedAt(*t)
+	}
+	return sic
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (sic *SignupInviteCreate) SetExpiresAt(t time.Time) *SignupInviteCreate {
+	sic.mutation.SetExpiresAt(t)
+	return sic
+}
+
+// SetUsedAt sets the "used_at" field.
+func (sic *SignupInviteCreate) SetUsedAt(t time.Time) *SignupInviteCreate {
+	sic.mutation.SetUsedAt(t)
+	return sic
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (sic *SignupInviteCreate) SetNillableUsedAt(t *time.Time) *SignupInviteCreate {
+	if t != nil {
+		sic.SetUsedAt(*t)
+	}
+	return sic
+}
+
+// SetUsedBy sets the "used_by" field.
+func (sic *SignupInviteCreate) SetUsedBy(u uuid.UUID) *SignupInviteCreate {
+	sic.mutation.SetUsedBy(u)
+	return sic
+}
+
+// SetNillableUsedBy sets the "used_by" field if the given value is not nil.
+func (sic *SignupInviteCreate) SetNillableUsedBy(u *uuid.UUID) *SignupInviteCreate {
+	if u != nil {
+		sic.SetUsedBy(*u)
+	}
+	return sic
+}
+
+// SetID sets the "id" field.
+func (sic *SignupInviteCreate) SetID(u uuid.UUID) *SignupInviteCreate {
+	sic.mutation.SetID(u)
+	return sic
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (sic *SignupInviteCreate) SetNillableID(u *uuid.UUID) *SignupInviteCreate {
+	if u != nil {
+		sic.SetID(*u)
+	}
+	return sic
+}
+
+// Mutation returns the SignupInviteMutation object of the builder.
+func (sic *SignupInviteCreate) Mutation() *SignupInviteMutation {
+	return sic.mutation
+}
+
+// Save creates the SignupInvite in the database.
+func (sic *SignupInviteCreate) Save(ctx context.Context) (*SignupInvite, error) {
+	sic.defaults()
+	return withHooks(ctx, sic.sqlSave, sic.mutation, sic.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sic *SignupInviteCreate) SaveX(ctx context.Context) *SignupInvite {
+	v, err := sic.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sic *SignupInviteCreate) Exec(ctx context.Context) error {
+	_, err := sic.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sic *SignupInviteCreate) ExecX(ctx context.Context) {
+	if err := sic.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sic *SignupInviteCreate) defaults() {
+	if _, ok := sic.mutation.Email(); !ok {
+		v := signupinvite.DefaultEmail
+		sic.mutation.SetEmail(v)
+	}
+	if _, ok := sic.mutation.CreatedAt(); !ok {
+		v := signupinvite.DefaultCreatedAt()
+		sic.mutation.SetCreatedAt(v)
+	}
+	if _, ok := sic.mutation.ID(); !ok {
+		v := signupinvite.DefaultID()
+		sic.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sic *SignupInviteCreate) check() error {
+	if _, ok := sic.mutation.CodeHash(); !ok {
+		return &ValidationError{Name: "code_hash", err: errors.New(`ent: missing required field "SignupInvite.code_hash"`)}
+	}
+	if v, ok := sic.mutation.CodeHash(); ok {
+		if err := signupinvite.CodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "code_hash", err: fmt.Errorf(`ent: validator failed for field "SignupInvite.code_hash": %w`, err)}
+		}
+	}
+	if _, ok := sic.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "SignupInvite.email"`)}
+	}
+	if _, ok := sic.mutation.CreatedBy(); !ok {
+		return &ValidationError{Name: "created_by", err: errors.New(`ent: missing required field "SignupInvite.created_by"`)}
+	}
+	if v, ok := sic.mutation.CreatedBy(); ok {
+		if err := signupinvite.CreatedByValidator(v); err != nil {
+			return &ValidationError{Name: "created_by", err: fmt.Errorf(`ent: validator failed for field "SignupInvite.created_by": %w`, err)}
+		}
+	}
+	if _, ok := sic.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SignupInvite.created_at"`)}
+	}
+	if _, ok := sic.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "SignupInvite.expires_at"`)}
+	}
+	return nil
+}
+
+func (sic *SignupInviteCreate) sqlSave(ctx context.Context) (*SignupInvite, error) {
+	if err := sic.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sic.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sic.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	sic.mutation.id = &_node.ID
+	sic.mutation.done = true
+	return _node, nil
+}
+
+func (sic *SignupInviteCreate) createSpec() (*SignupInvite, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SignupInvite{config: sic.config}
+		_spec = sqlgraph.NewCreateSpec(signupinvite.Table, sqlgraph.NewFieldSpec(signupinvite.FieldID, field.TypeUUID))
+	)
+	if id, ok := sic.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := sic.mutation.CodeHash(); ok {
+		_spec.SetField(signupinvite.FieldCodeHash, field.TypeString, value)
+		_node.CodeHash = value
+	}
+	if value, ok := sic.mutation.Email(); ok {
+		_spec.SetField(signupinvite.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := sic.mutation.CreatedBy(); ok {
+		_spec.SetField(signupinvite.FieldCreatedBy, field.TypeString, value)
+		_node.CreatedBy = value
+	}
+	if value, ok := sic.mutation.CreatedAt(); ok {
+		_spec.SetField(signupinvite.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := sic.mutation.ExpiresAt(); ok {
+		_spec.SetField(signupinvite.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := sic.mutation.UsedAt(); ok {
+		_spec.SetField(signupinvite.FieldUsedAt, field.TypeTime, value)
+		_node.UsedAt = &value
+	}
+	if value, ok := sic.mutation.UsedBy(); ok {
+		_spec.SetField(signupinvite.FieldUsedBy, field.TypeUUID, value)
+		_node.UsedBy = &value
+	}
+	return _node, _spec
+}
+
+// SignupInviteCreateBulk is the builder for creating many SignupInvite entities in bulk.
+type SignupInviteCreateBulk struct {
+	config
+	err      error
+	builders []*SignupInviteCreate
+}
+
+// Save creates the SignupInvite entities in the database.
+func (sicb *SignupInviteCreateBulk) Save(ctx context.Context) ([]*SignupInvite, error) {
+	if sicb.err != nil {
+		return nil, sicb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(sicb.builders))
+	nodes := make([]*SignupInvite, len(sicb.builders))
+	mutators := make([]Mutator, len(sicb.builders))
+	for i := range sicb.builders {
+		func(i int, root context.Context) {
+			builder := sicb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SignupInviteMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, sicb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, sicb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, sicb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (sicb *SignupInviteCreateBulk) SaveX(ctx context.Context) []*SignupInvite {
+	v, err := sicb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sicb *SignupInviteCreateBulk) Exec(ctx context.Context) error {
+	_, err := sicb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sicb *SignupInviteCreateBulk) ExecX(ctx context.Context) {
+	if err := sicb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,281 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/predicate"
+	"mandacode.com/accounts/auth/ent/signupinvite"
+)
+
+// SignupInviteUpdate is the builder for updating SignupInvite entities.
+type SignupInviteUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SignupInviteMutation
+}
+
+// Where appends a list predicates to the SignupInviteUpdate builder.
+func (siu *SignupInviteUpdate) Where(ps ...predicate.SignupInvite) *SignupInviteUpdate {
+	siu.mutation.Where(ps...)
+	return siu
+}
+
+// SetUsedAt sets the "used_at" field.
+func (siu *SignupInviteUpdate) SetUsedAt(t time.Time) *SignupInviteUpdate {
+	siu.mutation.SetUsedAt(t)
+	return siu
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (siu *SignupInviteUpdate) SetNillableUsedAt(t *time.Time) *SignupInviteUpdate {
+	if t != nil {
+		siu.SetUsedAt(*t)
+	}
+	return siu
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (siu *SignupInviteUpdate) ClearUsedAt() *SignupInviteUpdate {
+	siu.mutation.ClearUsedAt()
+	return siu
+}
+
+// SetUsedBy sets the "used_by" field.
+func (siu *SignupInviteUpdate) SetUsedBy(u uuid.UUID) *SignupInviteUpdate {
+	siu.mutation.SetUsedBy(u)
+	return siu
+}
+
+// SetNillableUsedBy sets the "used_by" field if the given value is not nil.
+func (siu *SignupInviteUpdate) SetNillableUsedBy(u *uuid.UUID) *SignupInviteUpdate {
+	if u != nil {
+		siu.SetUsedBy(*u)
+	}
+	return siu
+}
+
+// ClearUsedBy clears the value of the "used_by" field.
+func (siu *SignupInviteUpdate) ClearUsedBy() *SignupInviteUpdate {
+	siu.mutation.ClearUsedBy()
+	return siu
+}
+
+// Mutation returns the SignupInviteMutation object of the builder.
+func (siu *SignupInviteUpdate) Mutation() *SignupInviteMutation {
+	return siu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (siu *SignupInviteUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, siu.sqlSave, siu.mutation, siu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (siu *SignupInviteUpdate) SaveX(ctx context.Context) int {
+	affected, err := siu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (siu *SignupInviteUpdate) Exec(ctx context.Context) error {
+	_, err := siu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (siu *SignupInviteUpdate) ExecX(ctx context.Context) {
+	if err := siu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (siu *SignupInviteUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(signupinvite.Table, signupinvite.Columns, sqlgraph.NewFieldSpec(signupinvite.FieldID, field.TypeUUID))
+	if ps := siu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := siu.mutation.UsedAt(); ok {
+		_spec.SetField(signupinvite.FieldUsedAt, field.TypeTime, value)
+	}
+	if siu.mutation.UsedAtCleared() {
+		_spec.ClearField(signupinvite.FieldUsedAt, field.TypeTime)
+	}
+	if value, ok := siu.mutation.UsedBy(); ok {
+		_spec.SetField(signupinvite.FieldUsedBy, field.TypeUUID, value)
+	}
+	if siu.mutation.UsedByCleared() {
+		_spec.ClearField(signupinvite.FieldUsedBy, field.TypeUUID)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, siu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{signupinvite.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	siu.mutation.done = true
+	return n, nil
+}
+
+// SignupInviteUpdateOne is the builder for updating a single SignupInvite entity.
+type SignupInviteUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SignupInviteMutation
+}
+
+// SetUsedAt sets the "used_at" field.
+func (siuo *SignupInviteUpdateOne) SetUsedAt(t time.Time) *SignupInviteUpdateOne {
+	siuo.mutation.SetUsedAt(t)
+	return siuo
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (siuo *SignupInviteUpdateOne) SetNillableUsedAt(t *time.Time) *SignupInviteUpdateOne {
+	if t != nil {
+		siuo.SetUsedAt(*t)
+	}
+	return siuo
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (siuo *SignupInviteUpdateOne) ClearUsedAt() *SignupInviteUpdateOne {
+	siuo.mutation.ClearUsedAt()
+	return siuo
+}
+
+// SetUsedBy sets the "used_by" field.
+func (siuo *SignupInviteUpdateOne) SetUsedBy(u uuid.UUID) *SignupInviteUpdateOne {
+	siuo.mutation.SetUsedBy(u)
+	return siuo
+}
+
+// SetNillableUsedBy sets the "used_by" field if the given value is not nil.
+func (siuo *SignupInviteUpdateOne) SetNillableUsedBy(u *uuid.UUID) *SignupInviteUpdateOne {
+	if u != nil {
+		siuo.SetUsedBy(*u)
+	}
+	return siuo
+}
+
+// ClearUsedBy clears the value of the "used_by" field.
+func (siuo *SignupInviteUpdateOne) ClearUsedBy() *SignupInviteUpdateOne {
+	siuo.mutation.ClearUsedBy()
+	return siuo
+}
+
+// Mutation returns the SignupInviteMutation object of the builder.
+func (siuo *SignupInviteUpdateOne) Mutation() *SignupInviteMutation {
+	return siuo.mutation
+}
+
+// Where appends a list predicates to the SignupInviteUpdate builder.
+func (siuo *SignupInviteUpdateOne) Where(ps ...predicate.SignupInvite) *SignupInviteUpdateOne {
+	siuo.mutation.Where(ps...)
+	return siuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (siuo *SignupInviteUpdateOne) Select(field string, fields ...string) *SignupInviteUpdateOne {
+	siuo.fields = append([]string{field}, fields...)
+	return siuo
+}
+
+// Save executes the query and returns the updated SignupInvite entity.
+func (siuo *SignupInviteUpdateOne) Save(ctx context.Context) (*SignupInvite, error) {
+	return withHooks(ctx, siuo.sqlSave, siuo.mutation, siuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (siuo *SignupInviteUpdateOne) SaveX(ctx context.Context) *SignupInvite {
+	node, err := siuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (siuo *SignupInviteUpdateOne) Exec(ctx context.Context) error {
+	_, err := siuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (siuo *SignupInviteUpdateOne) ExecX(ctx context.Context) {
+	if err := siuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (siuo *SignupInviteUpdateOne) sqlSave(ctx context.Context) (_node *SignupInvite, err error) {
+	_spec := sqlgraph.NewUpdateSpec(signupinvite.Table, signupinvite.Columns, sqlgraph.NewFieldSpec(signupinvite.FieldID, field.TypeUUID))
+	id, ok := siuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SignupInvite.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := siuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, signupinvite.FieldID)
+		for _, f := range fields {
+			if !signupinvite.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != signupinvite.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := siuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := siuo.mutation.UsedAt(); ok {
+		_spec.SetField(signupinvite.FieldUsedAt, field.TypeTime, value)
+	}
+	if siuo.mutation.UsedAtCleared() {
+		_spec.ClearField(signupinvite.FieldUsedAt, field.TypeTime)
+	}
+	if value, ok := siuo.mutation.UsedBy(); ok {
+		_spec.SetField(signupinvite.FieldUsedBy, field.TypeUUID, value)
+	}
+	if siuo.mutation.UsedByCleared() {
+		_spec.ClearField(signupinvite.FieldUsedBy, field.TypeUUID)
+	}
+	_node = &SignupInvite{config: siuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, siuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{signupinvite.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	siuo.mutation.done = true
+	return _node, nil
+}
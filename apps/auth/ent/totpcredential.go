@@ -0,0 +1,177 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+)
+
+// TotpCredential is the model entity for the TotpCredential schema.
+type TotpCredential struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The unique identifier for the TOTP credential
+	ID uuid.UUID `json:"id,omitempty"`
+	// The unique identifier for the user owning this TOTP credential
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// The shared TOTP secret, encrypted with the application key
+	EncryptedSecret string `json:"-"`
+	// Indicates if the enrollment has been confirmed with a valid code
+	IsConfirmed bool `json:"is_confirmed,omitempty"`
+	// The display name chosen by the user
+	Name string `json:"name,omitempty"`
+	// The last time step accepted for this credential, used to reject replayed codes
+	LastUsedStep int64 `json:"last_used_step,omitempty"`
+	// The time when the TOTP credential was created
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// The time when the TOTP credential was last updated
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TotpCredential) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case totpcredential.FieldIsConfirmed:
+			values[i] = new(sql.NullBool)
+		case totpcredential.FieldLastUsedStep:
+			values[i] = new(sql.NullInt64)
+		case totpcredential.FieldEncryptedSecret, totpcredential.FieldName:
+			values[i] = new(sql.NullString)
+		case totpcredential.FieldCreatedAt, totpcredential.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case totpcredential.FieldID, totpcredential.FieldUserID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TotpCredential fields.
+func (tc *TotpCredential) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case totpcredential.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				tc.ID = *value
+			}
+		case totpcredential.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				tc.UserID = *value
+			}
+		case totpcredential.FieldEncryptedSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted_secret", values[i])
+			} else if value.Valid {
+				tc.EncryptedSecret = value.String
+			}
+		case totpcredential.FieldIsConfirmed:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field is_confirmed", values[i])
+			} else if value.Valid {
+				tc.IsConfirmed = value.Bool
+			}
+		case totpcredential.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				tc.Name = value.String
+			}
+		case totpcredential.FieldLastUsedStep:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field last_used_step", values[i])
+			} else if value.Valid {
+				tc.LastUsedStep = value.Int64
+			}
+		case totpcredential.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				tc.CreatedAt = value.Time
+			}
+		case totpcredential.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				tc.UpdatedAt = value.Time
+			}
+		default:
+			tc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TotpCredential.
+// This includes values selected through modifiers, order, etc.
+func (tc *TotpCredential) Value(name string) (ent.Value, error) {
+	return tc.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TotpCredential.
+// Note that you need to call TotpCredential.Unwrap() before calling this method if this TotpCredential
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (tc *TotpCredential) Update() *TotpCredentialUpdateOne {
+	return NewTotpCredentialClient(tc.config).UpdateOne(tc)
+}
+
+// Unwrap unwraps the TotpCredential entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (tc *TotpCredential) Unwrap() *TotpCredential {
+	_tx, ok := tc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: TotpCredential is not a transactional entity")
+	}
+	tc.config.driver = _tx.drv
+	return tc
+}
+
+// String implements the fmt.Stringer.
+func (tc *TotpCredential) String() string {
+	var builder strings.Builder
+	builder.WriteString("TotpCredential(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", tc.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", tc.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("encrypted_secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("is_confirmed=")
+	builder.WriteString(fmt.Sprintf("%v", tc.IsConfirmed))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(tc.Name)
+	builder.WriteString(", ")
+	builder.WriteString("last_used_step=")
+	builder.WriteString(fmt.Sprintf("%v", tc.LastUsedStep))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(tc.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(tc.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// TotpCredentials is a parsable slice of TotpCredential.
+type TotpCredentials []*TotpCredential
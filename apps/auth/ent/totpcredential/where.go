@@ -0,0 +1,406 @@
+// Code generated by ent, DO NOT EDIT.
+
+package totpcredential
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldUserID, v))
+}
+
+// EncryptedSecret applies equality check predicate on the "encrypted_secret" field. It's identical to EncryptedSecretEQ.
+func EncryptedSecret(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldEncryptedSecret, v))
+}
+
+// IsConfirmed applies equality check predicate on the "is_confirmed" field. It's identical to IsConfirmedEQ.
+func IsConfirmed(v bool) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldIsConfirmed, v))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldName, v))
+}
+
+// LastUsedStep applies equality check predicate on the "last_used_step" field. It's identical to LastUsedStepEQ.
+func LastUsedStep(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldLastUsedStep, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v uuid.UUID) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldUserID, v))
+}
+
+// EncryptedSecretEQ applies the EQ predicate on the "encrypted_secret" field.
+func EncryptedSecretEQ(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretNEQ applies the NEQ predicate on the "encrypted_secret" field.
+func EncryptedSecretNEQ(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretIn applies the In predicate on the "encrypted_secret" field.
+func EncryptedSecretIn(vs ...string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldEncryptedSecret, vs...))
+}
+
+// EncryptedSecretNotIn applies the NotIn predicate on the "encrypted_secret" field.
+func EncryptedSecretNotIn(vs ...string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldEncryptedSecret, vs...))
+}
+
+// EncryptedSecretGT applies the GT predicate on the "encrypted_secret" field.
+func EncryptedSecretGT(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretGTE applies the GTE predicate on the "encrypted_secret" field.
+func EncryptedSecretGTE(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretLT applies the LT predicate on the "encrypted_secret" field.
+func EncryptedSecretLT(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretLTE applies the LTE predicate on the "encrypted_secret" field.
+func EncryptedSecretLTE(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretContains applies the Contains predicate on the "encrypted_secret" field.
+func EncryptedSecretContains(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldContains(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretHasPrefix applies the HasPrefix predicate on the "encrypted_secret" field.
+func EncryptedSecretHasPrefix(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldHasPrefix(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretHasSuffix applies the HasSuffix predicate on the "encrypted_secret" field.
+func EncryptedSecretHasSuffix(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldHasSuffix(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretEqualFold applies the EqualFold predicate on the "encrypted_secret" field.
+func EncryptedSecretEqualFold(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEqualFold(FieldEncryptedSecret, v))
+}
+
+// EncryptedSecretContainsFold applies the ContainsFold predicate on the "encrypted_secret" field.
+func EncryptedSecretContainsFold(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldContainsFold(FieldEncryptedSecret, v))
+}
+
+// IsConfirmedEQ applies the EQ predicate on the "is_confirmed" field.
+func IsConfirmedEQ(v bool) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldIsConfirmed, v))
+}
+
+// IsConfirmedNEQ applies the NEQ predicate on the "is_confirmed" field.
+func IsConfirmedNEQ(v bool) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldIsConfirmed, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldContainsFold(FieldName, v))
+}
+
+// LastUsedStepEQ applies the EQ predicate on the "last_used_step" field.
+func LastUsedStepEQ(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldLastUsedStep, v))
+}
+
+// LastUsedStepNEQ applies the NEQ predicate on the "last_used_step" field.
+func LastUsedStepNEQ(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldLastUsedStep, v))
+}
+
+// LastUsedStepIn applies the In predicate on the "last_used_step" field.
+func LastUsedStepIn(vs ...int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldLastUsedStep, vs...))
+}
+
+// LastUsedStepNotIn applies the NotIn predicate on the "last_used_step" field.
+func LastUsedStepNotIn(vs ...int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldLastUsedStep, vs...))
+}
+
+// LastUsedStepGT applies the GT predicate on the "last_used_step" field.
+func LastUsedStepGT(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldLastUsedStep, v))
+}
+
+// LastUsedStepGTE applies the GTE predicate on the "last_used_step" field.
+func LastUsedStepGTE(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldLastUsedStep, v))
+}
+
+// LastUsedStepLT applies the LT predicate on the "last_used_step" field.
+func LastUsedStepLT(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldLastUsedStep, v))
+}
+
+// LastUsedStepLTE applies the LTE predicate on the "last_used_step" field.
+func LastUsedStepLTE(v int64) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldLastUsedStep, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TotpCredential) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TotpCredential) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TotpCredential) predicate.TotpCredential {
+	return predicate.TotpCredential(sql.NotPredicates(p))
+}
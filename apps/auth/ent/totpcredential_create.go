@@ -0,0 +1,362 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+)
+
+// TotpCredentialCreate is the builder for creating a TotpCredential entity.
+type TotpCredentialCreate struct {
+	config
+	mutation *TotpCredentialMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (tcc *TotpCredentialCreate) SetUserID(u uuid.UUID) *TotpCredentialCreate {
+	tcc.mutation.SetUserID(u)
+	return tcc
+}
+
+// SetEncryptedSecret sets the "encrypted_secret" field.
+func (tcc *TotpCredentialCreate) SetEncryptedSecret(s string) *TotpCredentialCreate {
+	tcc.mutation.SetEncryptedSecret(s)
+	return tcc
+}
+
+// SetIsConfirmed sets the "is_confirmed" field.
+func (tcc *TotpCredentialCreate) SetIsConfirmed(b bool) *TotpCredentialCreate {
+	tcc.mutation.SetIsConfirmed(b)
+	return tcc
+}
+
+// SetNillableIsConfirmed sets the "is_confirmed" field if the given value is not nil.
+func (tcc *TotpCredentialCreate) SetNillableIsConfirmed(b *bool) *TotpCredentialCreate {
+	if b != nil {
+		tcc.SetIsConfirmed(*b)
+	}
+	return tcc
+}
+
+// SetName sets the "name" field.
+func (tcc *TotpCredentialCreate) SetName(s string) *TotpCredentialCreate {
+	tcc.mutation.SetName(s)
+	return tcc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (tcc *TotpCredentialCreate) SetNillableName(s *string) *TotpCredentialCreate {
+	if s != nil {
+		tcc.SetName(*s)
+	}
+	return tcc
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (tcc *TotpCredentialCreate) SetLastUsedStep(i int64) *TotpCredentialCreate {
+	tcc.mutation.SetLastUsedStep(i)
+	return tcc
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (tcc *TotpCredentialCreate) SetNillableLastUsedStep(i *int64) *TotpCredentialCreate {
+	if i != nil {
+		tcc.SetLastUsedStep(*i)
+	}
+	return tcc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (tcc *TotpCredentialCreate) SetCreatedAt(t time.Time) *TotpCredentialCreate {
+	tcc.mutation.SetCreatedAt(t)
+	return tcc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (tcc *TotpCredentialCreate) SetNillableCreatedAt(t *time.Time) *TotpCredentialCreate {
+	if t != nil {
+		tcc.SetCreatedAt(*t)
+	}
+	return tcc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tcc *TotpCredentialCreate) SetUpdatedAt(t time.Time) *TotpCredentialCreate {
+	tcc.mutation.SetUpdatedAt(t)
+	return tcc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (tcc *TotpCredentialCreate) SetNillableUpdatedAt(t *time.Time) *TotpCredentialCreate {
+	if t != nil {
+		tcc.SetUpdatedAt(*t)
+	}
+	return tcc
+}
+
+// SetID sets the "id" field.
+func (tcc *TotpCredentialCreate) SetID(u uuid.UUID) *TotpCredentialCreate {
+	tcc.mutation.SetID(u)
+	return tcc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (tcc *TotpCredentialCreate) SetNillableID(u *uuid.UUID) *TotpCredentialCreate {
+	if u != nil {
+		tcc.SetID(*u)
+	}
+	return tcc
+}
+
+// Mutation returns the TotpCredentialMutation object of the builder.
+func (tcc *TotpCredentialCreate) Mutation() *TotpCredentialMutation {
+	return tcc.mutation
+}
+
+// Save creates the TotpCredential in the database.
+func (tcc *TotpCredentialCreate) Save(ctx context.Context) (*TotpCredential, error) {
+	tcc.defaults()
+	return withHooks(ctx, tcc.sqlSave, tcc.mutation, tcc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tcc *TotpCredentialCreate) SaveX(ctx context.Context) *TotpCredential {
+	v, err := tcc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tcc *TotpCredentialCreate) Exec(ctx context.Context) error {
+	_, err := tcc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcc *TotpCredentialCreate) ExecX(ctx context.Context) {
+	if err := tcc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tcc *TotpCredentialCreate) defaults() {
+	if _, ok := tcc.mutation.IsConfirmed(); !ok {
+		v := totpcredential.DefaultIsConfirmed
+		tcc.mutation.SetIsConfirmed(v)
+	}
+	if _, ok := tcc.mutation.Name(); !ok {
+		v := totpcredential.DefaultName
+		tcc.mutation.SetName(v)
+	}
+	if _, ok := tcc.mutation.LastUsedStep(); !ok {
+		v := totpcredential.DefaultLastUsedStep
+		tcc.mutation.SetLastUsedStep(v)
+	}
+	if _, ok := tcc.mutation.CreatedAt(); !ok {
+		v := totpcredential.DefaultCreatedAt()
+		tcc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := tcc.mutation.UpdatedAt(); !ok {
+		v := totpcredential.DefaultUpdatedAt()
+		tcc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := tcc.mutation.ID(); !ok {
+		v := totpcredential.DefaultID()
+		tcc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tcc *TotpCredentialCreate) check() error {
+	if _, ok := tcc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "TotpCredential.user_id"`)}
+	}
+	if _, ok := tcc.mutation.EncryptedSecret(); !ok {
+		return &ValidationError{Name: "encrypted_secret", err: errors.New(`ent: missing required field "TotpCredential.encrypted_secret"`)}
+	}
+	if v, ok := tcc.mutation.EncryptedSecret(); ok {
+		if err := totpcredential.EncryptedSecretValidator(v); err != nil {
+			return &ValidationError{Name: "encrypted_secret", err: fmt.Errorf(`ent: validator failed for field "TotpCredential.encrypted_secret": %w`, err)}
+		}
+	}
+	if _, ok := tcc.mutation.IsConfirmed(); !ok {
+		return &ValidationError{Name: "is_confirmed", err: errors.New(`ent: missing required field "TotpCredential.is_confirmed"`)}
+	}
+	if _, ok := tcc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "TotpCredential.name"`)}
+	}
+	if v, ok := tcc.mutation.Name(); ok {
+		if err := totpcredential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "TotpCredential.name": %w`, err)}
+		}
+	}
+	if _, ok := tcc.mutation.LastUsedStep(); !ok {
+		return &ValidationError{Name: "last_used_step", err: errors.New(`ent: missing required field "TotpCredential.last_used_step"`)}
+	}
+	if _, ok := tcc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "TotpCredential.created_at"`)}
+	}
+	if _, ok := tcc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "TotpCredential.updated_at"`)}
+	}
+	return nil
+}
+
+func (tcc *TotpCredentialCreate) sqlSave(ctx context.Context) (*TotpCredential, error) {
+	if err := tcc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tcc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tcc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	tcc.mutation.id = &_node.ID
+	tcc.mutation.done = true
+	return _node, nil
+}
+
+func (tcc *TotpCredentialCreate) createSpec() (*TotpCredential, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TotpCredential{config: tcc.config}
+		_spec = sqlgraph.NewCreateSpec(totpcredential.Table, sqlgraph.NewFieldSpec(totpcredential.FieldID, field.TypeUUID))
+	)
+	if id, ok := tcc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := tcc.mutation.UserID(); ok {
+		_spec.SetField(totpcredential.FieldUserID, field.TypeUUID, value)
+		_node.UserID = value
+	}
+	if value, ok := tcc.mutation.EncryptedSecret(); ok {
+		_spec.SetField(totpcredential.FieldEncryptedSecret, field.TypeString, value)
+		_node.EncryptedSecret = value
+	}
+	if value, ok := tcc.mutation.IsConfirmed(); ok {
+		_spec.SetField(totpcredential.FieldIsConfirmed, field.TypeBool, value)
+		_node.IsConfirmed = value
+	}
+	if value, ok := tcc.mutation.Name(); ok {
+		_spec.SetField(totpcredential.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := tcc.mutation.LastUsedStep(); ok {
+		_spec.SetField(totpcredential.FieldLastUsedStep, field.TypeInt64, value)
+		_node.LastUsedStep = value
+	}
+	if value, ok := tcc.mutation.CreatedAt(); ok {
+		_spec.SetField(totpcredential.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := tcc.mutation.UpdatedAt(); ok {
+		_spec.SetField(totpcredential.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// TotpCredentialCreateBulk is the builder for creating many TotpCredential entities in bulk.
+type TotpCredentialCreateBulk struct {
+	config
+	err      error
+	builders []*TotpCredentialCreate
+}
+
+// Save creates the TotpCredential entities in the database.
+func (tccb *TotpCredentialCreateBulk) Save(ctx context.Context) ([]*TotpCredential, error) {
+	if tccb.err != nil {
+		return nil, tccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(tccb.builders))
+	nodes := make([]*TotpCredential, len(tccb.builders))
+	mutators := make([]Mutator, len(tccb.builders))
+	for i := range tccb.builders {
+		func(i int, root context.Context) {
+			builder := tccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TotpCredentialMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tccb *TotpCredentialCreateBulk) SaveX(ctx context.Context) []*TotpCredential {
+	v, err := tccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tccb *TotpCredentialCreateBulk) Exec(ctx context.Context) error {
+	_, err := tccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tccb *TotpCredentialCreateBulk) ExecX(ctx context.Context) {
+	if err := tccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,439 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/predicate"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+)
+
+// TotpCredentialUpdate is the builder for updating TotpCredential entities.
+type TotpCredentialUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TotpCredentialMutation
+}
+
+// Where appends a list predicates to the TotpCredentialUpdate builder.
+func (tcu *TotpCredentialUpdate) Where(ps ...predicate.TotpCredential) *TotpCredentialUpdate {
+	tcu.mutation.Where(ps...)
+	return tcu
+}
+
+// SetUserID sets the "user_id" field.
+func (tcu *TotpCredentialUpdate) SetUserID(u uuid.UUID) *TotpCredentialUpdate {
+	tcu.mutation.SetUserID(u)
+	return tcu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (tcu *TotpCredentialUpdate) SetNillableUserID(u *uuid.UUID) *TotpCredentialUpdate {
+	if u != nil {
+		tcu.SetUserID(*u)
+	}
+	return tcu
+}
+
+// SetEncryptedSecret sets the "encrypted_secret" field.
+func (tcu *TotpCredentialUpdate) SetEncryptedSecret(s string) *TotpCredentialUpdate {
+	tcu.mutation.SetEncryptedSecret(s)
+	return tcu
+}
+
+// SetNillableEncryptedSecret sets the "encrypted_secret" field if the given value is not nil.
+func (tcu *TotpCredentialUpdate) SetNillableEncryptedSecret(s *string) *TotpCredentialUpdate {
+	if s != nil {
+		tcu.SetEncryptedSecret(*s)
+	}
+	return tcu
+}
+
+// SetIsConfirmed sets the "is_confirmed" field.
+func (tcu *TotpCredentialUpdate) SetIsConfirmed(b bool) *TotpCredentialUpdate {
+	tcu.mutation.SetIsConfirmed(b)
+	return tcu
+}
+
+// SetNillableIsConfirmed sets the "is_confirmed" field if the given value is not nil.
+func (tcu *TotpCredentialUpdate) SetNillableIsConfirmed(b *bool) *TotpCredentialUpdate {
+	if b != nil {
+		tcu.SetIsConfirmed(*b)
+	}
+	return tcu
+}
+
+// SetName sets the "name" field.
+func (tcu *TotpCredentialUpdate) SetName(s string) *TotpCredentialUpdate {
+	tcu.mutation.SetName(s)
+	return tcu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (tcu *TotpCredentialUpdate) SetNillableName(s *string) *TotpCredentialUpdate {
+	if s != nil {
+		tcu.SetName(*s)
+	}
+	return tcu
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (tcu *TotpCredentialUpdate) SetLastUsedStep(i int64) *TotpCredentialUpdate {
+	tcu.mutation.ResetLastUsedStep()
+	tcu.mutation.SetLastUsedStep(i)
+	return tcu
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (tcu *TotpCredentialUpdate) SetNillableLastUsedStep(i *int64) *TotpCredentialUpdate {
+	if i != nil {
+		tcu.SetLastUsedStep(*i)
+	}
+	return tcu
+}
+
+// AddLastUsedStep adds i to the "last_used_step" field.
+func (tcu *TotpCredentialUpdate) AddLastUsedStep(i int64) *TotpCredentialUpdate {
+	tcu.mutation.AddLastUsedStep(i)
+	return tcu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tcu *TotpCredentialUpdate) SetUpdatedAt(t time.Time) *TotpCredentialUpdate {
+	tcu.mutation.SetUpdatedAt(t)
+	return tcu
+}
+
+// Mutation returns the TotpCredentialMutation object of the builder.
+func (tcu *TotpCredentialUpdate) Mutation() *TotpCredentialMutation {
+	return tcu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tcu *TotpCredentialUpdate) Save(ctx context.Context) (int, error) {
+	tcu.defaults()
+	return withHooks(ctx, tcu.sqlSave, tcu.mutation, tcu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcu *TotpCredentialUpdate) SaveX(ctx context.Context) int {
+	affected, err := tcu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tcu *TotpCredentialUpdate) Exec(ctx context.Context) error {
+	_, err := tcu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcu *TotpCredentialUpdate) ExecX(ctx context.Context) {
+	if err := tcu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tcu *TotpCredentialUpdate) defaults() {
+	if _, ok := tcu.mutation.UpdatedAt(); !ok {
+		v := totpcredential.UpdateDefaultUpdatedAt()
+		tcu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tcu *TotpCredentialUpdate) check() error {
+	if v, ok := tcu.mutation.EncryptedSecret(); ok {
+		if err := totpcredential.EncryptedSecretValidator(v); err != nil {
+			return &ValidationError{Name: "encrypted_secret", err: fmt.Errorf(`ent: validator failed for field "TotpCredential.encrypted_secret": %w`, err)}
+		}
+	}
+	if v, ok := tcu.mutation.Name(); ok {
+		if err := totpcredential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "TotpCredential.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tcu *TotpCredentialUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := tcu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(totpcredential.Table, totpcredential.Columns, sqlgraph.NewFieldSpec(totpcredential.FieldID, field.TypeUUID))
+	if ps := tcu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tcu.mutation.UserID(); ok {
+		_spec.SetField(totpcredential.FieldUserID, field.TypeUUID, value)
+	}
+	if value, ok := tcu.mutation.EncryptedSecret(); ok {
+		_spec.SetField(totpcredential.FieldEncryptedSecret, field.TypeString, value)
+	}
+	if value, ok := tcu.mutation.IsConfirmed(); ok {
+		_spec.SetField(totpcredential.FieldIsConfirmed, field.TypeBool, value)
+	}
+	if value, ok := tcu.mutation.Name(); ok {
+		_spec.SetField(totpcredential.FieldName, field.TypeString, value)
+	}
+	if value, ok := tcu.mutation.LastUsedStep(); ok {
+		_spec.SetField(totpcredential.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := tcu.mutation.AddedLastUsedStep(); ok {
+		_spec.AddField(totpcredential.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := tcu.mutation.UpdatedAt(); ok {
+		_spec.SetField(totpcredential.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tcu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{totpcredential.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tcu.mutation.done = true
+	return n, nil
+}
+
+// TotpCredentialUpdateOne is the builder for updating a single TotpCredential entity.
+type TotpCredentialUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TotpCredentialMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (tcuo *TotpCredentialUpdateOne) SetUserID(u uuid.UUID) *TotpCredentialUpdateOne {
+	tcuo.mutation.SetUserID(u)
+	return tcuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (tcuo *TotpCredentialUpdateOne) SetNillableUserID(u *uuid.UUID) *TotpCredentialUpdateOne {
+	if u != nil {
+		tcuo.SetUserID(*u)
+	}
+	return tcuo
+}
+
+// SetEncryptedSecret sets the "encrypted_secret" field.
+func (tcuo *TotpCredentialUpdateOne) SetEncryptedSecret(s string) *TotpCredentialUpdateOne {
+	tcuo.mutation.SetEncryptedSecret(s)
+	return tcuo
+}
+
+// SetNillableEncryptedSecret sets the "encrypted_secret" field if the given value is not nil.
+func (tcuo *TotpCredentialUpdateOne) SetNillableEncryptedSecret(s *string) *TotpCredentialUpdateOne {
+	if s != nil {
+		tcuo.SetEncryptedSecret(*s)
+	}
+	return tcuo
+}
+
+// SetIsConfirmed sets the "is_confirmed" field.
+func (tcuo *TotpCredentialUpdateOne) SetIsConfirmed(b bool) *TotpCredentialUpdateOne {
+	tcuo.mutation.SetIsConfirmed(b)
+	return tcuo
+}
+
+// SetNillableIsConfirmed sets the "is_confirmed" field if the given value is not nil.
+func (tcuo *TotpCredentialUpdateOne) SetNillableIsConfirmed(b *bool) *TotpCredentialUpdateOne {
+	if b != nil {
+		tcuo.SetIsConfirmed(*b)
+	}
+	return tcuo
+}
+
+// SetName sets the "name" field.
+func (tcuo *TotpCredentialUpdateOne) SetName(s string) *TotpCredentialUpdateOne {
+	tcuo.mutation.SetName(s)
+	return tcuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (tcuo *TotpCredentialUpdateOne) SetNillableName(s *string) *TotpCredentialUpdateOne {
+	if s != nil {
+		tcuo.SetName(*s)
+	}
+	return tcuo
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (tcuo *TotpCredentialUpdateOne) SetLastUsedStep(i int64) *TotpCredentialUpdateOne {
+	tcuo.mutation.ResetLastUsedStep()
+	tcuo.mutation.SetLastUsedStep(i)
+	return tcuo
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (tcuo *TotpCredentialUpdateOne) SetNillableLastUsedStep(i *int64) *TotpCredentialUpdateOne {
+	if i != nil {
+		tcuo.SetLastUsedStep(*i)
+	}
+	return tcuo
+}
+
+// AddLastUsedStep adds i to the "last_used_step" field.
+func (tcuo *TotpCredentialUpdateOne) AddLastUsedStep(i int64) *TotpCredentialUpdateOne {
+	tcuo.mutation.AddLastUsedStep(i)
+	return tcuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tcuo *TotpCredentialUpdateOne) SetUpdatedAt(t time.Time) *TotpCredentialUpdateOne {
+	tcuo.mutation.SetUpdatedAt(t)
+	return tcuo
+}
+
+// Mutation returns the TotpCredentialMutation object of the builder.
+func (tcuo *TotpCredentialUpdateOne) Mutation() *TotpCredentialMutation {
+	return tcuo.mutation
+}
+
+// Where appends a list predicates to the TotpCredentialUpdate builder.
+func (tcuo *TotpCredentialUpdateOne) Where(ps ...predicate.TotpCredential) *TotpCredentialUpdateOne {
+	tcuo.mutation.Where(ps...)
+	return tcuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tcuo *TotpCredentialUpdateOne) Select(field string, fields ...string) *TotpCredentialUpdateOne {
+	tcuo.fields = append([]string{field}, fields...)
+	return tcuo
+}
+
+// Save executes the query and returns the updated TotpCredential entity.
+func (tcuo *TotpCredentialUpdateOne) Save(ctx context.Context) (*TotpCredential, error) {
+	tcuo.defaults()
+	return withHooks(ctx, tcuo.sqlSave, tcuo.mutation, tcuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcuo *TotpCredentialUpdateOne) SaveX(ctx context.Context) *TotpCredential {
+	node, err := tcuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tcuo *TotpCredentialUpdateOne) Exec(ctx context.Context) error {
+	_, err := tcuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcuo *TotpCredentialUpdateOne) ExecX(ctx context.Context) {
+	if err := tcuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tcuo *TotpCredentialUpdateOne) defaults() {
+	if _, ok := tcuo.mutation.UpdatedAt(); !ok {
+		v := totpcredential.UpdateDefaultUpdatedAt()
+		tcuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tcuo *TotpCredentialUpdateOne) check() error {
+	if v, ok := tcuo.mutation.EncryptedSecret(); ok {
+		if err := totpcredential.EncryptedSecretValidator(v); err != nil {
+			return &ValidationError{Name: "encrypted_secret", err: fmt.Errorf(`ent: validator failed for field "TotpCredential.encrypted_secret": %w`, err)}
+		}
+	}
+	if v, ok := tcuo.mutation.Name(); ok {
+		if err := totpcredential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "TotpCredential.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tcuo *TotpCredentialUpdateOne) sqlSave(ctx context.Context) (_node *TotpCredential, err error) {
+	if err := tcuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(totpcredential.Table, totpcredential.Columns, sqlgraph.NewFieldSpec(totpcredential.FieldID, field.TypeUUID))
+	id, ok := tcuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "TotpCredential.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tcuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, totpcredential.FieldID)
+		for _, f := range fields {
+			if !totpcredential.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != totpcredential.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tcuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tcuo.mutation.UserID(); ok {
+		_spec.SetField(totpcredential.FieldUserID, field.TypeUUID, value)
+	}
+	if value, ok := tcuo.mutation.EncryptedSecret(); ok {
+		_spec.SetField(totpcredential.FieldEncryptedSecret, field.TypeString, value)
+	}
+	if value, ok := tcuo.mutation.IsConfirmed(); ok {
+		_spec.SetField(totpcredential.FieldIsConfirmed, field.TypeBool, value)
+	}
+	if value, ok := tcuo.mutation.Name(); ok {
+		_spec.SetField(totpcredential.FieldName, field.TypeString, value)
+	}
+	if value, ok := tcuo.mutation.LastUsedStep(); ok {
+		_spec.SetField(totpcredential.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := tcuo.mutation.AddedLastUsedStep(); ok {
+		_spec.AddField(totpcredential.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := tcuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(totpcredential.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &TotpCredential{config: tcuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tcuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{totpcredential.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tcuo.mutation.done = true
+	return _node, nil
+}
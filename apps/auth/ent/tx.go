@@ -14,6 +14,20 @@ type Tx struct {
 	config
 	// AuthAccount is the client for interacting with the AuthAccount builders.
 	AuthAccount *AuthAccountClient
+	// AuthActivity is the client for interacting with the AuthActivity builders.
+	AuthActivity *AuthActivityClient
+	// ProviderToken is the client for interacting with the ProviderToken builders.
+	ProviderToken *ProviderTokenClient
+	// RecoveryCode is the client for interacting with the RecoveryCode builders.
+	RecoveryCode *RecoveryCodeClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
+	// SignupInvite is the client for interacting with the SignupInvite builders.
+	SignupInvite *SignupInviteClient
+	// TotpCredential is the client for interacting with the TotpCredential builders.
+	TotpCredential *TotpCredentialClient
+	// WebauthnCredential is the client for interacting with the WebauthnCredential builders.
+	WebauthnCredential *WebauthnCredentialClient
 
 	// lazily loaded.
 	client     *Client
@@ -146,6 +160,13 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.AuthAccount = NewAuthAccountClient(tx.config)
+	tx.AuthActivity = NewAuthActivityClient(tx.config)
+	tx.ProviderToken = NewProviderTokenClient(tx.config)
+	tx.RecoveryCode = NewRecoveryCodeClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
+	tx.SignupInvite = NewSignupInviteClient(tx.config)
+	tx.TotpCredential = NewTotpCredentialClient(tx.config)
+	tx.WebauthnCredential = NewWebauthnCredentialClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
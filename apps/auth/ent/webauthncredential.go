@@ -0,0 +1,241 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent/webauthncredential"
+)
+
+// WebauthnCredential is the model entity for the WebauthnCredential schema.
+type WebauthnCredential struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The unique identifier for the WebAuthn credential
+	ID uuid.UUID `json:"id,omitempty"`
+	// The unique identifier for the user owning this WebAuthn credential
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// The credential ID chosen by the authenticator
+	CredentialID []byte `json:"credential_id,omitempty"`
+	// The COSE encoded credential public key
+	PublicKey []byte `json:"public_key,omitempty"`
+	// The last signature counter reported by the authenticator
+	SignCount uint32 `json:"sign_count,omitempty"`
+	// The AAGUID identifying the authenticator model
+	Aaguid uuid.UUID `json:"aaguid,omitempty"`
+	// The transports the authenticator supports, as reported by the client
+	Transports []string `json:"transports,omitempty"`
+	// A user chosen label for the credential
+	Name string `json:"name,omitempty"`
+	// Indicates if the credential can be synced to other devices
+	BackupEligible bool `json:"backup_eligible,omitempty"`
+	// Indicates if the credential is currently backed up
+	BackupState bool `json:"backup_state,omitempty"`
+	// The time when the credential was last used to sign in
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	// The time when the WebAuthn credential was created
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// The time when the WebAuthn credential was last updated
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*WebauthnCredential) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case webauthncredential.FieldCredentialID, webauthncredential.FieldPublicKey, webauthncredential.FieldTransports:
+			values[i] = new([]byte)
+		case webauthncredential.FieldBackupEligible, webauthncredential.FieldBackupState:
+			values[i] = new(sql.NullBool)
+		case webauthncredential.FieldSignCount:
+			values[i] = new(sql.NullInt64)
+		case webauthncredential.FieldName:
+			values[i] = new(sql.NullString)
+		case webauthncredential.FieldLastUsedAt, webauthncredential.FieldCreatedAt, webauthncredential.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case webauthncredential.FieldID, webauthncredential.FieldUserID, webauthncredential.FieldAaguid:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the WebauthnCredential fields.
+func (wc *WebauthnCredential) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case webauthncredential.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				wc.ID = *value
+			}
+		case webauthncredential.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				wc.UserID = *value
+			}
+		case webauthncredential.FieldCredentialID:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field credential_id", values[i])
+			} else if value != nil {
+				wc.CredentialID = *value
+			}
+		case webauthncredential.FieldPublicKey:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field public_key", values[i])
+			} else if value != nil {
+				wc.PublicKey = *value
+			}
+		case webauthncredential.FieldSignCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field sign_count", values[i])
+			} else if value.Valid {
+				wc.SignCount = uint32(value.Int64)
+			}
+		case webauthncredential.FieldAaguid:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field aaguid", values[i])
+			} else if value != nil {
+				wc.Aaguid = *value
+			}
+		case webauthncredential.FieldTransports:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field transports", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &wc.Transports); err != nil {
+					return fmt.Errorf("unmarshal field transports: %w", err)
+				}
+			}
+		case webauthncredential.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				wc.Name = value.String
+			}
+		case webauthncredential.FieldBackupEligible:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field backup_eligible", values[i])
+			} else if value.Valid {
+				wc.BackupEligible = value.Bool
+			}
+		case webauthncredential.FieldBackupState:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field backup_state", values[i])
+			} else if value.Valid {
+				wc.BackupState = value.Bool
+			}
+		case webauthncredential.FieldLastUsedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_used_at", values[i])
+			} else if value.Valid {
+				wc.LastUsedAt = new(time.Time)
+				*wc.LastUsedAt = value.Time
+			}
+		case webauthncredential.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				wc.CreatedAt = value.Time
+			}
+		case webauthncredential.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				wc.UpdatedAt = value.Time
+			}
+		default:
+			wc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the WebauthnCredential.
+// This includes values selected through modifiers, order, etc.
+func (wc *WebauthnCredential) Value(name string) (ent.Value, error) {
+	return wc.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this WebauthnCredential.
+// Note that you need to call WebauthnCredential.Unwrap() before calling this method if this WebauthnCredential
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (wc *WebauthnCredential) Update() *WebauthnCredentialUpdateOne {
+	return NewWebauthnCredentialClient(wc.config).UpdateOne(wc)
+}
+
+// Unwrap unwraps the WebauthnCredential entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (wc *WebauthnCredential) Unwrap() *WebauthnCredential {
+	_tx, ok := wc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: WebauthnCredential is not a transactional entity")
+	}
+	wc.config.driver = _tx.drv
+	return wc
+}
+
+// String implements the fmt.Stringer.
+func (wc *WebauthnCredential) String() string {
+	var builder strings.Builder
+	builder.WriteString("WebauthnCredential(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", wc.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", wc.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("credential_id=")
+	builder.WriteString(fmt.Sprintf("%v", wc.CredentialID))
+	builder.WriteString(", ")
+	builder.WriteString("public_key=")
+	builder.WriteString(fmt.Sprintf("%v", wc.PublicKey))
+	builder.WriteString(", ")
+	builder.WriteString("sign_count=")
+	builder.WriteString(fmt.Sprintf("%v", wc.SignCount))
+	builder.WriteString(", ")
+	builder.WriteString("aaguid=")
+	builder.WriteString(fmt.Sprintf("%v", wc.Aaguid))
+	builder.WriteString(", ")
+	builder.WriteString("transports=")
+	builder.WriteString(fmt.Sprintf("%v", wc.Transports))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(wc.Name)
+	builder.WriteString(", ")
+	builder.WriteString("backup_eligible=")
+	builder.WriteString(fmt.Sprintf("%v", wc.BackupEligible))
+	builder.WriteString(", ")
+	builder.WriteString("backup_state=")
+	builder.WriteString(fmt.Sprintf("%v", wc.BackupState))
+	builder.WriteString(", ")
+	if v := wc.LastUsedAt; v != nil {
+		builder.WriteString("last_used_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(wc.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(wc.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// WebauthnCredentials is a parsable slice of WebauthnCredential.
+type WebauthnCredentials []*WebauthnCredential
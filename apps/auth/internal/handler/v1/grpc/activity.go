@@ -0,0 +1,103 @@
+package grpchandlerv1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	activityv1 "github.com/mandacode-com/accounts-proto/go/auth/activity/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	"mandacode.com/accounts/auth/internal/usecase/activity"
+	activitydto "mandacode.com/accounts/auth/internal/usecase/activity/dto"
+)
+
+// ActivityHandler lets support tooling search the authentication history of
+// all users. Only the admin clients may call it.
+type ActivityHandler struct {
+	activityv1.UnimplementedAuthActivityServiceServer
+	historyUsecase *activity.HistoryUsecase
+	adminClients   adminClients
+	logger         *zap.Logger
+}
+
+// ListAuthActivities implements activityv1.AuthActivityServiceServer.
+func (h *ActivityHandler) ListAuthActivities(ctx context.Context, req *activityv1.ListAuthActivitiesRequest) (*activityv1.ListAuthActivitiesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	clientName, err := h.adminClients.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := activitydto.SearchInput{
+		IPAddress: req.IpAddress,
+		Cursor:    req.PageToken,
+		Limit:     int(req.PageSize),
+	}
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, errors.New(err.Error(), "Invalid User ID", errcode.ErrInvalidInput)
+		}
+		input.UserID = userID
+	}
+	if req.Outcome != "" {
+		outcome, err := activitymodels.ParseOutcome(req.Outcome)
+		if err != nil {
+			return nil, err
+		}
+		input.Outcome = outcome
+	}
+	if req.From != nil {
+		input.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		input.To = req.To.AsTime()
+	}
+
+	page, err := h.historyUsecase.SearchActivity(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	h.logger.Info("auth activity searched by admin",
+		zap.String("client", clientName),
+		zap.String("user_id", req.UserId),
+		zap.String("ip_address", req.IpAddress),
+	)
+
+	resp := &activityv1.ListAuthActivitiesResponse{
+		Activities:    make([]*activityv1.AuthActivity, 0, len(page.Activities)),
+		NextPageToken: page.NextCursor,
+	}
+	for _, item := range page.Activities {
+		entry := &activityv1.AuthActivity{
+			Id:            item.ID.String(),
+			Method:        item.Method.String(),
+			Provider:      item.Provider,
+			IpAddress:     item.IPAddress,
+			UserAgent:     item.UserAgent,
+			Outcome:       item.Outcome.String(),
+			FailureReason: item.FailureReason,
+			CreatedAt:     timestamppb.New(item.CreatedAt),
+		}
+		if item.UserID != uuid.Nil {
+			entry.UserId = item.UserID.String()
+		}
+		resp.Activities = append(resp.Activities, entry)
+	}
+	return resp, nil
+}
+
+// NewActivityHandler creates a new ActivityHandler accepting calls from the
+// given admin clients.
+func NewActivityHandler(historyUsecase *activity.HistoryUsecase, adminClients []string, logger *zap.Logger) activityv1.AuthActivityServiceServer {
+	return &ActivityHandler{
+		historyUsecase: historyUsecase,
+		adminClients:   newAdminClients(adminClients),
+		logger:         logger,
+	}
+}
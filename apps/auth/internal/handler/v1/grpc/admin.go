@@ -0,0 +1,33 @@
+package grpchandlerv1
+
+import (
+	"context"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	grpcmiddleware "mandacode.com/accounts/auth/internal/middleware/grpc"
+)
+
+// adminClients is the set of client names allowed to call admin services.
+type adminClients map[string]struct{}
+
+// newAdminClients creates the set of the given client names.
+func newAdminClients(names []string) adminClients {
+	admins := make(adminClients, len(names))
+	for _, name := range names {
+		admins[name] = struct{}{}
+	}
+	return admins
+}
+
+// authorize returns the name of the calling client if it is an admin client.
+func (a adminClients) authorize(ctx context.Context) (string, error) {
+	clientName, ok := grpcmiddleware.GetClientName(ctx)
+	if !ok {
+		return "", errors.New("client name is missing in context", "Unauthenticated", errcode.ErrUnauthorized)
+	}
+	if _, ok := a[clientName]; !ok {
+		return "", errors.New("client "+clientName+" is not an admin client", "Forbidden", errcode.ErrForbidden)
+	}
+	return clientName, nil
+}
@@ -0,0 +1,135 @@
+package grpchandlerv1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	invitev1 "github.com/mandacode-com/accounts-proto/go/auth/invite/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
+	signupdto "mandacode.com/accounts/auth/internal/usecase/signup/dto"
+)
+
+// InviteHandler lets support tooling create, list and revoke the invite codes
+// admitting signups while signup is invite-only. Only the admin clients may
+// call it.
+type InviteHandler struct {
+	invitev1.UnimplementedInviteServiceServer
+	inviteUsecase *signup.InviteUsecase
+	adminClients  adminClients
+	logger        *zap.Logger
+}
+
+// CreateInvite implements invitev1.InviteServiceServer.
+func (h *InviteHandler) CreateInvite(ctx context.Context, req *invitev1.CreateInviteRequest) (*invitev1.CreateInviteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	clientName, err := h.adminClients.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := signupdto.CreateInviteInput{
+		Email:     req.Email,
+		CreatedBy: clientName,
+	}
+	if req.ExpiresIn != nil {
+		input.TTL = req.ExpiresIn.AsDuration()
+	}
+
+	code, invite, err := h.inviteUsecase.CreateInvite(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	h.logger.Info("signup invite created by admin",
+		zap.String("client", clientName),
+		zap.String("invite_id", invite.ID.String()),
+		zap.String("email", invite.Email),
+	)
+
+	return &invitev1.CreateInviteResponse{
+		Invite: newInviteMessage(invite),
+		Code:   code,
+	}, nil
+}
+
+// ListInvites implements invitev1.InviteServiceServer.
+func (h *InviteHandler) ListInvites(ctx context.Context, req *invitev1.ListInvitesRequest) (*invitev1.ListInvitesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	if _, err := h.adminClients.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	invites, err := h.inviteUsecase.ListInvites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &invitev1.ListInvitesResponse{
+		Invites: make([]*invitev1.Invite, 0, len(invites)),
+	}
+	for _, item := range invites {
+		resp.Invites = append(resp.Invites, newInviteMessage(item))
+	}
+	return resp, nil
+}
+
+// RevokeInvite implements invitev1.InviteServiceServer.
+func (h *InviteHandler) RevokeInvite(ctx context.Context, req *invitev1.RevokeInviteRequest) (*invitev1.RevokeInviteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	clientName, err := h.adminClients.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inviteID, err := uuid.Parse(req.InviteId)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid Invite ID", errcode.ErrInvalidInput)
+	}
+
+	if err := h.inviteUsecase.RevokeInvite(ctx, inviteID); err != nil {
+		return nil, err
+	}
+	h.logger.Info("signup invite revoked by admin",
+		zap.String("client", clientName),
+		zap.String("invite_id", req.InviteId),
+	)
+
+	return &invitev1.RevokeInviteResponse{}, nil
+}
+
+// newInviteMessage converts an invite to its message, without its code.
+func newInviteMessage(invite *dbmodels.SignupInvite) *invitev1.Invite {
+	message := &invitev1.Invite{
+		Id:        invite.ID.String(),
+		Email:     invite.Email,
+		CreatedBy: invite.CreatedBy,
+		CreatedAt: timestamppb.New(invite.CreatedAt),
+		ExpiresAt: timestamppb.New(invite.ExpiresAt),
+	}
+	if invite.UsedAt != nil {
+		message.UsedAt = timestamppb.New(*invite.UsedAt)
+	}
+	if invite.UsedBy != nil {
+		message.UsedBy = invite.UsedBy.String()
+	}
+	return message
+}
+
+// NewInviteHandler creates a new InviteHandler accepting calls from the given
+// admin clients.
+func NewInviteHandler(inviteUsecase *signup.InviteUsecase, adminClients []string, logger *zap.Logger) invitev1.InviteServiceServer {
+	return &InviteHandler{
+		inviteUsecase: inviteUsecase,
+		adminClients:  newAdminClients(adminClients),
+		logger:        logger,
+	}
+}
@@ -0,0 +1,74 @@
+package grpchandlerv1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	providertokenv1 "github.com/mandacode-com/accounts-proto/go/auth/providertoken/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	grpcmiddleware "mandacode.com/accounts/auth/internal/middleware/grpc"
+	"mandacode.com/accounts/auth/internal/usecase/providertoken"
+	providertokendto "mandacode.com/accounts/auth/internal/usecase/providertoken/dto"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+type ProviderTokenHandler struct {
+	providertokenv1.UnimplementedProviderTokenServiceServer
+	fetchUsecase *providertoken.FetchUsecase
+	logger       *zap.Logger
+}
+
+// GetProviderToken implements providertokenv1.ProviderTokenServiceServer.
+func (h *ProviderTokenHandler) GetProviderToken(ctx context.Context, req *providertokenv1.GetProviderTokenRequest) (*providertokenv1.GetProviderTokenResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+
+	clientName, ok := grpcmiddleware.GetClientName(ctx)
+	if !ok {
+		return nil, errors.New("client name is missing in context", "Unauthenticated", errcode.ErrUnauthorized)
+	}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid User ID", errcode.ErrInvalidInput)
+	}
+	provider, err := util.ParseProvider(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := h.fetchUsecase.FetchProviderToken(ctx, providertokendto.FetchInput{
+		UserID:    userID,
+		Provider:  provider,
+		Requester: clientName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.logger.Info("provider token fetched",
+		zap.String("client", clientName),
+		zap.String("user_id", req.UserId),
+		zap.String("provider", req.Provider),
+	)
+
+	resp := &providertokenv1.GetProviderTokenResponse{
+		AccessToken: output.AccessToken,
+		TokenType:   output.TokenType,
+		Scope:       output.Scope,
+	}
+	if !output.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(output.ExpiresAt)
+	}
+	return resp, nil
+}
+
+// NewProviderTokenHandler creates a new ProviderTokenHandler with the provided use case.
+func NewProviderTokenHandler(fetchUsecase *providertoken.FetchUsecase, logger *zap.Logger) providertokenv1.ProviderTokenServiceServer {
+	return &ProviderTokenHandler{
+		fetchUsecase: fetchUsecase,
+		logger:       logger,
+	}
+}
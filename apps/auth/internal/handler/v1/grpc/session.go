@@ -0,0 +1,125 @@
+package grpchandlerv1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	sessionv1 "github.com/mandacode-com/accounts-proto/go/auth/session/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	sessionusecase "mandacode.com/accounts/auth/internal/usecase/session"
+)
+
+// SessionHandler lets support tooling list and revoke the sessions of any
+// user. Only the admin clients may call it.
+type SessionHandler struct {
+	sessionv1.UnimplementedSessionServiceServer
+	manageUsecase *sessionusecase.ManageUsecase
+	adminClients  adminClients
+	logger        *zap.Logger
+}
+
+// ListSessions implements sessionv1.SessionServiceServer.
+func (h *SessionHandler) ListSessions(ctx context.Context, req *sessionv1.ListSessionsRequest) (*sessionv1.ListSessionsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	if _, err := h.adminClients.authorize(ctx); err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid User ID", errcode.ErrInvalidInput)
+	}
+
+	sessions, err := h.manageUsecase.ListSessions(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sessionv1.ListSessionsResponse{
+		Sessions: make([]*sessionv1.Session, 0, len(sessions)),
+	}
+	for _, item := range sessions {
+		resp.Sessions = append(resp.Sessions, &sessionv1.Session{
+			Id:         item.ID.String(),
+			DeviceName: item.DeviceName,
+			UserAgent:  item.UserAgent,
+			IpAddress:  item.IPAddress,
+			CreatedAt:  timestamppb.New(item.CreatedAt),
+			LastUsedAt: timestamppb.New(item.LastUsedAt),
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession implements sessionv1.SessionServiceServer.
+func (h *SessionHandler) RevokeSession(ctx context.Context, req *sessionv1.RevokeSessionRequest) (*sessionv1.RevokeSessionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	clientName, err := h.adminClients.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid User ID", errcode.ErrInvalidInput)
+	}
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid Session ID", errcode.ErrInvalidInput)
+	}
+
+	if err := h.manageUsecase.RevokeSession(ctx, userID, sessionID); err != nil {
+		return nil, err
+	}
+	h.logger.Info("session revoked by admin",
+		zap.String("client", clientName),
+		zap.String("user_id", req.UserId),
+		zap.String("session_id", req.SessionId),
+	)
+
+	return &sessionv1.RevokeSessionResponse{}, nil
+}
+
+// RevokeAllSessions implements sessionv1.SessionServiceServer.
+func (h *SessionHandler) RevokeAllSessions(ctx context.Context, req *sessionv1.RevokeAllSessionsRequest) (*sessionv1.RevokeAllSessionsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.New(err.Error(), "Invalid Request", errcode.ErrInvalidInput)
+	}
+	clientName, err := h.adminClients.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid User ID", errcode.ErrInvalidInput)
+	}
+
+	revoked, err := h.manageUsecase.RevokeAllSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	h.logger.Info("all sessions revoked by admin",
+		zap.String("client", clientName),
+		zap.String("user_id", req.UserId),
+		zap.Int("revoked", revoked),
+	)
+
+	return &sessionv1.RevokeAllSessionsResponse{
+		Revoked: int32(revoked),
+	}, nil
+}
+
+// NewSessionHandler creates a new SessionHandler accepting calls from the
+// given admin clients.
+func NewSessionHandler(manageUsecase *sessionusecase.ManageUsecase, adminClients []string, logger *zap.Logger) sessionv1.SessionServiceServer {
+	return &SessionHandler{
+		manageUsecase: manageUsecase,
+		adminClients:  newAdminClients(adminClients),
+		logger:        logger,
+	}
+}
@@ -0,0 +1,518 @@
+package httphandlerv1
+
+import (
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+
+	handlerv1dto "mandacode.com/accounts/auth/internal/handler/v1/http/dto"
+	httpmiddleware "mandacode.com/accounts/auth/internal/middleware/http"
+	"mandacode.com/accounts/auth/internal/usecase/localauth"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+	"mandacode.com/accounts/auth/internal/usecase/oauthauth"
+	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// AccountHandler serves account management routes for authenticated users.
+// Its routes must be registered behind httpmiddleware.Authenticate.
+type AccountHandler struct {
+	passwordChange *localauth.PasswordChangeUsecase
+	emailChange    *localauth.EmailChangeUsecase
+	totp           *localauth.TotpUsecase
+	mfaFactor      *mfa.FactorUsecase
+	recoveryCode   *mfa.RecoveryCodeUsecase
+	oauthLink      *oauthauth.LinkUsecase
+	logger         *zap.Logger
+	validator      *validator.Validate
+}
+
+// NewAccountHandler creates a new AccountHandler instance
+func NewAccountHandler(
+	passwordChange *localauth.PasswordChangeUsecase,
+	emailChange *localauth.EmailChangeUsecase,
+	totp *localauth.TotpUsecase,
+	mfaFactor *mfa.FactorUsecase,
+	recoveryCode *mfa.RecoveryCodeUsecase,
+	oauthLink *oauthauth.LinkUsecase,
+	logger *zap.Logger,
+	validator *validator.Validate,
+) (*AccountHandler, error) {
+	if passwordChange == nil {
+		return nil, stdErrors.New("passwordChange cannot be nil")
+	}
+	if emailChange == nil {
+		return nil, stdErrors.New("emailChange cannot be nil")
+	}
+	if totp == nil {
+		return nil, stdErrors.New("totp cannot be nil")
+	}
+	if mfaFactor == nil {
+		return nil, stdErrors.New("mfaFactor cannot be nil")
+	}
+	if recoveryCode == nil {
+		return nil, stdErrors.New("recoveryCode cannot be nil")
+	}
+	if oauthLink == nil {
+		return nil, stdErrors.New("oauthLink cannot be nil")
+	}
+	if validator == nil {
+		return nil, stdErrors.New("validator cannot be nil")
+	}
+
+	return &AccountHandler{
+		passwordChange: passwordChange,
+		emailChange:    emailChange,
+		totp:           totp,
+		mfaFactor:      mfaFactor,
+		recoveryCode:   recoveryCode,
+		oauthLink:      oauthLink,
+		logger:         logger,
+		validator:      validator,
+	}, nil
+}
+
+func (h *AccountHandler) ValidateRequest(req interface{}) error {
+	if req == nil {
+		return errors.New("request cannot be nil", "InvalidRequest", errcode.ErrInvalidInput)
+	}
+	if err := h.validator.Struct(req); err != nil {
+		joinedErr := errors.Join(err, "validation failed")
+		return errors.Upgrade(joinedErr, "InvalidRequest", errcode.ErrInvalidInput)
+	}
+	return nil
+}
+
+// RegisterRoutes registers the account management routes
+func (h *AccountHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/password", h.ChangePassword)
+	rg.POST("/email", h.ChangeEmail)
+	rg.POST("/mfa/totp", h.EnrollTotp)
+	rg.POST("/mfa/totp/confirm", h.ConfirmTotp)
+	rg.PATCH("/mfa/totp", h.RenameTotp)
+	rg.DELETE("/mfa/totp", h.RemoveTotp)
+	rg.PATCH("/mfa/passkeys/:passkeyID", h.RenamePasskey)
+	rg.DELETE("/mfa/passkeys/:passkeyID", h.RemovePasskey)
+	rg.POST("/mfa/recovery-codes", h.GenerateRecoveryCodes)
+	rg.DELETE("/mfa/recovery-codes", h.RemoveRecoveryCodes)
+	rg.GET("/mfa/factors", h.ListFactors)
+	rg.GET("/identities", h.ListIdentities)
+	rg.POST("/identities/:provider", h.LinkIdentity)
+	rg.DELETE("/identities/:provider", h.UnlinkIdentity)
+}
+
+// ChangePassword handles changing the password of the authenticated user
+func (h *AccountHandler) ChangePassword(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	var req handlerv1dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := localauthdto.ChangePasswordInput{
+		UserID:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		SignOutOthers:   req.SignOutOthers,
+	}
+
+	accessToken, refreshToken, err := h.passwordChange.ChangePassword(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if !req.SignOutOthers {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	// Other sessions were revoked; keep the caller signed in with the new tokens.
+	// Browser sessions get the refresh token in the session, other clients directly.
+	session := sessions.Default(c)
+	if session.Get("refresh_token") == nil {
+		c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		})
+		return
+	}
+
+	session.Set("refresh_token", refreshToken)
+	if err := session.Save(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerv1dto.AccessTokenResponse{
+		AccessToken: accessToken,
+	})
+}
+
+// ChangeEmail handles requesting an email change for the authenticated user
+func (h *AccountHandler) ChangeEmail(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	var req handlerv1dto.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := localauthdto.ChangeEmailInput{
+		UserID:   userID,
+		Password: req.Password,
+		NewEmail: req.NewEmail,
+	}
+
+	if err := h.emailChange.RequestEmailChange(c.Request.Context(), input); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// EnrollTotp handles starting TOTP enrollment for the authenticated user
+func (h *AccountHandler) EnrollTotp(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	secret, provisioningURI, err := h.totp.EnrollTotp(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.TotpEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: provisioningURI,
+	})
+}
+
+// ConfirmTotp handles confirming TOTP enrollment with the first code
+func (h *AccountHandler) ConfirmTotp(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	var req handlerv1dto.ConfirmTotpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := localauthdto.ConfirmTotpInput{
+		UserID: userID,
+		Code:   req.Code,
+	}
+
+	if err := h.totp.ConfirmTotp(c.Request.Context(), input); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFactors handles listing the second factors of the authenticated user
+func (h *AccountHandler) ListFactors(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	factors, err := h.mfaFactor.ListFactors(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.FactorListResponse{
+		Factors: make([]handlerv1dto.FactorResponse, 0, len(factors)),
+	}
+	for _, factor := range factors {
+		item := handlerv1dto.FactorResponse{
+			Type:       factor.Type,
+			Name:       factor.Name,
+			CreatedAt:  factor.CreatedAt,
+			LastUsedAt: factor.LastUsedAt,
+		}
+		if factor.ID != uuid.Nil {
+			item.ID = factor.ID.String()
+		}
+		if factor.Type == mfadto.FactorTypeRecoveryCodes {
+			item.Remaining = &factor.Remaining
+		}
+		response.Factors = append(response.Factors, item)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RenameTotp handles renaming the TOTP authenticator of the authenticated user
+func (h *AccountHandler) RenameTotp(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	var req handlerv1dto.RenameFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.mfaFactor.RenameTotp(c.Request.Context(), userID, req.Name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveTotp handles removing the TOTP authenticator of the authenticated user
+func (h *AccountHandler) RemoveTotp(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	if err := h.mfaFactor.RemoveTotp(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RenamePasskey handles renaming a passkey of the authenticated user
+func (h *AccountHandler) RenamePasskey(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	passkeyID, err := uuid.Parse(c.Param("passkeyID"))
+	if err != nil {
+		c.Error(errors.New("invalid passkeyID format", "InvalidPasskeyIDFormat", errcode.ErrInvalidInput))
+		return
+	}
+
+	var req handlerv1dto.RenameFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.mfaFactor.RenamePasskey(c.Request.Context(), userID, passkeyID, req.Name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemovePasskey handles removing a passkey of the authenticated user
+func (h *AccountHandler) RemovePasskey(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	passkeyID, err := uuid.Parse(c.Param("passkeyID"))
+	if err != nil {
+		c.Error(errors.New("invalid passkeyID format", "InvalidPasskeyIDFormat", errcode.ErrInvalidInput))
+		return
+	}
+
+	if err := h.mfaFactor.RemovePasskey(c.Request.Context(), userID, passkeyID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GenerateRecoveryCodes handles generating a new set of recovery codes for the authenticated user
+func (h *AccountHandler) GenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	codes, err := h.recoveryCode.GenerateRecoveryCodes(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.RecoveryCodesResponse{
+		Codes: codes,
+	})
+}
+
+// RemoveRecoveryCodes handles removing the recovery codes of the authenticated user
+func (h *AccountHandler) RemoveRecoveryCodes(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	if err := h.mfaFactor.RemoveRecoveryCodes(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListIdentities handles listing the identities linked to the authenticated user
+func (h *AccountHandler) ListIdentities(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	accounts, err := h.oauthLink.ListLinkedAccounts(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.IdentityListResponse{
+		Identities: make([]handlerv1dto.IdentityResponse, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		response.Identities = append(response.Identities, handlerv1dto.IdentityResponse{
+			Provider:   string(account.Provider),
+			Email:      account.Email,
+			IsVerified: account.IsVerified,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// LinkIdentity handles linking an OAuth identity to the authenticated user
+func (h *AccountHandler) LinkIdentity(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	provider, err := util.ParseProvider(c.Param("provider"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req handlerv1dto.LinkIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := oauthdto.LinkInput{
+		UserID:      userID,
+		Provider:    provider,
+		AccessToken: req.AccessToken,
+		IDToken:     req.IDToken,
+		Nonce:       req.Nonce,
+		Code:        req.Code,
+	}
+
+	account, err := h.oauthLink.LinkProvider(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlerv1dto.IdentityResponse{
+		Provider:   string(account.Provider),
+		Email:      account.Email,
+		IsVerified: account.IsVerified,
+	})
+}
+
+// UnlinkIdentity handles unlinking an OAuth identity from the authenticated user
+func (h *AccountHandler) UnlinkIdentity(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	provider, err := util.ParseProvider(c.Param("provider"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.oauthLink.UnlinkProvider(c.Request.Context(), userID, provider); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package httphandlerv1
+
+import (
+	stdErrors "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+
+	handlerv1dto "mandacode.com/accounts/auth/internal/handler/v1/http/dto"
+	httpmiddleware "mandacode.com/accounts/auth/internal/middleware/http"
+	"mandacode.com/accounts/auth/internal/usecase/activity"
+)
+
+// ActivityHandler serves the authentication history of the authenticated user.
+type ActivityHandler struct {
+	history *activity.HistoryUsecase
+	logger  *zap.Logger
+}
+
+// NewActivityHandler creates a new ActivityHandler instance
+func NewActivityHandler(history *activity.HistoryUsecase, logger *zap.Logger) (*ActivityHandler, error) {
+	if history == nil {
+		return nil, stdErrors.New("history cannot be nil")
+	}
+	if logger == nil {
+		return nil, stdErrors.New("logger cannot be nil")
+	}
+
+	return &ActivityHandler{
+		history: history,
+		logger:  logger,
+	}, nil
+}
+
+// RegisterAccountRoutes registers the activity routes.
+// They must be registered behind httpmiddleware.Authenticate.
+func (h *ActivityHandler) RegisterAccountRoutes(rg *gin.RouterGroup) {
+	rg.GET("/activity", h.ListActivity)
+}
+
+// ListActivity handles paging through the recent activity of the
+// authenticated user, newest first. The cursor query parameter takes the
+// next_cursor of the previous page.
+func (h *ActivityHandler) ListActivity(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			c.Error(errors.New("invalid limit: "+value, "Invalid Limit", errcode.ErrInvalidInput))
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.history.ListUserActivity(c.Request.Context(), userID, c.Query("cursor"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.ActivityListResponse{
+		Activities: make([]handlerv1dto.ActivityResponse, 0, len(page.Activities)),
+		NextCursor: page.NextCursor,
+	}
+	for _, item := range page.Activities {
+		response.Activities = append(response.Activities, handlerv1dto.ActivityResponse{
+			ID:            item.ID.String(),
+			Method:        item.Method.String(),
+			Provider:      item.Provider,
+			IPAddress:     item.IPAddress,
+			UserAgent:     item.UserAgent,
+			Outcome:       item.Outcome.String(),
+			FailureReason: item.FailureReason,
+			CreatedAt:     item.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,19 @@
+package handlerv1dto
+
+import "time"
+
+type ActivityResponse struct {
+	ID            string    `json:"id"`
+	Method        string    `json:"method"`
+	Provider      string    `json:"provider,omitempty"`
+	IPAddress     string    `json:"ip_address,omitempty"`
+	UserAgent     string    `json:"user_agent,omitempty"`
+	Outcome       string    `json:"outcome"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ActivityListResponse struct {
+	Activities []ActivityResponse `json:"activities"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
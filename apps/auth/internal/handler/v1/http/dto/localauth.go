@@ -1,8 +1,9 @@
 package handlerv1dto
 
 type LocalLoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8,max=64"`
+	Email          string `json:"email" binding:"required,email"`
+	Password       string `json:"password" binding:"required,min=8,max=64"`
+	ChallengeToken string `json:"challenge_token"`
 }
 
 type IssueCodeResponse struct {
@@ -11,7 +12,92 @@ type IssueCodeResponse struct {
 }
 
 type LocalSignupRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8,max=64"`
+	Email              string `json:"email" binding:"required,email"`
+	Password           string `json:"password" binding:"required,max=64"`
+	VerificationMethod string `json:"verification_method" binding:"omitempty,oneof=link otp"`
+	ChallengeToken     string `json:"challenge_token"`
+	InviteCode         string `json:"invite_code"`
 }
 
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,max=64"`
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type MagicLinkLoginRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type EmailOTPRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResendVerificationRequest struct {
+	Email          string `json:"email" binding:"required,email"`
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type EmailOTPConfirmRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Code  string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,max=64"`
+	SignOutOthers   bool   `json:"sign_out_others"`
+}
+
+type ChangeEmailRequest struct {
+	Password string `json:"password" binding:"required"`
+	NewEmail string `json:"new_email" binding:"required,email"`
+}
+
+type ChangeEmailConfirmRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ChallengeResponse struct {
+	Required bool   `json:"required"`
+	Provider string `json:"provider,omitempty"`
+	SiteKey  string `json:"site_key,omitempty"`
+}
+
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code" binding:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" binding:"required_without=Code,excluded_with=Code,max=64"`
+}
+
+type MFAPasskeyOptionsRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// MFAPasskeyLoginRequest answers an MFA challenge with a passkey assertion
+// started by /login/mfa/passkey/options
+type MFAPasskeyLoginRequest struct {
+	MFAToken string              `json:"mfa_token" binding:"required"`
+	Passkey  PasskeyLoginRequest `json:"passkey" binding:"required"`
+}
+
+type TotpEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type ConfirmTotpRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
@@ -0,0 +1,24 @@
+package handlerv1dto
+
+import "time"
+
+type RenameFactorRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type FactorResponse struct {
+	ID         string     `json:"id,omitempty"`
+	Type       string     `json:"type"`
+	Name       string     `json:"name,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Remaining  *int       `json:"remaining,omitempty"` // Unused recovery codes
+}
+
+type FactorListResponse struct {
+	Factors []FactorResponse `json:"factors"`
+}
+
+type RecoveryCodesResponse struct {
+	Codes []string `json:"codes"`
+}
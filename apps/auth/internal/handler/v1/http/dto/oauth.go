@@ -1,7 +1,12 @@
 package handlerv1dto
 
+// MobileOAuthLoginRequest carries the token a native SDK obtained. The ID
+// token is preferred where the provider issues one.
 type MobileOAuthLoginRequest struct {
-	AccessToken string `json:"access_token" binding:"required"`
+	AccessToken string `json:"access_token" binding:"required_without=IDToken"`
+	IDToken     string `json:"id_token" binding:"required_without=AccessToken"`
+	Nonce       string `json:"nonce" binding:"required_with=IDToken"` // The nonce passed to the SDK, required with an ID token
+	InviteCode  string `json:"invite_code"`                           // Admits the signup of a new user while signup is invite-only
 }
 
 type OAuthCallbackResponse struct {
@@ -9,3 +14,47 @@ type OAuthCallbackResponse struct {
 	UserID string `json:"user_id"`
 }
 
+type LinkIdentityRequest struct {
+	AccessToken string `json:"access_token" binding:"required_without_all=IDToken Code"`
+	IDToken     string `json:"id_token" binding:"required_without_all=AccessToken Code"`
+	Nonce       string `json:"nonce" binding:"required_with=IDToken"`
+	Code        string `json:"code" binding:"required_without_all=AccessToken IDToken"`
+}
+
+type IdentityResponse struct {
+	Provider   string `json:"provider"`
+	Email      string `json:"email"`
+	IsVerified bool   `json:"is_verified"`
+}
+
+type IdentityListResponse struct {
+	Identities []IdentityResponse `json:"identities"`
+}
+
+// LinkRequiredResponse is returned instead of tokens when the identity must be
+// linked to an existing account first
+type LinkRequiredResponse struct {
+	LinkRequired bool   `json:"link_required"`
+	LinkToken    string `json:"link_token"`
+}
+
+type ConfirmLinkRequest struct {
+	LinkToken string `json:"link_token" binding:"required"`
+	Password  string `json:"password" binding:"required"`
+}
+
+// OAuthMFARequest answers the MFA challenge of an OAuth login with a TOTP
+// code, a recovery code or a passkey assertion. Passkey assertions are started
+// with /v1/auth/local/login/mfa/passkey/options
+type OAuthMFARequest struct {
+	MFAToken     string               `json:"mfa_token" binding:"required"`
+	Code         string               `json:"code" binding:"required_without_all=RecoveryCode Passkey,omitempty,len=6,numeric"`
+	RecoveryCode string               `json:"recovery_code" binding:"omitempty,max=64"`
+	Passkey      *PasskeyLoginRequest `json:"passkey" binding:"omitempty"`
+}
+
+// ConfirmLinkMFARequest answers the MFA challenge of a link confirmation
+type ConfirmLinkMFARequest struct {
+	LinkToken string `json:"link_token" binding:"required"`
+	OAuthMFARequest
+}
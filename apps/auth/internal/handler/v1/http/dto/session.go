@@ -0,0 +1,27 @@
+package handlerv1dto
+
+import "time"
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// RevokeOtherSessionsRequest carries the refresh token identifying the session
+// to keep, for clients that do not keep it in the session.
+type RevokeOtherSessionsRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
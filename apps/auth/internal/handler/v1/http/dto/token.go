@@ -8,3 +8,15 @@ type TokenResponse struct {
 type AccessTokenResponse struct {
 	AccessToken string `json:"access_token"`
 }
+
+// LogoutRequest carries the refresh token of clients that do not keep it in
+// the session.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshRequest carries the refresh token of clients that do not keep it in
+// the session.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -13,38 +13,78 @@ import (
 	"go.uber.org/zap"
 
 	handlerv1dto "mandacode.com/accounts/auth/internal/handler/v1/http/dto"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
+	"mandacode.com/accounts/auth/internal/usecase/emailauth"
 	"mandacode.com/accounts/auth/internal/usecase/localauth"
 	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
 )
 
 type LocalAuthHandler struct {
-	localLogin  *localauth.LoginUsecase
-	localSignup *localauth.SignupUsecase
-	logger      *zap.Logger
-	validator   *validator.Validate
+	localLogin     *localauth.LoginUsecase
+	mfaChallenge   *mfa.ChallengeUsecase
+	magicLink      *emailauth.MagicLinkUsecase
+	loginOTP       *emailauth.OTPUsecase
+	localSignup    *localauth.SignupUsecase
+	passwordReset  *localauth.PasswordResetUsecase
+	emailChange    *localauth.EmailChangeUsecase
+	challengeGuard *challenge.Guard
+	logger         *zap.Logger
+	validator      *validator.Validate
 }
 
 func NewLocalAuthHandler(
 	localLogin *localauth.LoginUsecase,
+	mfaChallenge *mfa.ChallengeUsecase,
+	magicLink *emailauth.MagicLinkUsecase,
+	loginOTP *emailauth.OTPUsecase,
 	localSignup *localauth.SignupUsecase,
+	passwordReset *localauth.PasswordResetUsecase,
+	emailChange *localauth.EmailChangeUsecase,
+	challengeGuard *challenge.Guard,
 	logger *zap.Logger,
 	validator *validator.Validate,
 ) (*LocalAuthHandler, error) {
 	if localLogin == nil {
 		return nil, stdErrors.New("localLogin cannot be nil")
 	}
+	if mfaChallenge == nil {
+		return nil, stdErrors.New("mfaChallenge cannot be nil")
+	}
+	if magicLink == nil {
+		return nil, stdErrors.New("magicLink cannot be nil")
+	}
+	if loginOTP == nil {
+		return nil, stdErrors.New("loginOTP cannot be nil")
+	}
 	if localSignup == nil {
 		return nil, stdErrors.New("localSignup cannot be nil")
 	}
+	if passwordReset == nil {
+		return nil, stdErrors.New("passwordReset cannot be nil")
+	}
+	if emailChange == nil {
+		return nil, stdErrors.New("emailChange cannot be nil")
+	}
+	if challengeGuard == nil {
+		return nil, stdErrors.New("challengeGuard cannot be nil")
+	}
 	if validator == nil {
 		return nil, stdErrors.New("validator cannot be nil")
 	}
 
 	return &LocalAuthHandler{
-		localLogin:  localLogin,
-		localSignup: localSignup,
-		logger:      logger,
-		validator:   validator,
+		localLogin:     localLogin,
+		mfaChallenge:   mfaChallenge,
+		magicLink:      magicLink,
+		loginOTP:       loginOTP,
+		localSignup:    localSignup,
+		passwordReset:  passwordReset,
+		emailChange:    emailChange,
+		challengeGuard: challengeGuard,
+		logger:         logger,
+		validator:      validator,
 	}, nil
 }
 
@@ -62,9 +102,24 @@ func (h *LocalAuthHandler) ValidateRequest(req interface{}) error {
 // RegisterRoutes registers the local authentication routes
 func (h *LocalAuthHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.POST("/login", h.Login)
+	rg.POST("/login/mfa", h.LoginMFA)
+	rg.POST("/login/mfa/passkey/options", h.MFAPasskeyOptions)
+	rg.POST("/login/mfa/passkey", h.LoginMFAPasskey)
 	rg.POST("/login/code", h.LoginCode)
+	rg.POST("/login/code/mfa", h.LoginCodeMFA)
+	rg.POST("/login/code/mfa/passkey", h.LoginCodeMFAPasskey)
+	rg.POST("/login/link", h.RequestMagicLink)
+	rg.POST("/login/link/confirm", h.LoginMagicLink)
+	rg.POST("/login/otp", h.RequestLoginOTP)
+	rg.POST("/login/otp/confirm", h.LoginOTP)
+	rg.GET("/challenge", h.Challenge)
 	rg.POST("/signup", h.Signup)
+	rg.POST("/signup/otp", h.ResendVerificationOTP)
+	rg.POST("/signup/otp/confirm", h.VerifyEmailOTP)
 	rg.GET("/verify/:userID", h.VerifyCode)
+	rg.POST("/password/reset", h.RequestPasswordReset)
+	rg.POST("/password/reset/confirm", h.ConfirmPasswordReset)
+	rg.POST("/email/confirm", h.ConfirmEmailChange)
 }
 
 // Login handles local user login
@@ -87,42 +142,258 @@ func (h *LocalAuthHandler) Login(c *gin.Context) {
 	}
 
 	input := localauthdto.LoginInput{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:          req.Email,
+		Password:       req.Password,
+		ClientIP:       c.ClientIP(),
+		ChallengeToken: req.ChallengeToken,
 	}
 
-	// If responseType is "direct", return access and refresh tokens directly
-	if responseType == "direct" {
-		accessToken, refreshToken, err := h.localLogin.Login(c.Request.Context(), input)
-		if err != nil {
-			c.Error(err)
-			return
-		}
-		response := handlerv1dto.TokenResponse{
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
-		}
-		c.JSON(http.StatusOK, response)
+	accessToken, refreshToken, mfaToken, err := h.localLogin.Login(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A second factor is required; the client continues with /login/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// LoginMFA handles completing a local user login with a TOTP code or recovery code
+func (h *LocalAuthHandler) LoginMFA(c *gin.Context) {
+	responseType := c.Query("response_type")
+	if responseType != "" && responseType != "direct" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid response type"})
+		return
+	}
+
+	var req handlerv1dto.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := mfadto.ChallengeInput{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+	}
+
+	accessToken, refreshToken, err := h.localLogin.LoginWithMFA(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// MFAPasskeyOptions handles starting a passkey assertion to answer an MFA challenge
+func (h *LocalAuthHandler) MFAPasskeyOptions(c *gin.Context) {
+	var req handlerv1dto.MFAPasskeyOptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	options, err := h.mfaChallenge.PasskeyOptions(c.Request.Context(), req.MFAToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// LoginMFAPasskey handles completing a local user login with a passkey
+func (h *LocalAuthHandler) LoginMFAPasskey(c *gin.Context) {
+	responseType := c.Query("response_type")
+	if responseType != "" && responseType != "direct" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid response type"})
+		return
+	}
+
+	var req handlerv1dto.MFAPasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	assertion := toAssertionInput(&req.Passkey)
+	input := mfadto.ChallengeInput{
+		MFAToken: req.MFAToken,
+		Passkey:  &assertion,
+	}
+
+	accessToken, refreshToken, err := h.localLogin.LoginWithMFA(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// RequestMagicLink handles sending a passwordless login link
+func (h *LocalAuthHandler) RequestMagicLink(c *gin.Context) {
+	var req handlerv1dto.MagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.magicLink.RequestMagicLink(c.Request.Context(), req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Always accept the request so that account existence is not revealed
+	c.Status(http.StatusAccepted)
+}
+
+// LoginMagicLink handles local user login with the token from a login link
+func (h *LocalAuthHandler) LoginMagicLink(c *gin.Context) {
+	responseType := c.Query("response_type")
+	if responseType != "" && responseType != "direct" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid response type"})
+		return
+	}
+
+	var req handlerv1dto.MagicLinkLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	accessToken, refreshToken, mfaToken, err := h.magicLink.LoginWithMagicLink(c.Request.Context(), req.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A second factor is required; the client continues with /login/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// RequestLoginOTP handles sending a one-time login code by email
+func (h *LocalAuthHandler) RequestLoginOTP(c *gin.Context) {
+	var req handlerv1dto.EmailOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.loginOTP.RequestLoginOTP(c.Request.Context(), req.Email, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Always accept the request so that account existence is not revealed
+	c.Status(http.StatusAccepted)
+}
+
+// LoginOTP handles local user login with a one-time code sent by email
+func (h *LocalAuthHandler) LoginOTP(c *gin.Context) {
+	responseType := c.Query("response_type")
+	if responseType != "" && responseType != "direct" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid response type"})
+		return
+	}
+
+	var req handlerv1dto.EmailOTPConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	// If responseType is not "direct", save the refresh token in the session
-	accessToken, refreshToken, err := h.localLogin.Login(c.Request.Context(), input)
+	accessToken, refreshToken, mfaToken, err := h.loginOTP.LoginWithOTP(c.Request.Context(), req.Email, req.Code, c.ClientIP())
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	// A second factor is required; the client continues with /login/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// respondWithTokens returns both tokens for "direct" responses. Otherwise the
+// refresh token is saved in the session and only the access token is returned.
+func (h *LocalAuthHandler) respondWithTokens(c *gin.Context, responseType string, accessToken string, refreshToken string) {
+	if responseType == "direct" {
+		c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		})
+		return
+	}
+
 	session := sessions.Default(c)
 	session.Set("refresh_token", refreshToken)
 	if err := session.Save(); err != nil {
 		c.Error(err)
 		return
 	}
-	response := handlerv1dto.AccessTokenResponse{
+	c.JSON(http.StatusOK, handlerv1dto.AccessTokenResponse{
 		AccessToken: accessToken,
-	}
-	c.JSON(http.StatusOK, response)
+	})
 }
 
 // LoginCode handles issuing a login code for local user login
@@ -139,16 +410,27 @@ func (h *LocalAuthHandler) LoginCode(c *gin.Context) {
 	}
 
 	input := localauthdto.LoginInput{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:          req.Email,
+		Password:       req.Password,
+		ClientIP:       c.ClientIP(),
+		ChallengeToken: req.ChallengeToken,
 	}
 
-	code, userID, err := h.localLogin.IssueLoginCode(c.Request.Context(), input)
+	code, userID, mfaToken, err := h.localLogin.IssueLoginCode(c.Request.Context(), input)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	// A second factor is required; the client continues with /login/code/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
 	response := handlerv1dto.IssueCodeResponse{
 		Code:   code,
 		UserID: userID.String(),
@@ -156,6 +438,89 @@ func (h *LocalAuthHandler) LoginCode(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// LoginCodeMFA handles issuing a login code after a TOTP code is verified
+func (h *LocalAuthHandler) LoginCodeMFA(c *gin.Context) {
+	var req handlerv1dto.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := mfadto.ChallengeInput{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+	}
+
+	code, userID, err := h.localLogin.IssueLoginCodeWithMFA(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.IssueCodeResponse{
+		Code:   code,
+		UserID: userID.String(),
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// LoginCodeMFAPasskey handles issuing a login code after a passkey assertion
+// answers the MFA challenge
+func (h *LocalAuthHandler) LoginCodeMFAPasskey(c *gin.Context) {
+	var req handlerv1dto.MFAPasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	assertion := toAssertionInput(&req.Passkey)
+	input := mfadto.ChallengeInput{
+		MFAToken: req.MFAToken,
+		Passkey:  &assertion,
+	}
+
+	code, userID, err := h.localLogin.IssueLoginCodeWithMFA(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.IssueCodeResponse{
+		Code:   code,
+		UserID: userID.String(),
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Challenge tells the client whether its next login, signup or verification
+// resend must carry a solved bot challenge, and which one to render
+func (h *LocalAuthHandler) Challenge(c *gin.Context) {
+	requirement, err := h.challengeGuard.Requirement(c.Request.Context(), c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.ChallengeResponse{}
+	if requirement != nil {
+		response.Required = true
+		response.Provider = requirement.Provider
+		response.SiteKey = requirement.SiteKey
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // Signup handles local user signup
 func (h *LocalAuthHandler) Signup(c *gin.Context) {
 	var req handlerv1dto.LocalSignupRequest
@@ -170,8 +535,12 @@ func (h *LocalAuthHandler) Signup(c *gin.Context) {
 	}
 
 	input := localauthdto.SignupInput{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:              req.Email,
+		Password:           req.Password,
+		VerificationMethod: req.VerificationMethod,
+		ClientIP:           c.ClientIP(),
+		ChallengeToken:     req.ChallengeToken,
+		InviteCode:         req.InviteCode,
 	}
 
 	userID, err := h.localSignup.Signup(c.Request.Context(), input)
@@ -183,6 +552,55 @@ func (h *LocalAuthHandler) Signup(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"user_id": userID.String()})
 }
 
+// ResendVerificationOTP handles sending a new email verification code
+func (h *LocalAuthHandler) ResendVerificationOTP(c *gin.Context) {
+	var req handlerv1dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := localauthdto.ResendVerificationInput{
+		Email:          req.Email,
+		ClientIP:       c.ClientIP(),
+		ChallengeToken: req.ChallengeToken,
+	}
+
+	if err := h.localSignup.ResendVerificationOTP(c.Request.Context(), input); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Always accept the request so that account existence is not revealed
+	c.Status(http.StatusAccepted)
+}
+
+// VerifyEmailOTP handles verifying the email of a new account with a one-time code
+func (h *LocalAuthHandler) VerifyEmailOTP(c *gin.Context) {
+	var req handlerv1dto.EmailOTPConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.localSignup.VerifyEmailWithOTP(c.Request.Context(), req.Email, req.Code, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // VerifyCode handles verification of the login code
 func (h *LocalAuthHandler) VerifyCode(c *gin.Context) {
 	// Get userID and code from the request
@@ -209,7 +627,7 @@ func (h *LocalAuthHandler) VerifyCode(c *gin.Context) {
 		return
 	}
 	// Verify the login code
-	accessToken, refreshToken, err := h.localLogin.VerifyLoginCode(c.Request.Context(), userIDParsed, code)
+	accessToken, refreshToken, err := h.localLogin.VerifyLoginCode(c.Request.Context(), userIDParsed, code, c.ClientIP())
 	if err != nil {
 		c.Error(err)
 		return
@@ -235,3 +653,73 @@ func (h *LocalAuthHandler) VerifyCode(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response)
 }
+
+// RequestPasswordReset handles sending a password reset mail
+func (h *LocalAuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req handlerv1dto.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.passwordReset.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Always accept the request so that account existence is not revealed
+	c.Status(http.StatusAccepted)
+}
+
+// ConfirmPasswordReset handles setting a new password with a reset token
+func (h *LocalAuthHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req handlerv1dto.PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := localauthdto.ResetPasswordInput{
+		Token:    req.Token,
+		Password: req.Password,
+	}
+
+	if err := h.passwordReset.ResetPassword(c.Request.Context(), input); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ConfirmEmailChange handles applying an email change with the token from the verification mail
+func (h *LocalAuthHandler) ConfirmEmailChange(c *gin.Context) {
+	var req handlerv1dto.ChangeEmailConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	email, err := h.emailChange.ConfirmEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"email": email})
+}
@@ -1,8 +1,10 @@
 package httphandlerv1
 
 import (
+	"crypto/subtle"
 	stdErrors "errors"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -12,11 +14,16 @@ import (
 	"github.com/mandacode-com/golib/errors/errcode"
 	"go.uber.org/zap"
 	handlerv1dto "mandacode.com/accounts/auth/internal/handler/v1/http/dto"
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
 	"mandacode.com/accounts/auth/internal/usecase/oauthauth"
 	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
 	"mandacode.com/accounts/auth/internal/util"
 )
 
+// oauthStateCookie holds the state of the login attempt started in the browser.
+const oauthStateCookie = "oauth_state"
+
 type OAuthHandler struct {
 	oauthLogin *oauthauth.LoginUsecase
 	logger     *zap.Logger
@@ -72,26 +79,43 @@ func (h *OAuthHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/login/:provider", h.Login)
 	rg.POST("/m/login/:provider", h.MobileLogin)
 	rg.GET("/callback/:provider", h.Callback)
+	rg.POST("/callback/:provider", h.Callback) // Providers using the form_post response mode
 	rg.GET("/verify/:user_id", h.VerifyCode)
+	rg.POST("/login/mfa", h.LoginMFA)
+	rg.POST("/m/login/mfa", h.MobileLoginMFA)
+	rg.POST("/link/confirm", h.ConfirmLink)
+	rg.POST("/m/link/confirm", h.MobileConfirmLink)
+	rg.POST("/link/confirm/mfa", h.ConfirmLinkMFA)
+	rg.POST("/m/link/confirm/mfa", h.MobileConfirmLinkMFA)
 }
 
 func (h *OAuthHandler) Login(c *gin.Context) {
-	provider := c.Param("provider")
-	if provider == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "provider is required"})
+	provider, err := util.ParseProvider(c.Param("provider"))
+	if err != nil {
+		h.LogError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid provider"})
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	// Get Login URL from the use case
-	loginURL, err := h.oauthLogin.GetLoginURL(ctx, provider)
+	// Start a login attempt and get the Login URL from the use case
+	loginURL, state, err := h.oauthLogin.GetLoginURL(ctx, provider, c.Query("return_to"), c.Query("invite_code"))
 	if err != nil {
 		h.LogError(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get login URL"})
+		if appErr, ok := err.(*errors.AppError); ok {
+			c.JSON(errcode.MapCodeToHTTP(appErr.Code()), gin.H{"error": appErr.Public()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get login URL"})
+		}
 		return
 	}
 
+	// Bind the attempt to this browser. SameSite=None lets the cookie through
+	// on the cross-site POST of providers using the form_post response mode.
+	c.SetSameSite(http.SameSiteNoneMode)
+	c.SetCookie(oauthStateCookie, state, 0, "/", "", true, true)
+
 	c.Redirect(http.StatusFound, loginURL)
 }
 
@@ -117,7 +141,7 @@ func (h *OAuthHandler) MobileLogin(c *gin.Context) {
 	}
 
 	// Exchange code for access token and user info
-	providerEnum, err := util.ConvertToEnt(provider)
+	providerEnum, err := util.ParseProvider(provider)
 	if err != nil {
 		h.LogError(err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid provider"})
@@ -126,9 +150,12 @@ func (h *OAuthHandler) MobileLogin(c *gin.Context) {
 	input := oauthdto.LoginInput{
 		Provider:    providerEnum,
 		AccessToken: req.AccessToken,
+		IDToken:     req.IDToken,
+		Nonce:       req.Nonce,
 		Code:        "",
+		InviteCode:  req.InviteCode,
 	}
-	accessToken, refreshToken, err := h.oauthLogin.Login(ctx, input)
+	accessToken, refreshToken, linkToken, mfaToken, err := h.oauthLogin.Login(ctx, input)
 
 	if err != nil {
 		h.LogError(err)
@@ -140,6 +167,24 @@ func (h *OAuthHandler) MobileLogin(c *gin.Context) {
 		return
 	}
 
+	// The identity matches a local account; the client continues with /m/link/confirm
+	if linkToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.LinkRequiredResponse{
+			LinkRequired: true,
+			LinkToken:    linkToken,
+		})
+		return
+	}
+
+	// A second factor is required; the client continues with /m/login/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -147,47 +192,112 @@ func (h *OAuthHandler) MobileLogin(c *gin.Context) {
 }
 
 func (h *OAuthHandler) Callback(c *gin.Context) {
-	provider := c.Param("provider")
-	if provider == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "provider is required"})
+	provider, err := util.ParseProvider(c.Param("provider"))
+	if err != nil {
+		h.LogError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid provider"})
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	// Extract code from query parameters
+	// Extract code and state from query parameters, or from the form with form_post
 	code := c.Query("code")
+	if code == "" {
+		code = c.PostForm("code")
+	}
 	if code == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
 		return
 	}
+	state := c.Query("state")
+	if state == "" {
+		state = c.PostForm("state")
+	}
+
+	// The state must match the attempt started in this browser
+	cookieState, _ := c.Cookie(oauthStateCookie)
+	c.SetSameSite(http.SameSiteNoneMode)
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", true, true)
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		h.LogError(errors.New("state does not match the login attempt", "Invalid State", errcode.ErrUnauthorized))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid state"})
+		return
+	}
 
 	// Exchange code for access token and user info
-	providerEnum, err := util.ConvertToEnt(provider)
+	input := oauthdto.CallbackInput{
+		Provider: provider,
+		Code:     code,
+		State:    state,
+		// Apple posts the name of the user on the first login only
+		Name: oauthapi.ParseAppleUserName(c.PostForm("user")),
+	}
+	output, err := h.oauthLogin.IssueLoginCode(ctx, input)
 	if err != nil {
 		h.LogError(err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid provider"})
+		if appErr, ok := err.(*errors.AppError); ok && (appErr.Code() == errcode.ErrUnauthorized || appErr.Code() == errcode.ErrForbidden) {
+			c.JSON(errcode.MapCodeToHTTP(appErr.Code()), gin.H{"error": appErr.Public()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to login with OAuth"})
+		}
 		return
 	}
-	input := oauthdto.LoginInput{
-		Provider:    providerEnum,
-		Code:        code,
-		AccessToken: "",
+
+	if output.ReturnTo != "" {
+		c.Redirect(http.StatusFound, callbackRedirectURL(output))
+		return
 	}
-	code, userID, err := h.oauthLogin.IssueLoginCode(ctx, input)
-	if err != nil {
-		h.LogError(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to login with OAuth"})
+
+	// The identity matches a local account; the client continues with /link/confirm
+	if output.LinkToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.LinkRequiredResponse{
+			LinkRequired: true,
+			LinkToken:    output.LinkToken,
+		})
+		return
+	}
+
+	// A second factor is required; the client continues with /login/mfa
+	if output.MFAToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    output.MFAToken,
+		})
 		return
 	}
 
 	response := handlerv1dto.OAuthCallbackResponse{
-		Code:   code,
-		UserID: userID.String(),
+		Code:   output.Code,
+		UserID: output.UserID.String(),
 	}
 	c.JSON(http.StatusOK, response)
 }
 
+// callbackRedirectURL adds the result of the callback to the return URL of
+// the login attempt, with the same fields as the JSON responses.
+func callbackRedirectURL(output *oauthdto.LoginCodeOutput) string {
+	returnTo, err := url.Parse(output.ReturnTo)
+	if err != nil {
+		return output.ReturnTo // Checked when the attempt was started
+	}
+
+	q := returnTo.Query()
+	switch {
+	case output.LinkToken != "":
+		q.Set("link_required", "true")
+		q.Set("link_token", output.LinkToken)
+	case output.MFAToken != "":
+		q.Set("mfa_required", "true")
+		q.Set("mfa_token", output.MFAToken)
+	default:
+		q.Set("code", output.Code)
+		q.Set("user_id", output.UserID.String())
+	}
+	returnTo.RawQuery = q.Encode()
+	return returnTo.String()
+}
+
 func (h *OAuthHandler) VerifyCode(c *gin.Context) {
 	code := c.Query("code")
 	if code == "" {
@@ -204,7 +314,7 @@ func (h *OAuthHandler) VerifyCode(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	accessToken, refreshToken, err := h.oauthLogin.VerifyLoginCode(ctx, userUID, code)
+	accessToken, refreshToken, err := h.oauthLogin.VerifyLoginCode(ctx, userUID, code, c.ClientIP())
 	if err != nil {
 		h.LogError(err)
 		if appErr, ok := err.(*errors.AppError); ok {
@@ -222,3 +332,204 @@ func (h *OAuthHandler) VerifyCode(c *gin.Context) {
 		AccessToken: accessToken,
 	})
 }
+
+// bindConfirmLink binds and validates a link confirmation request.
+func (h *OAuthHandler) bindConfirmLink(c *gin.Context) (oauthdto.ConfirmLinkInput, bool) {
+	var req handlerv1dto.ConfirmLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.LogError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return oauthdto.ConfirmLinkInput{}, false
+	}
+	if err := h.ValidateRequest(&req); err != nil {
+		h.LogError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return oauthdto.ConfirmLinkInput{}, false
+	}
+
+	return oauthdto.ConfirmLinkInput{
+		LinkToken: req.LinkToken,
+		Password:  req.Password,
+		ClientIP:  c.ClientIP(),
+	}, true
+}
+
+// ConfirmLink handles confirming a pending link with the local password and
+// issues a login code like Callback
+func (h *OAuthHandler) ConfirmLink(c *gin.Context) {
+	input, ok := h.bindConfirmLink(c)
+	if !ok {
+		return
+	}
+
+	code, userID, mfaToken, err := h.oauthLogin.IssueLoginCodeWithLink(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A second factor is required; the client continues with /link/confirm/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.OAuthCallbackResponse{
+		Code:   code,
+		UserID: userID.String(),
+	})
+}
+
+// MobileConfirmLink handles confirming a pending link with the local password
+// and issues tokens like MobileLogin
+func (h *OAuthHandler) MobileConfirmLink(c *gin.Context) {
+	input, ok := h.bindConfirmLink(c)
+	if !ok {
+		return
+	}
+
+	accessToken, refreshToken, mfaToken, err := h.oauthLogin.ConfirmLink(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A second factor is required; the client continues with /m/link/confirm/mfa
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, handlerv1dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// toChallengeInput converts an MFA request to the answer of the challenge.
+func toChallengeInput(req *handlerv1dto.OAuthMFARequest) mfadto.ChallengeInput {
+	input := mfadto.ChallengeInput{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+	}
+	if req.Passkey != nil {
+		assertion := toAssertionInput(req.Passkey)
+		input.Passkey = &assertion
+	}
+	return input
+}
+
+// LoginMFA handles issuing a login code like Callback once the MFA challenge
+// of the callback is passed
+func (h *OAuthHandler) LoginMFA(c *gin.Context) {
+	var req handlerv1dto.OAuthMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	code, userID, err := h.oauthLogin.IssueLoginCodeWithMFA(c.Request.Context(), toChallengeInput(&req))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.OAuthCallbackResponse{
+		Code:   code,
+		UserID: userID.String(),
+	})
+}
+
+// MobileLoginMFA handles issuing tokens like MobileLogin once the MFA
+// challenge of the login is passed
+func (h *OAuthHandler) MobileLoginMFA(c *gin.Context) {
+	var req handlerv1dto.OAuthMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	accessToken, refreshToken, err := h.oauthLogin.LoginWithMFA(c.Request.Context(), toChallengeInput(&req))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// bindConfirmLinkMFA binds and validates the MFA answer of a link confirmation.
+func (h *OAuthHandler) bindConfirmLinkMFA(c *gin.Context) (oauthdto.ConfirmLinkMFAInput, bool) {
+	var req handlerv1dto.ConfirmLinkMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return oauthdto.ConfirmLinkMFAInput{}, false
+	}
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return oauthdto.ConfirmLinkMFAInput{}, false
+	}
+
+	return oauthdto.ConfirmLinkMFAInput{
+		LinkToken: req.LinkToken,
+		Challenge: toChallengeInput(&req.OAuthMFARequest),
+	}, true
+}
+
+// ConfirmLinkMFA handles completing a link confirmation once its MFA
+// challenge is passed and issues a login code like Callback
+func (h *OAuthHandler) ConfirmLinkMFA(c *gin.Context) {
+	input, ok := h.bindConfirmLinkMFA(c)
+	if !ok {
+		return
+	}
+
+	code, userID, err := h.oauthLogin.IssueLoginCodeWithLinkMFA(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.OAuthCallbackResponse{
+		Code:   code,
+		UserID: userID.String(),
+	})
+}
+
+// MobileConfirmLinkMFA handles completing a link confirmation once its MFA
+// challenge is passed and issues tokens like MobileLogin
+func (h *OAuthHandler) MobileConfirmLinkMFA(c *gin.Context) {
+	input, ok := h.bindConfirmLinkMFA(c)
+	if !ok {
+		return
+	}
+
+	accessToken, refreshToken, err := h.oauthLogin.ConfirmLinkWithMFA(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
@@ -0,0 +1,264 @@
+package httphandlerv1
+
+import (
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+
+	handlerv1dto "mandacode.com/accounts/auth/internal/handler/v1/http/dto"
+	httpmiddleware "mandacode.com/accounts/auth/internal/middleware/http"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+	passkeydto "mandacode.com/accounts/auth/internal/usecase/passkeyauth/dto"
+)
+
+type PasskeyHandler struct {
+	passkeyLogin        *passkeyauth.LoginUsecase
+	passkeyRegistration *passkeyauth.RegistrationUsecase
+	logger              *zap.Logger
+	validator           *validator.Validate
+}
+
+// NewPasskeyHandler creates a new PasskeyHandler instance
+func NewPasskeyHandler(
+	passkeyLogin *passkeyauth.LoginUsecase,
+	passkeyRegistration *passkeyauth.RegistrationUsecase,
+	logger *zap.Logger,
+	validator *validator.Validate,
+) (*PasskeyHandler, error) {
+	if passkeyLogin == nil {
+		return nil, stdErrors.New("passkeyLogin cannot be nil")
+	}
+	if passkeyRegistration == nil {
+		return nil, stdErrors.New("passkeyRegistration cannot be nil")
+	}
+	if validator == nil {
+		return nil, stdErrors.New("validator cannot be nil")
+	}
+
+	return &PasskeyHandler{
+		passkeyLogin:        passkeyLogin,
+		passkeyRegistration: passkeyRegistration,
+		logger:              logger,
+		validator:           validator,
+	}, nil
+}
+
+func (h *PasskeyHandler) ValidateRequest(req interface{}) error {
+	if req == nil {
+		return errors.New("request cannot be nil", "InvalidRequest", errcode.ErrInvalidInput)
+	}
+	if err := h.validator.Struct(req); err != nil {
+		joinedErr := errors.Join(err, "validation failed")
+		return errors.Upgrade(joinedErr, "InvalidRequest", errcode.ErrInvalidInput)
+	}
+	return nil
+}
+
+// RegisterRoutes registers the passkey login routes
+func (h *PasskeyHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/login/options", h.LoginOptions)
+	rg.POST("/login", h.Login)
+	rg.POST("/login/code", h.LoginCode)
+	rg.GET("/verify/:userID", h.VerifyCode)
+}
+
+// RegisterAccountRoutes registers the passkey registration routes.
+// They must be registered behind httpmiddleware.Authenticate.
+func (h *PasskeyHandler) RegisterAccountRoutes(rg *gin.RouterGroup) {
+	rg.POST("/passkeys/options", h.RegistrationOptions)
+	rg.POST("/passkeys", h.Register)
+}
+
+// LoginOptions handles starting a passkey login
+func (h *PasskeyHandler) LoginOptions(c *gin.Context) {
+	options, err := h.passkeyLogin.BeginLogin(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Login handles passkey login
+func (h *PasskeyHandler) Login(c *gin.Context) {
+	responseType := c.Query("response_type")
+	if responseType != "" && responseType != "direct" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid response type"})
+		return
+	}
+
+	var req handlerv1dto.PasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	accessToken, refreshToken, err := h.passkeyLogin.Login(c.Request.Context(), toAssertionInput(&req))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// LoginCode handles issuing a login code for passkey login
+func (h *PasskeyHandler) LoginCode(c *gin.Context) {
+	var req handlerv1dto.PasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	code, userID, err := h.passkeyLogin.IssueLoginCode(c.Request.Context(), toAssertionInput(&req))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.IssueCodeResponse{
+		Code:   code,
+		UserID: userID.String(),
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyCode handles verification of the login code
+func (h *PasskeyHandler) VerifyCode(c *gin.Context) {
+	userID := c.Param("userID")
+	if userID == "" {
+		c.Error(errors.New("userID is required", "InvalidUserID", errcode.ErrInvalidInput))
+		return
+	}
+	code := c.Query("code")
+	if code == "" {
+		c.Error(errors.New("code is required", "InvalidCode", errcode.ErrInvalidInput))
+		return
+	}
+	responseType := c.Query("response_type")
+	if responseType != "direct" && responseType != "" {
+		c.Error(errors.New("invalid response type", "InvalidResponseType", errcode.ErrInvalidInput))
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID)
+	if err != nil {
+		c.Error(errors.New("invalid userID format", "InvalidUserIDFormat", errcode.ErrInvalidInput))
+		return
+	}
+
+	accessToken, refreshToken, err := h.passkeyLogin.VerifyLoginCode(c.Request.Context(), userIDParsed, code, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondWithTokens(c, responseType, accessToken, refreshToken)
+}
+
+// RegistrationOptions handles starting a passkey registration for the authenticated user
+func (h *PasskeyHandler) RegistrationOptions(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	options, err := h.passkeyRegistration.BeginRegistration(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Register handles storing a new passkey for the authenticated user
+func (h *PasskeyHandler) Register(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	var req handlerv1dto.PasskeyRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ValidateRequest(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := passkeydto.RegistrationInput{
+		UserID:            userID,
+		Name:              req.Name,
+		ClientDataJSON:    req.Response.ClientDataJSON,
+		AttestationObject: req.Response.AttestationObject,
+		Transports:        req.Response.Transports,
+	}
+
+	credential, err := h.passkeyRegistration.FinishRegistration(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlerv1dto.PasskeyResponse{
+		ID:        credential.ID.String(),
+		Name:      credential.Name,
+		CreatedAt: credential.CreatedAt,
+	})
+}
+
+// respondWithTokens returns both tokens for "direct" responses. Otherwise the
+// refresh token is saved in the session and only the access token is returned.
+func (h *PasskeyHandler) respondWithTokens(c *gin.Context, responseType string, accessToken string, refreshToken string) {
+	if responseType == "direct" {
+		c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		})
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set("refresh_token", refreshToken)
+	if err := session.Save(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerv1dto.AccessTokenResponse{
+		AccessToken: accessToken,
+	})
+}
+
+func toAssertionInput(req *handlerv1dto.PasskeyLoginRequest) passkeydto.AssertionInput {
+	return passkeydto.AssertionInput{
+		CredentialID:      req.ID,
+		ClientDataJSON:    req.Response.ClientDataJSON,
+		AuthenticatorData: req.Response.AuthenticatorData,
+		Signature:         req.Response.Signature,
+		UserHandle:        req.Response.UserHandle,
+	}
+}
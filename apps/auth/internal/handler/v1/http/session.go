@@ -0,0 +1,257 @@
+package httphandlerv1
+
+import (
+	stdErrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+
+	handlerv1dto "mandacode.com/accounts/auth/internal/handler/v1/http/dto"
+	httpmiddleware "mandacode.com/accounts/auth/internal/middleware/http"
+	sessionusecase "mandacode.com/accounts/auth/internal/usecase/session"
+	tokenusecase "mandacode.com/accounts/auth/internal/usecase/token"
+)
+
+// SessionHandler serves routes renewing, listing and ending the sessions of a user.
+type SessionHandler struct {
+	refresh *tokenusecase.RefreshUsecase
+	logout  *tokenusecase.LogoutUsecase
+	manage  *sessionusecase.ManageUsecase
+	logger  *zap.Logger
+}
+
+// NewSessionHandler creates a new SessionHandler instance
+func NewSessionHandler(
+	refresh *tokenusecase.RefreshUsecase,
+	logout *tokenusecase.LogoutUsecase,
+	manage *sessionusecase.ManageUsecase,
+	logger *zap.Logger,
+) (*SessionHandler, error) {
+	if refresh == nil {
+		return nil, stdErrors.New("refresh cannot be nil")
+	}
+	if logout == nil {
+		return nil, stdErrors.New("logout cannot be nil")
+	}
+	if manage == nil {
+		return nil, stdErrors.New("manage cannot be nil")
+	}
+	if logger == nil {
+		return nil, stdErrors.New("logger cannot be nil")
+	}
+
+	return &SessionHandler{
+		refresh: refresh,
+		logout:  logout,
+		manage:  manage,
+		logger:  logger,
+	}, nil
+}
+
+// RegisterRoutes registers the session routes
+func (h *SessionHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/refresh", h.Refresh)
+	rg.POST("/logout", h.Logout)
+}
+
+// RegisterAccountRoutes registers the session management routes.
+// They must be registered behind httpmiddleware.Authenticate.
+func (h *SessionHandler) RegisterAccountRoutes(rg *gin.RouterGroup) {
+	rg.GET("/sessions", h.ListSessions)
+	rg.DELETE("/sessions/:sessionID", h.RevokeSession)
+	rg.DELETE("/sessions", h.RevokeOtherSessions)
+}
+
+// Refresh handles exchanging a refresh token for a new token pair. The
+// refresh token is taken from the request body, or from the session for
+// browsers, whose session then holds the new refresh token.
+func (h *SessionHandler) Refresh(c *gin.Context) {
+	var req handlerv1dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !stdErrors.Is(err, io.EOF) {
+		c.Error(errors.Upgrade(err, "InvalidRequest", errcode.ErrInvalidInput))
+		return
+	}
+
+	if req.RefreshToken != "" {
+		accessToken, refreshToken, err := h.refresh.Refresh(c.Request.Context(), req.RefreshToken)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, handlerv1dto.TokenResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		})
+		return
+	}
+
+	session := sessions.Default(c)
+	sessionToken, _ := session.Get("refresh_token").(string)
+	if sessionToken == "" {
+		c.Error(errors.New("no refresh token to refresh", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+	accessToken, refreshToken, err := h.refresh.Refresh(c.Request.Context(), sessionToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	session.Set("refresh_token", refreshToken)
+	if err := session.Save(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerv1dto.AccessTokenResponse{
+		AccessToken: accessToken,
+	})
+}
+
+// Logout handles signing out the current session, or every session of the
+// user with ?all=true. The refresh token is taken from the request body, or
+// from the session for browsers.
+func (h *SessionHandler) Logout(c *gin.Context) {
+	all := false
+	if value := c.Query("all"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.Error(errors.New("invalid all parameter", "InvalidRequest", errcode.ErrInvalidInput))
+			return
+		}
+		all = parsed
+	}
+
+	var req handlerv1dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !stdErrors.Is(err, io.EOF) {
+		c.Error(errors.Upgrade(err, "InvalidRequest", errcode.ErrInvalidInput))
+		return
+	}
+
+	// Clear the session cookie first, so that the browser is signed out even
+	// if the revocation fails
+	session := sessions.Default(c)
+	refreshToken := req.RefreshToken
+	if refreshToken == "" {
+		refreshToken, _ = session.Get("refresh_token").(string)
+	}
+	session.Clear()
+	session.Options(sessions.Options{Path: "/", MaxAge: -1})
+	if err := session.Save(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if refreshToken == "" {
+		if all {
+			c.Error(errors.New("no refresh token to sign out", "Unauthorized", errcode.ErrUnauthorized))
+			return
+		}
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err := h.logout.Logout(c.Request.Context(), refreshToken, all); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// sessionRefreshToken returns the refresh token kept in the session of a
+// browser, or an empty string.
+func sessionRefreshToken(c *gin.Context) string {
+	refreshToken, _ := sessions.Default(c).Get("refresh_token").(string)
+	return refreshToken
+}
+
+// ListSessions handles listing the sessions of the authenticated user. The
+// session of a browser is marked as current.
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	sessionList, err := h.manage.ListSessions(c.Request.Context(), userID, sessionRefreshToken(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := handlerv1dto.SessionListResponse{
+		Sessions: make([]handlerv1dto.SessionResponse, 0, len(sessionList)),
+	}
+	for _, item := range sessionList {
+		response.Sessions = append(response.Sessions, handlerv1dto.SessionResponse{
+			ID:         item.ID.String(),
+			DeviceName: item.DeviceName,
+			UserAgent:  item.UserAgent,
+			IPAddress:  item.IPAddress,
+			CreatedAt:  item.CreatedAt,
+			LastUsedAt: item.LastUsedAt,
+			Current:    item.Current,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeSession handles signing out a single session of the authenticated user
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionID"))
+	if err != nil {
+		c.Error(errors.New("invalid sessionID format", "InvalidSessionIDFormat", errcode.ErrInvalidInput))
+		return
+	}
+
+	if err := h.manage.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions handles signing out every session of the authenticated
+// user but the current one, identified by the refresh token in the request
+// body or in the session.
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, ok := httpmiddleware.GetUserID(c)
+	if !ok {
+		c.Error(errors.New("user ID not found in context", "Unauthorized", errcode.ErrUnauthorized))
+		return
+	}
+
+	var req handlerv1dto.RevokeOtherSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !stdErrors.Is(err, io.EOF) {
+		c.Error(errors.Upgrade(err, "InvalidRequest", errcode.ErrInvalidInput))
+		return
+	}
+	refreshToken := req.RefreshToken
+	if refreshToken == "" {
+		refreshToken = sessionRefreshToken(c)
+	}
+
+	revoked, err := h.manage.RevokeOtherSessions(c.Request.Context(), userID, refreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlerv1dto.RevokeSessionsResponse{
+		Revoked: revoked,
+	})
+}
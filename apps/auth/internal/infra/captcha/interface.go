@@ -0,0 +1,33 @@
+package captcha
+
+import "context"
+
+// Providers of bot challenges, as configured and as told to clients.
+const (
+	ProviderHCaptcha  = "hcaptcha"
+	ProviderTurnstile = "turnstile"
+	ProviderRecaptcha = "recaptcha"
+	ProviderStub      = "stub"
+)
+
+// ChallengeVerifier checks that a client solved a bot challenge, such as a
+// CAPTCHA, of a provider.
+type ChallengeVerifier interface {
+	// Provider returns the name of the provider, one of the Provider constants.
+	Provider() string
+
+	// SiteKey returns the public key the client renders the challenge with.
+	SiteKey() string
+
+	// Verify checks the token a client obtained by solving the challenge.
+	//
+	// Parameters:
+	//   - ctx: The context for the operation.
+	//   - token: The response token of the challenge.
+	//   - remoteIP: The IP address of the client, sent to the provider as a hint.
+	//
+	// Returns:
+	//   - true if the challenge was solved.
+	//   - An error if the provider could not be reached or its answer read.
+	Verify(ctx context.Context, token string, remoteIP string) (bool, error)
+}
@@ -0,0 +1,30 @@
+package captcha
+
+import "context"
+
+// StubVerifier is a deterministic ChallengeVerifier for local development and
+// tests: it solves a challenge when the token equals its pass token, without
+// calling any provider.
+type StubVerifier struct {
+	passToken string
+}
+
+// Provider implements ChallengeVerifier.
+func (s *StubVerifier) Provider() string {
+	return ProviderStub
+}
+
+// SiteKey implements ChallengeVerifier. The stub needs no widget.
+func (s *StubVerifier) SiteKey() string {
+	return ""
+}
+
+// Verify implements ChallengeVerifier.
+func (s *StubVerifier) Verify(ctx context.Context, token string, remoteIP string) (bool, error) {
+	return s.passToken != "" && token == s.passToken, nil
+}
+
+// NewStubVerifier creates a new instance of StubVerifier accepting passToken.
+func NewStubVerifier(passToken string) *StubVerifier {
+	return &StubVerifier{passToken: passToken}
+}
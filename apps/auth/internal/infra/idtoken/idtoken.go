@@ -0,0 +1,207 @@
+package idtoken
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// leeway tolerates clock skew between us and the provider.
+const leeway = time.Minute
+
+var (
+	ErrMalformedToken   = errors.New("malformed ID token")
+	ErrUnsupportedAlg   = errors.New("unsupported ID token algorithm")
+	ErrInvalidSignature = errors.New("invalid ID token signature")
+	ErrInvalidIssuer    = errors.New("ID token issuer does not match")
+	ErrInvalidAudience  = errors.New("ID token audience does not match")
+	ErrExpired          = errors.New("ID token is expired")
+	ErrNotYetValid      = errors.New("ID token is issued in the future")
+)
+
+// header is the JOSE header of a token.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Audience is the "aud" claim, which is either a string or an array of strings.
+type Audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// Claims are the registered claims of a verified ID token.
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  Audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	IssuedAt  int64    `json:"iat"`
+	Nonce     string   `json:"nonce,omitempty"`
+
+	raw []byte
+}
+
+// Decode decodes the full claim set into v, for provider specific claims.
+func (c *Claims) Decode(v any) error {
+	return json.Unmarshal(c.raw, v)
+}
+
+// Verifier verifies ID tokens issued by one provider for one or more clients.
+type Verifier struct {
+	keySet    *KeySet
+	issuer    string
+	audiences []string
+	now       func() time.Time
+}
+
+// Verify checks the signature, issuer, audience and lifetime of the token and
+// returns its claims.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	key, err := v.keySet.Key(h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	claims := &Claims{raw: payload}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if claims.Issuer != v.issuer {
+		return nil, ErrInvalidIssuer
+	}
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(v.audiences, aud)
+	}) {
+		return nil, ErrInvalidAudience
+	}
+	now := v.now()
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(leeway)) {
+		return nil, ErrExpired
+	}
+	if now.Add(leeway).Before(time.Unix(claims.IssuedAt, 0)) {
+		return nil, ErrNotYetValid
+	}
+
+	return claims, nil
+}
+
+// NewVerifier creates a Verifier accepting tokens of issuer signed with keys
+// of keySet and issued for any of audiences.
+func NewVerifier(keySet *KeySet, issuer string, audiences ...string) *Verifier {
+	return &Verifier{
+		keySet:    keySet,
+		issuer:    issuer,
+		audiences: audiences,
+		now:       time.Now,
+	}
+}
+
+// SignES256 creates a compact JWS of the claims signed with the P-256 key.
+func SignES256(claims any, kid string, key *ecdsa.PrivateKey) (string, error) {
+	headerJSON, err := json.Marshal(header{Alg: "ES256", Kid: kid, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	// JWS uses the fixed size r || s encoding instead of ASN.1
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifySignature verifies a JWS signature made with RS256 or ES256.
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrInvalidSignature
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlg
+	}
+}
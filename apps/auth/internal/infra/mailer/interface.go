@@ -0,0 +1,14 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MessageWriter writes messages to the mail topic, as *kafka.Writer does.
+type MessageWriter interface {
+	// WriteMessages writes the messages, returning once they are written or
+	// failed.
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
@@ -11,8 +11,68 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// EventTypeHeader is the Kafka header the mailer service uses to pick the
+// protobuf message type of a mail event.
+const EventTypeHeader = "event_type"
+
+const (
+	EventTypeEmailVerification   = "email_verification"
+	EventTypePasswordReset       = "password_reset"
+	EventTypeEmailChange         = "email_change"
+	EventTypeEmailChangeNotice   = "email_change_notice"
+	EventTypeSecurityNotice      = "security_notice"
+	EventTypeMagicLink           = "magic_link"
+	EventTypeEmailOTP            = "email_otp"
+	EventTypeNewSignIn           = "new_sign_in"
+	EventTypePasswordChanged     = "password_changed"
+	EventTypeEmailChanged        = "email_changed"
+	EventTypeProviderLinkChanged = "provider_link_changed"
+	EventTypeMFAChanged          = "mfa_changed"
+)
+
+// One-time passcode purposes, rendered by the mailer service.
+const (
+	OTPPurposeEmailVerification = "email_verification"
+	OTPPurposeLogin             = "login"
+)
+
+// Security notice types, rendered by the mailer service.
+const (
+	NoticeRecoveryCodeUsed = "recovery_code_used"
+)
+
+// MFA changes, rendered by the mailer service.
+const (
+	MFAChangeTotpEnabled            = "totp_enabled"
+	MFAChangePasskeyAdded           = "passkey_added"
+	MFAChangeFactorRenamed          = "factor_renamed"
+	MFAChangeFactorRemoved          = "factor_removed"
+	MFAChangeRecoveryCodesGenerated = "recovery_codes_generated"
+)
+
 type Mailer struct {
-	writer *kafka.Writer
+	writer           MessageWriter
+	revokeSessionURL string
+}
+
+// publish marshals the event and writes it to the mail topic keyed by email.
+func (m *Mailer) publish(email string, eventType string, event proto.Message) error {
+	// Marshal the event to protobuf bytes
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to marshal "+eventType+" event", errcode.ErrInternalFailure)
+	}
+
+	// Create a message to send to Kafka
+	message := kafka.Message{
+		Key:   []byte(email),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: EventTypeHeader, Value: []byte(eventType)},
+		},
+	}
+
+	return m.writer.WriteMessages(context.Background(), message)
 }
 
 // SendEmailVerificationMail sends an email verification mail to the user.
@@ -26,24 +86,184 @@ func (m *Mailer) SendEmailVerificationMail(email string, verificationLink string
 		VerificationLink: verificationLink,
 		EventTime:        timestamppb.Now(),
 	}
-	// Marshal the event to protobuf bytes
-	data, err := proto.Marshal(event)
-	if err != nil {
-		return errors.New(err.Error(), "Failed to marshal email verification event", errcode.ErrInternalFailure)
+	return m.publish(email, EventTypeEmailVerification, event)
+}
+
+// SendPasswordResetMail sends a password reset mail to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the reset mail to.
+//   - resetLink: The link to be included in the email for resetting the password.
+func (m *Mailer) SendPasswordResetMail(email string, resetLink string) error {
+	event := &mailerv1.PasswordResetEvent{
+		Email:     email,
+		ResetLink: resetLink,
+		EventTime: timestamppb.Now(),
 	}
+	return m.publish(email, EventTypePasswordReset, event)
+}
 
-	// Create a message to send to Kafka
-	message := kafka.Message{
-		Key:   []byte(email),
-		Value: data,
+// SendEmailChangeVerificationMail sends a verification mail to the new address of an email change.
+//
+// Parameters:
+//   - email: The new email address to verify.
+//   - verificationLink: The link to be included in the email for confirming the change.
+func (m *Mailer) SendEmailChangeVerificationMail(email string, verificationLink string) error {
+	event := &mailerv1.EmailChangeEvent{
+		Email:            email,
+		VerificationLink: verificationLink,
+		EventTime:        timestamppb.Now(),
 	}
+	return m.publish(email, EventTypeEmailChange, event)
+}
 
-	return m.writer.WriteMessages(context.Background(), message)
+// SendEmailChangeNoticeMail notifies the current address that an email change was requested.
+//
+// Parameters:
+//   - email: The current email address of the user.
+//   - newEmail: The email address the user asked to change to.
+func (m *Mailer) SendEmailChangeNoticeMail(email string, newEmail string) error {
+	event := &mailerv1.EmailChangeNoticeEvent{
+		Email:     email,
+		NewEmail:  newEmail,
+		EventTime: timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeEmailChangeNotice, event)
+}
+
+// SendSecurityNoticeMail notifies the user of a sensitive change to their account.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - noticeType: The kind of change, one of the Notice constants.
+func (m *Mailer) SendSecurityNoticeMail(email string, noticeType string) error {
+	event := &mailerv1.SecurityNoticeEvent{
+		Email:      email,
+		NoticeType: noticeType,
+		EventTime:  timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeSecurityNotice, event)
+}
+
+// SendMagicLinkMail sends a passwordless login link to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the login link to.
+//   - loginLink: The link to be included in the email for logging in.
+func (m *Mailer) SendMagicLinkMail(email string, loginLink string) error {
+	event := &mailerv1.MagicLinkEvent{
+		Email:     email,
+		LoginLink: loginLink,
+		EventTime: timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeMagicLink, event)
+}
+
+// SendEmailOTPMail sends a one-time passcode to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the code to.
+//   - code: The one-time passcode.
+//   - purpose: What the code is for, one of the OTPPurpose constants.
+func (m *Mailer) SendEmailOTPMail(email string, code string, purpose string) error {
+	event := &mailerv1.EmailOTPEvent{
+		Email:     email,
+		Code:      code,
+		Purpose:   purpose,
+		EventTime: timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeEmailOTP, event)
+}
+
+// SendNewSignInMail warns the user of a sign-in from a device or location
+// their account was not used from before.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - deviceName: The device name sent by the client, empty if none.
+//   - userAgent: The user agent of the client.
+//   - ipAddress: The IP address of the client.
+func (m *Mailer) SendNewSignInMail(email string, deviceName string, userAgent string, ipAddress string) error {
+	event := &mailerv1.NewSignInEvent{
+		Email:             email,
+		DeviceName:        deviceName,
+		UserAgent:         userAgent,
+		IpAddress:         ipAddress,
+		RevokeSessionLink: m.revokeSessionURL,
+		EventTime:         timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeNewSignIn, event)
+}
+
+// SendPasswordChangedMail notifies the user that their password was changed.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - reset: Whether the password was reset through a reset link.
+func (m *Mailer) SendPasswordChangedMail(email string, reset bool) error {
+	event := &mailerv1.PasswordChangedEvent{
+		Email:             email,
+		Reset:             reset,
+		RevokeSessionLink: m.revokeSessionURL,
+		EventTime:         timestamppb.Now(),
+	}
+	return m.publish(email, EventTypePasswordChanged, event)
+}
+
+// SendEmailChangedMail notifies the previous address that the email of the account was changed.
+//
+// Parameters:
+//   - email: The previous email address of the user.
+//   - newEmail: The email address the account was changed to.
+func (m *Mailer) SendEmailChangedMail(email string, newEmail string) error {
+	event := &mailerv1.EmailChangedEvent{
+		Email:             email,
+		NewEmail:          newEmail,
+		RevokeSessionLink: m.revokeSessionURL,
+		EventTime:         timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeEmailChanged, event)
+}
+
+// SendProviderLinkChangedMail notifies the user that a provider was linked to or unlinked from their account.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - provider: The name of the provider.
+//   - linked: Whether the provider was linked, or unlinked otherwise.
+func (m *Mailer) SendProviderLinkChangedMail(email string, provider string, linked bool) error {
+	event := &mailerv1.ProviderLinkChangedEvent{
+		Email:             email,
+		Provider:          provider,
+		Linked:            linked,
+		RevokeSessionLink: m.revokeSessionURL,
+		EventTime:         timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeProviderLinkChanged, event)
+}
+
+// SendMFAChangedMail notifies the user of a change to their MFA factors.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - change: The kind of change, one of the MFAChange constants.
+func (m *Mailer) SendMFAChangedMail(email string, change string) error {
+	event := &mailerv1.MFAChangedEvent{
+		Email:             email,
+		Change:            change,
+		RevokeSessionLink: m.revokeSessionURL,
+		EventTime:         timestamppb.Now(),
+	}
+	return m.publish(email, EventTypeMFAChanged, event)
 }
 
 // NewMailer creates a new Mailer instance with the provided Kafka writer.
-func NewMailer(writer *kafka.Writer) *Mailer {
+//
+// revokeSessionURL is the page where the user reviews and revokes their
+// sessions; every security notification links to it.
+func NewMailer(writer MessageWriter, revokeSessionURL string) *Mailer {
 	return &Mailer{
-		writer: writer,
+		writer:           writer,
+		revokeSessionURL: revokeSessionURL,
 	}
 }
@@ -0,0 +1,256 @@
+package oauthapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"mandacode.com/accounts/auth/internal/infra/idtoken"
+	codeapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/codeapi"
+	infoapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/infoapi"
+	oauthapimeta "mandacode.com/accounts/auth/internal/infra/oauthapi/meta"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+)
+
+// appleClientSecretTTL is the lifetime of a generated client secret. Apple
+// accepts up to six months, but a fresh secret is cheap to sign.
+const appleClientSecretTTL = 5 * time.Minute
+
+// AppleEndpoints are the Apple endpoints used by AppleAPI.
+type AppleEndpoints struct {
+	Auth  string
+	Token string
+	Keys  string
+}
+
+// DefaultAppleEndpoints are the production Apple endpoints.
+var DefaultAppleEndpoints = AppleEndpoints{
+	Auth:  oauthapimeta.AppleAuthEndpoint,
+	Token: oauthapimeta.AppleTokenEndpoint,
+	Keys:  oauthapimeta.AppleKeysEndpoint,
+}
+
+// AppleAPI implements Sign in with Apple.
+//
+// Apple has no user info endpoint; the user is described by the ID token
+// returned with the access token, which GetUserInfo reads. Native apps receive
+// the same token as identityToken.
+type AppleAPI struct {
+	clientID    string
+	teamID      string
+	keyID       string
+	privateKey  *ecdsa.PrivateKey
+	redirectURL string
+	endpoints   AppleEndpoints
+	verifier    *idtoken.Verifier
+	native      *idtoken.Verifier // Also accepts the bundle IDs of the apps
+	validator   *validator.Validate
+}
+
+// appleClientSecretClaims are the claims of the client secret JWT.
+type appleClientSecretClaims struct {
+	Issuer    string `json:"iss"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	Audience  string `json:"aud"`
+	Subject   string `json:"sub"`
+}
+
+// clientSecret creates the client secret, a JWT signed with the private key
+// registered for the team.
+func (a *AppleAPI) clientSecret() (string, error) {
+	now := time.Now()
+	return idtoken.SignES256(appleClientSecretClaims{
+		Issuer:    a.teamID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(appleClientSecretTTL).Unix(),
+		Audience:  oauthapimeta.AppleIssuer,
+		Subject:   a.clientID,
+	}, a.keyID, a.privateKey)
+}
+
+// GetUserInfo verifies the Apple ID token and returns the user it describes.
+//
+// The name is always empty, as Apple only posts it to the callback on the
+// first login.
+func (a *AppleAPI) GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error) {
+	claims, err := a.verifier.Verify(token.IDToken)
+	if err != nil {
+		return nil, errors.New("invalid ID token: " + err.Error())
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, errors.New("ID token nonce does not match the login attempt")
+	}
+	return a.userInfoFromClaims(claims)
+}
+
+// GetNativeUserInfo verifies the identity token of Sign in with Apple on an
+// Apple device, issued to the bundle ID of the app.
+func (a *AppleAPI) GetNativeUserInfo(token NativeToken) (*oauthmodels.UserInfo, error) {
+	if token.IDToken == "" {
+		return nil, ErrIDTokenRequired
+	}
+
+	claims, err := a.native.Verify(token.IDToken)
+	if err != nil {
+		return nil, errors.New("invalid ID token: " + err.Error())
+	}
+	if err := checkNativeNonce(claims, token.Nonce); err != nil {
+		return nil, err
+	}
+	return a.userInfoFromClaims(claims)
+}
+
+// userInfoFromClaims returns the user described by verified ID token claims.
+func (a *AppleAPI) userInfoFromClaims(claims *idtoken.Claims) (*oauthmodels.UserInfo, error) {
+	var rawUserInfo infoapidto.RawAppleUserInfo
+	if err := claims.Decode(&rawUserInfo); err != nil {
+		return nil, errors.New("failed to decode ID token claims: " + err.Error())
+	}
+
+	oauthUserInfo := oauthmodels.NewUserInfo(
+		rawUserInfo.Sub,
+		rawUserInfo.Email,
+		"",
+		// Private relay addresses are created and verified by Apple
+		bool(rawUserInfo.EmailVerified) || bool(rawUserInfo.IsPrivateEmail),
+	)
+	if err := a.validator.StructExcept(oauthUserInfo, "Name"); err != nil {
+		return nil, errors.New("invalid user info structure: " + err.Error())
+	}
+
+	return oauthUserInfo, nil
+}
+
+// NewAppleAPI creates a new instance of AppleAPI.
+//
+// clientID is the Services ID, and privateKeyPEM the contents of the .p8 key
+// file whose ID is keyID. nativeClientIDs are the bundle IDs of the apps.
+func NewAppleAPI(clientID, teamID, keyID string, privateKeyPEM []byte, redirectURL string, nativeClientIDs []string, endpoints AppleEndpoints, validator *validator.Validate) (OAuthAPI, error) {
+	if clientID == "" || teamID == "" || keyID == "" || redirectURL == "" {
+		return nil, errors.New("client ID, team ID, key ID, and redirect URL must be set")
+	}
+
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New("failed to parse private key: " + err.Error())
+	}
+	privateKey, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an EC key")
+	}
+
+	keySet := idtoken.NewKeySet(endpoints.Keys, nil)
+	return &AppleAPI{
+		clientID:    clientID,
+		teamID:      teamID,
+		keyID:       keyID,
+		privateKey:  privateKey,
+		redirectURL: redirectURL,
+		endpoints:   endpoints,
+		verifier:    idtoken.NewVerifier(keySet, oauthapimeta.AppleIssuer, clientID),
+		native:      idtoken.NewVerifier(keySet, oauthapimeta.AppleIssuer, append([]string{clientID}, nativeClientIDs...)...),
+		validator:   validator,
+	}, nil
+}
+
+// GetAccessToken exchanges the code for the tokens of the user. The code
+// verifier is not sent, as Apple does not support PKCE; the nonce binds the ID
+// token to the login attempt instead.
+func (a *AppleAPI) GetAccessToken(code string, codeVerifier string) (*oauthmodels.Token, error) {
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("redirect_uri", a.redirectURL)
+	form.Set("grant_type", oauthapimeta.AppleGrantType)
+
+	token, err := a.requestToken(form)
+	if err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("ID token is empty in response")
+	}
+	return token, nil
+}
+
+// RefreshAccessToken obtains a new access token. Apple does not rotate
+// refresh tokens.
+func (a *AppleAPI) RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error) {
+	form := url.Values{}
+	form.Set("refresh_token", refreshToken)
+	form.Set("grant_type", oauthapimeta.RefreshGrantType)
+	return a.requestToken(form)
+}
+
+// requestToken posts the grant to the token endpoint with a fresh client secret.
+func (a *AppleAPI) requestToken(form url.Values) (*oauthmodels.Token, error) {
+	clientSecret, err := a.clientSecret()
+	if err != nil {
+		return nil, errors.New("failed to create client secret: " + err.Error())
+	}
+	form.Set("client_id", a.clientID)
+	form.Set("client_secret", clientSecret)
+
+	resp, err := http.PostForm(a.endpoints.Token, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("failed to retrieve access token: " + resp.Status)
+	}
+
+	var tokenResponse codeapidto.AppleTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, errors.New("failed to decode access token response: " + err.Error())
+	}
+
+	return oauthmodels.NewToken(
+		tokenResponse.AccessToken,
+		tokenResponse.RefreshToken,
+		tokenResponse.IDToken,
+		tokenResponse.TokenType,
+		"",
+		tokenResponse.ExpiresIn,
+	), nil
+}
+
+// GetLoginURL returns the authorization URL. Apple requires the form_post
+// response mode when the name or email scope is requested.
+func (a *AppleAPI) GetLoginURL(req AuthRequest) string {
+	q := url.Values{}
+	q.Set("client_id", a.clientID)
+	q.Set("redirect_uri", a.redirectURL)
+	q.Set("response_type", "code")
+	q.Set("response_mode", "form_post")
+	q.Set("scope", "name email")
+	q.Set("state", req.State)
+	q.Set("nonce", req.Nonce)
+
+	return a.endpoints.Auth + "?" + strings.ReplaceAll(q.Encode(), "+", "%20")
+}
+
+// ParseAppleUserName returns the full name of the "user" form field Apple posts
+// to the callback on the first login, or an empty string if there is none.
+func ParseAppleUserName(user string) string {
+	if user == "" {
+		return ""
+	}
+	var rawUser infoapidto.RawAppleUser
+	if err := json.Unmarshal([]byte(user), &rawUser); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(rawUser.Name.FirstName + " " + rawUser.Name.LastName)
+}
@@ -0,0 +1,51 @@
+package infoapidto
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// AppleBool is a boolean claim Apple sends either as a JSON boolean or as the
+// string "true" or "false".
+type AppleBool bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *AppleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = AppleBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	value, err := strconv.ParseBool(text)
+	if err != nil {
+		return err
+	}
+	*b = AppleBool(value)
+	return nil
+}
+
+// RawAppleUserInfo represents the user claims of an Apple ID token.
+//
+// Apple never includes the name; it is posted once to the callback, on the
+// first login only. Users hiding their email get a private relay address
+// ending in @privaterelay.appleid.com, which forwards to their real mailbox.
+type RawAppleUserInfo struct {
+	Sub            string    `json:"sub"`
+	Email          string    `json:"email"`
+	EmailVerified  AppleBool `json:"email_verified"`
+	IsPrivateEmail AppleBool `json:"is_private_email"`
+}
+
+// RawAppleUser represents the "user" form field posted to the callback on the
+// first login.
+type RawAppleUser struct {
+	Name struct {
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+	} `json:"name"`
+	Email string `json:"email"`
+}
@@ -0,0 +1,11 @@
+package infoapidto
+
+// OIDCDiscoveryDocument represents the fields of an OpenID Connect discovery
+// document used to talk to the provider.
+type OIDCDiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
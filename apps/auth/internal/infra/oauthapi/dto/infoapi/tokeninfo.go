@@ -0,0 +1,33 @@
+package infoapidto
+
+// RawGoogleTokenInfo represents the token info Google returns for an access token.
+type RawGoogleTokenInfo struct {
+	Aud string `json:"aud"`
+	Azp string `json:"azp"`
+	Sub string `json:"sub"`
+}
+
+// RawKakaoTokenInfo represents the token info Kakao returns for an access token.
+type RawKakaoTokenInfo struct {
+	ID        int64 `json:"id"`
+	AppID     int64 `json:"app_id"`
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// RawKakaoIDTokenClaims represents the user claims of a Kakao ID token.
+//
+// Kakao only includes the email when it is valid and verified.
+type RawKakaoIDTokenClaims struct {
+	Sub      string `json:"sub"`
+	Email    string `json:"email"`
+	Nickname string `json:"nickname"`
+}
+
+// RawNaverTokenInfo represents the result of the Naver token verification,
+// requested with info=true to include the client ID.
+type RawNaverTokenInfo struct {
+	ResultCode string `json:"resultcode"`
+	Response   struct {
+		ClientID string `json:"client_id"`
+	} `json:"response"`
+}
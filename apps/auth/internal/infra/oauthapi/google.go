@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"mandacode.com/accounts/auth/internal/infra/idtoken"
 	codeapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/codeapi"
 	infoapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/infoapi"
 	oauthapimeta "mandacode.com/accounts/auth/internal/infra/oauthapi/meta"
@@ -16,16 +19,19 @@ type googleAPI struct {
 	clientID     string
 	clientSecret string
 	redirectURL  string
+	audiences    []string
+	verifiers    []*idtoken.Verifier
 	validator    *validator.Validate
 }
 
-// GetUserInfo fetches user information from Google using the provided access token.
-func (g *googleAPI) GetUserInfo(accessToken string) (*oauthmodels.UserInfo, error) {
+// GetUserInfo fetches user information from Google using the provided access
+// token. The nonce is not checked, as the user info endpoint has no ID token.
+func (g *googleAPI) GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error) {
 	req, err := http.NewRequest("GET", oauthapimeta.GoogleUserInfoEndpoint, nil)
 	if err != nil {
 		return nil, errors.New("failed to create request: " + err.Error())
 	}
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -56,62 +62,180 @@ func (g *googleAPI) GetUserInfo(accessToken string) (*oauthmodels.UserInfo, erro
 	return oauthUserInfo, nil
 }
 
+// GetNativeUserInfo verifies the ID token of Google Sign-In, or checks the
+// audience of an access token with the token info endpoint.
+func (g *googleAPI) GetNativeUserInfo(token NativeToken) (*oauthmodels.UserInfo, error) {
+	if token.IDToken == "" {
+		return g.getNativeUserInfoByAccessToken(token.AccessToken)
+	}
+
+	var claims *idtoken.Claims
+	var err error
+	for _, verifier := range g.verifiers {
+		// Try the next issuer spelling only when the issuer is what failed
+		if claims, err = verifier.Verify(token.IDToken); err != idtoken.ErrInvalidIssuer {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.New("invalid ID token: " + err.Error())
+	}
+	if err := checkNativeNonce(claims, token.Nonce); err != nil {
+		return nil, err
+	}
+
+	var rawUserInfo infoapidto.RawGoogleUserInfo
+	if err := claims.Decode(&rawUserInfo); err != nil {
+		return nil, errors.New("failed to decode ID token claims: " + err.Error())
+	}
+
+	oauthUserInfo := oauthmodels.NewUserInfo(
+		rawUserInfo.Sub,
+		rawUserInfo.Email,
+		rawUserInfo.Name,
+		rawUserInfo.EmailVerified,
+	)
+	if err := g.validator.Struct(oauthUserInfo); err != nil {
+		return nil, errors.New("invalid user info structure: " + err.Error())
+	}
+
+	return oauthUserInfo, nil
+}
+
+// getNativeUserInfoByAccessToken checks that the access token was issued to
+// one of our clients before fetching the user info with it.
+func (g *googleAPI) getNativeUserInfoByAccessToken(accessToken string) (*oauthmodels.UserInfo, error) {
+	req, err := http.NewRequest("GET", oauthapimeta.GoogleTokenInfoEndpoint, nil)
+	if err != nil {
+		return nil, errors.New("failed to create request: " + err.Error())
+	}
+	q := req.URL.Query()
+	q.Add("access_token", accessToken)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.New("failed to fetch token info: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrTokenInfoRejected
+	}
+
+	var tokenInfo infoapidto.RawGoogleTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&tokenInfo); err != nil {
+		return nil, errors.New("failed to decode token info: " + err.Error())
+	}
+	if err := checkAudience(g.audiences, tokenInfo.Aud, tokenInfo.Azp); err != nil {
+		return nil, err
+	}
+
+	userInfo, err := g.GetUserInfo(&oauthmodels.Token{AccessToken: accessToken}, "")
+	if err != nil {
+		return nil, err
+	}
+	if userInfo.ProviderID != tokenInfo.Sub {
+		return nil, errors.New("token info subject does not match the user info")
+	}
+	return userInfo, nil
+}
+
 // NewGoogleAPI creates a new instance of GoogleAPI with the required parameters.
-func NewGoogleAPI(clientID, clientSecret, redirectURL string, validator *validator.Validate) (OAuthAPI, error) {
+//
+// nativeClientIDs are the client IDs of the mobile apps, whose tokens
+// GetNativeUserInfo accepts besides those of clientID.
+func NewGoogleAPI(clientID, clientSecret, redirectURL string, nativeClientIDs []string, validator *validator.Validate) (OAuthAPI, error) {
 	if clientID == "" || clientSecret == "" || redirectURL == "" {
 		return nil, errors.New("client ID, client secret, and redirect URL must be set")
 	}
 
+	audiences := append([]string{clientID}, nativeClientIDs...)
+	keySet := idtoken.NewKeySet(oauthapimeta.GoogleKeysEndpoint, http.DefaultClient)
 	return &googleAPI{
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		redirectURL:  redirectURL,
-		validator:    validator,
+		audiences:    audiences,
+		// Google issues ID tokens under both spellings of its issuer
+		verifiers: []*idtoken.Verifier{
+			idtoken.NewVerifier(keySet, oauthapimeta.GoogleIssuer, audiences...),
+			idtoken.NewVerifier(keySet, strings.TrimPrefix(oauthapimeta.GoogleIssuer, "https://"), audiences...),
+		},
+		validator: validator,
 	}, nil
 }
 
-func (g *googleAPI) GetAccessToken(code string) (string, error) {
+// GetAccessToken exchanges the code for the tokens of the user.
+func (g *googleAPI) GetAccessToken(code string, codeVerifier string) (*oauthmodels.Token, error) {
+	params := url.Values{}
+	params.Add("code", code)
+	params.Add("redirect_uri", g.redirectURL)
+	params.Add("grant_type", oauthapimeta.GoogleGrantType)
+	if codeVerifier != "" {
+		params.Add("code_verifier", codeVerifier)
+	}
+	return g.requestToken(params)
+}
+
+// RefreshAccessToken obtains a new access token. Google does not rotate
+// refresh tokens.
+func (g *googleAPI) RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error) {
+	params := url.Values{}
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", oauthapimeta.RefreshGrantType)
+	return g.requestToken(params)
+}
+
+// requestToken posts the grant to the token endpoint with the client credentials.
+func (g *googleAPI) requestToken(params url.Values) (*oauthmodels.Token, error) {
 	req, err := http.NewRequest("POST", oauthapimeta.GoogleTokenEndpoint, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	q := req.URL.Query()
-	q.Add("code", code)
-	q.Add("client_id", g.clientID)
-	q.Add("client_secret", g.clientSecret)
-	q.Add("redirect_uri", g.redirectURL)
-	q.Add("grant_type", oauthapimeta.GoogleGrantType)
-	req.URL.RawQuery = q.Encode()
+	params.Add("client_id", g.clientID)
+	params.Add("client_secret", g.clientSecret)
+	req.URL.RawQuery = params.Encode()
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", errors.New("failed to retrieve access token: " + resp.Status)
+		return nil, errors.New("failed to retrieve access token: " + resp.Status)
 	}
 
 	var tokenResponse codeapidto.GoogleAccessTokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return "", errors.New("failed to decode access token response: " + err.Error())
+		return nil, errors.New("failed to decode access token response: " + err.Error())
 	}
 	if tokenResponse.AccessToken == "" {
-		return "", errors.New("access token is empty in response")
+		return nil, errors.New("access token is empty in response")
 	}
 
-	return tokenResponse.AccessToken, nil
+	return oauthmodels.NewToken(
+		tokenResponse.AccessToken,
+		tokenResponse.RefreshToken,
+		tokenResponse.IDToken,
+		tokenResponse.TokenType,
+		tokenResponse.Scope,
+		tokenResponse.ExpiresIn,
+	), nil
 }
 
-func (g *googleAPI) GetLoginURL() string {
+func (g *googleAPI) GetLoginURL(req AuthRequest) string {
 	loginUrl := oauthapimeta.GoogleAuthEndpoint + "?client_id=" + g.clientID +
 		"&redirect_uri=" + g.redirectURL +
 		"&response_type=code" +
 		"&scope=email%20profile" +
-		"&access_type=offline"
+		"&access_type=offline" +
+		"&state=" + url.QueryEscape(req.State) +
+		"&code_challenge=" + url.QueryEscape(req.CodeChallenge) +
+		"&code_challenge_method=S256"
 
 	return loginUrl
 }
@@ -2,23 +2,55 @@ package oauthapi
 
 import oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
 
+// AuthRequest binds an authorization request to one login attempt.
+type AuthRequest struct {
+	State         string // Echoed back to the callback, ties it to the attempt
+	Nonce         string // Included in the ID token by providers issuing one
+	CodeChallenge string // S256 PKCE challenge, ignored by providers without PKCE
+}
+
+// NativeToken is a token a native SDK obtained from the provider.
+type NativeToken struct {
+	IDToken     string // Preferred where the provider issues one
+	AccessToken string // Opaque token, checked with the token info endpoint of the provider
+	Nonce       string // The nonce the app passed to the SDK, which the ID token must carry
+}
+
 type OAuthAPI interface {
 	// GetAccessToken retrieves an access token using the provided authorization code.
 	//
 	// Parameters:
 	//   - code: The authorization code received from the OAuth provider.
+	//   - codeVerifier: The PKCE verifier of the login attempt, or empty if the
+	//     code was not requested with a challenge.
 	//
 	// Returns:
-	//   - A string representing the access token.
+	//   - The tokens issued for the user.
 	//   - An error if the token retrieval fails.
-	GetAccessToken(code string) (string, error)
+	GetAccessToken(code string, codeVerifier string) (*oauthmodels.Token, error)
+
+	// RefreshAccessToken obtains a new access token with the refresh token.
+	//
+	// The refresh token of the result is empty if the provider did not rotate
+	// it, in which case the given one remains valid.
+	RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error)
 
 	// GetLoginURL returns the URL to redirect the user for OAuth login.
-	GetLoginURL() string
+	GetLoginURL(req AuthRequest) string
 
-	// GetUserInfo retrieves user information using the access token.
+	// GetUserInfo retrieves user information using the tokens of GetAccessToken.
 	//
 	// Parameters:
-	//   - accessToken: The access token obtained from the OAuth provider.
-	GetUserInfo(accessToken string) (*oauthmodels.UserInfo, error)
+	//   - token: The tokens obtained from the OAuth provider. Providers without
+	//     a user info endpoint read the ID token instead of the access token.
+	//   - nonce: The nonce the ID token must carry, or empty to skip the check.
+	//     Providers without ID tokens ignore it.
+	GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error)
+
+	// GetNativeUserInfo verifies a token obtained by a native SDK and retrieves
+	// the user it describes.
+	//
+	// Unlike GetUserInfo, the token must have been issued to one of our client
+	// IDs, so that a token of another app cannot sign in to ours.
+	GetNativeUserInfo(token NativeToken) (*oauthmodels.UserInfo, error)
 }
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
+	"mandacode.com/accounts/auth/internal/infra/idtoken"
 	codeapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/codeapi"
 	infoapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/infoapi"
 	oauthapimeta "mandacode.com/accounts/auth/internal/infra/oauthapi/meta"
@@ -16,16 +19,18 @@ type KakaoAPI struct {
 	clientID     string
 	clientSecret string
 	redirectURL  string
+	appID        int64
+	verifier     *idtoken.Verifier
 	validator    *validator.Validate
 }
 
 // GetUserInfo fetches user information from Kakao using the provided access token.
-func (k *KakaoAPI) GetUserInfo(accessToken string) (*oauthmodels.UserInfo, error) {
+func (k *KakaoAPI) GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error) {
 	req, err := http.NewRequest("GET", oauthapimeta.KakaoUserInfoEndpoint, nil)
 	if err != nil {
 		return nil, errors.New("failed to create request: " + err.Error())
 	}
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -56,59 +61,164 @@ func (k *KakaoAPI) GetUserInfo(accessToken string) (*oauthmodels.UserInfo, error
 	return oauthUserInfo, nil
 }
 
+// GetNativeUserInfo verifies the ID token of Kakao Login, or checks the app
+// of an access token with the token info endpoint.
+func (k *KakaoAPI) GetNativeUserInfo(token NativeToken) (*oauthmodels.UserInfo, error) {
+	if token.IDToken == "" {
+		return k.getNativeUserInfoByAccessToken(token.AccessToken)
+	}
+
+	claims, err := k.verifier.Verify(token.IDToken)
+	if err != nil {
+		return nil, errors.New("invalid ID token: " + err.Error())
+	}
+	if err := checkNativeNonce(claims, token.Nonce); err != nil {
+		return nil, err
+	}
+
+	var rawClaims infoapidto.RawKakaoIDTokenClaims
+	if err := claims.Decode(&rawClaims); err != nil {
+		return nil, errors.New("failed to decode ID token claims: " + err.Error())
+	}
+
+	oauthUserInfo := oauthmodels.NewUserInfo(
+		rawClaims.Sub,
+		rawClaims.Email,
+		rawClaims.Nickname,
+		rawClaims.Email != "", // Only a verified email is included
+	)
+	if err := k.validator.Struct(oauthUserInfo); err != nil {
+		return nil, errors.New("invalid user info structure: " + err.Error())
+	}
+
+	return oauthUserInfo, nil
+}
+
+// getNativeUserInfoByAccessToken checks that the access token was issued to
+// our app before fetching the user info with it.
+func (k *KakaoAPI) getNativeUserInfoByAccessToken(accessToken string) (*oauthmodels.UserInfo, error) {
+	if k.appID == 0 {
+		return nil, ErrIDTokenRequired
+	}
+
+	req, err := http.NewRequest("GET", oauthapimeta.KakaoTokenInfoEndpoint, nil)
+	if err != nil {
+		return nil, errors.New("failed to create request: " + err.Error())
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.New("failed to fetch token info: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrTokenInfoRejected
+	}
+
+	var tokenInfo infoapidto.RawKakaoTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&tokenInfo); err != nil {
+		return nil, errors.New("failed to decode token info: " + err.Error())
+	}
+	if tokenInfo.AppID != k.appID {
+		return nil, ErrAudienceMismatch
+	}
+
+	userInfo, err := k.GetUserInfo(&oauthmodels.Token{AccessToken: accessToken}, "")
+	if err != nil {
+		return nil, err
+	}
+	if userInfo.ProviderID != strconv.FormatInt(tokenInfo.ID, 10) {
+		return nil, errors.New("token info user does not match the user info")
+	}
+	return userInfo, nil
+}
+
 // NewKakaoAPI creates a new instance of KakaoAPI with the required parameters.
-func NewKakaoAPI(clientID, clientSecret, redirectURL string, validator *validator.Validate) (OAuthAPI, error) {
+//
+// nativeClientIDs are the native app keys, accepted as ID token audiences
+// besides clientID. appID is the numeric app ID access tokens must belong to;
+// with 0, native logins must use ID tokens.
+func NewKakaoAPI(clientID, clientSecret, redirectURL string, nativeClientIDs []string, appID int64, validator *validator.Validate) (OAuthAPI, error) {
 	if clientID == "" || clientSecret == "" || redirectURL == "" {
 		return nil, errors.New("clientID, clientSecret, and redirectURL must not be empty")
 	}
 
+	keySet := idtoken.NewKeySet(oauthapimeta.KakaoKeysEndpoint, http.DefaultClient)
 	return &KakaoAPI{
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		redirectURL:  redirectURL,
+		appID:        appID,
+		verifier:     idtoken.NewVerifier(keySet, oauthapimeta.KakaoIssuer, append([]string{clientID}, nativeClientIDs...)...),
 		validator:    validator,
 	}, nil
 }
 
-func (k *KakaoAPI) GetAccessToken(code string) (string, error) {
+// GetAccessToken exchanges the code for the tokens of the user. The code
+// verifier is not sent, as Kakao does not support PKCE.
+func (k *KakaoAPI) GetAccessToken(code string, codeVerifier string) (*oauthmodels.Token, error) {
+	params := url.Values{}
+	params.Add("code", code)
+	params.Add("redirect_uri", k.redirectURL)
+	params.Add("grant_type", oauthapimeta.KakaoGrantType)
+	return k.requestToken(params)
+}
+
+// RefreshAccessToken obtains a new access token. Kakao rotates the refresh
+// token only when it is close to expiry.
+func (k *KakaoAPI) RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error) {
+	params := url.Values{}
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", oauthapimeta.RefreshGrantType)
+	return k.requestToken(params)
+}
+
+// requestToken posts the grant to the token endpoint with the client credentials.
+func (k *KakaoAPI) requestToken(params url.Values) (*oauthmodels.Token, error) {
 	req, err := http.NewRequest("POST", oauthapimeta.KakaoTokenEndpoint, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	q := req.URL.Query()
-	q.Add("code", code)
-	q.Add("client_id", k.clientID)
-	q.Add("client_secret", k.clientSecret)
-	q.Add("redirect_uri", k.redirectURL)
-	q.Add("grant_type", oauthapimeta.KakaoGrantType)
-	req.URL.RawQuery = q.Encode()
+	params.Add("client_id", k.clientID)
+	params.Add("client_secret", k.clientSecret)
+	req.URL.RawQuery = params.Encode()
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", errors.New("failed to retrieve access token: " + resp.Status)
+		return nil, errors.New("failed to retrieve access token: " + resp.Status)
 	}
 
 	var tokenResponse codeapidto.KakaoAccessTokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return "", errors.New("failed to decode access token response: " + err.Error())
+		return nil, errors.New("failed to decode access token response: " + err.Error())
 	}
 	if tokenResponse.AccessToken == "" {
-		return "", errors.New("access token is empty")
+		return nil, errors.New("access token is empty")
 	}
 
-	return tokenResponse.AccessToken, nil
+	return oauthmodels.NewToken(
+		tokenResponse.AccessToken,
+		tokenResponse.RefreshToken,
+		tokenResponse.IDToken,
+		tokenResponse.TokenType,
+		tokenResponse.Scope,
+		tokenResponse.ExpiresIn,
+	), nil
 }
 
-func (k *KakaoAPI) GetLoginURL() string {
+func (k *KakaoAPI) GetLoginURL(req AuthRequest) string {
 	loginURL := oauthapimeta.KakaoAuthEndpoint + "?client_id=" + k.clientID +
-		"&redirect_uri=" + k.redirectURL + "&response_type=code&scope=account_email%20profile_nickname"
+		"&redirect_uri=" + k.redirectURL + "&response_type=code&scope=account_email%20profile_nickname" +
+		"&state=" + url.QueryEscape(req.State)
 
 	return loginURL
 }
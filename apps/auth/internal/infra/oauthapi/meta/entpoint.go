@@ -4,18 +4,21 @@ const (
 	GoogleTokenEndpoint = "https://oauth2.googleapis.com/token"
 	NaverTokenEndpoint  = "https://nid.naver.com/oauth2.0/token"
 	KakaoTokenEndpoint  = "https://kauth.kakao.com/oauth/token"
+	AppleTokenEndpoint  = "https://appleid.apple.com/auth/token"
 )
 
 const (
 	GoogleGrantType = "authorization_code"
 	NaverGrantType  = "authorization_code"
 	KakaoGrantType  = "authorization_code"
+	AppleGrantType  = "authorization_code"
 )
 
 const (
 	GoogleAuthEndpoint = "https://accounts.google.com/o/oauth2/auth"
 	NaverAuthEndpoint  = "https://nid.naver.com/oauth2.0/authorize"
 	KakaoAuthEndpoint  = "https://kauth.kakao.com/oauth/authorize"
+	AppleAuthEndpoint  = "https://appleid.apple.com/auth/authorize"
 )
 
 const (
@@ -23,3 +26,30 @@ const (
 	KakaoUserInfoEndpoint = "https://kapi.kakao.com/v2/user/me"
 	NaverUserInfoEndpoint = "https://openapi.naver.com/v1/nid/me"
 )
+
+const (
+	AppleIssuer       = "https://appleid.apple.com"
+	AppleKeysEndpoint = "https://appleid.apple.com/auth/keys"
+)
+
+const (
+	OIDCDiscoveryPath = "/.well-known/openid-configuration"
+	OIDCGrantType     = "authorization_code"
+)
+
+const (
+	GoogleIssuer       = "https://accounts.google.com"
+	GoogleKeysEndpoint = "https://www.googleapis.com/oauth2/v3/certs"
+	KakaoIssuer        = "https://kauth.kakao.com"
+	KakaoKeysEndpoint  = "https://kauth.kakao.com/.well-known/jwks.json"
+)
+
+const (
+	GoogleTokenInfoEndpoint = "https://oauth2.googleapis.com/tokeninfo"
+	KakaoTokenInfoEndpoint  = "https://kapi.kakao.com/v1/user/access_token_info"
+	NaverTokenInfoEndpoint  = "https://openapi.naver.com/v1/nid/verify"
+)
+
+const (
+	RefreshGrantType = "refresh_token"
+)
@@ -0,0 +1,39 @@
+package oauthapi
+
+import (
+	"errors"
+	"slices"
+
+	"mandacode.com/accounts/auth/internal/infra/idtoken"
+)
+
+var (
+	ErrIDTokenRequired   = errors.New("provider requires an ID token")
+	ErrNonceRequired     = errors.New("native ID token requires a nonce")
+	ErrNonceMismatch     = errors.New("ID token nonce does not match")
+	ErrAudienceMismatch  = errors.New("token was issued to another client")
+	ErrTokenInfoRejected = errors.New("token info endpoint rejected the token")
+)
+
+// checkNativeNonce compares the nonce claim of a native ID token with the
+// nonce the app passed to the SDK. A token without a nonce could be replayed
+// from any other app of the provider, so the nonce is required.
+func checkNativeNonce(claims *idtoken.Claims, nonce string) error {
+	if nonce == "" {
+		return ErrNonceRequired
+	}
+	if claims.Nonce != nonce {
+		return ErrNonceMismatch
+	}
+	return nil
+}
+
+// checkAudience checks that a token info audience is one of our client IDs.
+func checkAudience(audiences []string, audience ...string) error {
+	for _, aud := range audience {
+		if aud != "" && slices.Contains(audiences, aud) {
+			return nil
+		}
+	}
+	return ErrAudienceMismatch
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/go-playground/validator/v10"
 	codeapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/codeapi"
@@ -13,20 +14,20 @@ import (
 )
 
 type NaverAPI struct {
-	clientID         string
-	clientSecret     string
-	redirectURL      string
-	tokenRedirectURL string
-	validator        *validator.Validate
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	audiences    []string
+	validator    *validator.Validate
 }
 
 // GetUserInfo implements oauthapidomain.OAuthCode.
-func (n *NaverAPI) GetUserInfo(accessToken string) (*oauthmodels.UserInfo, error) {
+func (n *NaverAPI) GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error) {
 	req, err := http.NewRequest("GET", oauthapimeta.NaverUserInfoEndpoint, nil)
 	if err != nil {
 		return nil, errors.New("failed to create request: " + err.Error())
 	}
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -57,8 +58,49 @@ func (n *NaverAPI) GetUserInfo(accessToken string) (*oauthmodels.UserInfo, error
 	return oauthUserInfo, nil
 }
 
+// GetNativeUserInfo checks the client of the access token with the token
+// verification endpoint before fetching the user info. Naver issues no ID
+// tokens.
+func (n *NaverAPI) GetNativeUserInfo(token NativeToken) (*oauthmodels.UserInfo, error) {
+	if token.AccessToken == "" {
+		return nil, errors.New("access token is required")
+	}
+
+	req, err := http.NewRequest("GET", oauthapimeta.NaverTokenInfoEndpoint+"?info=true", nil)
+	if err != nil {
+		return nil, errors.New("failed to create request: " + err.Error())
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.New("failed to fetch token info: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrTokenInfoRejected
+	}
+
+	var tokenInfo infoapidto.RawNaverTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&tokenInfo); err != nil {
+		return nil, errors.New("failed to decode token info: " + err.Error())
+	}
+	if tokenInfo.ResultCode != "00" {
+		return nil, ErrTokenInfoRejected
+	}
+	if err := checkAudience(n.audiences, tokenInfo.Response.ClientID); err != nil {
+		return nil, err
+	}
+
+	return n.GetUserInfo(&oauthmodels.Token{AccessToken: token.AccessToken}, "")
+}
+
 // NewNaverAPI creates a new instance of NaverAPI with the required parameters.
-func NewNaverAPI(clientID, clientSecret, redirectURL string, validator *validator.Validate) (OAuthAPI, error) {
+//
+// nativeClientIDs are the client IDs of the mobile apps, whose tokens
+// GetNativeUserInfo accepts besides those of clientID.
+func NewNaverAPI(clientID, clientSecret, redirectURL string, nativeClientIDs []string, validator *validator.Validate) (OAuthAPI, error) {
 	if clientID == "" || clientSecret == "" || redirectURL == "" {
 		return nil, errors.New("client ID, client secret, and redirect URL must be set")
 	}
@@ -67,51 +109,76 @@ func NewNaverAPI(clientID, clientSecret, redirectURL string, validator *validato
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		redirectURL:  redirectURL,
+		audiences:    append([]string{clientID}, nativeClientIDs...),
 		validator:    validator,
 	}, nil
 }
 
-func (n *NaverAPI) GetAccessToken(code string) (string, error) {
+// GetAccessToken exchanges the code for the tokens of the user. The code
+// verifier is not sent, as Naver does not support PKCE.
+func (n *NaverAPI) GetAccessToken(code string, codeVerifier string) (*oauthmodels.Token, error) {
+	params := url.Values{}
+	params.Add("code", code)
+	params.Add("redirect_uri", n.redirectURL)
+	params.Add("grant_type", oauthapimeta.NaverGrantType)
+	return n.requestToken(params)
+}
+
+// RefreshAccessToken obtains a new access token. Naver does not rotate
+// refresh tokens.
+func (n *NaverAPI) RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error) {
+	params := url.Values{}
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", oauthapimeta.RefreshGrantType)
+	return n.requestToken(params)
+}
+
+// requestToken posts the grant to the token endpoint with the client credentials.
+func (n *NaverAPI) requestToken(params url.Values) (*oauthmodels.Token, error) {
 	req, err := http.NewRequest("POST", oauthapimeta.NaverTokenEndpoint, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	q := req.URL.Query()
-	q.Add("code", code)
-	q.Add("client_id", n.clientID)
-	q.Add("client_secret", n.clientSecret)
-	q.Add("redirect_uri", n.redirectURL)
-	q.Add("grant_type", oauthapimeta.NaverGrantType)
-	req.URL.RawQuery = q.Encode()
+	params.Add("client_id", n.clientID)
+	params.Add("client_secret", n.clientSecret)
+	req.URL.RawQuery = params.Encode()
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", errors.New("failed to retrieve access token: " + resp.Status)
+		return nil, errors.New("failed to retrieve access token: " + resp.Status)
 	}
 
 	var tokenResponse codeapidto.NaverAccessTokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return "", errors.New("failed to decode access token response: " + err.Error())
+		return nil, errors.New("failed to decode access token response: " + err.Error())
 	}
 	if tokenResponse.AccessToken == "" {
-		return "", errors.New("access token is empty")
+		return nil, errors.New("access token is empty")
 	}
 
-	return tokenResponse.AccessToken, nil
+	expiresIn, _ := tokenResponse.ExpiresIn.Int64()
+	return oauthmodels.NewToken(
+		tokenResponse.AccessToken,
+		tokenResponse.RefreshToken,
+		"",
+		tokenResponse.TokenType,
+		"",
+		int(expiresIn),
+	), nil
 }
 
-func (n *NaverAPI) GetLoginURL() string {
+func (n *NaverAPI) GetLoginURL(req AuthRequest) string {
 	loginURL := oauthapimeta.NaverAuthEndpoint + "?client_id=" + n.clientID +
 		"&response_type=code" +
 		"&redirect_uri=" + n.redirectURL +
-		"&state=" + n.tokenRedirectURL
+		"&state=" + url.QueryEscape(req.State)
 
 	return loginURL
 }
@@ -0,0 +1,316 @@
+package oauthapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"mandacode.com/accounts/auth/internal/infra/idtoken"
+	codeapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/codeapi"
+	infoapidto "mandacode.com/accounts/auth/internal/infra/oauthapi/dto/infoapi"
+	oauthapimeta "mandacode.com/accounts/auth/internal/infra/oauthapi/meta"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+)
+
+// oidcTimeout bounds the calls to the provider when no client is given, as
+// the discovery blocks the startup and the key set fetch blocks logins.
+const oidcTimeout = 10 * time.Second
+
+// OIDCClaimPaths are the ID token claims holding the user fields. A path
+// separated by dots reaches into nested objects, e.g. "profile.email".
+type OIDCClaimPaths struct {
+	Subject       string
+	Email         string
+	EmailVerified string
+	Name          string
+}
+
+// DefaultOIDCClaimPaths are the standard OpenID Connect claims.
+var DefaultOIDCClaimPaths = OIDCClaimPaths{
+	Subject:       "sub",
+	Email:         "email",
+	EmailVerified: "email_verified",
+	Name:          "name",
+}
+
+// OIDCAPI implements a generic OpenID Connect provider configured from its
+// discovery document.
+//
+// Like AppleAPI, the user is described by the ID token, which GetUserInfo reads.
+type OIDCAPI struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	claimPaths   OIDCClaimPaths
+	document     infoapidto.OIDCDiscoveryDocument
+	httpClient   *http.Client
+	verifier     *idtoken.Verifier
+	native       *idtoken.Verifier // Also accepts the client IDs of the apps
+	validator    *validator.Validate
+}
+
+// GetUserInfo verifies the ID token and maps its claims to the user info.
+func (o *OIDCAPI) GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error) {
+	claims, err := o.verifier.Verify(token.IDToken)
+	if err != nil {
+		return nil, errors.New("invalid ID token: " + err.Error())
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, errors.New("ID token nonce does not match the login attempt")
+	}
+	return o.userInfoFromClaims(claims)
+}
+
+// GetNativeUserInfo verifies an ID token obtained by a native app. Opaque
+// access tokens are refused, as there is no standard way to check their client.
+func (o *OIDCAPI) GetNativeUserInfo(token NativeToken) (*oauthmodels.UserInfo, error) {
+	if token.IDToken == "" {
+		return nil, ErrIDTokenRequired
+	}
+
+	claims, err := o.native.Verify(token.IDToken)
+	if err != nil {
+		return nil, errors.New("invalid ID token: " + err.Error())
+	}
+	if err := checkNativeNonce(claims, token.Nonce); err != nil {
+		return nil, err
+	}
+	return o.userInfoFromClaims(claims)
+}
+
+// userInfoFromClaims maps verified ID token claims to the user info through
+// the claim paths.
+func (o *OIDCAPI) userInfoFromClaims(claims *idtoken.Claims) (*oauthmodels.UserInfo, error) {
+	var rawClaims map[string]any
+	if err := claims.Decode(&rawClaims); err != nil {
+		return nil, errors.New("failed to decode ID token claims: " + err.Error())
+	}
+
+	oauthUserInfo := oauthmodels.NewUserInfo(
+		stringClaim(rawClaims, o.claimPaths.Subject),
+		stringClaim(rawClaims, o.claimPaths.Email),
+		stringClaim(rawClaims, o.claimPaths.Name),
+		boolClaim(rawClaims, o.claimPaths.EmailVerified),
+	)
+	// The name is optional, as not every provider releases it
+	if err := o.validator.StructExcept(oauthUserInfo, "Name"); err != nil {
+		return nil, errors.New("invalid user info structure: " + err.Error())
+	}
+
+	return oauthUserInfo, nil
+}
+
+// GetAccessToken exchanges the code for the tokens of the user.
+func (o *OIDCAPI) GetAccessToken(code string, codeVerifier string) (*oauthmodels.Token, error) {
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("redirect_uri", o.redirectURL)
+	form.Set("grant_type", oauthapimeta.OIDCGrantType)
+	if codeVerifier != "" {
+		form.Set("code_verifier", codeVerifier)
+	}
+
+	token, err := o.requestToken(form)
+	if err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("ID token is empty in response")
+	}
+	return token, nil
+}
+
+// RefreshAccessToken obtains a new access token with the refresh token.
+func (o *OIDCAPI) RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error) {
+	form := url.Values{}
+	form.Set("refresh_token", refreshToken)
+	form.Set("grant_type", oauthapimeta.RefreshGrantType)
+	return o.requestToken(form)
+}
+
+// requestToken posts the grant to the token endpoint, authenticating the
+// client the way the discovery document allows.
+func (o *OIDCAPI) requestToken(form url.Values) (*oauthmodels.Token, error) {
+	// client_secret_basic is the default when the provider does not say
+	basicAuth := !slices.Contains(o.document.TokenEndpointAuthMethodsSupported, "client_secret_post")
+	if !basicAuth {
+		form.Set("client_id", o.clientID)
+		form.Set("client_secret", o.clientSecret)
+	}
+
+	req, err := http.NewRequest("POST", o.document.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basicAuth {
+		req.SetBasicAuth(url.QueryEscape(o.clientID), url.QueryEscape(o.clientSecret))
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("failed to retrieve access token: " + resp.Status)
+	}
+
+	var tokenResponse codeapidto.OIDCTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, errors.New("failed to decode access token response: " + err.Error())
+	}
+
+	return oauthmodels.NewToken(
+		tokenResponse.AccessToken,
+		tokenResponse.RefreshToken,
+		tokenResponse.IDToken,
+		tokenResponse.TokenType,
+		tokenResponse.Scope,
+		tokenResponse.ExpiresIn,
+	), nil
+}
+
+// GetLoginURL returns the authorization URL of the discovery document.
+//
+// The PKCE challenge is always sent; providers without PKCE ignore it.
+func (o *OIDCAPI) GetLoginURL(req AuthRequest) string {
+	q := url.Values{}
+	q.Set("client_id", o.clientID)
+	q.Set("redirect_uri", o.redirectURL)
+	q.Set("response_type", "code")
+	q.Set("scope", strings.Join(o.scopes, " "))
+	q.Set("state", req.State)
+	q.Set("nonce", req.Nonce)
+	q.Set("code_challenge", req.CodeChallenge)
+	q.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(o.document.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return o.document.AuthorizationEndpoint + separator + strings.ReplaceAll(q.Encode(), "+", "%20")
+}
+
+// lookupClaim returns the claim at the dotted path, if any.
+func lookupClaim(claims map[string]any, path string) (any, bool) {
+	var value any = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// stringClaim returns the string claim at the path, or an empty string.
+func stringClaim(claims map[string]any, path string) string {
+	value, _ := lookupClaim(claims, path)
+	text, _ := value.(string)
+	return text
+}
+
+// boolClaim returns the boolean claim at the path, accepting the strings
+// "true" and "false" some providers send. A missing claim is false.
+func boolClaim(claims map[string]any, path string) bool {
+	value, _ := lookupClaim(claims, path)
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		parsed, _ := strconv.ParseBool(v)
+		return parsed
+	default:
+		return false
+	}
+}
+
+// discoverOIDC fetches the discovery document of the issuer.
+func discoverOIDC(issuer string, httpClient *http.Client) (infoapidto.OIDCDiscoveryDocument, error) {
+	var document infoapidto.OIDCDiscoveryDocument
+
+	resp, err := httpClient.Get(strings.TrimSuffix(issuer, "/") + oauthapimeta.OIDCDiscoveryPath)
+	if err != nil {
+		return document, errors.New("failed to fetch discovery document: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return document, errors.New("failed to fetch discovery document: " + resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return document, errors.New("failed to decode discovery document: " + err.Error())
+	}
+
+	// The issuer must match exactly, or ID tokens of another issuer could be accepted
+	if document.Issuer != issuer {
+		return document, errors.New("discovery document issuer " + document.Issuer + " does not match " + issuer)
+	}
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.JWKSURI == "" {
+		return document, errors.New("discovery document is missing endpoints")
+	}
+	return document, nil
+}
+
+// NewOIDCAPI creates a new instance of OIDCAPI, fetching the discovery
+// document of the issuer.
+//
+// nativeClientIDs are the client IDs of the mobile apps, whose ID tokens
+// GetNativeUserInfo accepts besides those of clientID. Empty claim paths fall
+// back to DefaultOIDCClaimPaths. A client timing out after oidcTimeout is
+// used if httpClient is nil.
+func NewOIDCAPI(issuer, clientID, clientSecret, redirectURL string, scopes, nativeClientIDs []string, claimPaths OIDCClaimPaths, httpClient *http.Client, validator *validator.Validate) (OAuthAPI, error) {
+	if issuer == "" || clientID == "" || clientSecret == "" || redirectURL == "" {
+		return nil, errors.New("issuer, client ID, client secret, and redirect URL must be set")
+	}
+	if !slices.Contains(scopes, "openid") {
+		return nil, errors.New("scopes must include openid")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: oidcTimeout}
+	}
+
+	if claimPaths.Subject == "" {
+		claimPaths.Subject = DefaultOIDCClaimPaths.Subject
+	}
+	if claimPaths.Email == "" {
+		claimPaths.Email = DefaultOIDCClaimPaths.Email
+	}
+	if claimPaths.EmailVerified == "" {
+		claimPaths.EmailVerified = DefaultOIDCClaimPaths.EmailVerified
+	}
+	if claimPaths.Name == "" {
+		claimPaths.Name = DefaultOIDCClaimPaths.Name
+	}
+
+	document, err := discoverOIDC(issuer, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := idtoken.NewKeySet(document.JWKSURI, httpClient)
+	return &OIDCAPI{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		claimPaths:   claimPaths,
+		document:     document,
+		httpClient:   httpClient,
+		verifier:     idtoken.NewVerifier(keySet, document.Issuer, clientID),
+		native:       idtoken.NewVerifier(keySet, document.Issuer, append([]string{clientID}, nativeClientIDs...)...),
+		validator:    validator,
+	}, nil
+}
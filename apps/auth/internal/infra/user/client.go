@@ -1,4 +1,4 @@
-package tokeninfra
+package userinfra
 
 import (
 	"context"
@@ -32,7 +32,7 @@ func NewUserServiceClient(addr string) (userv1.UserServiceClient, *grpc.ClientCo
 		return nil, nil, err
 	}
 	if healthResp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
-		return nil, nil, errors.New("token service is not serving")
+		return nil, nil, errors.New("user service is not serving")
 	}
 
 	client := userv1.NewUserServiceClient(conn)
@@ -0,0 +1,67 @@
+package grpcmiddleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// clientNameKey is the context key holding the name of the authenticated client.
+type clientNameKey struct{}
+
+// Authenticate checks the bearer secret of each call against the secrets of
+// the allowed clients, by client name, and stores the client name in the
+// context. Health checks are allowed without a secret.
+func Authenticate(clients map[string]string) grpc.UnaryServerInterceptor {
+	// Compare digests, so that the comparison does not reveal secret lengths
+	digests := make(map[string][sha256.Size]byte, len(clients))
+	for name, secret := range clients {
+		digests[name] = sha256.Sum256([]byte(secret))
+	}
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, "/"+grpc_health_v1.Health_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		var secret string
+		if values := md.Get("authorization"); len(values) == 1 {
+			secret, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+		if secret == "" {
+			return nil, errors.New("missing client secret", "Unauthenticated", errcode.ErrUnauthorized)
+		}
+
+		digest := sha256.Sum256([]byte(secret))
+		clientName := ""
+		for name, expected := range digests {
+			if subtle.ConstantTimeCompare(digest[:], expected[:]) == 1 {
+				clientName = name
+			}
+		}
+		if clientName == "" {
+			return nil, errors.New("unknown client secret", "Unauthenticated", errcode.ErrUnauthorized)
+		}
+
+		return handler(context.WithValue(ctx, clientNameKey{}, clientName), req)
+	}
+}
+
+// GetClientName returns the client name stored by Authenticate.
+func GetClientName(ctx context.Context) (string, bool) {
+	clientName, ok := ctx.Value(clientNameKey{}).(string)
+	return clientName, ok
+}
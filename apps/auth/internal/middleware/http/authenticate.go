@@ -0,0 +1,60 @@
+package httpmiddleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/usecase/token"
+)
+
+// UserIDKey is the gin context key holding the authenticated user ID.
+const UserIDKey = "user_id"
+
+// Authenticate verifies the bearer access token of the request and stores the
+// user ID in the gin context. Requests without a valid token are rejected.
+func Authenticate(verify *token.VerifyUsecase) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader("Authorization")
+		accessToken, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || accessToken == "" {
+			ctx.Error(errors.New("missing bearer token", "Unauthorized", errcode.ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		valid, userID, err := verify.Verify(ctx.Request.Context(), accessToken)
+		if err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		if !valid {
+			ctx.Error(errors.New("invalid access token", "Unauthorized", errcode.ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		userUID, err := uuid.Parse(*userID)
+		if err != nil {
+			ctx.Error(errors.New("invalid user ID in access token", "Unauthorized", errcode.ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(UserIDKey, userUID)
+		ctx.Next()
+	}
+}
+
+// GetUserID returns the user ID stored by Authenticate.
+func GetUserID(ctx *gin.Context) (uuid.UUID, bool) {
+	value, ok := ctx.Get(UserIDKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	userID, ok := value.(uuid.UUID)
+	return userID, ok
+}
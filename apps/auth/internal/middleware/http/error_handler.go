@@ -1,12 +1,18 @@
 package httpmiddleware
 
 import (
+	stdErrors "errors"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
 	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
 )
 
 func ErrorHandler(logger *zap.Logger) gin.HandlerFunc {
@@ -32,11 +38,35 @@ func ErrorHandler(logger *zap.Logger) gin.HandlerFunc {
 					zap.Error(appErr),
 				)
 
-				// Capture request body
-				ctx.JSON(errcode.MapCodeToHTTP(appErr.Code()), gin.H{
+				// Tell rate limited clients when to try again
+				var limitErr *ratelimitrepo.LimitError
+				if appErr.Code() == errcode.ErrTooManyRequests && stdErrors.As(appErr, &limitErr) {
+					retryAfter := int(math.Ceil(limitErr.RetryAfter.Seconds()))
+					ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+				}
+
+				body := gin.H{
 					"error": appErr.Public(),
 					"code":  appErr.Code(),
-				})
+				}
+
+				// List every broken password rule so they can be shown per field
+				var policyErr *passwordpolicy.PolicyError
+				if stdErrors.As(appErr, &policyErr) {
+					body["violations"] = policyErr.Violations
+				}
+
+				// Tell the client which bot challenge to render before retrying
+				var challengeErr *challenge.RequiredError
+				if stdErrors.As(appErr, &challengeErr) {
+					body["challenge"] = gin.H{
+						"provider": challengeErr.Provider,
+						"site_key": challengeErr.SiteKey,
+					}
+				}
+
+				// Capture request body
+				ctx.JSON(errcode.MapCodeToHTTP(appErr.Code()), body)
 				return
 			}
 
@@ -0,0 +1,38 @@
+package httpmiddleware
+
+import (
+	"github.com/gin-gonic/gin"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+)
+
+// DeviceNameHeader is the header in which clients may name the device signing in.
+const DeviceNameHeader = "X-Device-Name"
+
+// Bounds of the client supplied values stored with a session
+const (
+	maxDeviceNameLength = 64
+	maxUserAgentLength  = 512
+)
+
+// truncate cuts s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// RequestInfo stores the client IP, user agent and device name of the request
+// in the request context, where the sessions started by it are recorded from.
+func RequestInfo() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		info := reqmodels.RequestInfo{
+			IP:         ctx.ClientIP(),
+			UserAgent:  truncate(ctx.Request.UserAgent(), maxUserAgentLength),
+			DeviceName: truncate(ctx.GetHeader(DeviceNameHeader), maxDeviceNameLength),
+		}
+		ctx.Request = ctx.Request.WithContext(reqmodels.WithRequestInfo(ctx.Request.Context(), info))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,94 @@
+package activitymodels
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+)
+
+// Method is the way a user tried to authenticate.
+type Method string
+
+const (
+	MethodPassword          Method = "password"
+	MethodMFA               Method = "mfa"
+	MethodMagicLink         Method = "magic_link"
+	MethodEmailOTP          Method = "email_otp"
+	MethodOAuth             Method = "oauth"
+	MethodPasskey           Method = "passkey"
+	MethodLoginCode         Method = "login_code"
+	MethodRefresh           Method = "refresh"
+	MethodEmailVerification Method = "email_verification"
+)
+
+// String implements fmt.Stringer.
+func (m Method) String() string {
+	return string(m)
+}
+
+// Outcome is the result of an authentication attempt.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+	// OutcomeMFARequired is a first factor accepted while a second one is
+	// still required.
+	OutcomeMFARequired Outcome = "mfa_required"
+)
+
+// String implements fmt.Stringer.
+func (o Outcome) String() string {
+	return string(o)
+}
+
+// ParseOutcome parses the name of an outcome.
+func ParseOutcome(s string) (Outcome, error) {
+	switch outcome := Outcome(s); outcome {
+	case OutcomeSuccess, OutcomeFailure, OutcomeMFARequired:
+		return outcome, nil
+	}
+	return "", errors.New("unknown outcome: "+s, "Invalid Outcome", errcode.ErrInvalidInput)
+}
+
+// maxFailureReasonLength bounds the failure reason stored for an attempt.
+const maxFailureReasonLength = 255
+
+// Entry is an authentication attempt to record. The client of the attempt is
+// taken from the request information of its context.
+type Entry struct {
+	UserID        uuid.UUID // uuid.Nil if the user is unknown
+	Method        Method
+	Provider      string // Empty unless the method is OAuth
+	Outcome       Outcome
+	FailureReason string
+}
+
+// NewEntry describes an attempt by its error: a nil error is a success,
+// and any other error a failure whose reason is the error message. Internal
+// failures are recorded without their message, which may expose internals.
+func NewEntry(method Method, provider string, userID uuid.UUID, err error) *Entry {
+	entry := &Entry{
+		UserID:   userID,
+		Method:   method,
+		Provider: provider,
+		Outcome:  OutcomeSuccess,
+	}
+	if err == nil {
+		return entry
+	}
+
+	entry.Outcome = OutcomeFailure
+	if code := errors.Code(err); code == "" || code == errcode.ErrInternalFailure {
+		entry.FailureReason = "internal error"
+		return entry
+	}
+	reason, _, _ := strings.Cut(err.Error(), "\n")
+	if len(reason) > maxFailureReasonLength {
+		reason = reason[:maxFailureReasonLength]
+	}
+	entry.FailureReason = reason
+	return entry
+}
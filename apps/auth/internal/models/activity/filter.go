@@ -0,0 +1,59 @@
+package activitymodels
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+)
+
+// Cursor is the position of a recorded attempt in the history, which is
+// ordered by time and then ID, newest first.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode returns the opaque form of the cursor handed to clients.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "." + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor parses a cursor returned by Encode.
+func ParseCursor(s string) (Cursor, error) {
+	invalid := errors.New("malformed cursor", "Invalid Cursor", errcode.ErrInvalidInput)
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	nanos, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return Cursor{}, invalid
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	return Cursor{CreatedAt: time.Unix(0, unixNano), ID: parsedID}, nil
+}
+
+// Filter selects recorded attempts. Zero fields do not filter.
+type Filter struct {
+	UserID    uuid.UUID
+	IPAddress string
+	Outcome   Outcome
+	From      time.Time // Inclusive
+	To        time.Time // Exclusive
+	After     *Cursor   // Attempts past this position only
+	Limit     int
+}
@@ -0,0 +1,25 @@
+package activitymodels
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// NetworkPrefix returns the prefix shared by the IPv4 addresses of the /24
+// network of ip, such as "203.0.113.", so that addresses can be matched by
+// network as text.
+//
+// IPv6 addresses are not grouped, as their prefixes say little about the
+// location of the client; ok is false for them and for invalid addresses.
+func NetworkPrefix(ip string) (prefix string, ok bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", false
+	}
+	addr = addr.Unmap()
+	if !addr.Is4() {
+		return "", false
+	}
+	octets := addr.As4()
+	return fmt.Sprintf("%d.%d.%d.", octets[0], octets[1], octets[2]), true
+}
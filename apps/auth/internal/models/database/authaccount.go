@@ -2,7 +2,7 @@ package dbmodels
 
 import (
 	"github.com/google/uuid"
-	"mandacode.com/accounts/auth/ent/authaccount"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 type CreateLocalAuthAccountInput struct {
@@ -13,9 +13,9 @@ type CreateLocalAuthAccountInput struct {
 }
 
 type CreateOAuthAuthAccountInput struct {
-	UserID     uuid.UUID            `json:"user_id" validate:"required"`
-	Provider   authaccount.Provider `json:"provider" validate:"required,oneof=local google kakao naver apple"`
-	ProviderID string               `json:"provider_id" validate:"required"`
-	Email      string               `json:"email" validate:"required,email"`
-	IsVerified bool                 `json:"is_verified" validate:"omitempty"`
+	UserID     uuid.UUID               `json:"user_id" validate:"required"`
+	Provider   providermodels.Provider `json:"provider" validate:"required,ne=local"`
+	ProviderID string                  `json:"provider_id" validate:"required"`
+	Email      string                  `json:"email" validate:"required,email"`
+	IsVerified bool                    `json:"is_verified" validate:"omitempty"`
 }
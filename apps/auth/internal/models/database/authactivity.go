@@ -0,0 +1,45 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+)
+
+// AuthActivity is a recorded authentication attempt.
+type AuthActivity struct {
+	ID            uuid.UUID              `json:"id"`
+	UserID        uuid.UUID              `json:"user_id"` // uuid.Nil if the user is unknown
+	Method        activitymodels.Method  `json:"method"`
+	Provider      string                 `json:"provider"`
+	IPAddress     string                 `json:"ip_address"`
+	UserAgent     string                 `json:"user_agent"`
+	Outcome       activitymodels.Outcome `json:"outcome"`
+	FailureReason string                 `json:"failure_reason"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+// Cursor returns the position of the attempt in the history.
+func (a *AuthActivity) Cursor() activitymodels.Cursor {
+	return activitymodels.Cursor{CreatedAt: a.CreatedAt, ID: a.ID}
+}
+
+func NewAuthActivity(activity *ent.AuthActivity) *AuthActivity {
+	userID := uuid.Nil
+	if activity.UserID != nil {
+		userID = *activity.UserID
+	}
+	return &AuthActivity{
+		ID:            activity.ID,
+		UserID:        userID,
+		Method:        activity.Method,
+		Provider:      activity.Provider,
+		IPAddress:     activity.IPAddress,
+		UserAgent:     activity.UserAgent,
+		Outcome:       activity.Outcome,
+		FailureReason: activity.FailureReason,
+		CreatedAt:     activity.CreatedAt,
+	}
+}
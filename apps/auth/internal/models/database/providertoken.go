@@ -0,0 +1,23 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+)
+
+// ProviderToken is a decrypted token set of an OAuth provider.
+type ProviderToken struct {
+	ID            uuid.UUID
+	AuthAccountID uuid.UUID
+	UserID        uuid.UUID
+	Provider      providermodels.Provider
+	KeyID         string // The key the stored tokens are encrypted with
+	AccessToken   string
+	RefreshToken  string // Empty if the provider issued none
+	TokenType     string
+	Scope         string
+	ExpiresAt     time.Time // Zero if the provider did not say
+	UpdatedAt     time.Time
+}
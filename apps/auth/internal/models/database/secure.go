@@ -1,9 +1,11 @@
 package dbmodels
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"mandacode.com/accounts/auth/ent"
-	"mandacode.com/accounts/auth/ent/authaccount"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
 type SecureLocalAuthAccount struct {
@@ -25,12 +27,12 @@ func NewSecureLocalAuthAccount(authAccount *ent.AuthAccount) *SecureLocalAuthAcc
 }
 
 type SecureOAuthAuthAccount struct {
-	ID         uuid.UUID            `json:"id"`
-	UserID     uuid.UUID            `json:"user_id" validate:"required"`
-	Provider   authaccount.Provider `json:"provider" validate:"required,oneof=google kakao naver apple"`
-	ProviderID string               `json:"provider_id" validate:"required"`
-	Email      string               `json:"email" validate:"required,email"`
-	IsVerified bool                 `json:"is_verified" validate:"required"`
+	ID         uuid.UUID               `json:"id"`
+	UserID     uuid.UUID               `json:"user_id" validate:"required"`
+	Provider   providermodels.Provider `json:"provider" validate:"required,ne=local"`
+	ProviderID string                  `json:"provider_id" validate:"required"`
+	Email      string                  `json:"email" validate:"required,email"`
+	IsVerified bool                    `json:"is_verified" validate:"required"`
 }
 
 func NewSecureOAuthAuthAccount(authAccount *ent.AuthAccount) *SecureOAuthAuthAccount {
@@ -45,11 +47,11 @@ func NewSecureOAuthAuthAccount(authAccount *ent.AuthAccount) *SecureOAuthAuthAcc
 }
 
 type SecureAuthAccount struct {
-	ID         uuid.UUID            `json:"id"`
-	UserID     uuid.UUID            `json:"user_id" validate:"required"`
-	Provider   authaccount.Provider `json:"provider" validate:"required,oneof=local google kakao naver apple"`
-	Email      string               `json:"email" validate:"required,email"`
-	IsVerified bool                 `json:"is_verified" validate:"required"`
+	ID         uuid.UUID               `json:"id"`
+	UserID     uuid.UUID               `json:"user_id" validate:"required"`
+	Provider   providermodels.Provider `json:"provider" validate:"required"`
+	Email      string                  `json:"email" validate:"required,email"`
+	IsVerified bool                    `json:"is_verified" validate:"required"`
 }
 
 func NewSecureAuthAccount(authAccount *ent.AuthAccount) *SecureAuthAccount {
@@ -61,3 +63,63 @@ func NewSecureAuthAccount(authAccount *ent.AuthAccount) *SecureAuthAccount {
 		IsVerified: authAccount.IsVerified,
 	}
 }
+
+type SecureTotpCredential struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	IsConfirmed bool      `json:"is_confirmed"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewSecureTotpCredential(credential *ent.TotpCredential) *SecureTotpCredential {
+	return &SecureTotpCredential{
+		ID:          credential.ID,
+		UserID:      credential.UserID,
+		IsConfirmed: credential.IsConfirmed,
+		Name:        credential.Name,
+		CreatedAt:   credential.CreatedAt,
+	}
+}
+
+type SecureWebauthnCredential struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id" validate:"required"`
+	CredentialID []byte     `json:"credential_id" validate:"required"`
+	PublicKey    []byte     `json:"public_key" validate:"required"`
+	SignCount    uint32     `json:"sign_count"`
+	Transports   []string   `json:"transports"`
+	Name         string     `json:"name"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func NewSecureWebauthnCredential(credential *ent.WebauthnCredential) *SecureWebauthnCredential {
+	return &SecureWebauthnCredential{
+		ID:           credential.ID,
+		UserID:       credential.UserID,
+		CredentialID: credential.CredentialID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		Transports:   credential.Transports,
+		Name:         credential.Name,
+		LastUsedAt:   credential.LastUsedAt,
+		CreatedAt:    credential.CreatedAt,
+	}
+}
+
+type SecureRecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id" validate:"required"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewSecureRecoveryCode(code *ent.RecoveryCode) *SecureRecoveryCode {
+	return &SecureRecoveryCode{
+		ID:        code.ID,
+		UserID:    code.UserID,
+		UsedAt:    code.UsedAt,
+		CreatedAt: code.CreatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent"
+)
+
+// Session is a signed in device of a user, following one refresh token family.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	FamilyID   string    `json:"family_id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func NewSession(session *ent.Session) *Session {
+	return &Session{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		FamilyID:   session.FamilyID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
@@ -0,0 +1,32 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/ent"
+)
+
+// SignupInvite is a single-use code admitting one signup while signup is
+// invite-only. The code itself is only stored hashed.
+type SignupInvite struct {
+	ID        uuid.UUID  `json:"id"`
+	Email     string     `json:"email"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	UsedBy    *uuid.UUID `json:"used_by"`
+}
+
+func NewSignupInvite(invite *ent.SignupInvite) *SignupInvite {
+	return &SignupInvite{
+		ID:        invite.ID,
+		Email:     invite.Email,
+		CreatedBy: invite.CreatedBy,
+		CreatedAt: invite.CreatedAt,
+		ExpiresAt: invite.ExpiresAt,
+		UsedAt:    invite.UsedAt,
+		UsedBy:    invite.UsedBy,
+	}
+}
@@ -0,0 +1,15 @@
+package dbmodels
+
+import "github.com/google/uuid"
+
+type CreateWebauthnCredentialInput struct {
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+	CredentialID   []byte    `json:"credential_id" validate:"required"`
+	PublicKey      []byte    `json:"public_key" validate:"required"`
+	SignCount      uint32    `json:"sign_count"`
+	AAGUID         uuid.UUID `json:"aaguid"`
+	Transports     []string  `json:"transports"`
+	Name           string    `json:"name"`
+	BackupEligible bool      `json:"backup_eligible"`
+	BackupState    bool      `json:"backup_state"`
+}
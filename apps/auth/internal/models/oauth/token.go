@@ -0,0 +1,29 @@
+package oauthmodels
+
+import "time"
+
+// Token is the token set a provider issued for a user.
+type Token struct {
+	AccessToken  string
+	RefreshToken string // Empty if the provider issued none
+	IDToken      string // Empty if the provider issued none
+	TokenType    string
+	Scope        string
+	ExpiresAt    time.Time // Zero if the provider did not say
+}
+
+// NewToken creates a Token expiring expiresIn seconds from now. A non-positive
+// expiresIn leaves the expiry unknown.
+func NewToken(accessToken string, refreshToken string, idToken string, tokenType string, scope string, expiresIn int) *Token {
+	token := &Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		IDToken:      idToken,
+		TokenType:    tokenType,
+		Scope:        scope,
+	}
+	if expiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	}
+	return token
+}
@@ -0,0 +1,20 @@
+package providermodels
+
+// Provider is the name of the identity provider of an auth account.
+//
+// Besides the built-in providers, any name registered for a configured OpenID
+// Connect provider is valid.
+type Provider string
+
+const (
+	ProviderLocal  Provider = "local"
+	ProviderGoogle Provider = "google"
+	ProviderKakao  Provider = "kakao"
+	ProviderNaver  Provider = "naver"
+	ProviderApple  Provider = "apple"
+)
+
+// String implements fmt.Stringer.
+func (p Provider) String() string {
+	return string(p)
+}
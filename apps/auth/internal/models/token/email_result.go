@@ -8,3 +8,14 @@ type EmailVerificationResult struct {
 	Email  string    `json:"email"`
 	Code   string    `json:"code"`
 }
+
+// EmailTokenPurpose is the flow an emailed token is issued for. The token
+// service binds it to the token, so a token only verifies for its own flow.
+type EmailTokenPurpose string
+
+const (
+	EmailTokenPurposeVerifyEmail   EmailTokenPurpose = "verify_email"
+	EmailTokenPurposePasswordReset EmailTokenPurpose = "password_reset"
+	EmailTokenPurposeMagicLink     EmailTokenPurpose = "magic_link"
+	EmailTokenPurposeEmailChange   EmailTokenPurpose = "email_change"
+)
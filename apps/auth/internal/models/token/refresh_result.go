@@ -0,0 +1,10 @@
+package tokenmodels
+
+import "github.com/google/uuid"
+
+type RefreshTokenResult struct {
+	Valid    bool      `json:"valid"`
+	UserID   uuid.UUID `json:"user_id"`
+	TokenID  string    `json:"token_id"`  // Empty for tokens issued before rotation
+	FamilyID string    `json:"family_id"` // Empty for tokens issued before rotation
+}
@@ -0,0 +1,69 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rangePrefixLength is the number of hex characters of a SHA-1 hash used to
+// name a range file.
+const rangePrefixLength = 5
+
+// BreachedList looks up passwords in a local copy of a breached password
+// hash list, split into SHA-1 prefix ranges like the Have I Been Pwned range API.
+//
+// The directory holds one file per prefix, named "<PREFIX>.txt", with lines
+// of "<SUFFIX>:<COUNT>" where PREFIX and SUFFIX together are the upper case
+// SHA-1 hash of a password. Missing range files are treated as empty, so a
+// partial list can be used.
+type BreachedList struct {
+	dir string
+}
+
+// Contains reports whether the password is in the list.
+func (b *BreachedList) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:rangePrefixLength], hash[rangePrefixLength:]
+
+	file, err := os.Open(filepath.Join(b.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(entry, suffix) {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// NewBreachedList creates a BreachedList reading range files from dir.
+func NewBreachedList(dir string) (*BreachedList, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.New("breached password list is not a directory: " + dir)
+	}
+	return &BreachedList{
+		dir: dir,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package passwordpolicy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+)
+
+// Reasons reported for a password that does not meet the policy.
+const (
+	ReasonTooShort         = "too_short"
+	ReasonMissingLowercase = "missing_lowercase"
+	ReasonMissingUppercase = "missing_uppercase"
+	ReasonMissingDigit     = "missing_digit"
+	ReasonMissingSymbol    = "missing_symbol"
+	ReasonContainsEmail    = "contains_email"
+	ReasonTooWeak          = "too_weak"
+	ReasonBreached         = "breached"
+)
+
+// minEmailPartLength is the shortest email local-part that is looked for in a
+// password. Shorter ones would reject too many unrelated passwords.
+const minEmailPartLength = 3
+
+// Rules configures the checks of a Policy.
+type Rules struct {
+	MinLength     int
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	MinStrength   Strength
+}
+
+// Violation is a single reason a password was rejected.
+type Violation struct {
+	Field   string `json:"field"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// PolicyError lists every rule a password broke.
+// It is wrapped in an AppError with the errcode.ErrInvalidInput code.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		reasons = append(reasons, violation.Field+": "+violation.Reason)
+	}
+	return "password does not meet policy: " + strings.Join(reasons, ", ")
+}
+
+// Policy checks new passwords against the configured rules and an optional
+// list of breached passwords.
+type Policy struct {
+	rules    Rules
+	breached *BreachedList
+}
+
+// Validate checks a new password for the account with the given email.
+// field is the request field the password came from and is reported with
+// each violation.
+//
+// Returns:
+//   - An ErrInvalidInput error wrapping a *PolicyError if the password breaks any rule.
+//   - An ErrInternalFailure error if the breached password list cannot be read.
+func (p *Policy) Validate(field string, password string, email string) error {
+	var violations []Violation
+	violate := func(reason string, message string) {
+		violations = append(violations, Violation{Field: field, Reason: reason, Message: message})
+	}
+
+	if utf8.RuneCountInString(password) < p.rules.MinLength {
+		violate(ReasonTooShort, "Password is too short")
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	if p.rules.RequireLower && !hasLower {
+		violate(ReasonMissingLowercase, "Password must contain a lowercase letter")
+	}
+	if p.rules.RequireUpper && !hasUpper {
+		violate(ReasonMissingUppercase, "Password must contain an uppercase letter")
+	}
+	if p.rules.RequireDigit && !hasDigit {
+		violate(ReasonMissingDigit, "Password must contain a digit")
+	}
+	if p.rules.RequireSymbol && !hasSymbol {
+		violate(ReasonMissingSymbol, "Password must contain a symbol")
+	}
+
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+	if len(localPart) >= minEmailPartLength && strings.Contains(strings.ToLower(password), localPart) {
+		violate(ReasonContainsEmail, "Password must not contain your email address")
+	}
+
+	if EstimateStrength(password) < p.rules.MinStrength {
+		violate(ReasonTooWeak, "Password is too easy to guess")
+	}
+
+	if p.breached != nil {
+		breached, err := p.breached.Contains(password)
+		if err != nil {
+			return errors.New(err.Error(), "Failed to check breached passwords", errcode.ErrInternalFailure)
+		}
+		if breached {
+			violate(ReasonBreached, "Password has appeared in a data breach")
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.Upgrade(&PolicyError{Violations: violations}, "Password Does Not Meet Policy", errcode.ErrInvalidInput)
+	}
+	return nil
+}
+
+// NewPolicy creates a new instance of Policy.
+//
+// breached may be nil to skip the breached password check.
+func NewPolicy(rules Rules, breached *BreachedList) *Policy {
+	return &Policy{
+		rules:    rules,
+		breached: breached,
+	}
+}
@@ -0,0 +1,94 @@
+package passwordpolicy
+
+import (
+	"math"
+	"unicode"
+)
+
+// Strength is a rough estimate of how hard a password is to guess.
+type Strength int
+
+const (
+	StrengthVeryWeak Strength = iota
+	StrengthWeak
+	StrengthFair
+	StrengthStrong
+	StrengthVeryStrong
+)
+
+// Sizes of the character pools a password draws from.
+const (
+	lowerPoolSize  = 26
+	upperPoolSize  = 26
+	digitPoolSize  = 10
+	symbolPoolSize = 33
+	otherPoolSize  = 100
+)
+
+// patternWeight is how much a character continuing a repeat or a sequence,
+// like "aaa" or "abc", counts compared to a random one.
+const patternWeight = 0.25
+
+// EstimateStrength estimates the strength of a password from the character
+// pools it uses and its length. Characters repeating or continuing a sequence
+// with the previous one count for less.
+func EstimateStrength(password string) Strength {
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+	var length float64
+	prev := rune(-1)
+	for _, r := range password {
+		switch {
+		case r > unicode.MaxASCII:
+			hasOther = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+
+		if prev >= 0 && (r == prev || r == prev+1 || r == prev-1) {
+			length += patternWeight
+		} else {
+			length++
+		}
+		prev = r
+	}
+
+	pool := 0
+	if hasLower {
+		pool += lowerPoolSize
+	}
+	if hasUpper {
+		pool += upperPoolSize
+	}
+	if hasDigit {
+		pool += digitPoolSize
+	}
+	if hasSymbol {
+		pool += symbolPoolSize
+	}
+	if hasOther {
+		pool += otherPoolSize
+	}
+	if pool == 0 {
+		return StrengthVeryWeak
+	}
+
+	bits := length * math.Log2(float64(pool))
+	switch {
+	case bits < 30:
+		return StrengthVeryWeak
+	case bits < 45:
+		return StrengthWeak
+	case bits < 60:
+		return StrengthFair
+	case bits < 75:
+		return StrengthStrong
+	default:
+		return StrengthVeryStrong
+	}
+}
@@ -0,0 +1,124 @@
+package autheventrepo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	autheventv1 "github.com/mandacode-com/accounts-proto/go/auth/event/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AuthEventEmitter struct {
+	writer MessageWriter
+}
+
+// NewAuthEventEmitter creates a new AuthEventEmitter with the provided Kafka writer.
+func NewAuthEventEmitter(writer MessageWriter) *AuthEventEmitter {
+	return &AuthEventEmitter{
+		writer: writer,
+	}
+}
+
+// emit marshals the event and writes it to Kafka keyed by user ID.
+func (e *AuthEventEmitter) emit(ctx context.Context, event *autheventv1.AuthEvent) error {
+	event.EventTime = timestamppb.Now()
+
+	// Marshal the event to protobuf bytes
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to marshal auth event", errcode.ErrInternalFailure)
+	}
+
+	// Create a message to send to Kafka
+	message := kafka.Message{
+		Key:   []byte(event.UserId),
+		Value: data,
+	}
+
+	if err := e.writer.WriteMessages(ctx, message); err != nil {
+		return errors.New(err.Error(), "Failed to write auth event to Kafka", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// factorIDString returns the ID of a factor, or an empty string for factors without one.
+func factorIDString(factorID uuid.UUID) string {
+	if factorID == uuid.Nil {
+		return ""
+	}
+	return factorID.String()
+}
+
+// EmitFactorRenamedEvent emits an event for an MFA factor that got a new name.
+func (e *AuthEventEmitter) EmitFactorRenamedEvent(ctx context.Context, userID uuid.UUID, factorType autheventv1.FactorType, factorID uuid.UUID) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:     userID.String(),
+		EventType:  autheventv1.EventType_MFA_FACTOR_RENAMED,
+		FactorType: factorType,
+		FactorId:   factorID.String(),
+	})
+}
+
+// EmitFactorRemovedEvent emits an event for an MFA factor removed by the user.
+// factorID is uuid.Nil for factors without an ID, such as recovery codes.
+func (e *AuthEventEmitter) EmitFactorRemovedEvent(ctx context.Context, userID uuid.UUID, factorType autheventv1.FactorType, factorID uuid.UUID) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:     userID.String(),
+		EventType:  autheventv1.EventType_MFA_FACTOR_REMOVED,
+		FactorType: factorType,
+		FactorId:   factorIDString(factorID),
+	})
+}
+
+// EmitRecoveryCodesGeneratedEvent emits an event for a newly generated set of recovery codes.
+func (e *AuthEventEmitter) EmitRecoveryCodesGeneratedEvent(ctx context.Context, userID uuid.UUID) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:     userID.String(),
+		EventType:  autheventv1.EventType_MFA_RECOVERY_CODES_GENERATED,
+		FactorType: autheventv1.FactorType_RECOVERY_CODE,
+	})
+}
+
+// EmitRecoveryCodeUsedEvent emits an event for a recovery code used to pass an MFA challenge.
+func (e *AuthEventEmitter) EmitRecoveryCodeUsedEvent(ctx context.Context, userID uuid.UUID) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:     userID.String(),
+		EventType:  autheventv1.EventType_MFA_RECOVERY_CODE_USED,
+		FactorType: autheventv1.FactorType_RECOVERY_CODE,
+	})
+}
+
+// EmitAccountLinkedEvent emits an event for a provider identity linked
+// automatically to an existing user at login.
+func (e *AuthEventEmitter) EmitAccountLinkedEvent(ctx context.Context, userID uuid.UUID, provider string) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:    userID.String(),
+		EventType: autheventv1.EventType_OAUTH_ACCOUNT_LINKED,
+		Provider:  provider,
+	})
+}
+
+// EmitProviderTokenFetchedEvent emits an event for a provider token of the
+// user handed to an internal service, named by requester.
+func (e *AuthEventEmitter) EmitProviderTokenFetchedEvent(ctx context.Context, userID uuid.UUID, provider string, requester string) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:    userID.String(),
+		EventType: autheventv1.EventType_PROVIDER_TOKEN_FETCHED,
+		Provider:  provider,
+		Requester: requester,
+	})
+}
+
+// EmitRefreshTokenReusedEvent emits an event for a rotated refresh token
+// presented again, after which the whole token family was revoked.
+func (e *AuthEventEmitter) EmitRefreshTokenReusedEvent(ctx context.Context, userID uuid.UUID, familyID string) error {
+	return e.emit(ctx, &autheventv1.AuthEvent{
+		UserId:        userID.String(),
+		EventType:     autheventv1.EventType_REFRESH_TOKEN_REUSED,
+		TokenFamilyId: familyID,
+	})
+}
@@ -11,6 +11,23 @@ import (
 	"mandacode.com/accounts/auth/internal/util"
 )
 
+// consumeCodeScript deletes the code only when it was issued for the given
+// user, so a code can be redeemed by exactly one caller.
+//
+// Returns 1 if the code was consumed, 0 if it belongs to another user and -1
+// if no code exists.
+var consumeCodeScript = redis.NewScript(`
+local stored = redis.call("GET", KEYS[1])
+if not stored then
+	return -1
+end
+if stored ~= ARGV[1] then
+	return 0
+end
+redis.call("DEL", KEYS[1])
+return 1
+`)
+
 type CodeManager struct {
 	codeGen   *util.RandomGenerator
 	codeTTL   time.Duration
@@ -35,7 +52,7 @@ func (l *CodeManager) IssueCode(ctx context.Context, userID uuid.UUID) (string,
 
 	key := l.prefix + code
 
-	err = l.codeStore.Set(ctx, key, userID, l.codeTTL).Err()
+	err = l.codeStore.Set(ctx, key, userID.String(), l.codeTTL).Err()
 	if err != nil {
 		return "", err
 	}
@@ -55,25 +72,58 @@ func (l *CodeManager) IssueCode(ctx context.Context, userID uuid.UUID) (string,
 //   - An error if the validation fails.
 func (l *CodeManager) ValidateCode(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
 	key := l.prefix + code
-	storedUserID, err := l.codeStore.Get(ctx, key).Result()
+	res, err := consumeCodeScript.Run(ctx, l.codeStore, []string{key}, userID.String()).Int()
 	if err != nil {
-		if err == redis.Nil {
-			return false, nil // Code does not exist
-		}
-		return false, errors.New(err.Error(), "Failed to get login code from store", errcode.ErrInternalFailure)
+		return false, errors.New(err.Error(), "Failed to consume login code from store", errcode.ErrInternalFailure)
+	}
+
+	if res != 1 {
+		return false, nil // Code does not exist or does not match user ID
 	}
 
-	if storedUserID != userID.String() {
-		return false, nil // Code exists but does not match user ID
+	return true, nil // Code is valid and consumed
+}
+
+// GetUserID returns the user ID the code was issued for without consuming the code.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - code: The code to look up.
+//
+// Returns:
+//   - The user ID the code was issued for.
+//   - A boolean indicating whether the code exists.
+//   - An error if the lookup fails.
+func (l *CodeManager) GetUserID(ctx context.Context, code string) (uuid.UUID, bool, error) {
+	storedUserID, err := l.codeStore.Get(ctx, l.prefix+code).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return uuid.Nil, false, nil // Code does not exist
+		}
+		return uuid.Nil, false, errors.New(err.Error(), "Failed to get code from store", errcode.ErrInternalFailure)
 	}
 
-	// Delete the code after successful validation
-	err = l.codeStore.Del(ctx, code).Err()
+	userID, err := uuid.Parse(storedUserID)
 	if err != nil {
-		return false, errors.New(err.Error(), "Failed to delete login code from store", errcode.ErrInternalFailure)
+		return uuid.Nil, false, errors.New(err.Error(), "Invalid user ID in code store", errcode.ErrInternalFailure)
 	}
 
-	return true, nil // Code is valid and deleted
+	return userID, true, nil
+}
+
+// RevokeCode deletes the code so that it can no longer be validated.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - code: The code to revoke.
+//
+// Returns:
+//   - An error if the code could not be deleted.
+func (l *CodeManager) RevokeCode(ctx context.Context, code string) error {
+	if err := l.codeStore.Del(ctx, l.prefix+code).Err(); err != nil {
+		return errors.New(err.Error(), "Failed to delete code from store", errcode.ErrInternalFailure)
+	}
+	return nil
 }
 
 func NewCodeManager(codeGen *util.RandomGenerator, codeTTL time.Duration, codeStore *redis.Client, prefix string) *CodeManager {
@@ -0,0 +1,64 @@
+package coderepo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cooldown lets a mail be sent to an email once per duration.
+//
+// Emails are stored as an HMAC, so unknown emails can be claimed as well and
+// the cooldown does not reveal whether an account exists.
+type Cooldown struct {
+	store    *redis.Client
+	prefix   string
+	hashKey  []byte
+	duration time.Duration
+}
+
+// Claim starts the cooldown of the email if it is not running.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - email: The email a mail is about to be sent to.
+//
+// Returns:
+//   - The time left before a mail can be sent again, or zero if it was claimed.
+//   - An error if the cooldown cannot be read or started.
+func (c *Cooldown) Claim(ctx context.Context, email string) (time.Duration, error) {
+	mac := hmac.New(sha256.New, c.hashKey)
+	mac.Write([]byte(strings.ToLower(email)))
+	key := c.prefix + hex.EncodeToString(mac.Sum(nil))
+
+	claimed, err := c.store.SetNX(ctx, key, 1, c.duration).Result()
+	if err != nil {
+		return 0, errors.New(err.Error(), "Failed to start mail cooldown", errcode.ErrInternalFailure)
+	}
+	if claimed {
+		return 0, nil
+	}
+	left, err := c.store.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, errors.New(err.Error(), "Failed to read mail cooldown", errcode.ErrInternalFailure)
+	}
+	// The cooldown may have ended in between
+	return max(left, time.Millisecond), nil
+}
+
+// NewCooldown creates a new instance of Cooldown.
+func NewCooldown(store *redis.Client, prefix string, hashKey string, duration time.Duration) *Cooldown {
+	return &Cooldown{
+		store:    store,
+		prefix:   prefix,
+		hashKey:  []byte(hashKey),
+		duration: duration,
+	}
+}
@@ -0,0 +1,82 @@
+package coderepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// LoginAttempt is an OAuth web login waiting for the provider callback.
+type LoginAttempt struct {
+	Provider     providermodels.Provider `json:"provider"`
+	Nonce        string                  `json:"nonce"`
+	CodeVerifier string                  `json:"code_verifier"`
+	ReturnTo     string                  `json:"return_to,omitempty"`
+	InviteCode   string                  `json:"invite_code,omitempty"` // Admits the signup of a new user while signup is invite-only
+}
+
+// LoginAttemptManager stores login attempts under their random state until
+// the callback consumes them.
+type LoginAttemptManager struct {
+	codeGen    *util.RandomGenerator
+	attemptTTL time.Duration
+	store      *redis.Client
+	prefix     string
+}
+
+// IssueAttempt stores the login attempt and returns the state referring to it.
+func (l *LoginAttemptManager) IssueAttempt(ctx context.Context, attempt *LoginAttempt) (string, error) {
+	state, err := l.codeGen.GenerateSecureRandomCode()
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to generate state", errcode.ErrInternalFailure)
+	}
+
+	data, err := json.Marshal(attempt)
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to marshal login attempt", errcode.ErrInternalFailure)
+	}
+	if err := l.store.Set(ctx, l.prefix+state, data, l.attemptTTL).Err(); err != nil {
+		return "", errors.New(err.Error(), "Failed to store login attempt", errcode.ErrInternalFailure)
+	}
+
+	return state, nil
+}
+
+// ConsumeAttempt deletes the login attempt of the state and returns it, so
+// that a state is accepted only once.
+//
+// Returns:
+//   - The login attempt.
+//   - A boolean indicating whether the state exists.
+//   - An error if the lookup fails.
+func (l *LoginAttemptManager) ConsumeAttempt(ctx context.Context, state string) (*LoginAttempt, bool, error) {
+	data, err := l.store.GetDel(ctx, l.prefix+state).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil // State does not exist
+		}
+		return nil, false, errors.New(err.Error(), "Failed to get login attempt", errcode.ErrInternalFailure)
+	}
+
+	var attempt LoginAttempt
+	if err := json.Unmarshal(data, &attempt); err != nil {
+		return nil, false, errors.New(err.Error(), "Invalid login attempt in store", errcode.ErrInternalFailure)
+	}
+	return &attempt, true, nil
+}
+
+// NewLoginAttemptManager creates a new instance of LoginAttemptManager.
+func NewLoginAttemptManager(codeGen *util.RandomGenerator, attemptTTL time.Duration, store *redis.Client, prefix string) *LoginAttemptManager {
+	return &LoginAttemptManager{
+		codeGen:    codeGen,
+		attemptTTL: attemptTTL,
+		store:      store,
+		prefix:     prefix,
+	}
+}
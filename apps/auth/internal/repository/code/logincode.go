@@ -0,0 +1,116 @@
+package coderepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// consumeLoginCodeScript deletes the login code only when it was issued for
+// the given user, and returns the request information stored with it.
+//
+// Returns {1, info} if the code was consumed, {0} if it belongs to another
+// user and {-1} if no code exists.
+var consumeLoginCodeScript = redis.NewScript(`
+local userID = redis.call("HGET", KEYS[1], "user_id")
+if not userID then
+	return {-1}
+end
+if userID ~= ARGV[1] then
+	return {0}
+end
+local info = redis.call("HGET", KEYS[1], "info")
+redis.call("DEL", KEYS[1])
+return {1, info}
+`)
+
+// LoginCodeManager issues the login codes a client hands to its backend to
+// obtain tokens. Each code keeps the request information of the client that
+// signed in, so that the session started when the backend redeems it
+// describes that client rather than the backend.
+type LoginCodeManager struct {
+	codeGen   *util.RandomGenerator
+	codeTTL   time.Duration
+	codeStore *redis.Client
+	prefix    string
+}
+
+// IssueCode issues a new login code for the given user ID, storing the
+// request information of ctx with it.
+//
+// Parameters:
+//   - ctx: The context for the operation, carrying the request information.
+//   - userID: The unique identifier of the user.
+//
+// Returns:
+//   - A string representing the issued login code.
+//   - An error if the code could not be issued.
+func (l *LoginCodeManager) IssueCode(ctx context.Context, userID uuid.UUID) (string, error) {
+	code, err := l.codeGen.GenerateSecureRandomCode()
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to generate login code", errcode.ErrInternalFailure)
+	}
+
+	info, err := json.Marshal(reqmodels.RequestInfoFrom(ctx))
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to marshal request info", errcode.ErrInternalFailure)
+	}
+
+	key := l.prefix + code
+	_, err = l.codeStore.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "user_id", userID.String(), "info", info)
+		pipe.Expire(ctx, key, l.codeTTL)
+		return nil
+	})
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to store login code", errcode.ErrInternalFailure)
+	}
+
+	return code, nil
+}
+
+// ConsumeCode validates the provided login code for the given user ID and
+// consumes it.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - userID: The unique identifier of the user.
+//   - code: The login code to validate.
+//
+// Returns:
+//   - The request information of the client the code was issued to.
+//   - A boolean indicating whether the code was valid.
+//   - An error if the validation fails.
+func (l *LoginCodeManager) ConsumeCode(ctx context.Context, userID uuid.UUID, code string) (reqmodels.RequestInfo, bool, error) {
+	res, err := consumeLoginCodeScript.Run(ctx, l.codeStore, []string{l.prefix + code}, userID.String()).Slice()
+	if err != nil {
+		return reqmodels.RequestInfo{}, false, errors.New(err.Error(), "Failed to consume login code from store", errcode.ErrInternalFailure)
+	}
+	if status, _ := res[0].(int64); status != 1 || len(res) < 2 {
+		return reqmodels.RequestInfo{}, false, nil // Code does not exist or does not match user ID
+	}
+
+	var info reqmodels.RequestInfo
+	data, _ := res[1].(string)
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		return reqmodels.RequestInfo{}, false, errors.New(err.Error(), "Invalid request info in code store", errcode.ErrInternalFailure)
+	}
+	return info, true, nil
+}
+
+// NewLoginCodeManager creates a new instance of LoginCodeManager.
+func NewLoginCodeManager(codeGen *util.RandomGenerator, codeTTL time.Duration, codeStore *redis.Client, prefix string) *LoginCodeManager {
+	return &LoginCodeManager{
+		codeGen:   codeGen,
+		codeTTL:   codeTTL,
+		codeStore: codeStore,
+		prefix:    prefix,
+	}
+}
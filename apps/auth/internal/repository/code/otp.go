@@ -0,0 +1,138 @@
+package coderepo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+)
+
+// otpSpace is the number of distinct 6-digit codes.
+const otpSpace = 1_000_000
+
+// validateOTPScript counts an attempt and consumes the code when it matches or
+// when the attempts run out. The attempts are kept in their own key, so that
+// issuing a new code does not reset them.
+//
+// Returns 1 if the code matches, 0 if it does not and -1 if no code exists.
+var validateOTPScript = redis.NewScript(`
+local max = tonumber(ARGV[2])
+local attempts = tonumber(redis.call("GET", KEYS[2]) or "0")
+if attempts >= max then
+	redis.call("DEL", KEYS[1])
+	return 0
+end
+local hash = redis.call("GET", KEYS[1])
+if not hash then
+	return -1
+end
+attempts = redis.call("INCR", KEYS[2])
+if attempts == 1 then
+	redis.call("PEXPIRE", KEYS[2], ARGV[3])
+end
+if hash == ARGV[1] then
+	redis.call("DEL", KEYS[1], KEYS[2])
+	return 1
+end
+if attempts >= max then
+	redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// OTPManager issues short numeric one-time passcodes, one per user at a time.
+//
+// Codes are stored as an HMAC. Attempts are counted per user for codeTTL from
+// the first wrong guess, across resent codes, so the code of a user is
+// consumed after maxAttempts wrong guesses whatever the number of codes sent.
+// Codes can be sent to an email once per resend cooldown.
+type OTPManager struct {
+	store       *redis.Client
+	prefix      string
+	codeTTL     time.Duration
+	hashKey     []byte
+	maxAttempts int
+	resend      *Cooldown
+}
+
+func (o *OTPManager) key(userID uuid.UUID) string {
+	return o.prefix + userID.String()
+}
+
+func (o *OTPManager) attemptsKey(userID uuid.UUID) string {
+	return o.prefix + "attempts:" + userID.String()
+}
+
+// hash binds the code to the user so equal codes of different users differ.
+func (o *OTPManager) hash(userID uuid.UUID, code string) string {
+	mac := hmac.New(sha256.New, o.hashKey)
+	mac.Write([]byte(userID.String() + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ClaimResend starts the resend cooldown of the email if it is not running,
+// see Cooldown.Claim.
+func (o *OTPManager) ClaimResend(ctx context.Context, email string) (time.Duration, error) {
+	return o.resend.Claim(ctx, email)
+}
+
+// IssueOTP issues a new 6-digit code for the user, replacing any earlier one.
+//
+// No code is issued while the attempts of the user are used up, since it could
+// not be validated.
+func (o *OTPManager) IssueOTP(ctx context.Context, userID uuid.UUID) (string, error) {
+	attempts, err := o.store.Get(ctx, o.attemptsKey(userID)).Int()
+	if err != nil && err != redis.Nil {
+		return "", errors.New(err.Error(), "Failed to read one-time code attempts", errcode.ErrInternalFailure)
+	}
+	if attempts >= o.maxAttempts {
+		return "", errors.New("one-time code attempts used up", "Too Many Attempts", errcode.ErrTooManyRequests)
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(otpSpace))
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to generate one-time code", errcode.ErrInternalFailure)
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	if err := o.store.Set(ctx, o.key(userID), o.hash(userID, code), o.codeTTL).Err(); err != nil {
+		return "", errors.New(err.Error(), "Failed to store one-time code", errcode.ErrInternalFailure)
+	}
+
+	return code, nil
+}
+
+// ValidateOTP checks the code of the user and consumes it if it matches.
+//
+// Returns:
+//   - A boolean indicating whether the code is valid.
+//   - An error if the validation fails.
+func (o *OTPManager) ValidateOTP(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	keys := []string{o.key(userID), o.attemptsKey(userID)}
+	result, err := validateOTPScript.Run(ctx, o.store, keys, o.hash(userID, code), o.maxAttempts, o.codeTTL.Milliseconds()).Int()
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to validate one-time code", errcode.ErrInternalFailure)
+	}
+	return result == 1, nil
+}
+
+// NewOTPManager creates a new instance of OTPManager.
+func NewOTPManager(store *redis.Client, prefix string, codeTTL time.Duration, hashKey string, maxAttempts int, resendCooldown time.Duration) *OTPManager {
+	return &OTPManager{
+		store:       store,
+		prefix:      prefix,
+		codeTTL:     codeTTL,
+		hashKey:     []byte(hashKey),
+		maxAttempts: maxAttempts,
+		resend:      NewCooldown(store, prefix+"resend:", hashKey, resendCooldown),
+	}
+}
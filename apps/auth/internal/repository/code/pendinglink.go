@@ -0,0 +1,92 @@
+package coderepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// PendingLink is a provider identity waiting to be linked to an existing user.
+type PendingLink struct {
+	UserID     uuid.UUID               `json:"user_id"`
+	Provider   providermodels.Provider `json:"provider"`
+	ProviderID string                  `json:"provider_id"`
+	Email      string                  `json:"email"`
+	IsVerified bool                    `json:"is_verified"`
+}
+
+// PendingLinkManager stores pending links under random tokens until the user
+// confirms them.
+type PendingLinkManager struct {
+	codeGen *util.RandomGenerator
+	linkTTL time.Duration
+	store   *redis.Client
+	prefix  string
+}
+
+// IssueLink stores the pending link and returns the token referring to it.
+func (p *PendingLinkManager) IssueLink(ctx context.Context, link *PendingLink) (string, error) {
+	token, err := p.codeGen.GenerateSecureRandomCode()
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to generate link token", errcode.ErrInternalFailure)
+	}
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		return "", errors.New(err.Error(), "Failed to marshal pending link", errcode.ErrInternalFailure)
+	}
+	if err := p.store.Set(ctx, p.prefix+token, data, p.linkTTL).Err(); err != nil {
+		return "", errors.New(err.Error(), "Failed to store pending link", errcode.ErrInternalFailure)
+	}
+
+	return token, nil
+}
+
+// GetLink returns the pending link of the token without consuming it.
+//
+// Returns:
+//   - The pending link.
+//   - A boolean indicating whether the token exists.
+//   - An error if the lookup fails.
+func (p *PendingLinkManager) GetLink(ctx context.Context, token string) (*PendingLink, bool, error) {
+	data, err := p.store.Get(ctx, p.prefix+token).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil // Token does not exist
+		}
+		return nil, false, errors.New(err.Error(), "Failed to get pending link", errcode.ErrInternalFailure)
+	}
+
+	var link PendingLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		return nil, false, errors.New(err.Error(), "Invalid pending link in store", errcode.ErrInternalFailure)
+	}
+	return &link, true, nil
+}
+
+// ConsumeLink deletes the pending link of the token and reports whether it
+// still existed, so that a link is completed only once.
+func (p *PendingLinkManager) ConsumeLink(ctx context.Context, token string) (bool, error) {
+	deleted, err := p.store.Del(ctx, p.prefix+token).Result()
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to delete pending link", errcode.ErrInternalFailure)
+	}
+	return deleted == 1, nil
+}
+
+// NewPendingLinkManager creates a new instance of PendingLinkManager.
+func NewPendingLinkManager(codeGen *util.RandomGenerator, linkTTL time.Duration, store *redis.Client, prefix string) *PendingLinkManager {
+	return &PendingLinkManager{
+		codeGen: codeGen,
+		linkTTL: linkTTL,
+		store:   store,
+		prefix:  prefix,
+	}
+}
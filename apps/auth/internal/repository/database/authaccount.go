@@ -6,19 +6,23 @@ import (
 	"github.com/google/uuid"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
-	"golang.org/x/crypto/bcrypt"
+	"go.uber.org/zap"
 	"mandacode.com/accounts/auth/ent"
 	"mandacode.com/accounts/auth/ent/authaccount"
 	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	"mandacode.com/accounts/auth/internal/util"
 )
 
 type AuthAccountRepository struct {
 	client *ent.Client
+	hasher *util.PasswordHasher
+	logger *zap.Logger
 }
 
 // CreateLocalAuthAccount creates a new local authentication account.
 func (a *AuthAccountRepository) CreateLocalAuthAccount(ctx context.Context, account *dbmodels.CreateLocalAuthAccountInput) (*dbmodels.SecureLocalAuthAccount, error) {
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(account.Password), bcrypt.DefaultCost)
+	passwordHash, err := a.hasher.Hash(account.Password)
 	if err != nil {
 		return nil, errors.New(err.Error(), "Failed to generate password hash", errcode.ErrInternalFailure)
 	}
@@ -29,7 +33,7 @@ func (a *AuthAccountRepository) CreateLocalAuthAccount(ctx context.Context, acco
 		SetProvider("local").
 		SetEmail(account.Email).
 		SetIsVerified(account.IsVerified).
-		SetPasswordHash(string(passwordHash))
+		SetPasswordHash(passwordHash)
 
 	authAccount, err := create.Save(ctx)
 	if err != nil {
@@ -89,7 +93,7 @@ func (a *AuthAccountRepository) GetLocalAuthAccountByUserID(ctx context.Context,
 	authAccount, err := a.client.AuthAccount.Query().
 		Where(authaccount.And(
 			authaccount.UserID(userID),
-			authaccount.ProviderEQ(authaccount.ProviderLocal),
+			authaccount.ProviderEQ(providermodels.ProviderLocal),
 		)).
 		Only(ctx)
 	if err != nil {
@@ -107,7 +111,7 @@ func (a *AuthAccountRepository) GetLocalAuthAccountByEmail(ctx context.Context,
 	authAccount, err := a.client.AuthAccount.Query().
 		Where(authaccount.And(
 			authaccount.Email(email),
-			authaccount.ProviderEQ(authaccount.ProviderLocal),
+			authaccount.ProviderEQ(providermodels.ProviderLocal),
 		)).
 		Only(ctx)
 	if err != nil {
@@ -121,7 +125,7 @@ func (a *AuthAccountRepository) GetLocalAuthAccountByEmail(ctx context.Context,
 }
 
 // GetOAuthAuthAccountByUserID retrieves an OAuth authentication account by user ID.
-func (a *AuthAccountRepository) GetOAuthAuthAccountByUserID(ctx context.Context, userID uuid.UUID, provider authaccount.Provider) (*dbmodels.SecureOAuthAuthAccount, error) {
+func (a *AuthAccountRepository) GetOAuthAuthAccountByUserID(ctx context.Context, userID uuid.UUID, provider providermodels.Provider) (*dbmodels.SecureOAuthAuthAccount, error) {
 	authAccount, err := a.client.AuthAccount.Query().
 		Where(authaccount.And(
 			authaccount.UserID(userID),
@@ -139,8 +143,8 @@ func (a *AuthAccountRepository) GetOAuthAuthAccountByUserID(ctx context.Context,
 }
 
 // GetOAuthAccountByProviderAndProviderID retrieves an OAuth authentication account by provider and provider ID.
-func (a *AuthAccountRepository) GetOAuthAccountByProviderAndProviderID(ctx context.Context, provider authaccount.Provider, providerID string) (*dbmodels.SecureOAuthAuthAccount, error) {
-	if provider == authaccount.ProviderLocal {
+func (a *AuthAccountRepository) GetOAuthAccountByProviderAndProviderID(ctx context.Context, provider providermodels.Provider, providerID string) (*dbmodels.SecureOAuthAuthAccount, error) {
+	if provider == providermodels.ProviderLocal {
 		return nil, errors.New("Invalid provider", "Provider cannot be 'local' for OAuth accounts", errcode.ErrInvalidInput)
 	}
 
@@ -163,7 +167,7 @@ func (a *AuthAccountRepository) GetOAuthAccountByProviderAndProviderID(ctx conte
 
 // SetPasswordHash sets the password hash for a local authentication account.
 func (a *AuthAccountRepository) SetPasswordHash(ctx context.Context, userID uuid.UUID, password string) (*dbmodels.SecureLocalAuthAccount, error) {
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	passwordHash, err := a.hasher.Hash(password)
 	if err != nil {
 		return nil, errors.New(err.Error(), "Failed to generate password hash", errcode.ErrInternalFailure)
 	}
@@ -171,7 +175,7 @@ func (a *AuthAccountRepository) SetPasswordHash(ctx context.Context, userID uuid
 	localAccount, err := a.client.AuthAccount.Query().
 		Where(authaccount.And(
 			authaccount.UserID(userID),
-			authaccount.ProviderEQ(authaccount.ProviderLocal),
+			authaccount.ProviderEQ(providermodels.ProviderLocal),
 		)).
 		Only(ctx)
 	if err != nil {
@@ -182,7 +186,7 @@ func (a *AuthAccountRepository) SetPasswordHash(ctx context.Context, userID uuid
 	}
 
 	update := localAccount.Update().
-		SetPasswordHash(string(passwordHash))
+		SetPasswordHash(passwordHash)
 	authAccount, err := update.Save(ctx)
 	if err != nil {
 		return nil, errors.New(err.Error(), "Failed to update Local AuthAccount password hash", errcode.ErrInternalFailure)
@@ -191,6 +195,37 @@ func (a *AuthAccountRepository) SetPasswordHash(ctx context.Context, userID uuid
 	return dbmodels.NewSecureLocalAuthAccount(authAccount), nil
 }
 
+// SetLocalEmail changes the email of a local authentication account and marks it as verified.
+//
+// Returns an ErrConflict error if another local account already uses the email.
+func (a *AuthAccountRepository) SetLocalEmail(ctx context.Context, userID uuid.UUID, email string) (*dbmodels.SecureLocalAuthAccount, error) {
+	localAccount, err := a.client.AuthAccount.Query().
+		Where(authaccount.And(
+			authaccount.UserID(userID),
+			authaccount.ProviderEQ(providermodels.ProviderLocal),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("AuthAccount not found", "AuthAccount Not Found", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find Local AuthAccount", errcode.ErrInternalFailure)
+	}
+
+	update := localAccount.Update().
+		SetEmail(email).
+		SetIsVerified(true)
+	authAccount, err := update.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, errors.New("email already in use", "Email Already In Use", errcode.ErrConflict)
+		}
+		return nil, errors.New(err.Error(), "Failed to update Local AuthAccount email", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureLocalAuthAccount(authAccount), nil
+}
+
 // ComparePassword compares the provided password with the stored password hash.
 //
 // Parameters:
@@ -200,13 +235,17 @@ func (a *AuthAccountRepository) SetPasswordHash(ctx context.Context, userID uuid
 //
 // Returns:
 //   - bool: true if the password matches, false otherwise.
-//   - uuid.UUID: The user ID associated with the account.
+//   - uuid.UUID: The user ID associated with the account, also when the
+//     password does not match, or uuid.Nil if there is no such account.
 //   - error: An error if the operation fails, nil otherwise.
+//
+// A matching hash made with an old algorithm or weaker parameters is replaced
+// by a hash with the current ones.
 func (a *AuthAccountRepository) ComparePassword(ctx context.Context, email string, password string) (bool, uuid.UUID, error) {
 	localAccount, err := a.client.AuthAccount.Query().
 		Where(authaccount.And(
 			authaccount.Email(email),
-			authaccount.ProviderEQ(authaccount.ProviderLocal),
+			authaccount.ProviderEQ(providermodels.ProviderLocal),
 		)).
 		Only(ctx)
 	if err != nil {
@@ -216,16 +255,47 @@ func (a *AuthAccountRepository) ComparePassword(ctx context.Context, email strin
 		return false, uuid.Nil, errors.New(err.Error(), "Internal Error", errcode.ErrInternalFailure)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(*localAccount.PasswordHash), []byte(password)); err != nil {
-		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return false, uuid.Nil, nil // Password does not match, return user ID for further processing
-		}
+	passwordHash := *localAccount.PasswordHash
+	matched, err := a.hasher.Verify(passwordHash, password)
+	if err != nil {
 		return false, uuid.Nil, errors.New(err.Error(), "Internal Error", errcode.ErrInternalFailure)
 	}
+	if !matched {
+		return false, localAccount.UserID, nil // Password does not match
+	}
+
+	if a.hasher.NeedsRehash(passwordHash) {
+		// The old hash keeps working, so a failed upgrade is retried on the next login
+		if err := a.rehashPassword(ctx, localAccount.ID, passwordHash, password); err != nil {
+			a.logger.Warn("failed to upgrade password hash", zap.String("user_id", localAccount.UserID.String()), zap.Error(err))
+		}
+	}
 
 	return true, localAccount.UserID, nil // Password matches, return user ID
 }
 
+// rehashPassword replaces the password hash of an account with a hash of the
+// current algorithm, unless the password was changed in the meantime.
+func (a *AuthAccountRepository) rehashPassword(ctx context.Context, id uuid.UUID, oldHash string, password string) error {
+	passwordHash, err := a.hasher.Hash(password)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to generate password hash", errcode.ErrInternalFailure)
+	}
+
+	_, err = a.client.AuthAccount.Update().
+		Where(authaccount.And(
+			authaccount.ID(id),
+			authaccount.PasswordHash(oldHash),
+		)).
+		SetPasswordHash(passwordHash).
+		Save(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to update Local AuthAccount password hash", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
 // DeleteAuthAccountByUserID deletes an authentication account by user ID.
 func (a *AuthAccountRepository) DeleteAuthAccountByUserID(ctx context.Context, userID uuid.UUID) error {
 	_, err := a.client.AuthAccount.Delete().
@@ -257,7 +327,7 @@ func (a *AuthAccountRepository) DeleteAuthAccountByID(ctx context.Context, id uu
 }
 
 // DeleteAuthAccountByUserIDAndProvider deletes an authentication account by user ID and provider.
-func (a *AuthAccountRepository) DeleteAuthAccountByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider authaccount.Provider) error {
+func (a *AuthAccountRepository) DeleteAuthAccountByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider providermodels.Provider) error {
 	_, err := a.client.AuthAccount.Delete().
 		Where(authaccount.And(
 			authaccount.UserID(userID),
@@ -290,7 +360,7 @@ func (a *AuthAccountRepository) SetIsVerifiedByID(ctx context.Context, id uuid.U
 }
 
 // SetIsVerifiedByUserIDAndProvider sets the verification status of an OAuth authentication account by user ID and provider.
-func (a *AuthAccountRepository) SetIsVerifiedByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider authaccount.Provider, isVerified bool) (*dbmodels.SecureAuthAccount, error) {
+func (a *AuthAccountRepository) SetIsVerifiedByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider providermodels.Provider, isVerified bool) (*dbmodels.SecureAuthAccount, error) {
 	authAccount, err := a.client.AuthAccount.Query().
 		Where(authaccount.And(
 			authaccount.UserID(userID),
@@ -316,8 +386,10 @@ func (a *AuthAccountRepository) SetIsVerifiedByUserIDAndProvider(ctx context.Con
 }
 
 // NewAuthAccountRepository creates a new instance of authAccountRepository.
-func NewAuthAccountRepository(client *ent.Client) *AuthAccountRepository {
+func NewAuthAccountRepository(client *ent.Client, hasher *util.PasswordHasher, logger *zap.Logger) *AuthAccountRepository {
 	return &AuthAccountRepository{
 		client: client,
+		hasher: hasher,
+		logger: logger,
 	}
 }
@@ -0,0 +1,152 @@
+package dbrepo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/authactivity"
+	"mandacode.com/accounts/auth/ent/predicate"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+)
+
+// AuthActivityRepository keeps the append-only history of authentication
+// attempts.
+type AuthActivityRepository struct {
+	client *ent.Client
+}
+
+// RecordAuthActivity records an authentication attempt, made by the client
+// described by the request information of ctx.
+//
+// Parameters:
+//   - ctx: The context for the operation, carrying the request information.
+//   - entry: The attempt to record.
+func (a *AuthActivityRepository) RecordAuthActivity(ctx context.Context, entry *activitymodels.Entry) error {
+	info := reqmodels.RequestInfoFrom(ctx)
+	create := a.client.AuthActivity.Create().
+		SetID(uuid.New()).
+		SetMethod(entry.Method).
+		SetProvider(entry.Provider).
+		SetIPAddress(info.IP).
+		SetUserAgent(info.UserAgent).
+		SetOutcome(entry.Outcome).
+		SetFailureReason(entry.FailureReason)
+	if entry.UserID != uuid.Nil {
+		create.SetUserID(entry.UserID)
+	}
+	if err := create.Exec(ctx); err != nil {
+		return errors.New(err.Error(), "Failed to create AuthActivity", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// IsKnownClient reports whether the user already signed in successfully from
+// the client described by the request information of ctx: with the same user
+// agent and from the same network as given by activitymodels.NetworkPrefix.
+// IPv6 addresses must match exactly.
+//
+// A user without any successful sign-in has no client to compare with, and
+// neither does a request without client information; both count as known.
+func (a *AuthActivityRepository) IsKnownClient(ctx context.Context, userID uuid.UUID) (bool, error) {
+	succeeded := authactivity.And(
+		authactivity.UserID(userID),
+		authactivity.OutcomeEQ(activitymodels.OutcomeSuccess),
+	)
+	exists, err := a.client.AuthActivity.Query().
+		Where(succeeded).
+		Exist(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to find AuthActivities by UserID", errcode.ErrInternalFailure)
+	}
+	if !exists {
+		return true, nil
+	}
+
+	info := reqmodels.RequestInfoFrom(ctx)
+	if info.UserAgent == "" && info.IP == "" {
+		return true, nil
+	}
+
+	// A user agent alone is easy to copy, so the network must match as well
+	sameNetwork := authactivity.IPAddress(info.IP)
+	if prefix, ok := activitymodels.NetworkPrefix(info.IP); ok {
+		sameNetwork = authactivity.IPAddressHasPrefix(prefix)
+	}
+	known, err := a.client.AuthActivity.Query().
+		Where(succeeded, authactivity.UserAgent(info.UserAgent), sameNetwork).
+		Exist(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to find AuthActivities by client", errcode.ErrInternalFailure)
+	}
+
+	return known, nil
+}
+
+// ListAuthActivities retrieves the recorded attempts matching the filter,
+// newest first.
+func (a *AuthActivityRepository) ListAuthActivities(ctx context.Context, filter activitymodels.Filter) ([]*dbmodels.AuthActivity, error) {
+	var where []predicate.AuthActivity
+	if filter.UserID != uuid.Nil {
+		where = append(where, authactivity.UserID(filter.UserID))
+	}
+	if filter.IPAddress != "" {
+		where = append(where, authactivity.IPAddress(filter.IPAddress))
+	}
+	if filter.Outcome != "" {
+		where = append(where, authactivity.OutcomeEQ(filter.Outcome))
+	}
+	if !filter.From.IsZero() {
+		where = append(where, authactivity.CreatedAtGTE(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, authactivity.CreatedAtLT(filter.To))
+	}
+	if filter.After != nil {
+		where = append(where, authactivity.Or(
+			authactivity.CreatedAtLT(filter.After.CreatedAt),
+			authactivity.And(
+				authactivity.CreatedAt(filter.After.CreatedAt),
+				authactivity.IDLT(filter.After.ID),
+			),
+		))
+	}
+
+	activities, err := a.client.AuthActivity.Query().
+		Where(where...).
+		Order(ent.Desc(authactivity.FieldCreatedAt), ent.Desc(authactivity.FieldID)).
+		Limit(filter.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to find AuthActivities", errcode.ErrInternalFailure)
+	}
+
+	result := make([]*dbmodels.AuthActivity, 0, len(activities))
+	for _, item := range activities {
+		result = append(result, dbmodels.NewAuthActivity(item))
+	}
+
+	return result, nil
+}
+
+// DeleteAuthActivitiesByUserID deletes the history of a user.
+func (a *AuthActivityRepository) DeleteAuthActivitiesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := a.client.AuthActivity.Delete().
+		Where(authactivity.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete AuthActivities by UserID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// NewAuthActivityRepository creates a new instance of AuthActivityRepository.
+func NewAuthActivityRepository(client *ent.Client) *AuthActivityRepository {
+	return &AuthActivityRepository{client: client}
+}
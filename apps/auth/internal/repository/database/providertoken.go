@@ -0,0 +1,275 @@
+package dbrepo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/providertoken"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+type ProviderTokenRepository struct {
+	client  *ent.Client
+	keyring *util.Keyring
+}
+
+// encryptedToken holds the tokens encrypted with the current key.
+type encryptedToken struct {
+	keyID        string
+	accessToken  string
+	refreshToken *string
+}
+
+// associatedData binds the encrypted tokens to their user and provider, so
+// that they cannot be decrypted once moved to another row.
+func associatedData(userID uuid.UUID, provider providermodels.Provider) string {
+	return userID.String() + "|" + string(provider)
+}
+
+// encrypt encrypts the tokens of the provider for a user with the current key
+// of the keyring.
+func (p *ProviderTokenRepository) encrypt(userID uuid.UUID, provider providermodels.Provider, accessToken string, refreshToken string) (*encryptedToken, error) {
+	if p.keyring == nil {
+		return nil, errors.New("no provider token keys are configured", "Provider Token Storage Disabled", errcode.ErrInternalFailure)
+	}
+	additionalData := associatedData(userID, provider)
+	keyID, encryptedAccessToken, err := p.keyring.Encrypt(accessToken, additionalData)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to encrypt provider token", errcode.ErrInternalFailure)
+	}
+	encrypted := &encryptedToken{keyID: keyID, accessToken: encryptedAccessToken}
+
+	if refreshToken != "" {
+		_, encryptedRefreshToken, err := p.keyring.Encrypt(refreshToken, additionalData)
+		if err != nil {
+			return nil, errors.New(err.Error(), "Failed to encrypt provider token", errcode.ErrInternalFailure)
+		}
+		encrypted.refreshToken = &encryptedRefreshToken
+	}
+	return encrypted, nil
+}
+
+// decrypt decrypts the stored tokens.
+func (p *ProviderTokenRepository) decrypt(stored *ent.ProviderToken) (*dbmodels.ProviderToken, error) {
+	if p.keyring == nil {
+		return nil, errors.New("no provider token keys are configured", "Provider Token Storage Disabled", errcode.ErrInternalFailure)
+	}
+	additionalData := associatedData(stored.UserID, stored.Provider)
+	accessToken, err := p.keyring.Decrypt(stored.KeyID, stored.EncryptedAccessToken, additionalData)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to decrypt provider token", errcode.ErrInternalFailure)
+	}
+
+	token := &dbmodels.ProviderToken{
+		ID:            stored.ID,
+		AuthAccountID: stored.AuthAccountID,
+		UserID:        stored.UserID,
+		Provider:      stored.Provider,
+		KeyID:         stored.KeyID,
+		AccessToken:   accessToken,
+		TokenType:     stored.TokenType,
+		Scope:         stored.Scope,
+		UpdatedAt:     stored.UpdatedAt,
+	}
+	if stored.EncryptedRefreshToken != nil {
+		token.RefreshToken, err = p.keyring.Decrypt(stored.KeyID, *stored.EncryptedRefreshToken, additionalData)
+		if err != nil {
+			return nil, errors.New(err.Error(), "Failed to decrypt provider token", errcode.ErrInternalFailure)
+		}
+	}
+	if stored.ExpiresAt != nil {
+		token.ExpiresAt = *stored.ExpiresAt
+	}
+	return token, nil
+}
+
+// SaveProviderToken stores the tokens issued for the OAuth account, replacing
+// those stored before.
+//
+// A stored refresh token is kept if the provider issued no new one.
+func (p *ProviderTokenRepository) SaveProviderToken(ctx context.Context, account *dbmodels.SecureOAuthAuthAccount, token *oauthmodels.Token) error {
+	stored, err := p.client.ProviderToken.Query().
+		Where(providertoken.AuthAccountID(account.ID)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return errors.New(err.Error(), "Failed to find ProviderToken by AuthAccountID", errcode.ErrInternalFailure)
+	}
+
+	if stored != nil {
+		current, err := p.decrypt(stored)
+		if err != nil {
+			return err
+		}
+		_, err = p.ReplaceProviderToken(ctx, current, token)
+		return err
+	}
+
+	encrypted, err := p.encrypt(account.UserID, account.Provider, token.AccessToken, token.RefreshToken)
+	if err != nil {
+		return err
+	}
+	create := p.client.ProviderToken.Create().
+		SetID(uuid.New()).
+		SetAuthAccountID(account.ID).
+		SetUserID(account.UserID).
+		SetProvider(account.Provider).
+		SetKeyID(encrypted.keyID).
+		SetEncryptedAccessToken(encrypted.accessToken).
+		SetNillableEncryptedRefreshToken(encrypted.refreshToken).
+		SetTokenType(token.TokenType).
+		SetScope(token.Scope)
+	if !token.ExpiresAt.IsZero() {
+		create.SetExpiresAt(token.ExpiresAt)
+	}
+	if _, err := create.Save(ctx); err != nil {
+		if ent.IsConstraintError(err) {
+			return errors.New("ProviderToken already exists", "Provider Token Conflict", errcode.ErrConflict)
+		}
+		return errors.New(err.Error(), "Failed to create ProviderToken", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// GetProviderToken retrieves and decrypts the tokens of the provider for a user.
+func (p *ProviderTokenRepository) GetProviderToken(ctx context.Context, userID uuid.UUID, provider providermodels.Provider) (*dbmodels.ProviderToken, error) {
+	stored, err := p.client.ProviderToken.Query().
+		Where(providertoken.And(
+			providertoken.UserID(userID),
+			providertoken.Provider(provider),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("ProviderToken not found", "Provider Token Not Found", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find ProviderToken by UserID and Provider", errcode.ErrInternalFailure)
+	}
+
+	return p.decrypt(stored)
+}
+
+// ReplaceProviderToken replaces the stored tokens with newer ones, encrypting
+// them with the current key. The refresh token of current is kept if token
+// has none.
+//
+// The replacement only succeeds if the stored tokens are still those of
+// current, so that concurrent refreshes cannot overwrite each other.
+//
+// Returns:
+//   - bool: true if the tokens were replaced, false if they changed meanwhile.
+//   - error: An error if the operation fails, nil otherwise.
+func (p *ProviderTokenRepository) ReplaceProviderToken(ctx context.Context, current *dbmodels.ProviderToken, token *oauthmodels.Token) (bool, error) {
+	refreshToken := token.RefreshToken
+	if refreshToken == "" {
+		refreshToken = current.RefreshToken
+	}
+	encrypted, err := p.encrypt(current.UserID, current.Provider, token.AccessToken, refreshToken)
+	if err != nil {
+		return false, err
+	}
+
+	update := p.client.ProviderToken.Update().
+		Where(providertoken.And(
+			providertoken.ID(current.ID),
+			providertoken.UpdatedAt(current.UpdatedAt),
+		)).
+		SetKeyID(encrypted.keyID).
+		SetEncryptedAccessToken(encrypted.accessToken).
+		SetNillableEncryptedRefreshToken(encrypted.refreshToken).
+		SetTokenType(token.TokenType).
+		SetScope(token.Scope)
+	if token.ExpiresAt.IsZero() {
+		update.ClearExpiresAt()
+	} else {
+		update.SetExpiresAt(token.ExpiresAt)
+	}
+	affected, err := update.Save(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to update ProviderToken", errcode.ErrInternalFailure)
+	}
+
+	return affected == 1, nil
+}
+
+// RotateProviderTokens re-encrypts the tokens encrypted with an older key, so
+// that the older key can be retired from the keyring.
+//
+// Returns the number of token sets re-encrypted.
+func (p *ProviderTokenRepository) RotateProviderTokens(ctx context.Context) (int, error) {
+	if p.keyring == nil {
+		return 0, nil
+	}
+	stale, err := p.client.ProviderToken.Query().
+		Where(providertoken.KeyIDNEQ(p.keyring.CurrentKeyID())).
+		All(ctx)
+	if err != nil {
+		return 0, errors.New(err.Error(), "Failed to find ProviderTokens by KeyID", errcode.ErrInternalFailure)
+	}
+
+	rotated := 0
+	for _, stored := range stale {
+		current, err := p.decrypt(stored)
+		if err != nil {
+			return rotated, err
+		}
+		replaced, err := p.ReplaceProviderToken(ctx, current, &oauthmodels.Token{
+			AccessToken:  current.AccessToken,
+			RefreshToken: current.RefreshToken,
+			TokenType:    current.TokenType,
+			Scope:        current.Scope,
+			ExpiresAt:    current.ExpiresAt,
+		})
+		if err != nil {
+			return rotated, err
+		}
+		// A token replaced meanwhile was encrypted with the current key anyway
+		if replaced {
+			rotated++
+		}
+	}
+	return rotated, nil
+}
+
+// DeleteProviderTokenByUserIDAndProvider deletes the tokens of the provider for a user.
+func (p *ProviderTokenRepository) DeleteProviderTokenByUserIDAndProvider(ctx context.Context, userID uuid.UUID, provider providermodels.Provider) error {
+	_, err := p.client.ProviderToken.Delete().
+		Where(providertoken.And(
+			providertoken.UserID(userID),
+			providertoken.Provider(provider),
+		)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete ProviderToken by UserID and Provider", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// DeleteProviderTokensByUserID deletes the tokens of every provider for a user.
+func (p *ProviderTokenRepository) DeleteProviderTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := p.client.ProviderToken.Delete().
+		Where(providertoken.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete ProviderTokens by UserID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// NewProviderTokenRepository creates a new instance of ProviderTokenRepository.
+//
+// keyring may be nil when no provider tokens are stored; the repository can
+// then only delete tokens.
+func NewProviderTokenRepository(client *ent.Client, keyring *util.Keyring) *ProviderTokenRepository {
+	return &ProviderTokenRepository{
+		client:  client,
+		keyring: keyring,
+	}
+}
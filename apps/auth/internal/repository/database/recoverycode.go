@@ -0,0 +1,143 @@
+package dbrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/recoverycode"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+)
+
+type RecoveryCodeRepository struct {
+	client *ent.Client
+}
+
+// hashRecoveryCode normalizes a recovery code as typed by the user and hashes it.
+//
+// Recovery codes are long random values, so a fast unsalted hash is enough and
+// lets a code be looked up directly.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// ReplaceRecoveryCodes stores a new set of recovery codes for the user.
+// All previous codes of the user, used or not, are removed.
+func (r *RecoveryCodeRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []string) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to start transaction", errcode.ErrInternalFailure)
+	}
+
+	if _, err := tx.RecoveryCode.Delete().
+		Where(recoverycode.UserID(userID)).
+		Exec(ctx); err != nil {
+		tx.Rollback()
+		return errors.New(err.Error(), "Failed to delete RecoveryCodes by UserID", errcode.ErrInternalFailure)
+	}
+
+	creates := make([]*ent.RecoveryCodeCreate, 0, len(codes))
+	for _, code := range codes {
+		creates = append(creates, tx.RecoveryCode.Create().
+			SetID(uuid.New()).
+			SetUserID(userID).
+			SetCodeHash(hashRecoveryCode(code)))
+	}
+	if err := tx.RecoveryCode.CreateBulk(creates...).Exec(ctx); err != nil {
+		tx.Rollback()
+		return errors.New(err.Error(), "Failed to create RecoveryCodes", errcode.ErrInternalFailure)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New(err.Error(), "Failed to commit RecoveryCodes", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// GetRecoveryCodesByUserID retrieves all recovery codes of a user, used or not.
+func (r *RecoveryCodeRepository) GetRecoveryCodesByUserID(ctx context.Context, userID uuid.UUID) ([]*dbmodels.SecureRecoveryCode, error) {
+	codes, err := r.client.RecoveryCode.Query().
+		Where(recoverycode.UserID(userID)).
+		All(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to find RecoveryCodes by UserID", errcode.ErrInternalFailure)
+	}
+
+	secureCodes := make([]*dbmodels.SecureRecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		secureCodes = append(secureCodes, dbmodels.NewSecureRecoveryCode(code))
+	}
+
+	return secureCodes, nil
+}
+
+// ConsumeRecoveryCode marks an unused recovery code of the user as used.
+//
+// The code is marked in a transaction that is only committed once redeem
+// succeeds, so that a code is never spent on a login that fails afterwards.
+//
+// Returns:
+//   - bool: true if the code was valid and unused, false otherwise.
+//   - error: An error if the operation or redeem fails, nil otherwise.
+func (r *RecoveryCodeRepository) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, code string, redeem func() error) (bool, error) {
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to start transaction", errcode.ErrInternalFailure)
+	}
+
+	affected, err := tx.RecoveryCode.Update().
+		Where(recoverycode.And(
+			recoverycode.UserID(userID),
+			recoverycode.CodeHash(hashRecoveryCode(code)),
+			recoverycode.UsedAtIsNil(),
+		)).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return false, errors.New(err.Error(), "Failed to consume RecoveryCode", errcode.ErrInternalFailure)
+	}
+	if affected != 1 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := redeem(); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.New(err.Error(), "Failed to commit RecoveryCode", errcode.ErrInternalFailure)
+	}
+
+	return true, nil
+}
+
+// DeleteRecoveryCodesByUserID deletes all recovery codes of a user.
+func (r *RecoveryCodeRepository) DeleteRecoveryCodesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.client.RecoveryCode.Delete().
+		Where(recoverycode.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete RecoveryCodes by UserID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// NewRecoveryCodeRepository creates a new instance of RecoveryCodeRepository.
+func NewRecoveryCodeRepository(client *ent.Client) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		client: client,
+	}
+}
@@ -0,0 +1,145 @@
+package dbrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/session"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+)
+
+type SessionRepository struct {
+	client *ent.Client
+}
+
+// CreateSession records a session started by a login, described by the
+// request information of ctx.
+//
+// Parameters:
+//   - ctx: The context for the operation, carrying the request information.
+//   - userID: The unique identifier of the user signed in.
+//   - familyID: The family of the refresh token issued at login.
+//   - expiresAt: The expiration time of the refresh token.
+func (s *SessionRepository) CreateSession(ctx context.Context, userID uuid.UUID, familyID string, expiresAt time.Time) (*dbmodels.Session, error) {
+	info := reqmodels.RequestInfoFrom(ctx)
+	created, err := s.client.Session.Create().
+		SetID(uuid.New()).
+		SetUserID(userID).
+		SetFamilyID(familyID).
+		SetDeviceName(info.DeviceName).
+		SetUserAgent(info.UserAgent).
+		SetIPAddress(info.IP).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, errors.New("Session already exists", "Session Conflict", errcode.ErrConflict)
+		}
+		return nil, errors.New(err.Error(), "Failed to create Session", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSession(created), nil
+}
+
+// TouchSession records a rotation of the refresh token of the session, from
+// the IP address of the request information of ctx.
+//
+// A family without a session, such as one started before sessions were
+// recorded, is ignored.
+func (s *SessionRepository) TouchSession(ctx context.Context, familyID string, expiresAt time.Time) error {
+	update := s.client.Session.Update().
+		Where(session.FamilyID(familyID)).
+		SetLastUsedAt(time.Now()).
+		SetExpiresAt(expiresAt)
+	if ip := reqmodels.RequestInfoFrom(ctx).IP; ip != "" {
+		update.SetIPAddress(ip)
+	}
+	if _, err := update.Save(ctx); err != nil {
+		return errors.New(err.Error(), "Failed to update Session by FamilyID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// GetActiveSessionsByUserID retrieves the sessions of a user whose refresh
+// token has not expired, most recently used first.
+func (s *SessionRepository) GetActiveSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*dbmodels.Session, error) {
+	sessions, err := s.client.Session.Query().
+		Where(session.And(
+			session.UserID(userID),
+			session.ExpiresAtGT(time.Now()),
+		)).
+		Order(ent.Desc(session.FieldLastUsedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to find Sessions by UserID", errcode.ErrInternalFailure)
+	}
+
+	result := make([]*dbmodels.Session, 0, len(sessions))
+	for _, item := range sessions {
+		result = append(result, dbmodels.NewSession(item))
+	}
+
+	return result, nil
+}
+
+// GetSessionByUserIDAndID retrieves a session of a user.
+func (s *SessionRepository) GetSessionByUserIDAndID(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*dbmodels.Session, error) {
+	found, err := s.client.Session.Query().
+		Where(session.And(
+			session.ID(sessionID),
+			session.UserID(userID),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("Session not found", "Session Not Found", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find Session by UserID and ID", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSession(found), nil
+}
+
+// DeleteSessionByID deletes a session.
+func (s *SessionRepository) DeleteSessionByID(ctx context.Context, sessionID uuid.UUID) error {
+	if err := s.client.Session.DeleteOneID(sessionID).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+		return errors.New(err.Error(), "Failed to delete Session by ID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// DeleteSessionByFamilyID deletes the session following a refresh token family.
+func (s *SessionRepository) DeleteSessionByFamilyID(ctx context.Context, familyID string) error {
+	_, err := s.client.Session.Delete().
+		Where(session.FamilyID(familyID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete Session by FamilyID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// DeleteSessionsByUserID deletes every session of a user.
+func (s *SessionRepository) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := s.client.Session.Delete().
+		Where(session.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete Sessions by UserID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// NewSessionRepository creates a new instance of SessionRepository.
+func NewSessionRepository(client *ent.Client) *SessionRepository {
+	return &SessionRepository{client: client}
+}
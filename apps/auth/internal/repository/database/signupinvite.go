@@ -0,0 +1,143 @@
+package dbrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/signupinvite"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+)
+
+type SignupInviteRepository struct {
+	client *ent.Client
+}
+
+// hashInviteCode normalizes an invite code as typed by the user and hashes it.
+// Like recovery codes, invite codes are long random values looked up directly.
+func hashInviteCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateSignupInvite stores a new invite code.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - code: The invite code in plain text, stored hashed.
+//   - email: The only email the invite admits, empty to admit any email.
+//   - createdBy: The name of the admin client creating the invite.
+//   - expiresAt: The time after which the invite can no longer be used.
+func (r *SignupInviteRepository) CreateSignupInvite(ctx context.Context, code string, email string, createdBy string, expiresAt time.Time) (*dbmodels.SignupInvite, error) {
+	created, err := r.client.SignupInvite.Create().
+		SetID(uuid.New()).
+		SetCodeHash(hashInviteCode(code)).
+		SetEmail(strings.ToLower(email)).
+		SetCreatedBy(createdBy).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, errors.New("SignupInvite already exists", "Invite Conflict", errcode.ErrConflict)
+		}
+		return nil, errors.New(err.Error(), "Failed to create SignupInvite", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSignupInvite(created), nil
+}
+
+// GetSignupInvites retrieves every invite, used or not, newest first.
+func (r *SignupInviteRepository) GetSignupInvites(ctx context.Context) ([]*dbmodels.SignupInvite, error) {
+	invites, err := r.client.SignupInvite.Query().
+		Order(ent.Desc(signupinvite.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to find SignupInvites", errcode.ErrInternalFailure)
+	}
+
+	result := make([]*dbmodels.SignupInvite, 0, len(invites))
+	for _, item := range invites {
+		result = append(result, dbmodels.NewSignupInvite(item))
+	}
+
+	return result, nil
+}
+
+// RedeemSignupInvite marks an unused, unexpired invite admitting the email as
+// used by userID, so that no other signup can use it.
+//
+// Returns:
+//   - *dbmodels.SignupInvite: The redeemed invite, or nil if the code is
+//     unknown, used, expired or meant for another email.
+//   - error: An error if the operation fails, nil otherwise.
+func (r *SignupInviteRepository) RedeemSignupInvite(ctx context.Context, code string, email string, userID uuid.UUID) (*dbmodels.SignupInvite, error) {
+	codeHash := hashInviteCode(code)
+	affected, err := r.client.SignupInvite.Update().
+		Where(signupinvite.And(
+			signupinvite.CodeHash(codeHash),
+			signupinvite.UsedAtIsNil(),
+			signupinvite.ExpiresAtGT(time.Now()),
+			signupinvite.EmailIn("", strings.ToLower(email)),
+		)).
+		SetUsedAt(time.Now()).
+		SetUsedBy(userID).
+		Save(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to redeem SignupInvite", errcode.ErrInternalFailure)
+	}
+	if affected != 1 {
+		return nil, nil
+	}
+
+	redeemed, err := r.client.SignupInvite.Query().
+		Where(signupinvite.CodeHash(codeHash)).
+		Only(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to find SignupInvite by CodeHash", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSignupInvite(redeemed), nil
+}
+
+// ReleaseSignupInvite makes a redeemed invite usable again after the signup
+// it was redeemed for failed.
+func (r *SignupInviteRepository) ReleaseSignupInvite(ctx context.Context, inviteID uuid.UUID) error {
+	_, err := r.client.SignupInvite.Update().
+		Where(signupinvite.ID(inviteID)).
+		ClearUsedAt().
+		ClearUsedBy().
+		Save(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to release SignupInvite", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// DeleteSignupInviteByID deletes an invite.
+//
+// Returns:
+//   - bool: true if the invite existed, false otherwise.
+//   - error: An error if the operation fails, nil otherwise.
+func (r *SignupInviteRepository) DeleteSignupInviteByID(ctx context.Context, inviteID uuid.UUID) (bool, error) {
+	if err := r.client.SignupInvite.DeleteOneID(inviteID).Exec(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.New(err.Error(), "Failed to delete SignupInvite by ID", errcode.ErrInternalFailure)
+	}
+
+	return true, nil
+}
+
+// NewSignupInviteRepository creates a new instance of SignupInviteRepository.
+func NewSignupInviteRepository(client *ent.Client) *SignupInviteRepository {
+	return &SignupInviteRepository{client: client}
+}
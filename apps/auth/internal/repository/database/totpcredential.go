@@ -0,0 +1,219 @@
+package dbrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+type TotpCredentialRepository struct {
+	client *ent.Client
+	cipher *util.Cipher
+}
+
+// SetPendingTotpSecret stores a new, unconfirmed TOTP secret for the user.
+//
+// An earlier unconfirmed secret is replaced. Returns an ErrConflict error if
+// the user already has a confirmed TOTP credential.
+func (t *TotpCredentialRepository) SetPendingTotpSecret(ctx context.Context, userID uuid.UUID, secret string) (*dbmodels.SecureTotpCredential, error) {
+	encryptedSecret, err := t.cipher.Encrypt(secret, userID.String())
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to encrypt TOTP secret", errcode.ErrInternalFailure)
+	}
+
+	credential, err := t.client.TotpCredential.Query().
+		Where(totpcredential.UserID(userID)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, errors.New(err.Error(), "Failed to find TotpCredential by UserID", errcode.ErrInternalFailure)
+	}
+
+	if credential == nil {
+		credential, err = t.client.TotpCredential.Create().
+			SetID(uuid.New()).
+			SetUserID(userID).
+			SetEncryptedSecret(encryptedSecret).
+			Save(ctx)
+		if err != nil {
+			if ent.IsConstraintError(err) {
+				return nil, errors.New("TotpCredential already exists", "TOTP Already Enrolled", errcode.ErrConflict)
+			}
+			return nil, errors.New(err.Error(), "Failed to create TotpCredential", errcode.ErrInternalFailure)
+		}
+		return dbmodels.NewSecureTotpCredential(credential), nil
+	}
+
+	if credential.IsConfirmed {
+		return nil, errors.New("TotpCredential already confirmed", "TOTP Already Enrolled", errcode.ErrConflict)
+	}
+
+	credential, err = credential.Update().
+		SetEncryptedSecret(encryptedSecret).
+		SetLastUsedStep(0).
+		Save(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to update TotpCredential secret", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureTotpCredential(credential), nil
+}
+
+// GetTotpCredentialByUserID retrieves the TOTP credential of a user.
+func (t *TotpCredentialRepository) GetTotpCredentialByUserID(ctx context.Context, userID uuid.UUID) (*dbmodels.SecureTotpCredential, error) {
+	credential, err := t.client.TotpCredential.Query().
+		Where(totpcredential.UserID(userID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("TotpCredential not found", "TOTP Not Enrolled", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find TotpCredential by UserID", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureTotpCredential(credential), nil
+}
+
+// ConfirmTotpCredential confirms the pending TOTP credential of a user with a
+// code generated from its secret.
+//
+// Returns:
+//   - bool: true if the code matched and the credential is now confirmed.
+//   - error: An error if the operation fails, nil otherwise.
+func (t *TotpCredentialRepository) ConfirmTotpCredential(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	credential, err := t.client.TotpCredential.Query().
+		Where(totpcredential.And(
+			totpcredential.UserID(userID),
+			totpcredential.IsConfirmed(false),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, errors.New("pending TotpCredential not found", "TOTP Enrollment Not Found", errcode.ErrNotFound)
+		}
+		return false, errors.New(err.Error(), "Failed to find TotpCredential by UserID", errcode.ErrInternalFailure)
+	}
+
+	step, ok, err := t.validateCode(credential, code)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	_, err = credential.Update().
+		SetIsConfirmed(true).
+		SetLastUsedStep(step).
+		Save(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to confirm TotpCredential", errcode.ErrInternalFailure)
+	}
+
+	return true, nil
+}
+
+// VerifyTotpCode checks a code against the confirmed TOTP credential of a user.
+//
+// A code is accepted at most once; replaying a code of an already used time
+// step fails.
+//
+// Returns:
+//   - bool: true if the code is valid, false otherwise.
+//   - error: An error if the operation fails, nil otherwise.
+func (t *TotpCredentialRepository) VerifyTotpCode(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	credential, err := t.client.TotpCredential.Query().
+		Where(totpcredential.And(
+			totpcredential.UserID(userID),
+			totpcredential.IsConfirmed(true),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, errors.New("TotpCredential not found", "TOTP Not Enrolled", errcode.ErrNotFound)
+		}
+		return false, errors.New(err.Error(), "Failed to find TotpCredential by UserID", errcode.ErrInternalFailure)
+	}
+
+	step, ok, err := t.validateCode(credential, code)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	// Only advance forward so concurrent requests cannot both use the same step
+	affected, err := t.client.TotpCredential.Update().
+		Where(totpcredential.And(
+			totpcredential.ID(credential.ID),
+			totpcredential.LastUsedStepLT(step),
+		)).
+		SetLastUsedStep(step).
+		Save(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to update TotpCredential last used step", errcode.ErrInternalFailure)
+	}
+
+	return affected == 1, nil
+}
+
+// RenameTotpCredential sets the display name of the confirmed TOTP credential of a user.
+func (t *TotpCredentialRepository) RenameTotpCredential(ctx context.Context, userID uuid.UUID, name string) (*dbmodels.SecureTotpCredential, error) {
+	credential, err := t.client.TotpCredential.Query().
+		Where(totpcredential.And(
+			totpcredential.UserID(userID),
+			totpcredential.IsConfirmed(true),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("TotpCredential not found", "TOTP Not Enrolled", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find TotpCredential by UserID", errcode.ErrInternalFailure)
+	}
+
+	credential, err = credential.Update().
+		SetName(name).
+		Save(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to rename TotpCredential", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureTotpCredential(credential), nil
+}
+
+// DeleteTotpCredentialByUserID deletes the TOTP credential of a user.
+func (t *TotpCredentialRepository) DeleteTotpCredentialByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := t.client.TotpCredential.Delete().
+		Where(totpcredential.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete TotpCredential by UserID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// validateCode decrypts the secret of the credential and checks the code.
+func (t *TotpCredentialRepository) validateCode(credential *ent.TotpCredential, code string) (int64, bool, error) {
+	secret, err := t.cipher.Decrypt(credential.EncryptedSecret, credential.UserID.String())
+	if err != nil {
+		return 0, false, errors.New(err.Error(), "Failed to decrypt TOTP secret", errcode.ErrInternalFailure)
+	}
+
+	step, ok := util.ValidateTotp(secret, code, time.Now())
+	if !ok || step <= credential.LastUsedStep {
+		return 0, false, nil
+	}
+
+	return step, true, nil
+}
+
+// NewTotpCredentialRepository creates a new instance of TotpCredentialRepository.
+func NewTotpCredentialRepository(client *ent.Client, cipher *util.Cipher) *TotpCredentialRepository {
+	return &TotpCredentialRepository{
+		client: client,
+		cipher: cipher,
+	}
+}
@@ -0,0 +1,165 @@
+package dbrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/webauthncredential"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+)
+
+type WebauthnCredentialRepository struct {
+	client *ent.Client
+}
+
+// CreateWebauthnCredential stores a newly registered WebAuthn credential.
+//
+// Returns an ErrConflict error if the credential ID is already registered.
+func (w *WebauthnCredentialRepository) CreateWebauthnCredential(ctx context.Context, input *dbmodels.CreateWebauthnCredentialInput) (*dbmodels.SecureWebauthnCredential, error) {
+	create := w.client.WebauthnCredential.Create().
+		SetID(uuid.New()).
+		SetUserID(input.UserID).
+		SetCredentialID(input.CredentialID).
+		SetPublicKey(input.PublicKey).
+		SetSignCount(input.SignCount).
+		SetAaguid(input.AAGUID).
+		SetTransports(input.Transports).
+		SetName(input.Name).
+		SetBackupEligible(input.BackupEligible).
+		SetBackupState(input.BackupState)
+
+	credential, err := create.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, errors.New("WebauthnCredential already exists", "Passkey Already Registered", errcode.ErrConflict)
+		}
+		return nil, errors.New(err.Error(), "Failed to create WebauthnCredential", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureWebauthnCredential(credential), nil
+}
+
+// GetWebauthnCredentialsByUserID retrieves all WebAuthn credentials of a user.
+func (w *WebauthnCredentialRepository) GetWebauthnCredentialsByUserID(ctx context.Context, userID uuid.UUID) ([]*dbmodels.SecureWebauthnCredential, error) {
+	credentials, err := w.client.WebauthnCredential.Query().
+		Where(webauthncredential.UserID(userID)).
+		Order(ent.Asc(webauthncredential.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to find WebauthnCredentials by UserID", errcode.ErrInternalFailure)
+	}
+
+	secureCredentials := make([]*dbmodels.SecureWebauthnCredential, 0, len(credentials))
+	for _, credential := range credentials {
+		secureCredentials = append(secureCredentials, dbmodels.NewSecureWebauthnCredential(credential))
+	}
+
+	return secureCredentials, nil
+}
+
+// GetWebauthnCredentialByCredentialID retrieves a WebAuthn credential by the ID chosen by the authenticator.
+func (w *WebauthnCredentialRepository) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (*dbmodels.SecureWebauthnCredential, error) {
+	credential, err := w.client.WebauthnCredential.Query().
+		Where(webauthncredential.CredentialID(credentialID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("WebauthnCredential not found", "Passkey Not Found", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find WebauthnCredential by CredentialID", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureWebauthnCredential(credential), nil
+}
+
+// SetWebauthnCredentialUsed records a successful assertion with the new sign count.
+//
+// The update only applies while the stored counter is unchanged, so two
+// concurrent assertions cannot both succeed with the same counter.
+//
+// Returns:
+//   - bool: true if the credential was updated, false if the counter changed in the meantime.
+//   - error: An error if the operation fails, nil otherwise.
+func (w *WebauthnCredentialRepository) SetWebauthnCredentialUsed(ctx context.Context, id uuid.UUID, previousSignCount uint32, signCount uint32) (bool, error) {
+	affected, err := w.client.WebauthnCredential.Update().
+		Where(webauthncredential.And(
+			webauthncredential.ID(id),
+			webauthncredential.SignCount(previousSignCount),
+		)).
+		SetSignCount(signCount).
+		SetLastUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to update WebauthnCredential sign count", errcode.ErrInternalFailure)
+	}
+
+	return affected == 1, nil
+}
+
+// RenameWebauthnCredential sets the display name of a WebAuthn credential owned by the user.
+func (w *WebauthnCredentialRepository) RenameWebauthnCredential(ctx context.Context, userID uuid.UUID, id uuid.UUID, name string) (*dbmodels.SecureWebauthnCredential, error) {
+	credential, err := w.client.WebauthnCredential.Query().
+		Where(webauthncredential.And(
+			webauthncredential.ID(id),
+			webauthncredential.UserID(userID),
+		)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("WebauthnCredential not found", "Passkey Not Found", errcode.ErrNotFound)
+		}
+		return nil, errors.New(err.Error(), "Failed to find WebauthnCredential by ID", errcode.ErrInternalFailure)
+	}
+
+	credential, err = credential.Update().
+		SetName(name).
+		Save(ctx)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to rename WebauthnCredential", errcode.ErrInternalFailure)
+	}
+
+	return dbmodels.NewSecureWebauthnCredential(credential), nil
+}
+
+// DeleteWebauthnCredential deletes a WebAuthn credential owned by the user.
+//
+// Returns an ErrNotFound error if the user has no such credential.
+func (w *WebauthnCredentialRepository) DeleteWebauthnCredential(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	affected, err := w.client.WebauthnCredential.Delete().
+		Where(webauthncredential.And(
+			webauthncredential.ID(id),
+			webauthncredential.UserID(userID),
+		)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete WebauthnCredential", errcode.ErrInternalFailure)
+	}
+	if affected == 0 {
+		return errors.New("WebauthnCredential not found", "Passkey Not Found", errcode.ErrNotFound)
+	}
+
+	return nil
+}
+
+// DeleteWebauthnCredentialsByUserID deletes all WebAuthn credentials of a user.
+func (w *WebauthnCredentialRepository) DeleteWebauthnCredentialsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := w.client.WebauthnCredential.Delete().
+		Where(webauthncredential.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to delete WebauthnCredentials by UserID", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// NewWebauthnCredentialRepository creates a new instance of WebauthnCredentialRepository.
+func NewWebauthnCredentialRepository(client *ent.Client) *WebauthnCredentialRepository {
+	return &WebauthnCredentialRepository{
+		client: client,
+	}
+}
@@ -0,0 +1,58 @@
+package ratelimitrepo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+)
+
+// Counter counts events per key over a sliding window in Redis, without
+// limiting anything by itself.
+type Counter struct {
+	store  *redis.Client
+	prefix string
+	window time.Duration
+}
+
+// Add counts an event for each key.
+func (c *Counter) Add(ctx context.Context, keys ...string) error {
+	now := time.Now()
+	windowStart := strconv.FormatInt(now.Add(-c.window).UnixMilli(), 10)
+	pipe := c.store.TxPipeline()
+	for _, key := range keys {
+		eventsKey := c.prefix + key
+		pipe.ZAdd(ctx, eventsKey, redis.Z{Score: float64(now.UnixMilli()), Member: uuid.NewString()})
+		pipe.ZRemRangeByScore(ctx, eventsKey, "-inf", "("+windowStart)
+		pipe.PExpire(ctx, eventsKey, c.window)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.New(err.Error(), "Failed to count event", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// Count returns the number of events of the key within the window.
+func (c *Counter) Count(ctx context.Context, key string) (int64, error) {
+	windowStart := strconv.FormatInt(time.Now().Add(-c.window).UnixMilli(), 10)
+	count, err := c.store.ZCount(ctx, c.prefix+key, windowStart, "+inf").Result()
+	if err != nil {
+		return 0, errors.New(err.Error(), "Failed to read event count", errcode.ErrInternalFailure)
+	}
+	return count, nil
+}
+
+// NewCounter creates a new instance of Counter.
+//
+// prefix should be distinct per counted kind of event.
+func NewCounter(store *redis.Client, prefix string, window time.Duration) *Counter {
+	return &Counter{
+		store:  store,
+		prefix: prefix,
+		window: window,
+	}
+}
@@ -0,0 +1,196 @@
+package ratelimitrepo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+)
+
+// Policy configures how failed attempts are limited.
+//
+// Failures are counted per key over a sliding window. The first FreeAttempts
+// failures are not delayed; each further failure doubles the wait before the
+// next attempt, starting at BaseDelay and capped at MaxDelay. After
+// MaxAttempts failures the key is locked out for Lockout.
+type Policy struct {
+	Window       time.Duration
+	FreeAttempts int
+	MaxAttempts  int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Lockout      time.Duration
+}
+
+// LimitError carries the time a client has to wait before trying again.
+// It is wrapped in an AppError with the errcode.ErrTooManyRequests code.
+type LimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return "too many failed attempts, retry after " + e.RetryAfter.String()
+}
+
+// Limiter tracks failed attempts in Redis and rejects attempts while a key is
+// delayed or locked out.
+type Limiter struct {
+	store  *redis.Client
+	prefix string
+	policy Policy
+}
+
+func (l *Limiter) failuresKey(key string) string {
+	return l.prefix + "failures:" + key
+}
+
+func (l *Limiter) lockKey(key string) string {
+	return l.prefix + "lock:" + key
+}
+
+// delay returns the wait required after the given number of failures.
+func (l *Limiter) delay(failures int64) time.Duration {
+	excess := failures - int64(l.policy.FreeAttempts)
+	if excess <= 0 || l.policy.BaseDelay <= 0 {
+		return 0
+	}
+	delay := l.policy.BaseDelay
+	for i := int64(1); i < excess && delay < l.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, l.policy.MaxDelay)
+}
+
+// retryAfter returns how long the key has to wait before the next attempt, or
+// zero if an attempt is allowed now.
+func (l *Limiter) retryAfter(ctx context.Context, key string, now time.Time) (time.Duration, error) {
+	locked, err := l.store.PTTL(ctx, l.lockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if locked > 0 {
+		return locked, nil
+	}
+
+	failuresKey := l.failuresKey(key)
+	windowStart := strconv.FormatInt(now.Add(-l.policy.Window).UnixMilli(), 10)
+	pipe := l.store.Pipeline()
+	pipe.ZRemRangeByScore(ctx, failuresKey, "-inf", "("+windowStart)
+	count := pipe.ZCard(ctx, failuresKey)
+	last := pipe.ZRevRangeWithScores(ctx, failuresKey, 0, 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	delay := l.delay(count.Val())
+	if delay == 0 || len(last.Val()) == 0 {
+		return 0, nil
+	}
+	lastFailure := time.UnixMilli(int64(last.Val()[0].Score))
+	return max(lastFailure.Add(delay).Sub(now), 0), nil
+}
+
+// Check rejects the attempt if any of the keys is delayed or locked out.
+//
+// Returns:
+//   - An ErrTooManyRequests error wrapping a *LimitError if the attempt must wait.
+//   - An ErrInternalFailure error if the store cannot be read.
+func (l *Limiter) Check(ctx context.Context, keys ...string) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, key := range keys {
+		retryAfter, err := l.retryAfter(ctx, key, now)
+		if err != nil {
+			return errors.New(err.Error(), "Failed to check attempt limit", errcode.ErrInternalFailure)
+		}
+		wait = max(wait, retryAfter)
+	}
+	if wait > 0 {
+		return errors.Upgrade(&LimitError{RetryAfter: wait}, "Too Many Attempts", errcode.ErrTooManyRequests)
+	}
+	return nil
+}
+
+// RecordFailure records a failed attempt for each key and locks out the keys
+// that reached the maximum number of failures.
+func (l *Limiter) RecordFailure(ctx context.Context, keys ...string) error {
+	now := time.Now()
+	windowStart := strconv.FormatInt(now.Add(-l.policy.Window).UnixMilli(), 10)
+	for _, key := range keys {
+		failuresKey := l.failuresKey(key)
+		pipe := l.store.TxPipeline()
+		pipe.ZAdd(ctx, failuresKey, redis.Z{Score: float64(now.UnixMilli()), Member: uuid.NewString()})
+		pipe.ZRemRangeByScore(ctx, failuresKey, "-inf", "("+windowStart)
+		count := pipe.ZCard(ctx, failuresKey)
+		pipe.PExpire(ctx, failuresKey, l.policy.Window)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return errors.New(err.Error(), "Failed to record failed attempt", errcode.ErrInternalFailure)
+		}
+
+		if count.Val() < int64(l.policy.MaxAttempts) {
+			continue
+		}
+		// Start over with a clean window once the lockout ends
+		pipe = l.store.TxPipeline()
+		pipe.Set(ctx, l.lockKey(key), now.UnixMilli(), l.policy.Lockout)
+		pipe.Del(ctx, failuresKey)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return errors.New(err.Error(), "Failed to lock out key", errcode.ErrInternalFailure)
+		}
+	}
+	return nil
+}
+
+// IsLockedOut reports whether the key reached the maximum number of failures
+// and is locked out.
+func (l *Limiter) IsLockedOut(ctx context.Context, key string) (bool, error) {
+	count, err := l.store.Exists(ctx, l.lockKey(key)).Result()
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to check lockout", errcode.ErrInternalFailure)
+	}
+	return count > 0, nil
+}
+
+// Reset clears the failures of the keys after a successful attempt.
+// Lockouts are not lifted.
+func (l *Limiter) Reset(ctx context.Context, keys ...string) error {
+	failuresKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		failuresKeys = append(failuresKeys, l.failuresKey(key))
+	}
+	if err := l.store.Del(ctx, failuresKeys...).Err(); err != nil {
+		return errors.New(err.Error(), "Failed to reset failed attempts", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// EmailKey returns the limiter key for an email address.
+func EmailKey(email string) string {
+	return "email:" + strings.ToLower(email)
+}
+
+// UserKey returns the limiter key for a user.
+func UserKey(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// IPKey returns the limiter key for a client IP address.
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// NewLimiter creates a new instance of Limiter.
+//
+// prefix should be distinct per limited route so their counters do not mix.
+func NewLimiter(store *redis.Client, prefix string, policy Policy) *Limiter {
+	return &Limiter{
+		store:  store,
+		prefix: prefix,
+		policy: policy,
+	}
+}
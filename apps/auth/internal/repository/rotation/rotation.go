@@ -0,0 +1,66 @@
+package rotationrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+)
+
+// rotateScript replaces the current token of a family if it is still the
+// presented one. A family without an entry has never been rotated, so its only
+// token is the one issued at login.
+//
+// Returns 1 if the family was rotated and 0 if the presented token was
+// already replaced.
+var rotateScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and current ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+// RotationRepository tracks the current refresh token of each token family,
+// so that a rotated token presented again can be detected.
+type RotationRepository struct {
+	store  *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// Rotate records nextTokenID as the current token of the family if
+// previousTokenID still is.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - familyID: The family ID of the refresh tokens.
+//   - previousTokenID: The ID of the presented refresh token.
+//   - nextTokenID: The ID of the refresh token replacing it.
+//
+// Returns:
+//   - bool: true if the family was rotated, false if the presented token had
+//     already been rotated.
+//   - error: An error if the store could not be updated.
+func (r *RotationRepository) Rotate(ctx context.Context, familyID string, previousTokenID string, nextTokenID string) (bool, error) {
+	result, err := rotateScript.Run(ctx, r.store, []string{r.prefix + familyID}, previousTokenID, nextTokenID, r.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, errors.New(err.Error(), "Failed to rotate refresh token", errcode.ErrInternalFailure)
+	}
+	return result == 1, nil
+}
+
+// NewRotationRepository creates a new instance of RotationRepository.
+//
+// ttl must be at least the refresh token lifetime: an entry expiring earlier
+// would let a rotated token pass as the first token of its family.
+func NewRotationRepository(store *redis.Client, prefix string, ttl time.Duration) *RotationRepository {
+	return &RotationRepository{
+		store:  store,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
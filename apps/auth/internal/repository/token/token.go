@@ -42,11 +42,13 @@ func (t *TokenRepository) GenerateAccessToken(ctx context.Context, userID uuid.U
 //   - userID: The ID of the user for whom the email verification token is generated.
 //   - email: The email address to verify.
 //   - code: The verification code associated with the email.
-func (t *TokenRepository) GenerateEmailVerificationToken(ctx context.Context, userID uuid.UUID, email string, code string) (string, int64, error) {
+//   - purpose: The flow the token is issued for.
+func (t *TokenRepository) GenerateEmailVerificationToken(ctx context.Context, userID uuid.UUID, email string, code string, purpose tokenmodels.EmailTokenPurpose) (string, int64, error) {
 	resp, err := t.client.GenerateEmailVerificationToken(ctx, &tokenv1.GenerateEmailVerificationTokenRequest{
-		UserId: userID.String(),
-		Email:  email,
-		Code:   code,
+		UserId:  userID.String(),
+		Email:   email,
+		Code:    code,
+		Purpose: string(purpose),
 	})
 	if err != nil {
 		return "", 0, errors.Upgrade(err, "Failed to generate email verification token", errcode.ErrInternalFailure)
@@ -57,7 +59,8 @@ func (t *TokenRepository) GenerateEmailVerificationToken(ctx context.Context, us
 	return resp.Token, resp.ExpiresAt, nil
 }
 
-// GenerateRefreshToken creates a new refresh token for the user.
+// GenerateRefreshToken creates a new refresh token for the user, starting a
+// new token family.
 //
 // Parameters:
 //   - ctx: The context for the operation.
@@ -65,15 +68,18 @@ func (t *TokenRepository) GenerateEmailVerificationToken(ctx context.Context, us
 //
 // Returns:
 //   - token: The generated refresh token.
-func (t *TokenRepository) GenerateRefreshToken(ctx context.Context, userID uuid.UUID) (string, int64, error) {
+//   - familyID: The family ID of the generated refresh token.
+//   - expiresAt: The expiration time of the token in Unix timestamp format.
+//   - error: An error if the token generation fails, otherwise nil.
+func (t *TokenRepository) GenerateRefreshToken(ctx context.Context, userID uuid.UUID) (string, string, int64, error) {
 	resp, err := t.client.GenerateRefreshToken(ctx, &tokenv1.GenerateRefreshTokenRequest{UserId: userID.String()})
 	if err != nil {
-		return "", 0, errors.Upgrade(err, "Failed to generate refresh token", errcode.ErrInternalFailure)
+		return "", "", 0, errors.Upgrade(err, "Failed to generate refresh token", errcode.ErrInternalFailure)
 	}
 	if err := resp.ValidateAll(); err != nil {
-		return "", 0, errors.Upgrade(err, "Invalid response from token service", errcode.ErrInternalFailure)
+		return "", "", 0, errors.Upgrade(err, "Invalid response from token service", errcode.ErrInternalFailure)
 	}
-	return resp.Token, resp.ExpiresAt, nil
+	return resp.Token, resp.FamilyId, resp.ExpiresAt, nil
 }
 
 // VerifyAccessToken checks if the provided access token is valid.
@@ -102,12 +108,16 @@ func (t *TokenRepository) VerifyAccessToken(ctx context.Context, token string) (
 // Parameters:
 //   - ctx: The context for the operation.
 //   - token: The email verification token to verify.
+//   - purpose: The flow the token must have been issued for.
 //
 // Returns:
 //   - data: A pointer to an EmailVerificationResult containing the verification result.
 //   - error: An error if the verification fails, otherwise nil.
-func (t *TokenRepository) VerifyEmailVerificationToken(ctx context.Context, token string) (*tokenmodels.EmailVerificationResult, error) {
-	resp, err := t.client.VerifyEmailVerificationToken(ctx, &tokenv1.VerifyEmailVerificationTokenRequest{Token: token})
+func (t *TokenRepository) VerifyEmailVerificationToken(ctx context.Context, token string, purpose tokenmodels.EmailTokenPurpose) (*tokenmodels.EmailVerificationResult, error) {
+	resp, err := t.client.VerifyEmailVerificationToken(ctx, &tokenv1.VerifyEmailVerificationTokenRequest{
+		Token:   token,
+		Purpose: string(purpose),
+	})
 	if err != nil {
 		return nil, errors.Upgrade(err, "Failed to verify email verification token", errcode.ErrInternalFailure)
 	}
@@ -149,6 +159,69 @@ func (t *TokenRepository) VerifyRefreshToken(ctx context.Context, token string)
 	return resp.Valid, resp.UserId, nil
 }
 
+// RotateRefreshToken creates the refresh token replacing one of the family.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - userID: The ID of the user for whom the refresh token is generated.
+//   - familyID: The family of the refresh token being replaced.
+//
+// Returns:
+//   - token: The generated refresh token.
+//   - tokenID: The ID of the generated refresh token.
+//   - expiresAt: The expiration time of the token in Unix timestamp format.
+//   - error: An error if the token generation fails, otherwise nil.
+func (t *TokenRepository) RotateRefreshToken(ctx context.Context, userID uuid.UUID, familyID string) (string, string, int64, error) {
+	resp, err := t.client.GenerateRefreshToken(ctx, &tokenv1.GenerateRefreshTokenRequest{
+		UserId:   userID.String(),
+		FamilyId: &familyID,
+	})
+	if err != nil {
+		return "", "", 0, errors.Upgrade(err, "Failed to generate refresh token", errcode.ErrInternalFailure)
+	}
+	if err := resp.ValidateAll(); err != nil {
+		return "", "", 0, errors.Upgrade(err, "Invalid response from token service", errcode.ErrInternalFailure)
+	}
+	return resp.Token, resp.TokenId, resp.ExpiresAt, nil
+}
+
+// VerifyRefreshTokenDetails checks if the provided refresh token is valid and
+// returns the IDs of the token and its family along with the user ID.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - token: The refresh token to verify.
+//
+// Returns:
+//   - data: A pointer to a RefreshTokenResult containing the verification result.
+//   - error: An error if the verification fails, otherwise nil.
+func (t *TokenRepository) VerifyRefreshTokenDetails(ctx context.Context, token string) (*tokenmodels.RefreshTokenResult, error) {
+	resp, err := t.client.VerifyRefreshToken(ctx, &tokenv1.VerifyRefreshTokenRequest{Token: token})
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to verify refresh token", errcode.ErrInternalFailure)
+	}
+	if err := resp.ValidateAll(); err != nil {
+		return nil, errors.Upgrade(err, "Invalid response from token service", errcode.ErrInternalFailure)
+	}
+	if !resp.Valid || resp.UserId == nil {
+		return &tokenmodels.RefreshTokenResult{Valid: false}, nil
+	}
+
+	userUUID, err := uuid.Parse(*resp.UserId)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Invalid user ID in response", errcode.ErrInternalFailure)
+	}
+	data := &tokenmodels.RefreshTokenResult{
+		Valid:  resp.Valid,
+		UserID: userUUID,
+	}
+	if resp.TokenId != nil && resp.FamilyId != nil {
+		data.TokenID = *resp.TokenId
+		data.FamilyID = *resp.FamilyId
+	}
+	return data, nil
+}
+
 func NewTokenRepository(client tokenv1.TokenServiceClient) *TokenRepository {
 	return &TokenRepository{client: client}
 }
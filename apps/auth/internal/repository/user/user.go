@@ -66,3 +66,8 @@ func (u *UserServiceRepository) IsActive(ctx context.Context, userID uuid.UUID)
 	}
 	return resp, nil
 }
+
+// NewUserServiceRepository creates a new instance of UserServiceRepository.
+func NewUserServiceRepository(client userv1.UserServiceClient) *UserServiceRepository {
+	return &UserServiceRepository{client: client}
+}
@@ -0,0 +1,141 @@
+package signuppolicy
+
+import (
+	stdErrors "errors"
+	"strings"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+)
+
+// Mode decides who may create an account.
+type Mode string
+
+const (
+	ModeOpen   Mode = "open"   // Anyone may sign up
+	ModeClosed Mode = "closed" // Nobody may sign up
+	ModeInvite Mode = "invite" // Only holders of an invite code may sign up
+	ModeDomain Mode = "domain" // Only emails of allowed, not denied domains may sign up
+)
+
+// Rules configures a Policy.
+//
+// The domain lists only apply in ModeDomain. A domain also covers its
+// subdomains.
+type Rules struct {
+	Mode           Mode
+	AllowedDomains []string // Empty to allow every domain not denied
+	DeniedDomains  []string
+}
+
+// Policy decides whether an email may create an account, for local signups
+// and first OAuth logins alike.
+type Policy struct {
+	mode           Mode
+	allowedDomains []string
+	deniedDomains  []string
+}
+
+// InviteOnly reports whether every signup must redeem an invite code.
+func (p *Policy) InviteOnly() bool {
+	return p.mode == ModeInvite
+}
+
+// Check decides whether a new account may be created for email.
+// emailVerified tells whether the email is known to belong to the user, as for
+// the email of an OAuth identity the provider verified. Local signups verify
+// the email after the account is created, so they pass true.
+//
+// Invite codes are not checked here; see InviteOnly.
+//
+// Returns:
+//   - An ErrForbidden error if signup is closed or the email may not sign up.
+func (p *Policy) Check(email string, emailVerified bool) error {
+	switch p.mode {
+	case ModeClosed:
+		return errors.New("signup is closed", "Signup Closed", errcode.ErrForbidden)
+	case ModeDomain:
+		// A domain restriction means nothing for an email nobody verified
+		if !emailVerified {
+			return errors.New("email is not verified", "Verified Email Required", errcode.ErrForbidden)
+		}
+		return p.CheckDomain(email)
+	}
+	return nil
+}
+
+// CheckDomain decides whether an existing account may take email as its
+// address, so that a domain restriction cannot be bypassed by changing the
+// email after signup. Only ModeDomain restricts it.
+//
+// Returns:
+//   - An ErrForbidden error if the domain of email is not allowed.
+func (p *Policy) CheckDomain(email string) error {
+	if p.mode != ModeDomain {
+		return nil
+	}
+	domain := emailDomain(email)
+	if matchDomain(p.deniedDomains, domain) {
+		return errors.New("email domain "+domain+" is denied", "Email Domain Not Allowed", errcode.ErrForbidden)
+	}
+	if len(p.allowedDomains) > 0 && !matchDomain(p.allowedDomains, domain) {
+		return errors.New("email domain "+domain+" is not allowed", "Email Domain Not Allowed", errcode.ErrForbidden)
+	}
+	return nil
+}
+
+// emailDomain returns the lowercase domain of an email, or "" if it has none.
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
+
+// matchDomain reports whether domain is one of domains or a subdomain of one.
+func matchDomain(domains []string, domain string) bool {
+	if domain == "" {
+		return false
+	}
+	for _, item := range domains {
+		if domain == item || strings.HasSuffix(domain, "."+item) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDomains lowercases the domains, dropping a leading "@" or "."
+func normalizeDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.TrimLeft(strings.ToLower(strings.TrimSpace(domain)), "@.")
+		if domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+// NewPolicy creates a new instance of Policy.
+//
+// ModeDomain needs at least one allowed or denied domain.
+func NewPolicy(rules Rules) (*Policy, error) {
+	policy := &Policy{
+		mode:           rules.Mode,
+		allowedDomains: normalizeDomains(rules.AllowedDomains),
+		deniedDomains:  normalizeDomains(rules.DeniedDomains),
+	}
+
+	switch policy.mode {
+	case ModeOpen, ModeClosed, ModeInvite:
+	case ModeDomain:
+		if len(policy.allowedDomains) == 0 && len(policy.deniedDomains) == 0 {
+			return nil, stdErrors.New("domain mode needs allowed or denied domains")
+		}
+	default:
+		return nil, stdErrors.New("unknown signup mode: " + string(policy.mode))
+	}
+	return policy, nil
+}
@@ -0,0 +1,28 @@
+package activitydto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+)
+
+// SearchInput selects recorded attempts. Zero fields do not filter.
+type SearchInput struct {
+	UserID    uuid.UUID              `json:"user_id"`
+	IPAddress string                 `json:"ip_address"`
+	Outcome   activitymodels.Outcome `json:"outcome"`
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Cursor    string                 `json:"cursor"` // NextCursor of the previous page
+	Limit     int                    `json:"limit"`
+}
+
+// Page is a page of recorded attempts, newest first.
+//
+// NextCursor is empty on the last page.
+type Page struct {
+	Activities []*dbmodels.AuthActivity `json:"activities"`
+	NextCursor string                   `json:"next_cursor"`
+}
@@ -0,0 +1,82 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	activitydto "mandacode.com/accounts/auth/internal/usecase/activity/dto"
+)
+
+const (
+	// DefaultPageSize is the page size used when none is requested.
+	DefaultPageSize = 20
+	// MaxPageSize bounds the page size a caller may request.
+	MaxPageSize = 100
+)
+
+// HistoryUsecase pages through the history of authentication attempts, for
+// users reading their own activity and for support staff.
+type HistoryUsecase struct {
+	authActivity *dbrepo.AuthActivityRepository
+}
+
+// ListUserActivity returns a page of the recent activity of a user.
+func (h *HistoryUsecase) ListUserActivity(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*activitydto.Page, error) {
+	return h.SearchActivity(ctx, activitydto.SearchInput{
+		UserID: userID,
+		Cursor: cursor,
+		Limit:  limit,
+	})
+}
+
+// SearchActivity returns a page of the attempts matching the input.
+func (h *HistoryUsecase) SearchActivity(ctx context.Context, input activitydto.SearchInput) (*activitydto.Page, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, errors.New("time range is empty", "Invalid Time Range", errcode.ErrInvalidInput)
+	}
+
+	filter := activitymodels.Filter{
+		UserID:    input.UserID,
+		IPAddress: input.IPAddress,
+		Outcome:   input.Outcome,
+		From:      input.From,
+		To:        input.To,
+		// Fetch one more to know whether there is a next page
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		cursor, err := activitymodels.ParseCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = &cursor
+	}
+
+	activities, err := h.authActivity.ListAuthActivities(ctx, filter)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to list activity", errcode.ErrInternalFailure)
+	}
+
+	page := &activitydto.Page{Activities: activities}
+	if len(activities) > limit {
+		page.Activities = activities[:limit]
+		page.NextCursor = page.Activities[limit-1].Cursor().Encode()
+	}
+	return page, nil
+}
+
+// NewHistoryUsecase creates a new instance of HistoryUsecase.
+func NewHistoryUsecase(authActivity *dbrepo.AuthActivityRepository) *HistoryUsecase {
+	return &HistoryUsecase{
+		authActivity: authActivity,
+	}
+}
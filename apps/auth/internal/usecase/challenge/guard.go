@@ -0,0 +1,94 @@
+package challenge
+
+import (
+	"context"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/captcha"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+)
+
+// RequiredError tells the client which challenge to solve before retrying.
+// It is wrapped in an AppError with the errcode.ErrForbidden code.
+type RequiredError struct {
+	Provider string
+	SiteKey  string
+}
+
+func (e *RequiredError) Error() string {
+	return "a " + e.Provider + " challenge must be solved"
+}
+
+// Guard asks clients for a bot challenge once they look risky: when the
+// risky events counted for their IP address, such as failed logins and sent
+// verification mails, reach the threshold.
+type Guard struct {
+	verifier  captcha.ChallengeVerifier
+	risk      *ratelimitrepo.Counter
+	threshold int64
+}
+
+// Requirement returns the challenge the client at ip must solve, or nil if
+// it need not solve any. None is required when no verifier is configured.
+func (g *Guard) Requirement(ctx context.Context, ip string) (*RequiredError, error) {
+	if g.verifier == nil {
+		return nil, nil
+	}
+	count, err := g.risk.Count(ctx, ratelimitrepo.IPKey(ip))
+	if err != nil {
+		return nil, err
+	}
+	if count < g.threshold {
+		return nil, nil
+	}
+	return &RequiredError{Provider: g.verifier.Provider(), SiteKey: g.verifier.SiteKey()}, nil
+}
+
+// Check rejects the attempt of the client at ip if it must solve a challenge
+// and token does not solve it.
+//
+// Returns:
+//   - An ErrForbidden error wrapping a *RequiredError if the challenge is missing or unsolved.
+//   - An ErrInternalFailure error if the risk or the token cannot be checked.
+func (g *Guard) Check(ctx context.Context, ip string, token string) error {
+	requiredErr, err := g.Requirement(ctx, ip)
+	if err != nil {
+		return err
+	}
+	if requiredErr == nil {
+		return nil
+	}
+
+	if token == "" {
+		return errors.Upgrade(requiredErr, "Challenge Required", errcode.ErrForbidden)
+	}
+	solved, err := g.verifier.Verify(ctx, token, ip)
+	if err != nil {
+		return errors.New(err.Error(), "Failed to verify challenge", errcode.ErrInternalFailure)
+	}
+	if !solved {
+		return errors.Upgrade(requiredErr, "Challenge Failed", errcode.ErrForbidden)
+	}
+	return nil
+}
+
+// RecordRisk counts a risky event of the client at ip.
+func (g *Guard) RecordRisk(ctx context.Context, ip string) error {
+	if g.verifier == nil {
+		return nil
+	}
+	return g.risk.Add(ctx, ratelimitrepo.IPKey(ip))
+}
+
+// NewGuard creates a new instance of Guard.
+//
+// A nil verifier disables challenges. A threshold of zero asks every client
+// for a challenge.
+func NewGuard(verifier captcha.ChallengeVerifier, risk *ratelimitrepo.Counter, threshold int64) *Guard {
+	return &Guard{
+		verifier:  verifier,
+		risk:      risk,
+		threshold: threshold,
+	}
+}
@@ -0,0 +1,159 @@
+package emailauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// magicLinkRequestDuration is the least time RequestMagicLink takes, so that
+// the time taken to send a mail does not reveal that an account exists.
+const magicLinkRequestDuration = 500 * time.Millisecond
+
+// MagicLinkUsecase logs local accounts in with a single-use link sent to
+// their email.
+type MagicLinkUsecase struct {
+	authAccount       *dbrepo.AuthAccountRepository
+	token             *tokenrepo.TokenRepository
+	issuer            *session.Issuer
+	mailer            *mailer.Mailer
+	magicLinkManager  *coderepo.CodeManager
+	magicLinkCooldown *coderepo.Cooldown
+	mfaChallenge      *mfa.ChallengeUsecase
+	magicLinkURL      string
+}
+
+// RequestMagicLink sends a passwordless login link to the email of a local account.
+//
+// A link is sent to an email once per cooldown. Unknown emails are ignored,
+// taking as long as known ones, so that the response does not reveal whether
+// an account exists.
+func (m *MagicLinkUsecase) RequestMagicLink(ctx context.Context, email string) error {
+	defer util.WaitUntil(ctx, time.Now().Add(magicLinkRequestDuration))
+
+	retryAfter, err := m.magicLinkCooldown.Claim(ctx, email)
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		return errors.New("login link requested too often, retry after "+retryAfter.String(), "Too Many Requests", errcode.ErrTooManyRequests)
+	}
+
+	auth, err := m.authAccount.GetLocalAuthAccountByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return nil
+		}
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	// Generate a single-use login code and wrap it in a signed token
+	code, err := m.magicLinkManager.IssueCode(ctx, auth.UserID)
+	if err != nil {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	token, _, err := m.token.GenerateEmailVerificationToken(ctx, auth.UserID, auth.Email, code, tokenmodels.EmailTokenPurposeMagicLink)
+	if err != nil {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	// Send login link email
+	url := m.magicLinkURL + "?token=" + token
+	if err := m.mailer.SendMagicLinkMail(auth.Email, url); err != nil {
+		return errors.Upgrade(err, "Failed to send login link email", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// LoginWithMagicLink logs in with a token from a login link mail.
+//
+// Opening the link proves ownership of the email, so an unverified account is
+// marked as verified. If the user has MFA enabled, no tokens are issued and
+// mfaToken holds the challenge to pass to the MFA login of localauth instead.
+func (m *MagicLinkUsecase) LoginWithMagicLink(ctx context.Context, token string) (accessToken string, refreshToken string, mfaToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		m.issuer.RecordActivity(ctx, activitymodels.MethodMagicLink, "", userID, mfaToken != "", &err)
+	}()
+
+	result, err := m.token.VerifyEmailVerificationToken(ctx, token, tokenmodels.EmailTokenPurposeMagicLink)
+	if err != nil {
+		return "", "", "", errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if !result.Valid {
+		return "", "", "", errors.New("invalid or expired token", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	userID = result.UserID
+
+	auth, err := m.authAccount.GetLocalAuthAccountByUserID(ctx, result.UserID)
+	if err != nil {
+		return "", "", "", errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if auth.Email != result.Email {
+		return "", "", "", errors.New("email does not match", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	// Consume the login code so the link cannot be used twice
+	valid, err := m.magicLinkManager.ValidateCode(ctx, auth.UserID, result.Code)
+	if err != nil {
+		return "", "", "", errors.Upgrade(err, "Failed to validate login link", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		return "", "", "", errors.New("login link is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	if !auth.IsVerified {
+		if _, err := m.authAccount.SetIsVerifiedByID(ctx, auth.ID, true); err != nil {
+			return "", "", "", errors.Upgrade(err, "Failed to update user verification status", errcode.ErrInternalFailure)
+		}
+	}
+
+	mfaToken, err = m.mfaChallenge.IssueChallenge(ctx, auth.UserID)
+	if err != nil {
+		return "", "", "", err
+	}
+	if mfaToken != "" {
+		return "", "", mfaToken, nil
+	}
+
+	// Generate access and refresh tokens
+	accessToken, refreshToken, err = m.issuer.IssueToken(ctx, auth.UserID)
+	return accessToken, refreshToken, "", err
+}
+
+// NewMagicLinkUsecase creates a new instance of MagicLinkUsecase.
+func NewMagicLinkUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	token *tokenrepo.TokenRepository,
+	issuer *session.Issuer,
+	mailer *mailer.Mailer,
+	magicLinkManager *coderepo.CodeManager,
+	magicLinkCooldown *coderepo.Cooldown,
+	mfaChallenge *mfa.ChallengeUsecase,
+	magicLinkURL string,
+) *MagicLinkUsecase {
+	return &MagicLinkUsecase{
+		authAccount:       authAccount,
+		token:             token,
+		issuer:            issuer,
+		mailer:            mailer,
+		magicLinkManager:  magicLinkManager,
+		magicLinkCooldown: magicLinkCooldown,
+		mfaChallenge:      mfaChallenge,
+		magicLinkURL:      magicLinkURL,
+	}
+}
@@ -0,0 +1,153 @@
+package emailauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// otpRequestDuration is the least time RequestLoginOTP takes, so that the
+// time taken to send a code does not reveal that an account exists.
+const otpRequestDuration = 500 * time.Millisecond
+
+// OTPUsecase logs local accounts in with a one-time passcode sent to their
+// email.
+type OTPUsecase struct {
+	authAccount     *dbrepo.AuthAccountRepository
+	issuer          *session.Issuer
+	mailer          *mailer.Mailer
+	loginOTPManager *coderepo.OTPManager
+	mfaChallenge    *mfa.ChallengeUsecase
+	loginLimiter    *ratelimitrepo.Limiter
+}
+
+// RequestLoginOTP sends a one-time login passcode to the email of a local account.
+//
+// Requests are rejected while the email or client IP is limited by failed
+// logins, and a code is sent to an email once per cooldown. Unknown emails are
+// ignored, taking as long as known ones, so that the response does not reveal
+// whether an account exists.
+func (o *OTPUsecase) RequestLoginOTP(ctx context.Context, email string, clientIP string) error {
+	defer util.WaitUntil(ctx, time.Now().Add(otpRequestDuration))
+
+	if err := o.loginLimiter.Check(ctx, ratelimitrepo.EmailKey(email), ratelimitrepo.IPKey(clientIP)); err != nil {
+		return err
+	}
+	retryAfter, err := o.loginOTPManager.ClaimResend(ctx, email)
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		return errors.New("login code requested too often, retry after "+retryAfter.String(), "Too Many Requests", errcode.ErrTooManyRequests)
+	}
+
+	auth, err := o.authAccount.GetLocalAuthAccountByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return nil
+		}
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	code, err := o.loginOTPManager.IssueOTP(ctx, auth.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := o.mailer.SendEmailOTPMail(auth.Email, code, mailer.OTPPurposeLogin); err != nil {
+		return errors.Upgrade(err, "Failed to send login code", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// LoginWithOTP logs in with a one-time passcode sent by RequestLoginOTP.
+//
+// Wrong codes are counted by the login limiter per email and client IP, the
+// same as wrong passwords. Receiving the code proves ownership of the email,
+// so an unverified account is marked as verified. If the user has MFA
+// enabled, no tokens are issued and mfaToken holds the challenge to pass to
+// the MFA login of localauth instead.
+func (o *OTPUsecase) LoginWithOTP(ctx context.Context, email string, code string, clientIP string) (accessToken string, refreshToken string, mfaToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		o.issuer.RecordActivity(ctx, activitymodels.MethodEmailOTP, "", userID, mfaToken != "", &err)
+	}()
+
+	limitKeys := []string{ratelimitrepo.EmailKey(email), ratelimitrepo.IPKey(clientIP)}
+	if err := o.loginLimiter.Check(ctx, limitKeys...); err != nil {
+		return "", "", "", err
+	}
+
+	auth, err := o.authAccount.GetLocalAuthAccountByEmail(ctx, email)
+	if err != nil && !errors.Is(err, errcode.ErrNotFound) {
+		return "", "", "", errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+	if auth != nil {
+		userID = auth.UserID
+	}
+
+	// Unknown emails are checked against a code that never exists, so that
+	// they take as long as known ones
+	valid, err := o.loginOTPManager.ValidateOTP(ctx, userID, code)
+	if err != nil {
+		return "", "", "", errors.Upgrade(err, "Failed to validate login code", errcode.ErrInternalFailure)
+	}
+	if !valid || auth == nil {
+		if err := o.loginLimiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return "", "", "", err
+		}
+		return "", "", "", errors.New("login code is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	if !auth.IsVerified {
+		if _, err := o.authAccount.SetIsVerifiedByID(ctx, auth.ID, true); err != nil {
+			return "", "", "", errors.Upgrade(err, "Failed to update user verification status", errcode.ErrInternalFailure)
+		}
+	}
+
+	mfaToken, err = o.mfaChallenge.IssueChallenge(ctx, auth.UserID)
+	if err != nil {
+		return "", "", "", err
+	}
+	if mfaToken != "" {
+		return "", "", mfaToken, nil
+	}
+	if err := o.loginLimiter.Reset(ctx, ratelimitrepo.EmailKey(email)); err != nil {
+		return "", "", "", err
+	}
+
+	// Generate access and refresh tokens
+	accessToken, refreshToken, err = o.issuer.IssueToken(ctx, auth.UserID)
+	return accessToken, refreshToken, "", err
+}
+
+// NewOTPUsecase creates a new instance of OTPUsecase.
+func NewOTPUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	issuer *session.Issuer,
+	mailer *mailer.Mailer,
+	loginOTPManager *coderepo.OTPManager,
+	mfaChallenge *mfa.ChallengeUsecase,
+	loginLimiter *ratelimitrepo.Limiter,
+) *OTPUsecase {
+	return &OTPUsecase{
+		authAccount:     authAccount,
+		issuer:          issuer,
+		mailer:          mailer,
+		loginOTPManager: loginOTPManager,
+		mfaChallenge:    mfaChallenge,
+		loginLimiter:    loginLimiter,
+	}
+}
@@ -1,13 +1,63 @@
 package localauthdto
 
+import "github.com/google/uuid"
+
 type LoginInput struct {
 	Email    string             `json:"email"`
 	Password string             `json:"password"`
+	ClientIP string             `json:"client_ip"`
+	// ChallengeToken answers the bot challenge, required once the client looks risky.
+	ChallengeToken string `json:"challenge_token"`
 	// Info     models.RequestInfo `json:"info"`
 }
 
+// Ways of verifying the email of a new local account.
+const (
+	VerificationLink = "link"
+	VerificationOTP  = "otp"
+)
+
 type SignupInput struct {
 	Email    string             `json:"email"`
 	Password string             `json:"password"`
+	// VerificationMethod is VerificationLink or VerificationOTP; empty means VerificationLink.
+	VerificationMethod string `json:"verification_method"`
+	ClientIP           string `json:"client_ip"`
+	// ChallengeToken answers the bot challenge, as in LoginInput.
+	ChallengeToken string `json:"challenge_token"`
+	// InviteCode admits the signup while signup is invite-only.
+	InviteCode string `json:"invite_code"`
 	// Info     models.RequestInfo `json:"info"`
 }
+
+// ResendVerificationInput asks for a new verification mail for the email of
+// an unverified local account.
+type ResendVerificationInput struct {
+	Email    string `json:"email"`
+	ClientIP string `json:"client_ip"`
+	// ChallengeToken answers the bot challenge, as in LoginInput.
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ChangePasswordInput struct {
+	UserID          uuid.UUID `json:"user_id"`
+	CurrentPassword string    `json:"current_password"`
+	NewPassword     string    `json:"new_password"`
+	SignOutOthers   bool      `json:"sign_out_others"`
+}
+
+type ChangeEmailInput struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Password string    `json:"password"`
+	NewEmail string    `json:"new_email"`
+}
+
+type ConfirmTotpInput struct {
+	UserID uuid.UUID `json:"user_id"`
+	Code   string    `json:"code"`
+}
@@ -0,0 +1,144 @@
+package localauth
+
+import (
+	"context"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+)
+
+type EmailChangeUsecase struct {
+	authAccount       *dbrepo.AuthAccountRepository
+	token             *tokenrepo.TokenRepository
+	mailer            *mailer.Mailer
+	notifier          *notice.SecurityNotifier
+	changeCodeManager *coderepo.CodeManager
+	signupPolicy      *signuppolicy.Policy
+	changeEmailURL    string
+}
+
+// RequestEmailChange starts an email change for the local account of the user.
+//
+// A verification link is sent to the new address and a notice to the current
+// one. The stored email is not touched until ConfirmEmailChange succeeds. The
+// new address must be of a domain the signup policy allows.
+func (e *EmailChangeUsecase) RequestEmailChange(ctx context.Context, input localauthdto.ChangeEmailInput) error {
+	auth, err := e.authAccount.GetLocalAuthAccountByUserID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return errors.New("user has no local account", "Local Account Not Found", errcode.ErrNotFound)
+		}
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	verified, userID, err := e.authAccount.ComparePassword(ctx, auth.Email, input.Password)
+	if err != nil {
+		return err
+	}
+	if !verified || userID != input.UserID {
+		return errors.New("password does not match", "Invalid Password", errcode.ErrUnauthorized)
+	}
+	if auth.Email == input.NewEmail {
+		return errors.New("new email is the same as the current email", "New Email Must Differ", errcode.ErrInvalidInput)
+	}
+	if err := e.signupPolicy.CheckDomain(input.NewEmail); err != nil {
+		return err
+	}
+
+	// Fail early if the address is taken; the unique index is checked again on confirmation
+	if _, err := e.authAccount.GetLocalAuthAccountByEmail(ctx, input.NewEmail); err == nil {
+		return errors.New("email already in use", "Email Already In Use", errcode.ErrConflict)
+	} else if !errors.Is(err, errcode.ErrNotFound) {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	// The token carries the new address, so nothing else has to be stored
+	code, err := e.changeCodeManager.IssueCode(ctx, auth.UserID)
+	if err != nil {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	token, _, err := e.token.GenerateEmailVerificationToken(ctx, auth.UserID, input.NewEmail, code, tokenmodels.EmailTokenPurposeEmailChange)
+	if err != nil {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	url := e.changeEmailURL + "?token=" + token
+	if err := e.mailer.SendEmailChangeVerificationMail(input.NewEmail, url); err != nil {
+		return errors.Upgrade(err, "Failed to send verification email", errcode.ErrInternalFailure)
+	}
+	if err := e.mailer.SendEmailChangeNoticeMail(auth.Email, input.NewEmail); err != nil {
+		return errors.Upgrade(err, "Failed to send email change notice", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange applies an email change using the token from the verification mail
+// and tells the previous address that the change was made.
+//
+// Returns an ErrConflict error if the new address has been taken in the meantime,
+// or an ErrForbidden error if the signup policy no longer allows its domain.
+func (e *EmailChangeUsecase) ConfirmEmailChange(ctx context.Context, token string) (email string, err error) {
+	result, err := e.token.VerifyEmailVerificationToken(ctx, token, tokenmodels.EmailTokenPurposeEmailChange)
+	if err != nil {
+		return "", errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if !result.Valid {
+		return "", errors.New("invalid or expired token", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	valid, err := e.changeCodeManager.ValidateCode(ctx, result.UserID, result.Code)
+	if err != nil {
+		return "", errors.Upgrade(err, "Failed to validate email change code", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		return "", errors.New("email change code is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	// The policy may have changed since the change was requested
+	if err := e.signupPolicy.CheckDomain(result.Email); err != nil {
+		return "", err
+	}
+
+	previous, err := e.authAccount.GetLocalAuthAccountByUserID(ctx, result.UserID)
+	if err != nil {
+		return "", errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+	auth, err := e.authAccount.SetLocalEmail(ctx, result.UserID, result.Email)
+	if err != nil {
+		return "", err
+	}
+	e.notifier.NotifyEmailChanged(ctx, auth.UserID, previous.Email, auth.Email)
+
+	return auth.Email, nil
+}
+
+// NewEmailChangeUsecase creates a new instance of EmailChangeUsecase.
+func NewEmailChangeUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	token *tokenrepo.TokenRepository,
+	mailer *mailer.Mailer,
+	notifier *notice.SecurityNotifier,
+	changeCodeManager *coderepo.CodeManager,
+	signupPolicy *signuppolicy.Policy,
+	changeEmailURL string,
+) *EmailChangeUsecase {
+	return &EmailChangeUsecase{
+		authAccount:       authAccount,
+		token:             token,
+		mailer:            mailer,
+		notifier:          notifier,
+		changeCodeManager: changeCodeManager,
+		signupPolicy:      signupPolicy,
+		changeEmailURL:    changeEmailURL,
+	}
+}
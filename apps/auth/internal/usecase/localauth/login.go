@@ -7,25 +7,57 @@ import (
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
 
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
 	coderepo "mandacode.com/accounts/auth/internal/repository/code"
 	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
-	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
 	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+	"mandacode.com/accounts/auth/internal/usecase/session"
 )
 
 type LoginUsecase struct {
-	authAccount      *dbrepo.AuthAccountRepository
-	token            *tokenrepo.TokenRepository
-	loginCodeManager *coderepo.CodeManager
+	authAccount       *dbrepo.AuthAccountRepository
+	issuer            *session.Issuer
+	loginCodeManager  *coderepo.LoginCodeManager
+	mfaChallenge      *mfa.ChallengeUsecase
+	loginLimiter      *ratelimitrepo.Limiter
+	loginCodeLimiter  *ratelimitrepo.Limiter
+	verifyCodeLimiter *ratelimitrepo.Limiter
+	challengeGuard    *challenge.Guard
 }
 
-func (l *LoginUsecase) checkUserVerified(ctx context.Context, input localauthdto.LoginInput) (uuid.UUID, error) {
+// checkUserVerified checks the credentials of a login attempt. Failed attempts
+// are counted by the limiter of the route per email and client IP, and as a
+// risk of the client IP by the challenge guard. The failures of the email are
+// only reset once the login is complete, see resetLoginFailures.
+//
+// The user ID is also returned with an error when the account is known, so
+// that the failed attempt can be recorded for the user.
+func (l *LoginUsecase) checkUserVerified(ctx context.Context, input localauthdto.LoginInput, limiter *ratelimitrepo.Limiter) (uuid.UUID, error) {
+	if err := l.challengeGuard.Check(ctx, input.ClientIP, input.ChallengeToken); err != nil {
+		return uuid.Nil, err
+	}
+	limitKeys := []string{ratelimitrepo.EmailKey(input.Email), ratelimitrepo.IPKey(input.ClientIP)}
+	if err := limiter.Check(ctx, limitKeys...); err != nil {
+		return uuid.Nil, err
+	}
+
 	verified, userID, err := l.authAccount.ComparePassword(ctx, input.Email, input.Password)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	if !verified {
-		return uuid.Nil, errors.New("invalid email or password", "Unauthorized", errcode.ErrUnauthorized)
+		if err := limiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return uuid.Nil, err
+		}
+		if err := l.challengeGuard.RecordRisk(ctx, input.ClientIP); err != nil {
+			return uuid.Nil, err
+		}
+		return userID, errors.New("invalid email or password", "Unauthorized", errcode.ErrUnauthorized)
 	}
 
 	authAccount, err := l.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
@@ -33,15 +65,85 @@ func (l *LoginUsecase) checkUserVerified(ctx context.Context, input localauthdto
 		return uuid.Nil, errors.Upgrade(err, "Failed to get auth account", errcode.ErrInternalFailure)
 	}
 	if !authAccount.IsVerified {
-		return uuid.Nil, errors.New("user is not verified", "User Email Not Verified", errcode.ErrUnauthorized)
+		return userID, errors.New("user is not verified", "User Email Not Verified", errcode.ErrUnauthorized)
 	}
 
 	return userID, nil
 }
 
+// resetLoginFailures clears the failures counted for the email of the user by
+// the limiter of the route once the login is complete. Users without a local
+// account have no such failures.
+func (l *LoginUsecase) resetLoginFailures(ctx context.Context, userID uuid.UUID, limiter *ratelimitrepo.Limiter) error {
+	authAccount, err := l.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return nil
+		}
+		return errors.Upgrade(err, "Failed to get auth account", errcode.ErrInternalFailure)
+	}
+	return limiter.Reset(ctx, ratelimitrepo.EmailKey(authAccount.Email))
+}
+
+// completeMFAChallenge passes the MFA challenge and resets the failures
+// counted by the limiter of the route for the user.
+//
+// Once the challenge is found, its user ID is also returned with an error.
+func (l *LoginUsecase) completeMFAChallenge(ctx context.Context, input mfadto.ChallengeInput, limiter *ratelimitrepo.Limiter) (uuid.UUID, error) {
+	userID, err := l.mfaChallenge.CompleteChallenge(ctx, input)
+	if err != nil {
+		return userID, err
+	}
+	if err := l.resetLoginFailures(ctx, userID, limiter); err != nil {
+		return userID, err
+	}
+	return userID, nil
+}
+
 // IssueLoginCode implements localauthdomain.LoginUsecase.
-func (l *LoginUsecase) IssueLoginCode(ctx context.Context, input localauthdto.LoginInput) (code string, userID uuid.UUID, err error) {
-	userID, err = l.checkUserVerified(ctx, input)
+//
+// If the user has MFA enabled, no code is issued and mfaToken holds the
+// challenge to pass to IssueLoginCodeWithMFA instead.
+func (l *LoginUsecase) IssueLoginCode(ctx context.Context, input localauthdto.LoginInput) (code string, userID uuid.UUID, mfaToken string, err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodPassword, "", attemptUserID, mfaToken != "", &err)
+	}()
+
+	userID, err = l.checkUserVerified(ctx, input, l.loginCodeLimiter)
+	attemptUserID = userID
+	if err != nil {
+		return "", uuid.Nil, "", err
+	}
+
+	mfaToken, err = l.mfaChallenge.IssueChallenge(ctx, userID)
+	if err != nil {
+		return "", uuid.Nil, "", err
+	}
+	if mfaToken != "" {
+		return "", uuid.Nil, mfaToken, nil
+	}
+	if err := l.loginCodeLimiter.Reset(ctx, ratelimitrepo.EmailKey(input.Email)); err != nil {
+		return "", uuid.Nil, "", err
+	}
+
+	code, err = l.loginCodeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return "", uuid.Nil, "", errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	return code, userID, "", nil
+}
+
+// IssueLoginCodeWithMFA issues a login code after the MFA challenge is passed.
+func (l *LoginUsecase) IssueLoginCodeWithMFA(ctx context.Context, input mfadto.ChallengeInput) (code string, userID uuid.UUID, err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodMFA, "", attemptUserID, false, &err)
+	}()
+
+	userID, err = l.completeMFAChallenge(ctx, input, l.loginCodeLimiter)
+	attemptUserID = userID
 	if err != nil {
 		return "", uuid.Nil, err
 	}
@@ -51,56 +153,107 @@ func (l *LoginUsecase) IssueLoginCode(ctx context.Context, input localauthdto.Lo
 		return "", uuid.Nil, errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
 	}
 
-	userID = userID
 	return code, userID, nil
 }
 
 // VerifyLoginCode implements localauthdomain.LoginUsecase.
-func (l *LoginUsecase) VerifyLoginCode(ctx context.Context, userID uuid.UUID, code string) (accessToken string, refreshToken string, err error) {
-	valid, err := l.loginCodeManager.ValidateCode(ctx, userID, code)
+func (l *LoginUsecase) VerifyLoginCode(ctx context.Context, userID uuid.UUID, code string, clientIP string) (accessToken string, refreshToken string, err error) {
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodLoginCode, "", userID, false, &err)
+	}()
+
+	limitKeys := []string{ratelimitrepo.UserKey(userID), ratelimitrepo.IPKey(clientIP)}
+	if err := l.verifyCodeLimiter.Check(ctx, limitKeys...); err != nil {
+		return "", "", err
+	}
+
+	info, valid, err := l.loginCodeManager.ConsumeCode(ctx, userID, code)
 	if err != nil {
 		return "", "", errors.Upgrade(err, "Failed to validate login code", errcode.ErrInternalFailure)
 	}
 	if !valid {
+		if err := l.verifyCodeLimiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return "", "", err
+		}
 		return "", "", errors.New("login code is invalid or expired", "Failed to validate login code", errcode.ErrUnauthorized)
 	}
+	if err := l.verifyCodeLimiter.Reset(ctx, ratelimitrepo.UserKey(userID)); err != nil {
+		return "", "", err
+	}
+
+	// The session describes the client that signed in, not the backend
+	// redeeming the code
+	ctx = reqmodels.WithRequestInfo(ctx, info)
 
 	// Generate access and refresh tokens
-	return l.issueToken(ctx, userID)
+	return l.issuer.IssueToken(ctx, userID)
 }
 
 // Login implements localauthdomain.LoginUsecase.
-func (l *LoginUsecase) Login(ctx context.Context, input localauthdto.LoginInput) (accessToken string, refreshToken string, err error) {
-	userID, err := l.checkUserVerified(ctx, input)
+//
+// If the user has MFA enabled, no tokens are issued and mfaToken holds the
+// challenge to pass to LoginWithMFA instead.
+func (l *LoginUsecase) Login(ctx context.Context, input localauthdto.LoginInput) (accessToken string, refreshToken string, mfaToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodPassword, "", userID, mfaToken != "", &err)
+	}()
+
+	userID, err = l.checkUserVerified(ctx, input, l.loginLimiter)
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
+	}
+
+	mfaToken, err = l.mfaChallenge.IssueChallenge(ctx, userID)
+	if err != nil {
+		return "", "", "", err
+	}
+	if mfaToken != "" {
+		return "", "", mfaToken, nil
+	}
+	if err := l.loginLimiter.Reset(ctx, ratelimitrepo.EmailKey(input.Email)); err != nil {
+		return "", "", "", err
 	}
 
 	// Generate access and refresh tokens
-	return l.issueToken(ctx, userID)
+	accessToken, refreshToken, err = l.issuer.IssueToken(ctx, userID)
+	return accessToken, refreshToken, "", err
 }
 
-// issueToken issues a new access token and refresh token for the user.
-func (l *LoginUsecase) issueToken(ctx context.Context, userID uuid.UUID) (accessToken string, refreshToken string, err error) {
-	accessToken, _, err = l.token.GenerateAccessToken(ctx, userID)
-	if err != nil {
-		return "", "", errors.Upgrade(err, "Failed to generate token", errcode.ErrInternalFailure)
-	}
-	refreshToken, _, err = l.token.GenerateRefreshToken(ctx, userID)
+// LoginWithMFA issues tokens after the MFA challenge is passed.
+func (l *LoginUsecase) LoginWithMFA(ctx context.Context, input mfadto.ChallengeInput) (accessToken string, refreshToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodMFA, "", userID, false, &err)
+	}()
+
+	userID, err = l.completeMFAChallenge(ctx, input, l.loginLimiter)
 	if err != nil {
-		return "", "", errors.Upgrade(err, "Failed to generate token", errcode.ErrInternalFailure)
+		return "", "", err
 	}
-	return accessToken, refreshToken, nil
+
+	// Generate access and refresh tokens
+	return l.issuer.IssueToken(ctx, userID)
 }
 
 func NewLoginUsecase(
 	authAccount *dbrepo.AuthAccountRepository,
-	token *tokenrepo.TokenRepository,
-	loginCodeManager *coderepo.CodeManager,
+	issuer *session.Issuer,
+	loginCodeManager *coderepo.LoginCodeManager,
+	mfaChallenge *mfa.ChallengeUsecase,
+	loginLimiter *ratelimitrepo.Limiter,
+	loginCodeLimiter *ratelimitrepo.Limiter,
+	verifyCodeLimiter *ratelimitrepo.Limiter,
+	challengeGuard *challenge.Guard,
 ) *LoginUsecase {
 	return &LoginUsecase{
-		authAccount:      authAccount,
-		token:            token,
-		loginCodeManager: loginCodeManager,
+		authAccount:       authAccount,
+		issuer:            issuer,
+		loginCodeManager:  loginCodeManager,
+		mfaChallenge:      mfaChallenge,
+		loginLimiter:      loginLimiter,
+		loginCodeLimiter:  loginCodeLimiter,
+		verifyCodeLimiter: verifyCodeLimiter,
+		challengeGuard:    challengeGuard,
 	}
 }
@@ -0,0 +1,156 @@
+package localauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// resetRequestDuration is the least time RequestPasswordReset takes, so that
+// the time taken to send a mail does not reveal that an account exists.
+const resetRequestDuration = 500 * time.Millisecond
+
+type PasswordResetUsecase struct {
+	authAccount      *dbrepo.AuthAccountRepository
+	token            *tokenrepo.TokenRepository
+	revocation       *revocationrepo.RevocationRepository
+	session          *dbrepo.SessionRepository
+	mailer           *mailer.Mailer
+	notifier         *notice.SecurityNotifier
+	resetCodeManager *coderepo.CodeManager
+	resetCooldown    *coderepo.Cooldown
+	passwordPolicy   *passwordpolicy.Policy
+	resetPasswordURL string
+}
+
+// RequestPasswordReset sends a password reset link to the email of a local account.
+//
+// A link is sent to an email once per cooldown. Unknown emails are ignored,
+// taking as long as known ones, so that the response does not reveal whether
+// an account exists.
+func (p *PasswordResetUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	defer util.WaitUntil(ctx, time.Now().Add(resetRequestDuration))
+
+	retryAfter, err := p.resetCooldown.Claim(ctx, email)
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		return errors.New("password reset requested too often, retry after "+retryAfter.String(), "Too Many Requests", errcode.ErrTooManyRequests)
+	}
+
+	auth, err := p.authAccount.GetLocalAuthAccountByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return nil
+		}
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	// Generate a single-use reset code and wrap it in a signed token
+	code, err := p.resetCodeManager.IssueCode(ctx, auth.UserID)
+	if err != nil {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	token, _, err := p.token.GenerateEmailVerificationToken(ctx, auth.UserID, auth.Email, code, tokenmodels.EmailTokenPurposePasswordReset)
+	if err != nil {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	// Send password reset email
+	url := p.resetPasswordURL + "?token=" + token
+	if err := p.mailer.SendPasswordResetMail(auth.Email, url); err != nil {
+		return errors.Upgrade(err, "Failed to send password reset email", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a token from a password reset mail
+// and revokes every refresh token issued to the user.
+func (p *PasswordResetUsecase) ResetPassword(ctx context.Context, input localauthdto.ResetPasswordInput) error {
+	result, err := p.token.VerifyEmailVerificationToken(ctx, input.Token, tokenmodels.EmailTokenPurposePasswordReset)
+	if err != nil {
+		return errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if !result.Valid {
+		return errors.New("invalid or expired token", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	auth, err := p.authAccount.GetLocalAuthAccountByUserID(ctx, result.UserID)
+	if err != nil {
+		return errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if auth.Email != result.Email {
+		return errors.New("email does not match", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	// Check the password before consuming the code so the link can be retried
+	if err := p.passwordPolicy.Validate("password", input.Password, auth.Email); err != nil {
+		return err
+	}
+
+	// Consume the reset code so the link cannot be used twice
+	valid, err := p.resetCodeManager.ValidateCode(ctx, auth.UserID, result.Code)
+	if err != nil {
+		return errors.Upgrade(err, "Failed to validate reset code", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		return errors.New("reset code is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	if _, err := p.authAccount.SetPasswordHash(ctx, auth.UserID, input.Password); err != nil {
+		return errors.Upgrade(err, "Failed to reset password", errcode.ErrInternalFailure)
+	}
+
+	// Sign out every existing session of the user
+	if err := p.revocation.RevokeUserTokens(ctx, auth.UserID); err != nil {
+		return errors.Upgrade(err, "Failed to revoke refresh tokens", errcode.ErrInternalFailure)
+	}
+	if err := p.session.DeleteSessionsByUserID(ctx, auth.UserID); err != nil {
+		return errors.Upgrade(err, "Failed to delete sessions", errcode.ErrInternalFailure)
+	}
+
+	p.notifier.NotifyPasswordChanged(ctx, auth.UserID, true)
+	return nil
+}
+
+// NewPasswordResetUsecase creates a new instance of PasswordResetUsecase.
+func NewPasswordResetUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	token *tokenrepo.TokenRepository,
+	revocation *revocationrepo.RevocationRepository,
+	session *dbrepo.SessionRepository,
+	mailer *mailer.Mailer,
+	notifier *notice.SecurityNotifier,
+	resetCodeManager *coderepo.CodeManager,
+	resetCooldown *coderepo.Cooldown,
+	passwordPolicy *passwordpolicy.Policy,
+	resetPasswordURL string,
+) *PasswordResetUsecase {
+	return &PasswordResetUsecase{
+		authAccount:      authAccount,
+		token:            token,
+		revocation:       revocation,
+		session:          session,
+		mailer:           mailer,
+		notifier:         notifier,
+		resetCodeManager: resetCodeManager,
+		resetCooldown:    resetCooldown,
+		passwordPolicy:   passwordPolicy,
+		resetPasswordURL: resetPasswordURL,
+	}
+}
@@ -0,0 +1,90 @@
+package localauth
+
+import (
+	"context"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+)
+
+type PasswordChangeUsecase struct {
+	authAccount    *dbrepo.AuthAccountRepository
+	issuer         *session.Issuer
+	revocation     *revocationrepo.RevocationRepository
+	session        *dbrepo.SessionRepository
+	passwordPolicy *passwordpolicy.Policy
+	notifier       *notice.SecurityNotifier
+}
+
+// ChangePassword changes the password of an authenticated user after
+// re-verifying the current password.
+//
+// When input.SignOutOthers is set, every refresh token of the user is revoked
+// and a new token pair is returned for the current session. Otherwise the
+// returned tokens are empty.
+func (p *PasswordChangeUsecase) ChangePassword(ctx context.Context, input localauthdto.ChangePasswordInput) (accessToken string, refreshToken string, err error) {
+	auth, err := p.authAccount.GetLocalAuthAccountByUserID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return "", "", errors.New("user has no local account", "Password Not Set", errcode.ErrNotFound)
+		}
+		return "", "", errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	verified, userID, err := p.authAccount.ComparePassword(ctx, auth.Email, input.CurrentPassword)
+	if err != nil {
+		return "", "", err
+	}
+	if !verified || userID != input.UserID {
+		return "", "", errors.New("current password does not match", "Invalid Current Password", errcode.ErrUnauthorized)
+	}
+	if input.CurrentPassword == input.NewPassword {
+		return "", "", errors.New("new password is the same as the current password", "New Password Must Differ", errcode.ErrInvalidInput)
+	}
+	if err := p.passwordPolicy.Validate("new_password", input.NewPassword, auth.Email); err != nil {
+		return "", "", err
+	}
+
+	if _, err := p.authAccount.SetPasswordHash(ctx, input.UserID, input.NewPassword); err != nil {
+		return "", "", errors.Upgrade(err, "Failed to change password", errcode.ErrInternalFailure)
+	}
+	p.notifier.NotifyPasswordChanged(ctx, input.UserID, false)
+
+	if !input.SignOutOthers {
+		return "", "", nil
+	}
+
+	// Revoke every session, then hand the caller a fresh token pair
+	if err := p.revocation.RevokeUserTokens(ctx, input.UserID); err != nil {
+		return "", "", errors.Upgrade(err, "Failed to revoke refresh tokens", errcode.ErrInternalFailure)
+	}
+	if err := p.session.DeleteSessionsByUserID(ctx, input.UserID); err != nil {
+		return "", "", errors.Upgrade(err, "Failed to delete sessions", errcode.ErrInternalFailure)
+	}
+	return p.issuer.IssueToken(ctx, input.UserID)
+}
+
+// NewPasswordChangeUsecase creates a new instance of PasswordChangeUsecase.
+func NewPasswordChangeUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	issuer *session.Issuer,
+	revocation *revocationrepo.RevocationRepository,
+	session *dbrepo.SessionRepository,
+	passwordPolicy *passwordpolicy.Policy,
+	notifier *notice.SecurityNotifier,
+) *PasswordChangeUsecase {
+	return &PasswordChangeUsecase{
+		authAccount:    authAccount,
+		issuer:         issuer,
+		revocation:     revocation,
+		session:        session,
+		passwordPolicy: passwordPolicy,
+		notifier:       notifier,
+	}
+}
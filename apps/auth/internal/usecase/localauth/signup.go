@@ -2,30 +2,63 @@ package localauth
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
 	"mandacode.com/accounts/auth/internal/infra/mailer"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
 	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
 	coderepo "mandacode.com/accounts/auth/internal/repository/code"
 	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
 	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
 	userrepo "mandacode.com/accounts/auth/internal/repository/user"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
 	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
+	"mandacode.com/accounts/auth/internal/util"
 )
 
+// otpRequestDuration is the least time ResendVerificationOTP takes, so that
+// the time taken to send a code does not reveal that an account exists.
+const otpRequestDuration = 500 * time.Millisecond
+
 type SignupUsecase struct {
 	authAccount      *dbrepo.AuthAccountRepository
 	userService      *userrepo.UserServiceRepository
 	token            *tokenrepo.TokenRepository
+	authActivity     *dbrepo.AuthActivityRepository
 	mailer           *mailer.Mailer
 	emailCodeManager *coderepo.CodeManager
+	emailOTPManager  *coderepo.OTPManager
+	otpLimiter       *ratelimitrepo.Limiter
+	passwordPolicy   *passwordpolicy.Policy
+	challengeGuard   *challenge.Guard
+	signupGate       *signup.Gate
 	verifyEmailURL   string
 }
 
+// checkChallenge rejects a request sending a mail unless the client passes the
+// challenge guard, and counts the request as a risk of the client, since every
+// such request can be used to send mail to anyone.
+func (s *SignupUsecase) checkChallenge(ctx context.Context, clientIP string, challengeToken string) error {
+	if err := s.challengeGuard.Check(ctx, clientIP, challengeToken); err != nil {
+		return err
+	}
+	return s.challengeGuard.RecordRisk(ctx, clientIP)
+}
+
 // ResendVerificationEmail implements localauthdomain.SignupUsecase.
-func (s *SignupUsecase) ResendVerificationEmail(ctx context.Context, email string) (success bool, err error) {
+func (s *SignupUsecase) ResendVerificationEmail(ctx context.Context, input localauthdto.ResendVerificationInput) (success bool, err error) {
+	if err := s.checkChallenge(ctx, input.ClientIP, input.ChallengeToken); err != nil {
+		return false, err
+	}
+
+	email := input.Email
 	auth, err := s.authAccount.GetLocalAuthAccountByEmail(ctx, email)
 	if err != nil {
 		return false, errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
@@ -41,7 +74,7 @@ func (s *SignupUsecase) ResendVerificationEmail(ctx context.Context, email strin
 		return false, errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
 	}
 
-	token, _, err := s.token.GenerateEmailVerificationToken(ctx, auth.UserID, email, code)
+	token, _, err := s.token.GenerateEmailVerificationToken(ctx, auth.UserID, email, code, tokenmodels.EmailTokenPurposeVerifyEmail)
 	if err != nil {
 		return false, errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
 	}
@@ -58,14 +91,26 @@ func (s *SignupUsecase) ResendVerificationEmail(ctx context.Context, email strin
 
 // Signup implements localauthdomain.SignupUsecase.
 func (s *SignupUsecase) Signup(ctx context.Context, input localauthdto.SignupInput) (userID uuid.UUID, err error) {
+	if err := s.checkChallenge(ctx, input.ClientIP, input.ChallengeToken); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.passwordPolicy.Validate("password", input.Password, input.Email); err != nil {
+		return uuid.Nil, err
+	}
+	// The email is verified once the account exists, before it can sign in
 	userID = uuid.New()
+	inviteID, err := s.signupGate.Admit(ctx, input.Email, true, input.InviteCode, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	createUserResp, err := s.userService.InitUser(ctx, userID)
 	if err != nil {
-		return uuid.Nil, errors.Upgrade(err, "Failed to create user", errcode.ErrInternalFailure)
+		return uuid.Nil, s.signupGate.Abort(ctx, inviteID, errors.Upgrade(err, "Failed to create user", errcode.ErrInternalFailure))
 	}
 	if createUserResp == nil || createUserResp.UserId != userID.String() {
 		s.userService.DeleteUser(ctx, userID)
-		return uuid.Nil, errors.New("failed to create user", "Internal Error", errcode.ErrInternalFailure)
+		return uuid.Nil, s.signupGate.Abort(ctx, inviteID, errors.New("failed to create user", "Internal Error", errcode.ErrInternalFailure))
 	}
 
 	auth, err := s.authAccount.CreateLocalAuthAccount(
@@ -79,13 +124,23 @@ func (s *SignupUsecase) Signup(ctx context.Context, input localauthdto.SignupInp
 	)
 	if err != nil {
 		s.userService.DeleteUser(ctx, userID)
-		return uuid.Nil, errors.Join(err, "failed to create user")
+		return uuid.Nil, s.signupGate.Abort(ctx, inviteID, errors.Join(err, "failed to create user"))
+	}
+
+	if input.VerificationMethod == localauthdto.VerificationOTP {
+		if err := s.sendVerificationOTP(ctx, auth.UserID, input.Email); err != nil {
+			return uuid.Nil, err
+		}
+		return auth.UserID, nil
 	}
 
 	// Generate email verification token
 	code, err := s.emailCodeManager.IssueCode(ctx, auth.UserID)
+	if err != nil {
+		return uuid.Nil, errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
 
-	token, _, err := s.token.GenerateEmailVerificationToken(ctx, auth.UserID, input.Email, code)
+	token, _, err := s.token.GenerateEmailVerificationToken(ctx, auth.UserID, input.Email, code, tokenmodels.EmailTokenPurposeVerifyEmail)
 	if err != nil {
 		return uuid.Nil, errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
 	}
@@ -100,15 +155,121 @@ func (s *SignupUsecase) Signup(ctx context.Context, input localauthdto.SignupInp
 	return auth.UserID, nil
 }
 
+// sendVerificationOTP mails a one-time passcode verifying the email of the user.
+func (s *SignupUsecase) sendVerificationOTP(ctx context.Context, userID uuid.UUID, email string) error {
+	code, err := s.emailOTPManager.IssueOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mailer.SendEmailOTPMail(email, code, mailer.OTPPurposeEmailVerification); err != nil {
+		return errors.Upgrade(err, "Failed to send verification code", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// ResendVerificationOTP sends a new one-time passcode verifying the email of
+// an unverified local account.
+//
+// A code is sent to an email once per cooldown. Unknown and already verified
+// emails are ignored, taking as long as others, so that the response does not
+// reveal whether an account exists.
+func (s *SignupUsecase) ResendVerificationOTP(ctx context.Context, input localauthdto.ResendVerificationInput) error {
+	if err := s.checkChallenge(ctx, input.ClientIP, input.ChallengeToken); err != nil {
+		return err
+	}
+	defer util.WaitUntil(ctx, time.Now().Add(otpRequestDuration))
+
+	email := input.Email
+
+	retryAfter, err := s.emailOTPManager.ClaimResend(ctx, email)
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		return errors.New("verification code requested too often, retry after "+retryAfter.String(), "Too Many Requests", errcode.ErrTooManyRequests)
+	}
+
+	auth, err := s.authAccount.GetLocalAuthAccountByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return nil
+		}
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+	if auth.IsVerified {
+		return nil
+	}
+
+	return s.sendVerificationOTP(ctx, auth.UserID, auth.Email)
+}
+
+// VerifyEmailWithOTP verifies the email of a local account with a one-time passcode.
+//
+// Wrong codes are counted by the OTP limiter per email and client IP. Unknown
+// and already verified emails fail as a wrong code does, so that the response
+// does not reveal whether an account exists.
+func (s *SignupUsecase) VerifyEmailWithOTP(ctx context.Context, email string, code string, clientIP string) (err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		s.recordActivity(ctx, attemptUserID, &err)
+	}()
+
+	limitKeys := []string{ratelimitrepo.EmailKey(email), ratelimitrepo.IPKey(clientIP)}
+	if err := s.otpLimiter.Check(ctx, limitKeys...); err != nil {
+		return err
+	}
+
+	auth, err := s.authAccount.GetLocalAuthAccountByEmail(ctx, email)
+	if err != nil && !errors.Is(err, errcode.ErrNotFound) {
+		return errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+	if auth != nil {
+		attemptUserID = auth.UserID
+	}
+	userID := uuid.Nil
+	if auth != nil && !auth.IsVerified {
+		userID = auth.UserID
+	}
+
+	// Other emails are checked against a code that never exists, so that they
+	// take as long as unverified ones
+	valid, err := s.emailOTPManager.ValidateOTP(ctx, userID, code)
+	if err != nil {
+		return errors.Upgrade(err, "Failed to validate verification code", errcode.ErrInternalFailure)
+	}
+	if !valid || userID == uuid.Nil {
+		if err := s.otpLimiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return err
+		}
+		return errors.New("verification code is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if err := s.otpLimiter.Reset(ctx, ratelimitrepo.EmailKey(email)); err != nil {
+		return err
+	}
+
+	if _, err := s.authAccount.SetIsVerifiedByID(ctx, auth.ID, true); err != nil {
+		return errors.Upgrade(err, "Failed to update user verification status", errcode.ErrInternalFailure)
+	}
+
+	return nil
+}
+
 // VerifyEmail implements localauthdomain.SignupUsecase.
 func (s *SignupUsecase) VerifyEmail(ctx context.Context, email string, token string) (success bool, err error) {
-	result, err := s.token.VerifyEmailVerificationToken(ctx, token)
+	var userID uuid.UUID
+	defer func() {
+		s.recordActivity(ctx, userID, &err)
+	}()
+
+	result, err := s.token.VerifyEmailVerificationToken(ctx, token, tokenmodels.EmailTokenPurposeVerifyEmail)
 	if err != nil {
 		return false, errors.Upgrade(err, "Unauthorized", errcode.ErrUnauthorized)
 	}
 	if !result.Valid {
 		return false, errors.New("invalid or expired token", "Unauthorized", errcode.ErrUnauthorized)
 	}
+	userID = result.UserID
 
 	auth, err := s.authAccount.GetLocalAuthAccountByUserID(ctx, result.UserID)
 	if err != nil {
@@ -139,19 +300,42 @@ func (s *SignupUsecase) VerifyEmail(ctx context.Context, email string, token str
 	return true, nil
 }
 
+// recordActivity records the outcome of an email verification ending with
+// *err. A verification that cannot be recorded fails.
+func (s *SignupUsecase) recordActivity(ctx context.Context, userID uuid.UUID, err *error) {
+	entry := activitymodels.NewEntry(activitymodels.MethodEmailVerification, "", userID, *err)
+	if recordErr := s.authActivity.RecordAuthActivity(ctx, entry); recordErr != nil && *err == nil {
+		*err = recordErr
+	}
+}
+
 // NewSignupUsecase creates a new instance of SignupUsecase.
 func NewSignupUsecase(
 	authAccount *dbrepo.AuthAccountRepository,
+	userService *userrepo.UserServiceRepository,
 	token *tokenrepo.TokenRepository,
+	authActivity *dbrepo.AuthActivityRepository,
 	mailer *mailer.Mailer,
 	emailCodeManager *coderepo.CodeManager,
+	emailOTPManager *coderepo.OTPManager,
+	otpLimiter *ratelimitrepo.Limiter,
+	passwordPolicy *passwordpolicy.Policy,
+	challengeGuard *challenge.Guard,
+	signupGate *signup.Gate,
 	verifyEmailURL string,
 ) *SignupUsecase {
 	return &SignupUsecase{
 		authAccount:      authAccount,
+		userService:      userService,
 		token:            token,
+		authActivity:     authActivity,
 		mailer:           mailer,
 		emailCodeManager: emailCodeManager,
+		emailOTPManager:  emailOTPManager,
+		otpLimiter:       otpLimiter,
+		passwordPolicy:   passwordPolicy,
+		challengeGuard:   challengeGuard,
+		signupGate:       signupGate,
 		verifyEmailURL:   verifyEmailURL,
 	}
 }
@@ -0,0 +1,75 @@
+package localauth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+type TotpUsecase struct {
+	authAccount    *dbrepo.AuthAccountRepository
+	totpCredential *dbrepo.TotpCredentialRepository
+	notifier       *notice.SecurityNotifier
+	issuer         string
+}
+
+// EnrollTotp starts TOTP enrollment for the local account of the user.
+//
+// The returned secret and provisioning URI are shown to the user once; the
+// credential is not used for login until ConfirmTotp succeeds.
+func (t *TotpUsecase) EnrollTotp(ctx context.Context, userID uuid.UUID) (secret string, provisioningURI string, err error) {
+	auth, err := t.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return "", "", errors.New("user has no local account", "Local Account Not Found", errcode.ErrNotFound)
+		}
+		return "", "", errors.Upgrade(err, "Internal Error", errcode.ErrInternalFailure)
+	}
+
+	secret, err = util.GenerateTotpSecret()
+	if err != nil {
+		return "", "", errors.Upgrade(err, "Failed to generate TOTP secret", errcode.ErrInternalFailure)
+	}
+
+	if _, err := t.totpCredential.SetPendingTotpSecret(ctx, userID, secret); err != nil {
+		return "", "", err
+	}
+
+	return secret, util.TotpProvisioningURI(t.issuer, auth.Email, secret), nil
+}
+
+// ConfirmTotp completes TOTP enrollment with the first code from the authenticator app.
+func (t *TotpUsecase) ConfirmTotp(ctx context.Context, input localauthdto.ConfirmTotpInput) error {
+	confirmed, err := t.totpCredential.ConfirmTotpCredential(ctx, input.UserID, input.Code)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("invalid TOTP code", "Invalid TOTP Code", errcode.ErrUnauthorized)
+	}
+
+	t.notifier.NotifyMFAChanged(ctx, input.UserID, mailer.MFAChangeTotpEnabled)
+	return nil
+}
+
+// NewTotpUsecase creates a new instance of TotpUsecase.
+func NewTotpUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	totpCredential *dbrepo.TotpCredentialRepository,
+	notifier *notice.SecurityNotifier,
+	issuer string,
+) *TotpUsecase {
+	return &TotpUsecase{
+		authAccount:    authAccount,
+		totpCredential: totpCredential,
+		notifier:       notifier,
+		issuer:         issuer,
+	}
+}
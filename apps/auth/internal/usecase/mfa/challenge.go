@@ -0,0 +1,194 @@
+package mfa
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+)
+
+// ChallengeUsecase asks for the second factor of users with MFA enabled,
+// whatever the first factor of their login.
+type ChallengeUsecase struct {
+	totpCredential     *dbrepo.TotpCredentialRepository
+	webauthnCredential *dbrepo.WebauthnCredentialRepository
+	recoveryCode       *dbrepo.RecoveryCodeRepository
+	authEvent          *autheventrepo.AuthEventEmitter
+	notifier           *notice.SecurityNotifier
+	passkeyVerifier    *passkeyauth.AssertionVerifier
+	challengeManager   *coderepo.CodeManager
+	limiter            *ratelimitrepo.Limiter
+}
+
+// IssueChallenge issues an MFA challenge for the user once the first factor
+// is accepted. An empty token is returned if the user has no second factor,
+// that is neither a confirmed TOTP authenticator nor a passkey.
+func (c *ChallengeUsecase) IssueChallenge(ctx context.Context, userID uuid.UUID) (string, error) {
+	enrolled, err := hasSecondFactor(ctx, c.totpCredential, c.webauthnCredential, userID)
+	if err != nil {
+		return "", err
+	}
+	if !enrolled {
+		return "", nil
+	}
+
+	mfaToken, err := c.challengeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return "", errors.Upgrade(err, "Failed to issue MFA challenge", errcode.ErrInternalFailure)
+	}
+	return mfaToken, nil
+}
+
+// HasSecondFactor reports whether logins of the user must pass an MFA challenge.
+func (c *ChallengeUsecase) HasSecondFactor(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return hasSecondFactor(ctx, c.totpCredential, c.webauthnCredential, userID)
+}
+
+// PasskeyOptions starts the assertion of a passkey of the user of the MFA
+// challenge, to answer the challenge with.
+func (c *ChallengeUsecase) PasskeyOptions(ctx context.Context, mfaToken string) (*webauthn.RequestOptions, error) {
+	userID, ok, err := c.challengeManager.GetUserID(ctx, mfaToken)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to get MFA challenge", errcode.ErrInternalFailure)
+	}
+	if !ok {
+		return nil, errors.New("MFA challenge is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if err := c.limiter.Check(ctx, ratelimitrepo.UserKey(userID)); err != nil {
+		return nil, err
+	}
+	return c.passkeyVerifier.RequestOptions(ctx, userID)
+}
+
+// consume consumes an MFA challenge; a concurrent request may have used it
+// already.
+func (c *ChallengeUsecase) consume(ctx context.Context, userID uuid.UUID, mfaToken string) error {
+	consumed, err := c.challengeManager.ValidateCode(ctx, userID, mfaToken)
+	if err != nil {
+		return errors.Upgrade(err, "Failed to consume MFA challenge", errcode.ErrInternalFailure)
+	}
+	if !consumed {
+		return errors.New("MFA challenge is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	return nil
+}
+
+// fail records a wrong answer to an MFA challenge and deletes the challenge
+// once the user is locked out, so that it cannot be guessed at further. It
+// returns cause.
+func (c *ChallengeUsecase) fail(ctx context.Context, userID uuid.UUID, mfaToken string, cause error) error {
+	limitKey := ratelimitrepo.UserKey(userID)
+	if err := c.limiter.RecordFailure(ctx, limitKey); err != nil {
+		return err
+	}
+	locked, err := c.limiter.IsLockedOut(ctx, limitKey)
+	if err != nil {
+		return err
+	}
+	if locked {
+		if err := c.challengeManager.RevokeCode(ctx, mfaToken); err != nil {
+			return errors.Upgrade(err, "Failed to revoke MFA challenge", errcode.ErrInternalFailure)
+		}
+	}
+	return cause
+}
+
+// CompleteChallenge checks the TOTP code, recovery code or passkey assertion
+// for an MFA challenge and consumes the challenge on success. Wrong answers
+// are counted per user.
+//
+// Once the challenge is found, its user ID is also returned with an error.
+func (c *ChallengeUsecase) CompleteChallenge(ctx context.Context, input mfadto.ChallengeInput) (uuid.UUID, error) {
+	userID, ok, err := c.challengeManager.GetUserID(ctx, input.MFAToken)
+	if err != nil {
+		return uuid.Nil, errors.Upgrade(err, "Failed to get MFA challenge", errcode.ErrInternalFailure)
+	}
+	if !ok {
+		return uuid.Nil, errors.New("MFA challenge is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	limitKey := ratelimitrepo.UserKey(userID)
+	if err := c.limiter.Check(ctx, limitKey); err != nil {
+		return userID, err
+	}
+
+	switch {
+	case input.Passkey != nil:
+		if _, err := c.passkeyVerifier.VerifyAssertion(ctx, userID, *input.Passkey); err != nil {
+			if errors.Is(err, errcode.ErrInternalFailure) {
+				return userID, err
+			}
+			return userID, c.fail(ctx, userID, input.MFAToken, err)
+		}
+		if err := c.consume(ctx, userID, input.MFAToken); err != nil {
+			return userID, err
+		}
+	case input.RecoveryCode != "":
+		// The recovery code is only spent if the challenge is consumed with it
+		valid, err := c.recoveryCode.ConsumeRecoveryCode(ctx, userID, input.RecoveryCode, func() error {
+			return c.consume(ctx, userID, input.MFAToken)
+		})
+		if err != nil {
+			return userID, err
+		}
+		if !valid {
+			return userID, c.fail(ctx, userID, input.MFAToken, errors.New("invalid or used recovery code", "Invalid MFA Code", errcode.ErrUnauthorized))
+		}
+	default:
+		valid, err := c.totpCredential.VerifyTotpCode(ctx, userID, input.Code)
+		if err != nil {
+			return userID, err
+		}
+		if !valid {
+			return userID, c.fail(ctx, userID, input.MFAToken, errors.New("invalid TOTP code", "Invalid MFA Code", errcode.ErrUnauthorized))
+		}
+		if err := c.consume(ctx, userID, input.MFAToken); err != nil {
+			return userID, err
+		}
+	}
+	if err := c.limiter.Reset(ctx, limitKey); err != nil {
+		return userID, err
+	}
+
+	if input.RecoveryCode != "" {
+		// The use of a recovery code may mean the second factor is lost or
+		// compromised, so the user is warned
+		if err := c.authEvent.EmitRecoveryCodeUsedEvent(ctx, userID); err != nil {
+			return userID, err
+		}
+		c.notifier.NotifyRecoveryCodeUsed(ctx, userID)
+	}
+
+	return userID, nil
+}
+
+// NewChallengeUsecase creates a new instance of ChallengeUsecase.
+func NewChallengeUsecase(
+	totpCredential *dbrepo.TotpCredentialRepository,
+	webauthnCredential *dbrepo.WebauthnCredentialRepository,
+	recoveryCode *dbrepo.RecoveryCodeRepository,
+	authEvent *autheventrepo.AuthEventEmitter,
+	notifier *notice.SecurityNotifier,
+	passkeyVerifier *passkeyauth.AssertionVerifier,
+	challengeManager *coderepo.CodeManager,
+	limiter *ratelimitrepo.Limiter,
+) *ChallengeUsecase {
+	return &ChallengeUsecase{
+		totpCredential:     totpCredential,
+		webauthnCredential: webauthnCredential,
+		recoveryCode:       recoveryCode,
+		authEvent:          authEvent,
+		notifier:           notifier,
+		passkeyVerifier:    passkeyVerifier,
+		challengeManager:   challengeManager,
+		limiter:            limiter,
+	}
+}
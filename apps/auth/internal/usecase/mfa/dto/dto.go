@@ -0,0 +1,37 @@
+package mfadto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	passkeydto "mandacode.com/accounts/auth/internal/usecase/passkeyauth/dto"
+)
+
+// Factor types listed by FactorUsecase.ListFactors.
+const (
+	FactorTypeTotp          = "totp"
+	FactorTypePasskey       = "passkey"
+	FactorTypeRecoveryCodes = "recovery_codes"
+)
+
+// Factor describes an enrolled second factor of a user.
+//
+// Recovery codes are listed as a single factor without an ID; Remaining holds
+// the number of unused codes.
+type Factor struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       string     `json:"type"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Remaining  int        `json:"remaining"`
+}
+
+// ChallengeInput carries the answer to an MFA challenge: a TOTP code, a
+// recovery code or a passkey assertion.
+type ChallengeInput struct {
+	MFAToken     string                     `json:"mfa_token"`
+	Code         string                     `json:"code"`
+	RecoveryCode string                     `json:"recovery_code"`
+	Passkey      *passkeydto.AssertionInput `json:"passkey,omitempty"`
+}
@@ -0,0 +1,176 @@
+package mfa
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	autheventv1 "github.com/mandacode-com/accounts-proto/go/auth/event/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+)
+
+type FactorUsecase struct {
+	totpCredential     *dbrepo.TotpCredentialRepository
+	webauthnCredential *dbrepo.WebauthnCredentialRepository
+	recoveryCode       *dbrepo.RecoveryCodeRepository
+	authEvent          *autheventrepo.AuthEventEmitter
+	notifier           *notice.SecurityNotifier
+}
+
+// ListFactors lists the second factors enrolled by the user.
+// A TOTP enrollment that was never confirmed is not listed.
+func (f *FactorUsecase) ListFactors(ctx context.Context, userID uuid.UUID) ([]mfadto.Factor, error) {
+	factors := []mfadto.Factor{}
+
+	totp, err := f.totpCredential.GetTotpCredentialByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, errcode.ErrNotFound) {
+		return nil, errors.Upgrade(err, "Failed to get TOTP credential", errcode.ErrInternalFailure)
+	}
+	if err == nil && totp.IsConfirmed {
+		factors = append(factors, mfadto.Factor{
+			ID:        totp.ID,
+			Type:      mfadto.FactorTypeTotp,
+			Name:      totp.Name,
+			CreatedAt: totp.CreatedAt,
+		})
+	}
+
+	passkeys, err := f.webauthnCredential.GetWebauthnCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, passkey := range passkeys {
+		factors = append(factors, mfadto.Factor{
+			ID:         passkey.ID,
+			Type:       mfadto.FactorTypePasskey,
+			Name:       passkey.Name,
+			CreatedAt:  passkey.CreatedAt,
+			LastUsedAt: passkey.LastUsedAt,
+		})
+	}
+
+	codes, err := f.recoveryCode.GetRecoveryCodesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) > 0 {
+		factor := mfadto.Factor{
+			Type:      mfadto.FactorTypeRecoveryCodes,
+			CreatedAt: codes[0].CreatedAt,
+		}
+		for _, code := range codes {
+			if code.UsedAt == nil {
+				factor.Remaining++
+			} else if factor.LastUsedAt == nil || code.UsedAt.After(*factor.LastUsedAt) {
+				factor.LastUsedAt = code.UsedAt
+			}
+		}
+		factors = append(factors, factor)
+	}
+
+	return factors, nil
+}
+
+// RenameTotp sets the display name of the user's TOTP authenticator.
+func (f *FactorUsecase) RenameTotp(ctx context.Context, userID uuid.UUID, name string) error {
+	credential, err := f.totpCredential.RenameTotpCredential(ctx, userID, name)
+	if err != nil {
+		return err
+	}
+
+	if err := f.authEvent.EmitFactorRenamedEvent(ctx, userID, autheventv1.FactorType_TOTP, credential.ID); err != nil {
+		return err
+	}
+	f.notifier.NotifyMFAChanged(ctx, userID, mailer.MFAChangeFactorRenamed)
+	return nil
+}
+
+// RemoveTotp removes the user's TOTP authenticator, including a pending enrollment.
+func (f *FactorUsecase) RemoveTotp(ctx context.Context, userID uuid.UUID) error {
+	credential, err := f.totpCredential.GetTotpCredentialByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := f.totpCredential.DeleteTotpCredentialByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	// Nothing changed for the user until the enrollment was confirmed
+	if !credential.IsConfirmed {
+		return nil
+	}
+
+	if err := f.authEvent.EmitFactorRemovedEvent(ctx, userID, autheventv1.FactorType_TOTP, credential.ID); err != nil {
+		return err
+	}
+	f.notifier.NotifyMFAChanged(ctx, userID, mailer.MFAChangeFactorRemoved)
+	return nil
+}
+
+// RenamePasskey sets the display name of one of the user's passkeys.
+func (f *FactorUsecase) RenamePasskey(ctx context.Context, userID uuid.UUID, passkeyID uuid.UUID, name string) error {
+	if _, err := f.webauthnCredential.RenameWebauthnCredential(ctx, userID, passkeyID, name); err != nil {
+		return err
+	}
+
+	if err := f.authEvent.EmitFactorRenamedEvent(ctx, userID, autheventv1.FactorType_PASSKEY, passkeyID); err != nil {
+		return err
+	}
+	f.notifier.NotifyMFAChanged(ctx, userID, mailer.MFAChangeFactorRenamed)
+	return nil
+}
+
+// RemovePasskey removes one of the user's passkeys.
+func (f *FactorUsecase) RemovePasskey(ctx context.Context, userID uuid.UUID, passkeyID uuid.UUID) error {
+	if err := f.webauthnCredential.DeleteWebauthnCredential(ctx, userID, passkeyID); err != nil {
+		return err
+	}
+
+	if err := f.authEvent.EmitFactorRemovedEvent(ctx, userID, autheventv1.FactorType_PASSKEY, passkeyID); err != nil {
+		return err
+	}
+	f.notifier.NotifyMFAChanged(ctx, userID, mailer.MFAChangeFactorRemoved)
+	return nil
+}
+
+// RemoveRecoveryCodes removes all recovery codes of the user.
+func (f *FactorUsecase) RemoveRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	codes, err := f.recoveryCode.GetRecoveryCodesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return errors.New("user has no recovery codes", "Recovery Codes Not Found", errcode.ErrNotFound)
+	}
+	if err := f.recoveryCode.DeleteRecoveryCodesByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := f.authEvent.EmitFactorRemovedEvent(ctx, userID, autheventv1.FactorType_RECOVERY_CODE, uuid.Nil); err != nil {
+		return err
+	}
+	f.notifier.NotifyMFAChanged(ctx, userID, mailer.MFAChangeFactorRemoved)
+	return nil
+}
+
+// NewFactorUsecase creates a new instance of FactorUsecase.
+func NewFactorUsecase(
+	totpCredential *dbrepo.TotpCredentialRepository,
+	webauthnCredential *dbrepo.WebauthnCredentialRepository,
+	recoveryCode *dbrepo.RecoveryCodeRepository,
+	authEvent *autheventrepo.AuthEventEmitter,
+	notifier *notice.SecurityNotifier,
+) *FactorUsecase {
+	return &FactorUsecase{
+		totpCredential:     totpCredential,
+		webauthnCredential: webauthnCredential,
+		recoveryCode:       recoveryCode,
+		authEvent:          authEvent,
+		notifier:           notifier,
+	}
+}
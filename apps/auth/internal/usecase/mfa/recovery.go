@@ -0,0 +1,116 @@
+package mfa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// recoveryCodeCount is the number of recovery codes in a generated set.
+const recoveryCodeCount = 10
+
+type RecoveryCodeUsecase struct {
+	totpCredential     *dbrepo.TotpCredentialRepository
+	webauthnCredential *dbrepo.WebauthnCredentialRepository
+	recoveryCode       *dbrepo.RecoveryCodeRepository
+	authEvent          *autheventrepo.AuthEventEmitter
+	notifier           *notice.SecurityNotifier
+	codeGenerator      *util.RandomGenerator
+	logger             *zap.Logger
+}
+
+// hasSecondFactor reports whether the user has a confirmed TOTP authenticator or a passkey.
+func hasSecondFactor(ctx context.Context, totpCredential *dbrepo.TotpCredentialRepository, webauthnCredential *dbrepo.WebauthnCredentialRepository, userID uuid.UUID) (bool, error) {
+	totp, err := totpCredential.GetTotpCredentialByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, errcode.ErrNotFound) {
+		return false, errors.Upgrade(err, "Failed to get TOTP credential", errcode.ErrInternalFailure)
+	}
+	if err == nil && totp.IsConfirmed {
+		return true, nil
+	}
+
+	passkeys, err := webauthnCredential.GetWebauthnCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return len(passkeys) > 0, nil
+}
+
+// GenerateRecoveryCodes generates a new set of recovery codes for the user and
+// returns them in plain text. They are only stored hashed, so this is the only
+// time they can be shown. Earlier codes stop working.
+//
+// Once the codes are stored they are always returned; failing to publish the
+// event or to notify the user is only logged, since the user could not see the
+// codes that replaced their old ones otherwise.
+func (r *RecoveryCodeUsecase) GenerateRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	enrolled, err := hasSecondFactor(ctx, r.totpCredential, r.webauthnCredential, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enrolled {
+		return nil, errors.New("user has no second factor", "No Second Factor Enrolled", errcode.ErrInvalidInput)
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := r.codeGenerator.GenerateSecureRandomCode()
+		if err != nil {
+			return nil, errors.New(err.Error(), "Failed to generate recovery code", errcode.ErrInternalFailure)
+		}
+		codes = append(codes, formatRecoveryCode(code))
+	}
+
+	if err := r.recoveryCode.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+
+	if err := r.authEvent.EmitRecoveryCodesGeneratedEvent(ctx, userID); err != nil {
+		r.logger.Error("failed to emit recovery codes generated event", zap.String("user_id", userID.String()), zap.Error(err))
+	}
+	r.notifier.NotifyMFAChanged(ctx, userID, mailer.MFAChangeRecoveryCodesGenerated)
+
+	return codes, nil
+}
+
+// formatRecoveryCode splits a code into groups of four characters for readability.
+// The separators are ignored when a code is checked.
+func formatRecoveryCode(code string) string {
+	groups := make([]string, 0, (len(code)+3)/4)
+	for len(code) > 4 {
+		groups = append(groups, code[:4])
+		code = code[4:]
+	}
+	groups = append(groups, code)
+	return strings.Join(groups, "-")
+}
+
+// NewRecoveryCodeUsecase creates a new instance of RecoveryCodeUsecase.
+func NewRecoveryCodeUsecase(
+	totpCredential *dbrepo.TotpCredentialRepository,
+	webauthnCredential *dbrepo.WebauthnCredentialRepository,
+	recoveryCode *dbrepo.RecoveryCodeRepository,
+	authEvent *autheventrepo.AuthEventEmitter,
+	notifier *notice.SecurityNotifier,
+	codeGenerator *util.RandomGenerator,
+	logger *zap.Logger,
+) *RecoveryCodeUsecase {
+	return &RecoveryCodeUsecase{
+		totpCredential:     totpCredential,
+		webauthnCredential: webauthnCredential,
+		recoveryCode:       recoveryCode,
+		authEvent:          authEvent,
+		notifier:           notifier,
+		codeGenerator:      codeGenerator,
+		logger:             logger,
+	}
+}
@@ -0,0 +1,172 @@
+package notice
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+)
+
+// SecurityNotifier mails the user about sensitive events on their account.
+// Every notification links to the page where the user can revoke sessions.
+//
+// Notices are best-effort: the event they describe has already happened, so a
+// notice that cannot be sent is logged instead of failing the caller.
+type SecurityNotifier struct {
+	authAccount  *dbrepo.AuthAccountRepository
+	authActivity *dbrepo.AuthActivityRepository
+	mailer       *mailer.Mailer
+	logger       *zap.Logger
+}
+
+// report logs a notice that could not be sent.
+func (n *SecurityNotifier) report(notice string, err error, fields ...zap.Field) {
+	if err == nil {
+		return
+	}
+	fields = append(fields, zap.String("notice", notice), zap.Error(err))
+	n.logger.Error("failed to send security notice", fields...)
+}
+
+// email returns the address of the user's local account, or of their first
+// auth account if they have no local one.
+func (n *SecurityNotifier) email(ctx context.Context, userID uuid.UUID) (string, error) {
+	accounts, err := n.authAccount.GetAuthAccountsByUserID(ctx, userID)
+	if err != nil {
+		return "", errors.Upgrade(err, "Failed to get auth accounts", errcode.ErrInternalFailure)
+	}
+	if len(accounts) == 0 {
+		return "", errors.New("user has no auth account", "Account Not Found", errcode.ErrNotFound)
+	}
+	for _, account := range accounts {
+		if account.Provider == providermodels.ProviderLocal {
+			return account.Email, nil
+		}
+	}
+	return accounts[0].Email, nil
+}
+
+// NotifySignIn warns the user of a sign-in from a client their account was
+// not used from before, described by the request information of ctx.
+//
+// It must be called before the sign-in itself is recorded as a success, or
+// the client would already be known.
+func (n *SecurityNotifier) NotifySignIn(ctx context.Context, userID uuid.UUID) {
+	n.report("new_sign_in", n.notifySignIn(ctx, userID), zap.String("user_id", userID.String()))
+}
+
+func (n *SecurityNotifier) notifySignIn(ctx context.Context, userID uuid.UUID) error {
+	known, err := n.authActivity.IsKnownClient(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if known {
+		return nil
+	}
+
+	email, err := n.email(ctx, userID)
+	if err != nil {
+		return err
+	}
+	info := reqmodels.RequestInfoFrom(ctx)
+	if err := n.mailer.SendNewSignInMail(email, info.DeviceName, info.UserAgent, info.IP); err != nil {
+		return errors.Upgrade(err, "Failed to send new sign-in notice", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// NotifyPasswordChanged tells the user that their password was changed, or
+// reset through a reset link if reset is set.
+func (n *SecurityNotifier) NotifyPasswordChanged(ctx context.Context, userID uuid.UUID, reset bool) {
+	n.report("password_changed", n.notifyPasswordChanged(ctx, userID, reset), zap.String("user_id", userID.String()))
+}
+
+func (n *SecurityNotifier) notifyPasswordChanged(ctx context.Context, userID uuid.UUID, reset bool) error {
+	email, err := n.email(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := n.mailer.SendPasswordChangedMail(email, reset); err != nil {
+		return errors.Upgrade(err, "Failed to send password change notice", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// NotifyEmailChanged tells the previous address of the user that the email of
+// the account was changed to newEmail.
+func (n *SecurityNotifier) NotifyEmailChanged(ctx context.Context, userID uuid.UUID, previousEmail string, newEmail string) {
+	if err := n.mailer.SendEmailChangedMail(previousEmail, newEmail); err != nil {
+		n.report("email_changed", err, zap.String("user_id", userID.String()))
+	}
+}
+
+// NotifyProviderLinkChanged tells the user that the provider was linked to
+// their account, or unlinked from it if linked is false.
+func (n *SecurityNotifier) NotifyProviderLinkChanged(ctx context.Context, userID uuid.UUID, provider providermodels.Provider, linked bool) {
+	n.report("provider_link_changed", n.notifyProviderLinkChanged(ctx, userID, provider, linked), zap.String("user_id", userID.String()), zap.String("provider", provider.String()))
+}
+
+func (n *SecurityNotifier) notifyProviderLinkChanged(ctx context.Context, userID uuid.UUID, provider providermodels.Provider, linked bool) error {
+	email, err := n.email(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := n.mailer.SendProviderLinkChangedMail(email, provider.String(), linked); err != nil {
+		return errors.Upgrade(err, "Failed to send provider link notice", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// NotifyMFAChanged tells the user of a change to their MFA factors.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - userID: The unique identifier of the user.
+//   - change: The kind of change, one of the mailer.MFAChange constants.
+func (n *SecurityNotifier) NotifyMFAChanged(ctx context.Context, userID uuid.UUID, change string) {
+	n.report("mfa_changed", n.notifyMFAChanged(ctx, userID, change), zap.String("user_id", userID.String()), zap.String("change", change))
+}
+
+func (n *SecurityNotifier) notifyMFAChanged(ctx context.Context, userID uuid.UUID, change string) error {
+	email, err := n.email(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := n.mailer.SendMFAChangedMail(email, change); err != nil {
+		return errors.Upgrade(err, "Failed to send MFA change notice", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// NotifyRecoveryCodeUsed tells the user that a recovery code was used to sign
+// in, since it may mean their second factor is lost or compromised.
+func (n *SecurityNotifier) NotifyRecoveryCodeUsed(ctx context.Context, userID uuid.UUID) {
+	n.report("recovery_code_used", n.notifyRecoveryCodeUsed(ctx, userID), zap.String("user_id", userID.String()))
+}
+
+func (n *SecurityNotifier) notifyRecoveryCodeUsed(ctx context.Context, userID uuid.UUID) error {
+	email, err := n.email(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := n.mailer.SendSecurityNoticeMail(email, mailer.NoticeRecoveryCodeUsed); err != nil {
+		return errors.Upgrade(err, "Failed to send security notice", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// NewSecurityNotifier creates a new instance of SecurityNotifier.
+func NewSecurityNotifier(authAccount *dbrepo.AuthAccountRepository, authActivity *dbrepo.AuthActivityRepository, mailer *mailer.Mailer, logger *zap.Logger) *SecurityNotifier {
+	return &SecurityNotifier{
+		authAccount:  authAccount,
+		authActivity: authActivity,
+		mailer:       mailer,
+		logger:       logger,
+	}
+}
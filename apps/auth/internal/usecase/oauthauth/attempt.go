@@ -0,0 +1,94 @@
+package oauthauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// attemptSecretGenerator generates nonces and PKCE verifiers. 32 bytes encode
+// to 64 hex characters, within the 43 to 128 characters PKCE allows.
+var attemptSecretGenerator = util.NewRandomGenerator(32)
+
+// newLoginAttempt creates a login attempt with a fresh nonce and PKCE verifier.
+func newLoginAttempt(provider providermodels.Provider, returnTo string, inviteCode string) (*coderepo.LoginAttempt, error) {
+	nonce, err := attemptSecretGenerator.GenerateSecureRandomCode()
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to generate nonce", errcode.ErrInternalFailure)
+	}
+	codeVerifier, err := attemptSecretGenerator.GenerateSecureRandomCode()
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to generate code verifier", errcode.ErrInternalFailure)
+	}
+
+	return &coderepo.LoginAttempt{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ReturnTo:     returnTo,
+		InviteCode:   inviteCode,
+	}, nil
+}
+
+// consumeAttempt consumes the login attempt of the state, which must have
+// been started for the provider.
+func (l *LoginUsecase) consumeAttempt(ctx context.Context, provider providermodels.Provider, state string) (*coderepo.LoginAttempt, error) {
+	if state == "" {
+		return nil, errors.New("state is missing", "Invalid State", errcode.ErrUnauthorized)
+	}
+
+	attempt, ok, err := l.attemptManager.ConsumeAttempt(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("state is invalid or expired", "Invalid State", errcode.ErrUnauthorized)
+	}
+	if attempt.Provider != provider {
+		return nil, errors.New("state was issued for provider "+string(attempt.Provider), "Invalid State", errcode.ErrUnauthorized)
+	}
+	return attempt, nil
+}
+
+// codeChallengeS256 derives the S256 PKCE challenge of the verifier.
+func codeChallengeS256(codeVerifier string) string {
+	digest := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// isAllowedReturnURL reports whether returnTo has the scheme and host of one
+// of the allowed URLs and lies under its path.
+func isAllowedReturnURL(allowed []string, returnTo string) bool {
+	target, err := url.Parse(returnTo)
+	if err != nil || target.Scheme == "" || target.Host == "" || target.User != nil {
+		return false
+	}
+
+	// Resolve dot segments, so that the path cannot climb out of an allowed one
+	targetPath := path.Clean("/" + target.Path)
+
+	for _, candidate := range allowed {
+		base, err := url.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		if target.Scheme != base.Scheme || target.Host != base.Host {
+			continue
+		}
+		basePath := strings.TrimSuffix(base.Path, "/")
+		if targetPath == basePath || strings.HasPrefix(targetPath, basePath+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,302 @@
+package oauthauth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
+)
+
+// LinkPolicy decides what happens when a new provider identity has the email
+// of an existing local account.
+type LinkPolicy string
+
+const (
+	LinkPolicyNever    LinkPolicy = "never"    // Always create a new user
+	LinkPolicyVerified LinkPolicy = "verified" // Link when both emails are verified
+	LinkPolicyConfirm  LinkPolicy = "confirm"  // Link after the user enters the local password
+)
+
+// linkOrCreateUser resolves the user of a provider identity seen for the first
+// time, following the link policy.
+//
+// With LinkPolicyConfirm, no user is resolved and linkToken holds the pending
+// link to pass to ConfirmLink instead. So does LinkPolicyVerified for a local
+// account with MFA enabled.
+//
+// inviteCode is only redeemed if a new user is created.
+func (l *LoginUsecase) linkOrCreateUser(ctx context.Context, provider providermodels.Provider, userInfo *oauthmodels.UserInfo, inviteCode string) (account *dbmodels.SecureOAuthAuthAccount, linkToken string, err error) {
+	// An unverified provider email proves nothing about the local account
+	if l.linkPolicy == LinkPolicyNever || !userInfo.EmailVerified {
+		account, err = l.createOAuth(ctx, provider, userInfo, inviteCode)
+		return account, "", err
+	}
+
+	local, err := l.authAccount.GetLocalAuthAccountByEmail(ctx, userInfo.Email)
+	if err != nil {
+		if !errors.Is(err, errcode.ErrNotFound) {
+			return nil, "", errors.Upgrade(err, "Failed to get local account", errcode.ErrInternalFailure)
+		}
+		account, err = l.createOAuth(ctx, provider, userInfo, inviteCode)
+		return account, "", err
+	}
+
+	link := &coderepo.PendingLink{
+		UserID:     local.UserID,
+		Provider:   provider,
+		ProviderID: userInfo.ProviderID,
+		Email:      userInfo.Email,
+		IsVerified: userInfo.EmailVerified,
+	}
+	switch l.linkPolicy {
+	case LinkPolicyVerified:
+		if !local.IsVerified {
+			account, err = l.createOAuth(ctx, provider, userInfo, inviteCode)
+			return account, "", err
+		}
+		// The provider must not stand in for the second factor of the user
+		enrolled, err := l.mfaChallenge.HasSecondFactor(ctx, local.UserID)
+		if err != nil {
+			return nil, "", err
+		}
+		if enrolled {
+			linkToken, err = l.pendingLinkManager.IssueLink(ctx, link)
+			if err != nil {
+				return nil, "", err
+			}
+			return nil, linkToken, nil
+		}
+		account, err = l.linkOAuth(ctx, link)
+		return account, "", err
+	case LinkPolicyConfirm:
+		linkToken, err = l.pendingLinkManager.IssueLink(ctx, link)
+		if err != nil {
+			return nil, "", err
+		}
+		return nil, linkToken, nil
+	default:
+		return nil, "", errors.New("unknown link policy: "+string(l.linkPolicy), "Internal Error", errcode.ErrInternalFailure)
+	}
+}
+
+// linkOAuth creates the OAuth account of the pending link for its existing
+// user, records the link as an auth event and tells the user by mail.
+func (l *LoginUsecase) linkOAuth(ctx context.Context, link *coderepo.PendingLink) (*dbmodels.SecureOAuthAuthAccount, error) {
+	account, err := l.authAccount.CreateOAuthAuthAccount(ctx, &dbmodels.CreateOAuthAuthAccountInput{
+		UserID:     link.UserID,
+		Provider:   link.Provider,
+		ProviderID: link.ProviderID,
+		Email:      link.Email,
+		IsVerified: link.IsVerified,
+	})
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to link OAuth account", errcode.ErrInternalFailure)
+	}
+
+	if err := l.authEvent.EmitAccountLinkedEvent(ctx, link.UserID, string(link.Provider)); err != nil {
+		return nil, err
+	}
+	l.notifier.NotifyProviderLinkChanged(ctx, link.UserID, link.Provider, true)
+	return account, nil
+}
+
+// finishLink consumes the pending link and links the provider identity.
+func (l *LoginUsecase) finishLink(ctx context.Context, linkToken string, link *coderepo.PendingLink) (uuid.UUID, error) {
+	consumed, err := l.pendingLinkManager.ConsumeLink(ctx, linkToken)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !consumed {
+		return uuid.Nil, errors.New("link token was already used", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	account, err := l.linkOAuth(ctx, link)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !account.IsVerified {
+		return uuid.Nil, errors.New("user is not verified", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	return account.UserID, nil
+}
+
+// completeLink checks the local password for a pending link and links the
+// provider identity on success.
+//
+// If the user has MFA enabled, the link is kept pending and mfaToken holds
+// the challenge to pass to completeLinkWithMFA instead.
+func (l *LoginUsecase) completeLink(ctx context.Context, input oauthdto.ConfirmLinkInput) (userID uuid.UUID, mfaToken string, err error) {
+	link, ok, err := l.pendingLinkManager.GetLink(ctx, input.LinkToken)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if !ok {
+		return uuid.Nil, "", errors.New("link token is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	local, err := l.authAccount.GetLocalAuthAccountByUserID(ctx, link.UserID)
+	if err != nil {
+		return uuid.Nil, "", errors.Upgrade(err, "Failed to get local account", errcode.ErrInternalFailure)
+	}
+
+	// Count failures together with password logins of the same account
+	limitKeys := []string{ratelimitrepo.EmailKey(local.Email), ratelimitrepo.IPKey(input.ClientIP)}
+	if err := l.loginLimiter.Check(ctx, limitKeys...); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	verified, userID, err := l.authAccount.ComparePassword(ctx, local.Email, input.Password)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if !verified || userID != link.UserID {
+		if err := l.loginLimiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return uuid.Nil, "", err
+		}
+		return uuid.Nil, "", errors.New("invalid password", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	mfaToken, err = l.mfaChallenge.IssueChallenge(ctx, link.UserID)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if mfaToken != "" {
+		return link.UserID, mfaToken, nil
+	}
+	if err := l.loginLimiter.Reset(ctx, ratelimitrepo.EmailKey(local.Email)); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	userID, err = l.finishLink(ctx, input.LinkToken, link)
+	return userID, "", err
+}
+
+// completeLinkWithMFA links the provider identity of a pending link once the
+// MFA challenge issued by completeLink is passed.
+//
+// Once the challenge is found, its user ID is also returned with an error.
+func (l *LoginUsecase) completeLinkWithMFA(ctx context.Context, input oauthdto.ConfirmLinkMFAInput) (uuid.UUID, error) {
+	link, ok, err := l.pendingLinkManager.GetLink(ctx, input.LinkToken)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !ok {
+		return uuid.Nil, errors.New("link token is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	userID, err := l.mfaChallenge.CompleteChallenge(ctx, input.Challenge)
+	if err != nil {
+		return userID, err
+	}
+	if userID != link.UserID {
+		return userID, errors.New("MFA challenge is for another user", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	local, err := l.authAccount.GetLocalAuthAccountByUserID(ctx, link.UserID)
+	if err != nil {
+		return userID, errors.Upgrade(err, "Failed to get local account", errcode.ErrInternalFailure)
+	}
+	if err := l.loginLimiter.Reset(ctx, ratelimitrepo.EmailKey(local.Email)); err != nil {
+		return userID, err
+	}
+
+	return l.finishLink(ctx, input.LinkToken, link)
+}
+
+// ConfirmLink links a pending provider identity after the local password is
+// confirmed and issues tokens for the user.
+//
+// If the user has MFA enabled, no tokens are issued and mfaToken holds the
+// challenge to pass to ConfirmLinkWithMFA instead.
+func (l *LoginUsecase) ConfirmLink(ctx context.Context, input oauthdto.ConfirmLinkInput) (accessToken string, refreshToken string, mfaToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodOAuth, "", userID, mfaToken != "", &err)
+	}()
+
+	userID, mfaToken, err = l.completeLink(ctx, input)
+	if err != nil || mfaToken != "" {
+		return "", "", mfaToken, err
+	}
+
+	// Generate access and refresh tokens
+	accessToken, refreshToken, err = l.issuer.IssueToken(ctx, userID)
+	return accessToken, refreshToken, "", err
+}
+
+// ConfirmLinkWithMFA links a pending provider identity after the MFA
+// challenge of ConfirmLink is passed and issues tokens for the user.
+func (l *LoginUsecase) ConfirmLinkWithMFA(ctx context.Context, input oauthdto.ConfirmLinkMFAInput) (accessToken string, refreshToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodMFA, "", userID, false, &err)
+	}()
+
+	userID, err = l.completeLinkWithMFA(ctx, input)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Generate access and refresh tokens
+	return l.issuer.IssueToken(ctx, userID)
+}
+
+// IssueLoginCodeWithLink links a pending provider identity after the local
+// password is confirmed and issues a login code for the user.
+//
+// If the user has MFA enabled, no code is issued and mfaToken holds the
+// challenge to pass to IssueLoginCodeWithLinkMFA instead.
+func (l *LoginUsecase) IssueLoginCodeWithLink(ctx context.Context, input oauthdto.ConfirmLinkInput) (code string, userID uuid.UUID, mfaToken string, err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodOAuth, "", attemptUserID, mfaToken != "", &err)
+	}()
+
+	userID, mfaToken, err = l.completeLink(ctx, input)
+	attemptUserID = userID
+	if err != nil {
+		return "", uuid.Nil, "", err
+	}
+	if mfaToken != "" {
+		return "", uuid.Nil, mfaToken, nil
+	}
+
+	code, err = l.loginCodeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return "", uuid.Nil, "", errors.Upgrade(err, "Failed to issue login code", errcode.ErrInternalFailure)
+	}
+
+	return code, userID, "", nil
+}
+
+// IssueLoginCodeWithLinkMFA links a pending provider identity after the MFA
+// challenge of IssueLoginCodeWithLink is passed and issues a login code for
+// the user.
+func (l *LoginUsecase) IssueLoginCodeWithLinkMFA(ctx context.Context, input oauthdto.ConfirmLinkMFAInput) (code string, userID uuid.UUID, err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodMFA, "", attemptUserID, false, &err)
+	}()
+
+	userID, err = l.completeLinkWithMFA(ctx, input)
+	attemptUserID = userID
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	code, err = l.loginCodeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return "", uuid.Nil, errors.Upgrade(err, "Failed to issue login code", errcode.ErrInternalFailure)
+	}
+
+	return code, userID, nil
+}
@@ -1,17 +1,63 @@
 package oauthdto
 
-import "mandacode.com/accounts/auth/ent/authaccount"
+import (
+	"github.com/google/uuid"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+)
 
 type LoginInput struct {
-	Provider    authaccount.Provider `json:"provider"`
-	AccessToken string               `json:"access_token,omitempty"` // Optional, used for OAuth providers that require an access token
-	Code        string               `json:"code,omitempty"`         // Optional, used for OAuth providers that require a code exchange
+	Provider     providermodels.Provider `json:"provider"`
+	AccessToken  string                  `json:"access_token,omitempty"`  // Optional, an opaque token of a native SDK
+	IDToken      string                  `json:"id_token,omitempty"`      // Optional, an ID token of a native SDK, preferred over the access token
+	Code         string                  `json:"code,omitempty"`          // Optional, used for OAuth providers that require a code exchange
+	Name         string                  `json:"name,omitempty"`          // Optional, for providers sending the name apart from the user info
+	Nonce        string                  `json:"nonce,omitempty"`         // Nonce the ID token must carry
+	CodeVerifier string                  `json:"code_verifier,omitempty"` // PKCE verifier of the code
+	InviteCode   string                  `json:"invite_code,omitempty"`   // Optional, admits the signup of a new user while signup is invite-only
 	// Info        models.RequestInfo `json:"info"`
 }
 
+type CallbackInput struct {
+	Provider providermodels.Provider `json:"provider"`
+	Code     string                  `json:"code"`
+	State    string                  `json:"state"`
+	Name     string                  `json:"name,omitempty"` // Optional, for providers sending the name apart from the user info
+}
+
+type LoginCodeOutput struct {
+	Code      string    `json:"code,omitempty"`
+	UserID    uuid.UUID `json:"user_id,omitempty"`
+	LinkToken string    `json:"link_token,omitempty"` // Set instead of the code when the identity must be linked first
+	MFAToken  string    `json:"mfa_token,omitempty"`  // Set instead of the code when the user must pass an MFA challenge first
+	ReturnTo  string    `json:"return_to,omitempty"`
+}
+
 type SignupInput struct {
-	Provider    authaccount.Provider `json:"provider"`
-	AccessToken string               `json:"access_token,omitempty"` // Optional, used for OAuth providers that require an access token
-	Code        string               `json:"code,omitempty"`         // Optional, used for OAuth providers that require a code exchange
+	Provider    providermodels.Provider `json:"provider"`
+	AccessToken string                  `json:"access_token,omitempty"` // Optional, used for OAuth providers that require an access token
+	Code        string                  `json:"code,omitempty"`         // Optional, used for OAuth providers that require a code exchange
 	// Info        models.RequestInfo `json:"info"`
 }
+
+type LinkInput struct {
+	UserID      uuid.UUID               `json:"user_id"`
+	Provider    providermodels.Provider `json:"provider"`
+	AccessToken string                  `json:"access_token,omitempty"` // Optional, an opaque token of a native SDK
+	IDToken     string                  `json:"id_token,omitempty"`     // Optional, an ID token of a native SDK, preferred over the access token
+	Nonce       string                  `json:"nonce,omitempty"`        // Optional, the nonce the ID token must carry
+	Code        string                  `json:"code,omitempty"`         // Optional, used for OAuth providers that require a code exchange
+}
+
+type ConfirmLinkInput struct {
+	LinkToken string `json:"link_token"`
+	Password  string `json:"password"`
+	ClientIP  string `json:"client_ip"`
+}
+
+// ConfirmLinkMFAInput answers the MFA challenge issued when a pending link
+// was confirmed for a user with MFA enabled.
+type ConfirmLinkMFAInput struct {
+	LinkToken string                `json:"link_token"`
+	Challenge mfadto.ChallengeInput `json:"challenge"`
+}
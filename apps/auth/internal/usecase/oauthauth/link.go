@@ -0,0 +1,160 @@
+package oauthauth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
+)
+
+// LinkUsecase manages the OAuth identities attached to an existing user.
+type LinkUsecase struct {
+	authAccount        *dbrepo.AuthAccountRepository
+	webauthnCredential *dbrepo.WebauthnCredentialRepository
+	providerToken      *dbrepo.ProviderTokenRepository
+	notifier           *notice.SecurityNotifier
+	tokenProviders     []providermodels.Provider
+	oauthApiMap        map[providermodels.Provider]oauthapi.OAuthAPI
+}
+
+// ListLinkedAccounts lists the auth accounts of the user, including the local one.
+func (l *LinkUsecase) ListLinkedAccounts(ctx context.Context, userID uuid.UUID) ([]*dbmodels.SecureAuthAccount, error) {
+	accounts, err := l.authAccount.GetAuthAccountsByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to get auth accounts", errcode.ErrInternalFailure)
+	}
+	return accounts, nil
+}
+
+// LinkProvider attaches the provider identity to the user.
+//
+// The identity must not belong to another user, and the user can hold only one
+// identity per provider.
+func (l *LinkUsecase) LinkProvider(ctx context.Context, input oauthdto.LinkInput) (*dbmodels.SecureOAuthAuthAccount, error) {
+	if input.Provider == providermodels.ProviderLocal {
+		return nil, errors.New("cannot link a local account", "Invalid Provider", errcode.ErrInvalidInput)
+	}
+
+	userInfo, issued, err := getUserInfo(l.oauthApiMap, input.Provider, input.Code, "", oauthapi.NativeToken{
+		IDToken:     input.IDToken,
+		AccessToken: input.AccessToken,
+		Nonce:       input.Nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := l.authAccount.GetOAuthAccountByProviderAndProviderID(ctx, input.Provider, userInfo.ProviderID)
+	if err != nil && !errors.Is(err, errcode.ErrNotFound) {
+		return nil, errors.Upgrade(err, "Failed to get OAuth account", errcode.ErrInternalFailure)
+	}
+	if err == nil {
+		if existing.UserID == input.UserID {
+			return nil, errors.New("identity is already linked to this user", "Identity Already Linked", errcode.ErrConflict)
+		}
+		return nil, errors.New("identity is linked to another user", "Identity Linked To Another Account", errcode.ErrConflict)
+	}
+
+	_, err = l.authAccount.GetOAuthAuthAccountByUserID(ctx, input.UserID, input.Provider)
+	if err != nil && !errors.Is(err, errcode.ErrNotFound) {
+		return nil, errors.Upgrade(err, "Failed to get OAuth account", errcode.ErrInternalFailure)
+	}
+	if err == nil {
+		return nil, errors.New("user already has an identity of this provider", "Provider Already Linked", errcode.ErrConflict)
+	}
+
+	account, err := l.authAccount.CreateOAuthAuthAccount(ctx, &dbmodels.CreateOAuthAuthAccountInput{
+		UserID:     input.UserID,
+		Provider:   input.Provider,
+		ProviderID: userInfo.ProviderID,
+		Email:      userInfo.Email,
+		IsVerified: userInfo.EmailVerified,
+	})
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to link OAuth account", errcode.ErrInternalFailure)
+	}
+	if err := saveProviderToken(ctx, l.providerToken, l.tokenProviders, account, issued); err != nil {
+		return nil, err
+	}
+	l.notifier.NotifyProviderLinkChanged(ctx, input.UserID, input.Provider, true)
+	return account, nil
+}
+
+// UnlinkProvider detaches the provider identity from the user.
+//
+// It is refused when the user would be left without a way to sign in: another
+// local account, verified OAuth identity or passkey must remain.
+func (l *LinkUsecase) UnlinkProvider(ctx context.Context, userID uuid.UUID, provider providermodels.Provider) error {
+	if provider == providermodels.ProviderLocal {
+		return errors.New("cannot unlink a local account", "Invalid Provider", errcode.ErrInvalidInput)
+	}
+
+	accounts, err := l.authAccount.GetAuthAccountsByUserID(ctx, userID)
+	if err != nil {
+		return errors.Upgrade(err, "Failed to get auth accounts", errcode.ErrInternalFailure)
+	}
+
+	linked := false
+	remaining := 0
+	for _, account := range accounts {
+		switch {
+		case account.Provider == provider:
+			linked = true
+		case account.Provider == providermodels.ProviderLocal:
+			// A local account can always sign in, by password or by email
+			remaining++
+		case account.IsVerified:
+			remaining++
+		}
+	}
+	if !linked {
+		return errors.New("provider is not linked to this user", "Identity Not Found", errcode.ErrNotFound)
+	}
+
+	if remaining == 0 {
+		passkeys, err := l.webauthnCredential.GetWebauthnCredentialsByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(passkeys) == 0 {
+			return errors.New("unlinking would leave no way to sign in", "Cannot Unlink Last Sign-In Method", errcode.ErrConflict)
+		}
+	}
+
+	if err := l.authAccount.DeleteAuthAccountByUserIDAndProvider(ctx, userID, provider); err != nil {
+		return errors.Upgrade(err, "Failed to unlink OAuth account", errcode.ErrInternalFailure)
+	}
+	// The tokens of an unlinked identity must not remain usable
+	if err := l.providerToken.DeleteProviderTokenByUserIDAndProvider(ctx, userID, provider); err != nil {
+		return errors.Upgrade(err, "Failed to delete provider token", errcode.ErrInternalFailure)
+	}
+	l.notifier.NotifyProviderLinkChanged(ctx, userID, provider, false)
+	return nil
+}
+
+// NewLinkUsecase creates a new instance of LinkUsecase.
+func NewLinkUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	webauthnCredential *dbrepo.WebauthnCredentialRepository,
+	providerToken *dbrepo.ProviderTokenRepository,
+	notifier *notice.SecurityNotifier,
+	tokenProviders []providermodels.Provider,
+	oauthApiMap map[providermodels.Provider]oauthapi.OAuthAPI,
+) *LinkUsecase {
+	return &LinkUsecase{
+		authAccount:        authAccount,
+		webauthnCredential: webauthnCredential,
+		providerToken:      providerToken,
+		notifier:           notifier,
+		tokenProviders:     tokenProviders,
+		oauthApiMap:        oauthApiMap,
+	}
+}
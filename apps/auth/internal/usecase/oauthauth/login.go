@@ -3,40 +3,67 @@ package oauthauth
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
-	"mandacode.com/accounts/auth/ent/authaccount"
 
 	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
 	dbmodels "mandacode.com/accounts/auth/internal/models/database"
 	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
 	coderepo "mandacode.com/accounts/auth/internal/repository/code"
 	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
-	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
 	userrepo "mandacode.com/accounts/auth/internal/repository/user"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
 	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
 )
 
 type LoginUsecase struct {
-	authAccount      *dbrepo.AuthAccountRepository
-	userService      *userrepo.UserServiceRepository
-	token            *tokenrepo.TokenRepository
-	loginCodeManager *coderepo.CodeManager
-	oauthApiMap      map[authaccount.Provider]oauthapi.OAuthAPI
+	authAccount        *dbrepo.AuthAccountRepository
+	userService        *userrepo.UserServiceRepository
+	issuer             *session.Issuer
+	mfaChallenge       *mfa.ChallengeUsecase
+	authEvent          *autheventrepo.AuthEventEmitter
+	notifier           *notice.SecurityNotifier
+	signupGate         *signup.Gate
+	loginCodeManager   *coderepo.LoginCodeManager
+	pendingLinkManager *coderepo.PendingLinkManager
+	attemptManager     *coderepo.LoginAttemptManager
+	loginLimiter       *ratelimitrepo.Limiter
+	verifyCodeLimiter  *ratelimitrepo.Limiter
+	providerToken      *dbrepo.ProviderTokenRepository
+	tokenProviders     []providermodels.Provider
+	linkPolicy         LinkPolicy
+	returnURLs         []string
+	oauthApiMap        map[providermodels.Provider]oauthapi.OAuthAPI
 }
 
-// createOAuth creates a new OAuth account in the database.
-func (l *LoginUsecase) createOAuth(ctx context.Context, provider authaccount.Provider, userInfo *oauthmodels.UserInfo) (*dbmodels.SecureOAuthAuthAccount, error) {
+// createOAuth creates a new user with the OAuth account, if the signup policy
+// admits the identity.
+func (l *LoginUsecase) createOAuth(ctx context.Context, provider providermodels.Provider, userInfo *oauthmodels.UserInfo, inviteCode string) (*dbmodels.SecureOAuthAuthAccount, error) {
 	userID := uuid.New()
+	inviteID, err := l.signupGate.Admit(ctx, userInfo.Email, userInfo.EmailVerified, inviteCode, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	initUser, err := l.userService.InitUser(ctx, userID)
 	if err != nil {
-		return nil, errors.Upgrade(err, "Failed to initialize user", errcode.ErrInternalFailure)
+		return nil, l.signupGate.Abort(ctx, inviteID, errors.Upgrade(err, "Failed to initialize user", errcode.ErrInternalFailure))
 	}
 	if initUser.UserId != userID.String() {
 		l.userService.DeleteUser(ctx, userID)
-		return nil, errors.New("user initialization failed", "User Initialization Error", errcode.ErrInternalFailure)
+		return nil, l.signupGate.Abort(ctx, inviteID, errors.New("user initialization failed", "User Initialization Error", errcode.ErrInternalFailure))
 	}
 
 	account, err := l.authAccount.CreateOAuthAuthAccount(
@@ -50,154 +77,348 @@ func (l *LoginUsecase) createOAuth(ctx context.Context, provider authaccount.Pro
 		},
 	)
 	if err != nil {
-		return nil, errors.Upgrade(err, "Failed to create OAuth account", errcode.ErrInternalFailure)
+		l.userService.DeleteUser(ctx, userID)
+		return nil, l.signupGate.Abort(ctx, inviteID, errors.Upgrade(err, "Failed to create OAuth account", errcode.ErrInternalFailure))
 	}
 	return account, nil
 }
 
-// getAccessToken retrieves the access token from the OAuth API.
-func (l *LoginUsecase) getAccessToken(ctx context.Context, provider authaccount.Provider, code string) (string, error) {
-	api, ok := l.oauthApiMap[provider]
-	if !ok {
-		return "", errors.New(fmt.Sprintf("unsupported provider: %s", provider), "UnsupportedProvider", errcode.ErrInvalidInput)
+// upgradeLoginError upgrades err like errors.Upgrade, except for a signup the
+// signup policy refused, whose reason must reach the client.
+func upgradeLoginError(err error, publicMsg string, code string) error {
+	if errors.Is(err, errcode.ErrForbidden) {
+		return err
 	}
-	accessToken, err := api.GetAccessToken(code)
-	if err != nil {
-		return "", errors.Upgrade(err, "Failed to get access token from OAuth provider", errcode.ErrUnauthorized)
-	}
-	return accessToken, nil
+	return errors.Upgrade(err, publicMsg, code)
 }
 
-func (l *LoginUsecase) getOrCreateVerifiedUser(ctx context.Context, input oauthdto.LoginInput) (uuid.UUID, error) {
-	var oauthAccessToken string
-	if input.AccessToken == "" && input.Code != "" {
-		var err error
-		oauthAccessToken, err = l.getAccessToken(ctx, input.Provider, input.Code)
+// getUserInfo retrieves the user info of the provider identity, exchanging
+// the code first if one is given, or verifying the native token otherwise.
+//
+// codeVerifier and token.Nonce come from the login attempt of a web login.
+// Without a code, they come from the app, which got the token from a native SDK.
+//
+// issued holds the tokens the code was exchanged for, and is nil for native tokens.
+func getUserInfo(oauthApiMap map[providermodels.Provider]oauthapi.OAuthAPI, provider providermodels.Provider, code, codeVerifier string, token oauthapi.NativeToken) (userInfo *oauthmodels.UserInfo, issued *oauthmodels.Token, err error) {
+	api, ok := oauthApiMap[provider]
+	if !ok {
+		return nil, nil, errors.New(fmt.Sprintf("unsupported provider: %s", provider), "UnsupportedProvider", errcode.ErrInvalidInput)
+	}
+
+	switch {
+	case code != "":
+		issued, err = api.GetAccessToken(code, codeVerifier)
 		if err != nil {
-			return uuid.Nil, errors.Upgrade(err, "Failed to get access token", errcode.ErrUnauthorized)
+			return nil, nil, errors.Upgrade(err, "Failed to get access token from OAuth provider", errcode.ErrUnauthorized)
 		}
-	} else if input.AccessToken != "" {
-		oauthAccessToken = input.AccessToken
-	} else {
-		return uuid.Nil, errors.New("either access token or code must be provided", "Invalid Input", errcode.ErrInvalidInput)
+		userInfo, err = api.GetUserInfo(issued, token.Nonce)
+		if err != nil {
+			return nil, nil, errors.Upgrade(err, "Failed to get user info from OAuth provider", errcode.ErrUnauthorized)
+		}
+	case token.IDToken != "" || token.AccessToken != "":
+		userInfo, err = api.GetNativeUserInfo(token)
+		if err != nil {
+			return nil, nil, errors.Upgrade(err, "Failed to verify token of OAuth provider", errcode.ErrUnauthorized)
+		}
+	default:
+		return nil, nil, errors.New("either a token or code must be provided", "Invalid Input", errcode.ErrInvalidInput)
+	}
+
+	if userInfo == nil {
+		return nil, nil, errors.New("user info is nil", "InvalidUserInfo", errcode.ErrInvalidInput)
 	}
+	return userInfo, issued, nil
+}
+
+// saveProviderToken stores the tokens issued for the OAuth account for
+// internal services, if the provider is one whose tokens are kept.
+func saveProviderToken(ctx context.Context, repo *dbrepo.ProviderTokenRepository, tokenProviders []providermodels.Provider, account *dbmodels.SecureOAuthAuthAccount, issued *oauthmodels.Token) error {
+	if issued == nil || issued.AccessToken == "" || !slices.Contains(tokenProviders, account.Provider) {
+		return nil
+	}
+	if err := repo.SaveProviderToken(ctx, account, issued); err != nil {
+		return errors.Upgrade(err, "Failed to save provider token", errcode.ErrInternalFailure)
+	}
+	return nil
+}
 
-	userInfo, err := l.oauthApiMap[input.Provider].GetUserInfo(oauthAccessToken)
+// getOrCreateVerifiedUser resolves the user of the provider identity, creating
+// or linking a user for an identity seen for the first time.
+//
+// If the link policy asks the user to confirm a link, no user is resolved and
+// linkToken holds the pending link instead. The tokens the provider issued are
+// then not kept, and are stored at the next login instead.
+func (l *LoginUsecase) getOrCreateVerifiedUser(ctx context.Context, input oauthdto.LoginInput) (userID uuid.UUID, linkToken string, err error) {
+	userInfo, issued, err := getUserInfo(l.oauthApiMap, input.Provider, input.Code, input.CodeVerifier, oauthapi.NativeToken{
+		IDToken:     input.IDToken,
+		AccessToken: input.AccessToken,
+		Nonce:       input.Nonce,
+	})
 	if err != nil {
-		return uuid.Nil, errors.Upgrade(err, "Failed to get user info from OAuth provider", errcode.ErrUnauthorized)
+		return uuid.Nil, "", err
 	}
-	if userInfo == nil {
-		return uuid.Nil, errors.New("user info is nil", "InvalidUserInfo", errcode.ErrInvalidInput)
+	if userInfo.Name == "" {
+		userInfo.Name = input.Name
 	}
 
-	var verified bool
-	var userID uuid.UUID
 	oauth, err := l.authAccount.GetOAuthAccountByProviderAndProviderID(ctx, input.Provider, userInfo.ProviderID)
 	if err != nil {
-		if errors.Is(err, errcode.ErrNotFound) {
-			// User not found, create a new OAuth account
-			newAccount, err := l.createOAuth(ctx, input.Provider, userInfo)
-			if err != nil {
-				return uuid.Nil, errors.Upgrade(err, "Failed to create OAuth account", errcode.ErrInternalFailure)
-			}
-			userID = newAccount.UserID
-			verified = newAccount.IsVerified
+		if !errors.Is(err, errcode.ErrNotFound) {
+			return uuid.Nil, "", errors.Upgrade(err, "Failed to get OAuth account", errcode.ErrInternalFailure)
+		}
+		// User not found, create or link a new OAuth account
+		oauth, linkToken, err = l.linkOrCreateUser(ctx, input.Provider, userInfo, input.InviteCode)
+		if err != nil {
+			return uuid.Nil, "", upgradeLoginError(err, "Failed to create OAuth account", errcode.ErrInternalFailure)
+		}
+		if linkToken != "" {
+			return uuid.Nil, linkToken, nil
 		}
-		return uuid.Nil, errors.Upgrade(err, "Failed to get OAuth account", errcode.ErrInternalFailure)
-	} else {
-		userID = oauth.UserID
-		verified = oauth.IsVerified
 	}
 
-	if !verified {
-		return uuid.Nil, errors.New("user is not verified", "Unauthorized", errcode.ErrUnauthorized)
+	if !oauth.IsVerified {
+		return uuid.Nil, "", errors.New("user is not verified", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if err := saveProviderToken(ctx, l.providerToken, l.tokenProviders, oauth, issued); err != nil {
+		return uuid.Nil, "", err
 	}
 
-	return userID, nil
+	return oauth.UserID, "", nil
 }
 
-// GetLoginURL implements oauthdomain.LoginUsecase.
-func (l *LoginUsecase) GetLoginURL(ctx context.Context, provider string) (loginURL string, err error) {
-	api, ok := l.oauthApiMap[authaccount.Provider(provider)]
+// GetLoginURL starts a web login with the provider and returns the URL to
+// redirect the user to, along with the state the callback must present.
+//
+// returnTo is where the callback sends the user back to, and must match one
+// of the allowed return URLs. It may be empty. inviteCode is kept with the
+// attempt in case the login creates a new user.
+func (l *LoginUsecase) GetLoginURL(ctx context.Context, provider providermodels.Provider, returnTo string, inviteCode string) (loginURL string, state string, err error) {
+	api, ok := l.oauthApiMap[provider]
 	if !ok {
-		return "", errors.New("unsupported provider: "+provider, "Unsupported Provider", errcode.ErrInvalidInput)
+		return "", "", errors.New("unsupported provider: "+string(provider), "Unsupported Provider", errcode.ErrInvalidInput)
+	}
+	if returnTo != "" && !isAllowedReturnURL(l.returnURLs, returnTo) {
+		return "", "", errors.New("return URL is not allowed: "+returnTo, "Invalid Return URL", errcode.ErrInvalidInput)
+	}
+
+	attempt, err := newLoginAttempt(provider, returnTo, inviteCode)
+	if err != nil {
+		return "", "", err
 	}
-	loginURL = api.GetLoginURL()
-	return loginURL, nil
+	state, err = l.attemptManager.IssueAttempt(ctx, attempt)
+	if err != nil {
+		return "", "", err
+	}
+
+	loginURL = api.GetLoginURL(oauthapi.AuthRequest{
+		State:         state,
+		Nonce:         attempt.Nonce,
+		CodeChallenge: codeChallengeS256(attempt.CodeVerifier),
+	})
+	return loginURL, state, nil
 }
 
 // IssueLoginCode implements oauthdomain.LoginUsecase.
-func (l *LoginUsecase) IssueLoginCode(ctx context.Context, input oauthdto.LoginInput) (code string, userID uuid.UUID, err error) {
+//
+// The state must refer to a login attempt started with GetLoginURL for the
+// same provider; the attempt is consumed whether or not the login succeeds.
+//
+// If the identity must be linked to an existing user first, no code is issued
+// and LinkToken holds the pending link to pass to IssueLoginCodeWithLink instead.
+// If the user has MFA enabled, no code is issued and MFAToken holds the
+// challenge to pass to IssueLoginCodeWithMFA instead.
+func (l *LoginUsecase) IssueLoginCode(ctx context.Context, input oauthdto.CallbackInput) (output *oauthdto.LoginCodeOutput, err error) {
+	var userID uuid.UUID
+	defer func() {
+		if output == nil || output.LinkToken == "" {
+			mfaRequired := output != nil && output.MFAToken != ""
+			l.issuer.RecordActivity(ctx, activitymodels.MethodOAuth, input.Provider.String(), userID, mfaRequired, &err)
+		}
+	}()
+
+	attempt, err := l.consumeAttempt(ctx, input.Provider, input.State)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get or create verified user
-	userID, err = l.getOrCreateVerifiedUser(ctx, input)
+	userID, linkToken, err := l.getOrCreateVerifiedUser(ctx, oauthdto.LoginInput{
+		Provider:     input.Provider,
+		Code:         input.Code,
+		Name:         input.Name,
+		CodeVerifier: attempt.CodeVerifier,
+		Nonce:        attempt.Nonce,
+		InviteCode:   attempt.InviteCode,
+	})
 	if err != nil {
-		return "", uuid.Nil, errors.Upgrade(err, "Failed to get or create verified user", errcode.ErrUnauthorized)
+		return nil, upgradeLoginError(err, "Failed to get or create verified user", errcode.ErrUnauthorized)
+	}
+	if linkToken != "" {
+		return &oauthdto.LoginCodeOutput{LinkToken: linkToken, ReturnTo: attempt.ReturnTo}, nil
+	}
+
+	mfaToken, err := l.mfaChallenge.IssueChallenge(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaToken != "" {
+		return &oauthdto.LoginCodeOutput{MFAToken: mfaToken, ReturnTo: attempt.ReturnTo}, nil
 	}
 
 	// Generate and store login code
-	code, err = l.loginCodeManager.IssueCode(ctx, userID)
+	code, err := l.loginCodeManager.IssueCode(ctx, userID)
 	if err != nil {
-		return "", uuid.Nil, errors.Upgrade(err, "Failed to issue login code", errcode.ErrInternalFailure)
+		return nil, errors.Upgrade(err, "Failed to issue login code", errcode.ErrInternalFailure)
 	}
 
-	return code, userID, nil
+	return &oauthdto.LoginCodeOutput{Code: code, UserID: userID, ReturnTo: attempt.ReturnTo}, nil
 }
 
 // Login implements oauthdomain.LoginUsecase.
-func (l *LoginUsecase) Login(ctx context.Context, input oauthdto.LoginInput) (accessToken string, refreshToken string, err error) {
+//
+// If the identity must be linked to an existing user first, no tokens are
+// issued and linkToken holds the pending link to pass to ConfirmLink instead.
+// If the user has MFA enabled, no tokens are issued and mfaToken holds the
+// challenge to pass to LoginWithMFA instead.
+func (l *LoginUsecase) Login(ctx context.Context, input oauthdto.LoginInput) (accessToken string, refreshToken string, linkToken string, mfaToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		if linkToken == "" {
+			l.issuer.RecordActivity(ctx, activitymodels.MethodOAuth, input.Provider.String(), userID, mfaToken != "", &err)
+		}
+	}()
+
 	// Get or create verified user
-	userID, err := l.getOrCreateVerifiedUser(ctx, input)
+	userID, linkToken, err = l.getOrCreateVerifiedUser(ctx, input)
+	if err != nil {
+		return "", "", "", "", upgradeLoginError(err, "Failed to get or create verified user", errcode.ErrUnauthorized)
+	}
+	if linkToken != "" {
+		return "", "", linkToken, "", nil
+	}
+
+	mfaToken, err = l.mfaChallenge.IssueChallenge(ctx, userID)
 	if err != nil {
-		return "", "", errors.Upgrade(err, "Failed to get or create verified user", errcode.ErrUnauthorized)
+		return "", "", "", "", err
+	}
+	if mfaToken != "" {
+		return "", "", "", mfaToken, nil
 	}
 
 	// Generate access and refresh tokens
-	return l.issueToken(ctx, userID)
+	accessToken, refreshToken, err = l.issuer.IssueToken(ctx, userID)
+	return accessToken, refreshToken, "", "", err
 }
 
-// VerifyLoginCode implements oauthdomain.LoginUsecase.
-func (l *LoginUsecase) VerifyLoginCode(ctx context.Context, userID uuid.UUID, code string) (accessToken string, refreshToken string, err error) {
-	// Validate code
-	valid, err := l.loginCodeManager.ValidateCode(ctx, userID, code)
+// LoginWithMFA issues tokens after the MFA challenge of Login is passed.
+func (l *LoginUsecase) LoginWithMFA(ctx context.Context, input mfadto.ChallengeInput) (accessToken string, refreshToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodMFA, "", userID, false, &err)
+	}()
+
+	userID, err = l.mfaChallenge.CompleteChallenge(ctx, input)
 	if err != nil {
-		return "", "", errors.Upgrade(err, "Failed to validate login code", errcode.ErrInternalFailure)
-	}
-	if !valid {
-		return "", "", errors.New("login code is invalid or expired", "Failed to validate login code", errcode.ErrUnauthorized)
+		return "", "", err
 	}
 
 	// Generate access and refresh tokens
-	return l.issueToken(ctx, userID)
+	return l.issuer.IssueToken(ctx, userID)
 }
 
-// issueToken generates access and refresh tokens for the user.
-func (l *LoginUsecase) issueToken(ctx context.Context, userID uuid.UUID) (accessToken string, refreshToken string, err error) {
-	// Generate access token
-	accessToken, _, err = l.token.GenerateAccessToken(ctx, userID)
+// IssueLoginCodeWithMFA issues a login code after the MFA challenge of
+// IssueLoginCode is passed.
+func (l *LoginUsecase) IssueLoginCodeWithMFA(ctx context.Context, input mfadto.ChallengeInput) (code string, userID uuid.UUID, err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodMFA, "", attemptUserID, false, &err)
+	}()
+
+	userID, err = l.mfaChallenge.CompleteChallenge(ctx, input)
+	attemptUserID = userID
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	code, err = l.loginCodeManager.IssueCode(ctx, userID)
 	if err != nil {
-		return "", "", errors.Upgrade(err, "Failed to generate access token", errcode.ErrInternalFailure)
+		return "", uuid.Nil, errors.Upgrade(err, "Failed to issue login code", errcode.ErrInternalFailure)
+	}
+
+	return code, userID, nil
+}
+
+// VerifyLoginCode implements oauthdomain.LoginUsecase.
+func (l *LoginUsecase) VerifyLoginCode(ctx context.Context, userID uuid.UUID, code string, clientIP string) (accessToken string, refreshToken string, err error) {
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodLoginCode, "", userID, false, &err)
+	}()
+
+	limitKeys := []string{ratelimitrepo.UserKey(userID), ratelimitrepo.IPKey(clientIP)}
+	if err := l.verifyCodeLimiter.Check(ctx, limitKeys...); err != nil {
+		return "", "", err
 	}
 
-	// Generate refresh token
-	refreshToken, _, err = l.token.GenerateRefreshToken(ctx, userID)
+	// Validate code
+	info, valid, err := l.loginCodeManager.ConsumeCode(ctx, userID, code)
 	if err != nil {
-		return "", "", errors.Upgrade(err, "Failed to generate refresh token", errcode.ErrInternalFailure)
+		return "", "", errors.Upgrade(err, "Failed to validate login code", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		if err := l.verifyCodeLimiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return "", "", err
+		}
+		return "", "", errors.New("login code is invalid or expired", "Failed to validate login code", errcode.ErrUnauthorized)
+	}
+	if err := l.verifyCodeLimiter.Reset(ctx, ratelimitrepo.UserKey(userID)); err != nil {
+		return "", "", err
 	}
 
-	return accessToken, refreshToken, nil
+	// The session describes the client that signed in, not the backend
+	// redeeming the code
+	ctx = reqmodels.WithRequestInfo(ctx, info)
+
+	// Generate access and refresh tokens
+	return l.issuer.IssueToken(ctx, userID)
 }
 
 // NewLoginUsecase creates a new instance of LoginUsecase.
 func NewLoginUsecase(
 	authAccount *dbrepo.AuthAccountRepository,
-	token *tokenrepo.TokenRepository,
-	loginCodeManager *coderepo.CodeManager,
-	oauthApiMap map[authaccount.Provider]oauthapi.OAuthAPI,
+	userService *userrepo.UserServiceRepository,
+	issuer *session.Issuer,
+	mfaChallenge *mfa.ChallengeUsecase,
+	authEvent *autheventrepo.AuthEventEmitter,
+	notifier *notice.SecurityNotifier,
+	signupGate *signup.Gate,
+	loginCodeManager *coderepo.LoginCodeManager,
+	pendingLinkManager *coderepo.PendingLinkManager,
+	attemptManager *coderepo.LoginAttemptManager,
+	loginLimiter *ratelimitrepo.Limiter,
+	verifyCodeLimiter *ratelimitrepo.Limiter,
+	providerToken *dbrepo.ProviderTokenRepository,
+	tokenProviders []providermodels.Provider,
+	linkPolicy LinkPolicy,
+	returnURLs []string,
+	oauthApiMap map[providermodels.Provider]oauthapi.OAuthAPI,
 ) *LoginUsecase {
 	return &LoginUsecase{
-		authAccount:      authAccount,
-		token:            token,
-		loginCodeManager: loginCodeManager,
-		oauthApiMap:      oauthApiMap,
+		authAccount:        authAccount,
+		userService:        userService,
+		issuer:             issuer,
+		mfaChallenge:       mfaChallenge,
+		authEvent:          authEvent,
+		notifier:           notifier,
+		signupGate:         signupGate,
+		loginCodeManager:   loginCodeManager,
+		pendingLinkManager: pendingLinkManager,
+		attemptManager:     attemptManager,
+		loginLimiter:       loginLimiter,
+		verifyCodeLimiter:  verifyCodeLimiter,
+		providerToken:      providerToken,
+		tokenProviders:     tokenProviders,
+		linkPolicy:         linkPolicy,
+		returnURLs:         returnURLs,
+		oauthApiMap:        oauthApiMap,
 	}
 }
@@ -0,0 +1,131 @@
+package passkeyauth
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	passkeydto "mandacode.com/accounts/auth/internal/usecase/passkeyauth/dto"
+)
+
+// AssertionVerifier runs the assertion ceremonies of passkeys, both for
+// passwordless logins and as the second factor of another login.
+type AssertionVerifier struct {
+	webauthnCredential *dbrepo.WebauthnCredentialRepository
+	challengeManager   *coderepo.CodeManager
+	relyingParty       *webauthn.RelyingParty
+}
+
+// RequestOptions issues a challenge for an assertion by a passkey of the user.
+//
+// With uuid.Nil the user is not known yet; the authenticator picks a
+// discoverable credential and returns its user handle.
+func (v *AssertionVerifier) RequestOptions(ctx context.Context, userID uuid.UUID) (*webauthn.RequestOptions, error) {
+	allow := []webauthn.CredentialDescriptor{}
+	if userID != uuid.Nil {
+		credentials, err := v.webauthnCredential.GetWebauthnCredentialsByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(credentials) == 0 {
+			return nil, errors.New("user has no passkey", "Passkey Not Found", errcode.ErrNotFound)
+		}
+		for _, credential := range credentials {
+			allow = append(allow, webauthn.NewCredentialDescriptor(credential.CredentialID, credential.Transports))
+		}
+	}
+
+	challenge, err := v.challengeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to issue challenge", errcode.ErrInternalFailure)
+	}
+
+	return v.relyingParty.RequestOptions([]byte(challenge), allow), nil
+}
+
+// VerifyAssertion verifies the assertion response to a challenge issued by
+// RequestOptions for userID and returns the user the passkey belongs to. A
+// passkey of another user is refused unless userID is uuid.Nil.
+//
+// Once the passkey is found, the user it belongs to is also returned with an error.
+func (v *AssertionVerifier) VerifyAssertion(ctx context.Context, userID uuid.UUID, input passkeydto.AssertionInput) (uuid.UUID, error) {
+	credentialID, err := webauthn.DecodeBase64URL(input.CredentialID)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid credential ID encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	clientDataJSON, err := webauthn.DecodeBase64URL(input.ClientDataJSON)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid client data encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	authenticatorData, err := webauthn.DecodeBase64URL(input.AuthenticatorData)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid authenticator data encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	signature, err := webauthn.DecodeBase64URL(input.Signature)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid signature encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	userHandle, err := webauthn.DecodeBase64URL(input.UserHandle)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid user handle encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+
+	challenge, err := webauthn.ParseChallenge(clientDataJSON)
+	if err != nil {
+		return uuid.Nil, errors.New(err.Error(), "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	valid, err := v.challengeManager.ValidateCode(ctx, userID, string(challenge))
+	if err != nil {
+		return uuid.Nil, errors.Upgrade(err, "Failed to validate challenge", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		return uuid.Nil, errors.New("challenge is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	credential, err := v.webauthnCredential.GetWebauthnCredentialByCredentialID(ctx, credentialID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrNotFound) {
+			return uuid.Nil, errors.New("unknown passkey", "Unauthorized", errcode.ErrUnauthorized)
+		}
+		return uuid.Nil, err
+	}
+	if userID != uuid.Nil && credential.UserID != userID {
+		return credential.UserID, errors.New("passkey belongs to another user", "Unauthorized", errcode.ErrUnauthorized)
+	}
+	if len(userHandle) != 0 && !bytes.Equal(userHandle, credential.UserID[:]) {
+		return credential.UserID, errors.New("user handle does not match the passkey", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	signCount, err := v.relyingParty.VerifyAssertion(challenge, clientDataJSON, authenticatorData, signature, credential.PublicKey, credential.SignCount)
+	if err != nil {
+		return credential.UserID, errors.New(err.Error(), "Passkey Verification Failed", errcode.ErrUnauthorized)
+	}
+
+	updated, err := v.webauthnCredential.SetWebauthnCredentialUsed(ctx, credential.ID, credential.SignCount, signCount)
+	if err != nil {
+		return credential.UserID, err
+	}
+	if !updated {
+		return credential.UserID, errors.New("passkey was used concurrently", "Passkey Verification Failed", errcode.ErrUnauthorized)
+	}
+
+	return credential.UserID, nil
+}
+
+// NewAssertionVerifier creates a new instance of AssertionVerifier.
+func NewAssertionVerifier(
+	webauthnCredential *dbrepo.WebauthnCredentialRepository,
+	challengeManager *coderepo.CodeManager,
+	relyingParty *webauthn.RelyingParty,
+) *AssertionVerifier {
+	return &AssertionVerifier{
+		webauthnCredential: webauthnCredential,
+		challengeManager:   challengeManager,
+		relyingParty:       relyingParty,
+	}
+}
@@ -0,0 +1,23 @@
+package passkeydto
+
+import "github.com/google/uuid"
+
+// RegistrationInput carries the response of a registration ceremony.
+// Binary values are base64url encoded as in the WebAuthn JSON serialization.
+type RegistrationInput struct {
+	UserID            uuid.UUID `json:"user_id"`
+	Name              string    `json:"name"`
+	ClientDataJSON    string    `json:"client_data_json"`
+	AttestationObject string    `json:"attestation_object"`
+	Transports        []string  `json:"transports"`
+}
+
+// AssertionInput carries the response of an assertion ceremony.
+// Binary values are base64url encoded as in the WebAuthn JSON serialization.
+type AssertionInput struct {
+	CredentialID      string `json:"credential_id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"user_handle"`
+}
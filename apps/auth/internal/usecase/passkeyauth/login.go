@@ -0,0 +1,114 @@
+package passkeyauth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	passkeydto "mandacode.com/accounts/auth/internal/usecase/passkeyauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+)
+
+type LoginUsecase struct {
+	verifier          *AssertionVerifier
+	issuer            *session.Issuer
+	loginCodeManager  *coderepo.LoginCodeManager
+	verifyCodeLimiter *ratelimitrepo.Limiter
+}
+
+// BeginLogin starts a passwordless login. The user is not known yet; the
+// authenticator picks a discoverable credential and returns its user handle.
+func (l *LoginUsecase) BeginLogin(ctx context.Context) (*webauthn.RequestOptions, error) {
+	return l.verifier.RequestOptions(ctx, uuid.Nil)
+}
+
+// IssueLoginCode issues a login code after a successful passkey assertion.
+func (l *LoginUsecase) IssueLoginCode(ctx context.Context, input passkeydto.AssertionInput) (code string, userID uuid.UUID, err error) {
+	var attemptUserID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodPasskey, "", attemptUserID, false, &err)
+	}()
+
+	userID, err = l.verifier.VerifyAssertion(ctx, uuid.Nil, input)
+	attemptUserID = userID
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	code, err = l.loginCodeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return "", uuid.Nil, errors.Upgrade(err, "Failed to issue login code", errcode.ErrInternalFailure)
+	}
+
+	return code, userID, nil
+}
+
+// Login issues tokens after a successful passkey assertion.
+func (l *LoginUsecase) Login(ctx context.Context, input passkeydto.AssertionInput) (accessToken string, refreshToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodPasskey, "", userID, false, &err)
+	}()
+
+	userID, err = l.verifier.VerifyAssertion(ctx, uuid.Nil, input)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Generate access and refresh tokens
+	return l.issuer.IssueToken(ctx, userID)
+}
+
+// VerifyLoginCode exchanges a login code for tokens.
+func (l *LoginUsecase) VerifyLoginCode(ctx context.Context, userID uuid.UUID, code string, clientIP string) (accessToken string, refreshToken string, err error) {
+	defer func() {
+		l.issuer.RecordActivity(ctx, activitymodels.MethodLoginCode, "", userID, false, &err)
+	}()
+
+	limitKeys := []string{ratelimitrepo.UserKey(userID), ratelimitrepo.IPKey(clientIP)}
+	if err := l.verifyCodeLimiter.Check(ctx, limitKeys...); err != nil {
+		return "", "", err
+	}
+
+	info, valid, err := l.loginCodeManager.ConsumeCode(ctx, userID, code)
+	if err != nil {
+		return "", "", errors.Upgrade(err, "Failed to validate login code", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		if err := l.verifyCodeLimiter.RecordFailure(ctx, limitKeys...); err != nil {
+			return "", "", err
+		}
+		return "", "", errors.New("login code is invalid or expired", "Failed to validate login code", errcode.ErrUnauthorized)
+	}
+	if err := l.verifyCodeLimiter.Reset(ctx, ratelimitrepo.UserKey(userID)); err != nil {
+		return "", "", err
+	}
+
+	// The session describes the client that signed in, not the backend
+	// redeeming the code
+	ctx = reqmodels.WithRequestInfo(ctx, info)
+
+	// Generate access and refresh tokens
+	return l.issuer.IssueToken(ctx, userID)
+}
+
+// NewLoginUsecase creates a new instance of LoginUsecase.
+func NewLoginUsecase(
+	verifier *AssertionVerifier,
+	issuer *session.Issuer,
+	loginCodeManager *coderepo.LoginCodeManager,
+	verifyCodeLimiter *ratelimitrepo.Limiter,
+) *LoginUsecase {
+	return &LoginUsecase{
+		verifier:          verifier,
+		issuer:            issuer,
+		loginCodeManager:  loginCodeManager,
+		verifyCodeLimiter: verifyCodeLimiter,
+	}
+}
@@ -0,0 +1,122 @@
+package passkeyauth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	passkeydto "mandacode.com/accounts/auth/internal/usecase/passkeyauth/dto"
+)
+
+type RegistrationUsecase struct {
+	authAccount        *dbrepo.AuthAccountRepository
+	webauthnCredential *dbrepo.WebauthnCredentialRepository
+	challengeManager   *coderepo.CodeManager
+	notifier           *notice.SecurityNotifier
+	relyingParty       *webauthn.RelyingParty
+}
+
+// BeginRegistration starts a passkey registration for an authenticated user.
+//
+// The challenge is bound to the user, so the response can only be registered
+// to the same account.
+func (r *RegistrationUsecase) BeginRegistration(ctx context.Context, userID uuid.UUID) (*webauthn.CreationOptions, error) {
+	accounts, err := r.authAccount.GetAuthAccountsByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to get auth accounts", errcode.ErrInternalFailure)
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("user has no auth account", "Account Not Found", errcode.ErrNotFound)
+	}
+
+	credentials, err := r.webauthnCredential.GetWebauthnCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exclude := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		exclude = append(exclude, webauthn.NewCredentialDescriptor(credential.CredentialID, credential.Transports))
+	}
+
+	challenge, err := r.challengeManager.IssueCode(ctx, userID)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to issue challenge", errcode.ErrInternalFailure)
+	}
+
+	return r.relyingParty.CreationOptions([]byte(challenge), userID[:], accounts[0].Email, exclude), nil
+}
+
+// FinishRegistration verifies the registration response and stores the new passkey.
+func (r *RegistrationUsecase) FinishRegistration(ctx context.Context, input passkeydto.RegistrationInput) (*dbmodels.SecureWebauthnCredential, error) {
+	clientDataJSON, err := webauthn.DecodeBase64URL(input.ClientDataJSON)
+	if err != nil {
+		return nil, errors.New("invalid client data encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	attestationObject, err := webauthn.DecodeBase64URL(input.AttestationObject)
+	if err != nil {
+		return nil, errors.New("invalid attestation object encoding", "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+
+	challenge, err := webauthn.ParseChallenge(clientDataJSON)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Invalid Passkey Response", errcode.ErrInvalidInput)
+	}
+	valid, err := r.challengeManager.ValidateCode(ctx, input.UserID, string(challenge))
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to validate challenge", errcode.ErrInternalFailure)
+	}
+	if !valid {
+		return nil, errors.New("challenge is invalid or expired", "Unauthorized", errcode.ErrUnauthorized)
+	}
+
+	credential, err := r.relyingParty.VerifyRegistration(challenge, clientDataJSON, attestationObject, input.Transports)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Passkey Verification Failed", errcode.ErrUnauthorized)
+	}
+
+	aaguid, err := uuid.FromBytes(credential.AAGUID)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Passkey Verification Failed", errcode.ErrUnauthorized)
+	}
+
+	created, err := r.webauthnCredential.CreateWebauthnCredential(ctx, &dbmodels.CreateWebauthnCredentialInput{
+		UserID:         input.UserID,
+		CredentialID:   credential.ID,
+		PublicKey:      credential.PublicKey,
+		SignCount:      credential.SignCount,
+		AAGUID:         aaguid,
+		Transports:     credential.Transports,
+		Name:           input.Name,
+		BackupEligible: credential.BackupEligible,
+		BackupState:    credential.BackupState,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.notifier.NotifyMFAChanged(ctx, input.UserID, mailer.MFAChangePasskeyAdded)
+	return created, nil
+}
+
+// NewRegistrationUsecase creates a new instance of RegistrationUsecase.
+func NewRegistrationUsecase(
+	authAccount *dbrepo.AuthAccountRepository,
+	webauthnCredential *dbrepo.WebauthnCredentialRepository,
+	challengeManager *coderepo.CodeManager,
+	notifier *notice.SecurityNotifier,
+	relyingParty *webauthn.RelyingParty,
+) *RegistrationUsecase {
+	return &RegistrationUsecase{
+		authAccount:        authAccount,
+		webauthnCredential: webauthnCredential,
+		challengeManager:   challengeManager,
+		notifier:           notifier,
+		relyingParty:       relyingParty,
+	}
+}
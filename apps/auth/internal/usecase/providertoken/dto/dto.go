@@ -0,0 +1,23 @@
+package providertokendto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+)
+
+// FetchInput asks for the access token of the provider for a user.
+type FetchInput struct {
+	UserID    uuid.UUID
+	Provider  providermodels.Provider
+	Requester string // The internal service asking, recorded in the audit event
+}
+
+// FetchOutput is a provider access token valid at the time of the fetch.
+type FetchOutput struct {
+	AccessToken string
+	TokenType   string
+	Scope       string
+	ExpiresAt   time.Time // Zero if the provider did not say
+}
@@ -0,0 +1,119 @@
+package providertoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	providertokendto "mandacode.com/accounts/auth/internal/usecase/providertoken/dto"
+)
+
+// refreshMargin is how long before its expiry an access token is refreshed,
+// so that the requester still has time to use it.
+const refreshMargin = time.Minute
+
+// FetchUsecase hands the stored provider tokens of users to internal services.
+type FetchUsecase struct {
+	providerToken *dbrepo.ProviderTokenRepository
+	authEvent     *autheventrepo.AuthEventEmitter
+	oauthApiMap   map[providermodels.Provider]oauthapi.OAuthAPI
+}
+
+// FetchProviderToken returns an access token of the provider for the user,
+// refreshing it first if it is about to expire.
+//
+// Every token handed out is recorded in an auth event naming the requester;
+// if the event cannot be emitted, no token is returned.
+func (f *FetchUsecase) FetchProviderToken(ctx context.Context, input providertokendto.FetchInput) (*providertokendto.FetchOutput, error) {
+	if input.Requester == "" {
+		return nil, errors.New("requester is missing", "Invalid Requester", errcode.ErrInvalidInput)
+	}
+
+	stored, err := f.providerToken.GetProviderToken(ctx, input.UserID, input.Provider)
+	if err != nil {
+		return nil, err
+	}
+	output, err := f.freshToken(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.authEvent.EmitProviderTokenFetchedEvent(ctx, input.UserID, string(input.Provider), input.Requester); err != nil {
+		return nil, errors.Upgrade(err, "Failed to audit provider token fetch", errcode.ErrInternalFailure)
+	}
+	return output, nil
+}
+
+// freshToken returns the stored access token, or a refreshed one if it
+// expires within refreshMargin.
+func (f *FetchUsecase) freshToken(ctx context.Context, stored *dbmodels.ProviderToken) (*providertokendto.FetchOutput, error) {
+	if stored.ExpiresAt.IsZero() || time.Until(stored.ExpiresAt) > refreshMargin {
+		return newFetchOutput(stored), nil
+	}
+	if stored.RefreshToken == "" {
+		return nil, errors.New("provider token expired without a refresh token", "Provider Token Expired", errcode.ErrTokenExpired)
+	}
+
+	api, ok := f.oauthApiMap[stored.Provider]
+	if !ok {
+		return nil, errors.New("unsupported provider: "+string(stored.Provider), "Unsupported Provider", errcode.ErrInvalidInput)
+	}
+	token, err := api.RefreshAccessToken(stored.RefreshToken)
+	if err != nil {
+		return nil, errors.New(err.Error(), "Failed to refresh provider token", errcode.ErrDependencyFailure)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("refreshed access token is empty", "Failed to refresh provider token", errcode.ErrDependencyFailure)
+	}
+
+	replaced, err := f.providerToken.ReplaceProviderToken(ctx, stored, token)
+	if err != nil {
+		return nil, err
+	}
+	if !replaced {
+		// Another fetch refreshed the token meanwhile; hand out its result, as
+		// the provider may have rotated the refresh token used here
+		current, err := f.providerToken.GetProviderToken(ctx, stored.UserID, stored.Provider)
+		if err != nil {
+			return nil, err
+		}
+		return newFetchOutput(current), nil
+	}
+
+	return &providertokendto.FetchOutput{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Scope:       token.Scope,
+		ExpiresAt:   token.ExpiresAt,
+	}, nil
+}
+
+// newFetchOutput returns the access token of the stored tokens.
+func newFetchOutput(stored *dbmodels.ProviderToken) *providertokendto.FetchOutput {
+	return &providertokendto.FetchOutput{
+		AccessToken: stored.AccessToken,
+		TokenType:   stored.TokenType,
+		Scope:       stored.Scope,
+		ExpiresAt:   stored.ExpiresAt,
+	}
+}
+
+// NewFetchUsecase creates a new instance of FetchUsecase.
+func NewFetchUsecase(
+	providerToken *dbrepo.ProviderTokenRepository,
+	authEvent *autheventrepo.AuthEventEmitter,
+	oauthApiMap map[providermodels.Provider]oauthapi.OAuthAPI,
+) *FetchUsecase {
+	return &FetchUsecase{
+		providerToken: providerToken,
+		authEvent:     authEvent,
+		oauthApiMap:   oauthApiMap,
+	}
+}
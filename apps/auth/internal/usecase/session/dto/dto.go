@@ -0,0 +1,20 @@
+package sessiondto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session describes a signed in device of a user.
+//
+// Current marks the session of the refresh token presented with the request.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+)
+
+// Issuer starts the session of a granted login and records the outcome of
+// every login attempt, whatever the login method.
+type Issuer struct {
+	token        *tokenrepo.TokenRepository
+	session      *dbrepo.SessionRepository
+	authActivity *dbrepo.AuthActivityRepository
+	notifier     *notice.SecurityNotifier
+}
+
+// IssueToken issues a new access token and refresh token for the user and
+// records the session the refresh token starts.
+func (i *Issuer) IssueToken(ctx context.Context, userID uuid.UUID) (accessToken string, refreshToken string, err error) {
+	accessToken, _, err = i.token.GenerateAccessToken(ctx, userID)
+	if err != nil {
+		return "", "", errors.Upgrade(err, "Failed to generate token", errcode.ErrInternalFailure)
+	}
+	refreshToken, familyID, expiresAt, err := i.token.GenerateRefreshToken(ctx, userID)
+	if err != nil {
+		return "", "", errors.Upgrade(err, "Failed to generate token", errcode.ErrInternalFailure)
+	}
+	if _, err := i.session.CreateSession(ctx, userID, familyID, time.Unix(expiresAt, 0)); err != nil {
+		return "", "", errors.Upgrade(err, "Failed to record session", errcode.ErrInternalFailure)
+	}
+	return accessToken, refreshToken, nil
+}
+
+// RecordActivity records the outcome of a login attempt ending with *err,
+// warning the user first if it signed in from a new client. A granted login
+// that cannot be recorded fails, so that none is missing from the history.
+//
+// provider is empty for logins that do not go through an OAuth provider, and
+// mfaRequired marks a first factor that was accepted but awaits the second.
+func (i *Issuer) RecordActivity(ctx context.Context, method activitymodels.Method, provider string, userID uuid.UUID, mfaRequired bool, err *error) {
+	// Login codes are redeemed by the relying backend; the client signing in
+	// was checked when the code was issued
+	if *err == nil && !mfaRequired && method != activitymodels.MethodLoginCode {
+		i.notifier.NotifySignIn(ctx, userID)
+	}
+	entry := activitymodels.NewEntry(method, provider, userID, *err)
+	if mfaRequired {
+		entry.Outcome = activitymodels.OutcomeMFARequired
+	}
+	if recordErr := i.authActivity.RecordAuthActivity(ctx, entry); recordErr != nil && *err == nil {
+		*err = recordErr
+	}
+}
+
+// NewIssuer creates a new instance of Issuer.
+func NewIssuer(
+	token *tokenrepo.TokenRepository,
+	session *dbrepo.SessionRepository,
+	authActivity *dbrepo.AuthActivityRepository,
+	notifier *notice.SecurityNotifier,
+) *Issuer {
+	return &Issuer{
+		token:        token,
+		session:      session,
+		authActivity: authActivity,
+		notifier:     notifier,
+	}
+}
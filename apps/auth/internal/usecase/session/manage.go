@@ -0,0 +1,140 @@
+package session
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	sessiondto "mandacode.com/accounts/auth/internal/usecase/session/dto"
+)
+
+// ManageUsecase lists and revokes the sessions of a user, for the user
+// themselves and for support staff.
+type ManageUsecase struct {
+	session    *dbrepo.SessionRepository
+	revocation *revocationrepo.RevocationRepository
+	token      *tokenrepo.TokenRepository
+}
+
+// currentFamily returns the token family of the refresh token presented by the
+// user, or an empty string if there is none or it is not valid.
+func (m *ManageUsecase) currentFamily(ctx context.Context, userID uuid.UUID, refreshToken string) string {
+	if refreshToken == "" {
+		return ""
+	}
+	result, err := m.token.VerifyRefreshTokenDetails(ctx, refreshToken)
+	if err != nil || !result.Valid || result.UserID != userID {
+		return ""
+	}
+	return result.FamilyID
+}
+
+// ListSessions lists the active sessions of a user, most recently used first.
+//
+// refreshToken may be empty; otherwise the session it belongs to is marked as
+// current.
+func (m *ManageUsecase) ListSessions(ctx context.Context, userID uuid.UUID, refreshToken string) ([]*sessiondto.Session, error) {
+	sessions, err := m.session.GetActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.Upgrade(err, "Failed to list sessions", errcode.ErrInternalFailure)
+	}
+
+	currentFamily := m.currentFamily(ctx, userID, refreshToken)
+	result := make([]*sessiondto.Session, 0, len(sessions))
+	for _, item := range sessions {
+		result = append(result, &sessiondto.Session{
+			ID:         item.ID,
+			DeviceName: item.DeviceName,
+			UserAgent:  item.UserAgent,
+			IPAddress:  item.IPAddress,
+			CreatedAt:  item.CreatedAt,
+			LastUsedAt: item.LastUsedAt,
+			Current:    currentFamily != "" && item.FamilyID == currentFamily,
+		})
+	}
+	return result, nil
+}
+
+// RevokeSession signs out a single session of a user by revoking its refresh
+// token family.
+func (m *ManageUsecase) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	session, err := m.session.GetSessionByUserIDAndID(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := m.revocation.RevokeTokenFamily(ctx, session.FamilyID); err != nil {
+		return errors.Upgrade(err, "Failed to revoke session", errcode.ErrInternalFailure)
+	}
+	if err := m.session.DeleteSessionByID(ctx, session.ID); err != nil {
+		return errors.Upgrade(err, "Failed to delete session", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// RevokeOtherSessions signs out every session of a user but the one of the
+// presented refresh token.
+//
+// Returns the number of sessions signed out.
+func (m *ManageUsecase) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, refreshToken string) (int, error) {
+	currentFamily := m.currentFamily(ctx, userID, refreshToken)
+	if currentFamily == "" {
+		return 0, errors.New("refresh token of the current session is missing or invalid", "Current Session Unknown", errcode.ErrInvalidInput)
+	}
+
+	sessions, err := m.session.GetActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return 0, errors.Upgrade(err, "Failed to list sessions", errcode.ErrInternalFailure)
+	}
+
+	revoked := 0
+	for _, item := range sessions {
+		if item.FamilyID == currentFamily {
+			continue
+		}
+		if err := m.revocation.RevokeTokenFamily(ctx, item.FamilyID); err != nil {
+			return revoked, errors.Upgrade(err, "Failed to revoke session", errcode.ErrInternalFailure)
+		}
+		if err := m.session.DeleteSessionByID(ctx, item.ID); err != nil {
+			return revoked, errors.Upgrade(err, "Failed to delete session", errcode.ErrInternalFailure)
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// RevokeAllSessions signs out every session of a user, including those
+// started before sessions were recorded.
+//
+// Returns the number of recorded sessions signed out.
+func (m *ManageUsecase) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	sessions, err := m.session.GetActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return 0, errors.Upgrade(err, "Failed to list sessions", errcode.ErrInternalFailure)
+	}
+
+	if err := m.revocation.RevokeUserTokens(ctx, userID); err != nil {
+		return 0, errors.Upgrade(err, "Failed to revoke refresh tokens", errcode.ErrInternalFailure)
+	}
+	if err := m.session.DeleteSessionsByUserID(ctx, userID); err != nil {
+		return 0, errors.Upgrade(err, "Failed to delete sessions", errcode.ErrInternalFailure)
+	}
+	return len(sessions), nil
+}
+
+// NewManageUsecase creates a new instance of ManageUsecase.
+func NewManageUsecase(
+	session *dbrepo.SessionRepository,
+	revocation *revocationrepo.RevocationRepository,
+	token *tokenrepo.TokenRepository,
+) *ManageUsecase {
+	return &ManageUsecase{
+		session:    session,
+		revocation: revocation,
+		token:      token,
+	}
+}
@@ -0,0 +1,10 @@
+package signupdto
+
+import "time"
+
+// CreateInviteInput describes an invite an admin client creates.
+type CreateInviteInput struct {
+	Email     string        `json:"email"`      // Optional, the only email the invite admits
+	CreatedBy string        `json:"created_by"` // The name of the admin client
+	TTL       time.Duration `json:"ttl"`        // Optional, how long the invite stays usable
+}
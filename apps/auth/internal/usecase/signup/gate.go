@@ -0,0 +1,71 @@
+package signup
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+)
+
+// Gate admits or refuses the creation of new accounts following the signup
+// policy, for local signups and first OAuth logins alike.
+type Gate struct {
+	policy  *signuppolicy.Policy
+	invites *dbrepo.SignupInviteRepository
+}
+
+// Admit checks that an account may be created for email, redeeming
+// inviteCode for userID if signup is invite-only. See signuppolicy.Policy.Check
+// for emailVerified.
+//
+// The invite is marked used by userID as it is redeemed, so nothing is left to
+// record once the account is created. The returned invite ID must be passed to
+// Abort if the account could not be created. It is uuid.Nil when no invite was
+// redeemed.
+//
+// Returns:
+//   - An ErrForbidden error if the account may not be created.
+func (g *Gate) Admit(ctx context.Context, email string, emailVerified bool, inviteCode string, userID uuid.UUID) (inviteID uuid.UUID, err error) {
+	if err := g.policy.Check(email, emailVerified); err != nil {
+		return uuid.Nil, err
+	}
+	if !g.policy.InviteOnly() {
+		return uuid.Nil, nil
+	}
+
+	if inviteCode == "" {
+		return uuid.Nil, errors.New("signup is invite-only", "Invite Required", errcode.ErrForbidden)
+	}
+	invite, err := g.invites.RedeemSignupInvite(ctx, inviteCode, email, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if invite == nil {
+		return uuid.Nil, errors.New("invite code is invalid, used or expired", "Invalid Invite", errcode.ErrForbidden)
+	}
+	return invite.ID, nil
+}
+
+// Abort gives back the invite redeemed by Admit for a signup that failed with
+// cause, and returns cause. If the invite cannot be given back, the failure is
+// added to the message of cause.
+func (g *Gate) Abort(ctx context.Context, inviteID uuid.UUID, cause error) error {
+	if inviteID == uuid.Nil {
+		return cause
+	}
+	if err := g.invites.ReleaseSignupInvite(ctx, inviteID); err != nil {
+		return errors.Join(cause, "failed to release signup invite: "+err.Error())
+	}
+	return cause
+}
+
+// NewGate creates a new instance of Gate.
+func NewGate(policy *signuppolicy.Policy, invites *dbrepo.SignupInviteRepository) *Gate {
+	return &Gate{
+		policy:  policy,
+		invites: invites,
+	}
+}
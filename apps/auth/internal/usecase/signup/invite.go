@@ -0,0 +1,72 @@
+package signup
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	signupdto "mandacode.com/accounts/auth/internal/usecase/signup/dto"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// InviteUsecase lets admin clients manage the single-use invite codes
+// admitting signups while signup is invite-only.
+type InviteUsecase struct {
+	invites       *dbrepo.SignupInviteRepository
+	codeGenerator *util.RandomGenerator
+	defaultTTL    time.Duration
+}
+
+// CreateInvite creates an invite and returns its code in plain text. Codes
+// are only stored hashed, so this is the only time it can be shown.
+//
+// The invite expires after input.TTL, or after the default TTL if it is zero.
+func (i *InviteUsecase) CreateInvite(ctx context.Context, input signupdto.CreateInviteInput) (code string, invite *dbmodels.SignupInvite, err error) {
+	ttl := input.TTL
+	if ttl == 0 {
+		ttl = i.defaultTTL
+	}
+	if ttl < 0 {
+		return "", nil, errors.New("invite TTL must be positive", "Invalid Invite TTL", errcode.ErrInvalidInput)
+	}
+
+	code, err = i.codeGenerator.GenerateSecureRandomCode()
+	if err != nil {
+		return "", nil, errors.New(err.Error(), "Failed to generate invite code", errcode.ErrInternalFailure)
+	}
+	invite, err = i.invites.CreateSignupInvite(ctx, code, input.Email, input.CreatedBy, time.Now().Add(ttl))
+	if err != nil {
+		return "", nil, err
+	}
+	return code, invite, nil
+}
+
+// ListInvites lists every invite, used or not, newest first.
+func (i *InviteUsecase) ListInvites(ctx context.Context) ([]*dbmodels.SignupInvite, error) {
+	return i.invites.GetSignupInvites(ctx)
+}
+
+// RevokeInvite deletes an invite so that its code can no longer be used.
+func (i *InviteUsecase) RevokeInvite(ctx context.Context, inviteID uuid.UUID) error {
+	deleted, err := i.invites.DeleteSignupInviteByID(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("invite not found", "Invite Not Found", errcode.ErrNotFound)
+	}
+	return nil
+}
+
+// NewInviteUsecase creates a new instance of InviteUsecase.
+func NewInviteUsecase(invites *dbrepo.SignupInviteRepository, codeGenerator *util.RandomGenerator, defaultTTL time.Duration) *InviteUsecase {
+	return &InviteUsecase{
+		invites:       invites,
+		codeGenerator: codeGenerator,
+		defaultTTL:    defaultTTL,
+	}
+}
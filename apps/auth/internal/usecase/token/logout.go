@@ -0,0 +1,73 @@
+package token
+
+import (
+	"context"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+)
+
+type LogoutUsecase struct {
+	token      *tokenrepo.TokenRepository
+	revocation *revocationrepo.RevocationRepository
+	session    *dbrepo.SessionRepository
+}
+
+// Logout revokes the refresh token of the session being signed out.
+//
+// A token that is no longer valid is ignored unless all is set, so that
+// signing out twice succeeds.
+//
+// Parameters:
+//   - ctx: The context for the operation.
+//   - refreshToken: The refresh token of the session.
+//   - all: Whether to revoke every refresh token of the user instead.
+//
+// Returns:
+//   - err: An error if the operation fails, or nil if successful.
+func (l *LogoutUsecase) Logout(ctx context.Context, refreshToken string, all bool) error {
+	result, err := l.token.VerifyRefreshTokenDetails(ctx, refreshToken)
+	if err != nil || !result.Valid {
+		if all {
+			return errors.New("invalid refresh token", "Unauthorized", errcode.ErrUnauthorized)
+		}
+		return nil
+	}
+
+	if all {
+		if err := l.revocation.RevokeUserTokens(ctx, result.UserID); err != nil {
+			return errors.Upgrade(err, "Failed to revoke refresh tokens", errcode.ErrInternalFailure)
+		}
+		if err := l.session.DeleteSessionsByUserID(ctx, result.UserID); err != nil {
+			return errors.Upgrade(err, "Failed to delete sessions", errcode.ErrInternalFailure)
+		}
+		return nil
+	}
+
+	// Tokens issued before rotation have no family to revoke
+	if result.FamilyID == "" {
+		if err := l.revocation.RevokeToken(ctx, refreshToken); err != nil {
+			return errors.Upgrade(err, "Failed to revoke refresh token", errcode.ErrInternalFailure)
+		}
+		return nil
+	}
+	if err := l.revocation.RevokeTokenFamily(ctx, result.FamilyID); err != nil {
+		return errors.Upgrade(err, "Failed to revoke refresh token", errcode.ErrInternalFailure)
+	}
+	if err := l.session.DeleteSessionByFamilyID(ctx, result.FamilyID); err != nil {
+		return errors.Upgrade(err, "Failed to delete session", errcode.ErrInternalFailure)
+	}
+	return nil
+}
+
+// NewLogoutUsecase creates a new instance of LogoutUsecase.
+func NewLogoutUsecase(token *tokenrepo.TokenRepository, revocation *revocationrepo.RevocationRepository, session *dbrepo.SessionRepository) *LogoutUsecase {
+	return &LogoutUsecase{
+		token:      token,
+		revocation: revocation,
+		session:    session,
+	}
+}
@@ -2,19 +2,34 @@ package token
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	rotationrepo "mandacode.com/accounts/auth/internal/repository/rotation"
 	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
 )
 
 type RefreshUsecase struct {
-	token *tokenrepo.TokenRepository
+	token      *tokenrepo.TokenRepository
+	revocation *revocationrepo.RevocationRepository
+	rotation   *rotationrepo.RotationRepository
+	session    *dbrepo.SessionRepository
+	activity   *dbrepo.AuthActivityRepository
+	authEvent  *autheventrepo.AuthEventEmitter
 }
 
 // Refresh generates new access and refresh tokens based on a valid refresh token.
 //
+// The refresh token is rotated: the new one replaces it within its family and
+// it cannot be used again. Presenting a token that was already rotated means
+// that it leaked, so the whole family is revoked and a security event is sent.
+//
 // Parameters:
 //   - ctx: The context for the operation.
 //
@@ -23,35 +38,95 @@ type RefreshUsecase struct {
 //   - newRefreshToken: The newly generated refresh token.
 //   - err: An error if the operation fails, or nil if successful.
 func (r *RefreshUsecase) Refresh(ctx context.Context, refreshToken string) (newAccessToken string, newRefreshToken string, err error) {
+	var userID uuid.UUID
+	defer func() {
+		// A rotation cannot be undone, so a refresh that could not be recorded
+		// still succeeds; withholding the new token would end the session
+		_ = r.activity.RecordAuthActivity(ctx, activitymodels.NewEntry(activitymodels.MethodRefresh, "", userID, err))
+	}()
+
 	// Validate the refresh token
-	valid, userID, err := r.token.VerifyRefreshToken(ctx, refreshToken)
+	result, err := r.token.VerifyRefreshTokenDetails(ctx, refreshToken)
 	if err != nil {
 		return "", "", errors.New("failed to verify refresh token", "Unauthorized", errcode.ErrUnauthorized)
 	}
-	if !valid || userID == nil {
+	if !result.Valid {
 		return "", "", errors.New("invalid refresh token", "Unauthorized", errcode.ErrUnauthorized)
 	}
+	userID = result.UserID
 
-	// Generate new access and refresh tokens
-	userUID, err := uuid.Parse(*userID)
-	if err != nil {
-		return "", "", errors.New("invalid user ID in refresh token", "Invalid User ID", errcode.ErrUnauthorized)
+	if result.FamilyID == "" {
+		// Tokens issued before rotation start a new family and are revoked.
+		// The token is claimed first, so that concurrent requests presenting
+		// it cannot each start a family
+		claimed, err := r.revocation.ClaimToken(ctx, refreshToken)
+		if err != nil {
+			return "", "", errors.Upgrade(err, "Failed to revoke refresh token", errcode.ErrInternalFailure)
+		}
+		if !claimed {
+			return "", "", errors.New("refresh token was already used", "Unauthorized", errcode.ErrUnauthorized)
+		}
+		var familyID string
+		var expiresAt int64
+		newRefreshToken, familyID, expiresAt, err = r.token.GenerateRefreshToken(ctx, result.UserID)
+		if err != nil {
+			return "", "", errors.Join(err, "failed to generate new refresh token")
+		}
+		if _, err := r.session.CreateSession(ctx, result.UserID, familyID, time.Unix(expiresAt, 0)); err != nil {
+			return "", "", errors.Upgrade(err, "Failed to record session", errcode.ErrInternalFailure)
+		}
+	} else {
+		var newTokenID string
+		var expiresAt int64
+		newRefreshToken, newTokenID, expiresAt, err = r.token.RotateRefreshToken(ctx, result.UserID, result.FamilyID)
+		if err != nil {
+			return "", "", errors.Join(err, "failed to generate new refresh token")
+		}
+		rotated, err := r.rotation.Rotate(ctx, result.FamilyID, result.TokenID, newTokenID)
+		if err != nil {
+			return "", "", errors.Upgrade(err, "Failed to rotate refresh token", errcode.ErrInternalFailure)
+		}
+		if !rotated {
+			if err := r.revocation.RevokeTokenFamily(ctx, result.FamilyID); err != nil {
+				return "", "", errors.Upgrade(err, "Failed to revoke refresh tokens", errcode.ErrInternalFailure)
+			}
+			if err := r.session.DeleteSessionByFamilyID(ctx, result.FamilyID); err != nil {
+				return "", "", errors.Upgrade(err, "Failed to delete session", errcode.ErrInternalFailure)
+			}
+			if err := r.authEvent.EmitRefreshTokenReusedEvent(ctx, result.UserID, result.FamilyID); err != nil {
+				return "", "", errors.Upgrade(err, "Failed to emit refresh token reuse event", errcode.ErrInternalFailure)
+			}
+			return "", "", errors.New("refresh token was already rotated", "Unauthorized", errcode.ErrUnauthorized)
+		}
+		if err := r.session.TouchSession(ctx, result.FamilyID, time.Unix(expiresAt, 0)); err != nil {
+			return "", "", errors.Upgrade(err, "Failed to update session", errcode.ErrInternalFailure)
+		}
 	}
-	newAccessToken, _, err = r.token.GenerateAccessToken(ctx, userUID)
+
+	// Generate a new access token
+	newAccessToken, _, err = r.token.GenerateAccessToken(ctx, result.UserID)
 	if err != nil {
 		return "", "", errors.Join(err, "failed to generate new access token")
 	}
-	newRefreshToken, _, err = r.token.GenerateRefreshToken(ctx, userUID)
-	if err != nil {
-		return "", "", errors.Join(err, "failed to generate new refresh token")
-	}
 
 	return newAccessToken, newRefreshToken, nil
 }
 
-// NewRefreshUsecase creates a new instance of RefreshUsecase with the provided token repository.
-func NewRefreshUsecase(token *tokenrepo.TokenRepository) *RefreshUsecase {
+// NewRefreshUsecase creates a new instance of RefreshUsecase.
+func NewRefreshUsecase(
+	token *tokenrepo.TokenRepository,
+	revocation *revocationrepo.RevocationRepository,
+	rotation *rotationrepo.RotationRepository,
+	session *dbrepo.SessionRepository,
+	activity *dbrepo.AuthActivityRepository,
+	authEvent *autheventrepo.AuthEventEmitter,
+) *RefreshUsecase {
 	return &RefreshUsecase{
-		token: token,
+		token:      token,
+		revocation: revocation,
+		rotation:   rotation,
+		session:    session,
+		activity:   activity,
+		authEvent:  authEvent,
 	}
 }
@@ -8,18 +8,48 @@ import (
 )
 
 type UserEventUsecase struct {
-	authAccountRepo *dbrepo.AuthAccountRepository
+	authAccountRepo    *dbrepo.AuthAccountRepository
+	totpCredentialRepo *dbrepo.TotpCredentialRepository
+	webauthnCredRepo   *dbrepo.WebauthnCredentialRepository
+	recoveryCodeRepo   *dbrepo.RecoveryCodeRepository
+	providerTokenRepo  *dbrepo.ProviderTokenRepository
+	sessionRepo        *dbrepo.SessionRepository
+	authActivityRepo   *dbrepo.AuthActivityRepository
 }
 
 func (u *UserEventUsecase) HandleUserDeleted(ctx context.Context, userID uuid.UUID) error {
 	if err := u.authAccountRepo.DeleteAuthAccountByUserID(ctx, userID); err != nil {
 		return err
 	}
+	if err := u.totpCredentialRepo.DeleteTotpCredentialByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.webauthnCredRepo.DeleteWebauthnCredentialsByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.recoveryCodeRepo.DeleteRecoveryCodesByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.providerTokenRepo.DeleteProviderTokensByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.sessionRepo.DeleteSessionsByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.authActivityRepo.DeleteAuthActivitiesByUserID(ctx, userID); err != nil {
+		return err
+	}
 	return nil
 }
 
-func NewUserEventUsecase(authAccountRepo *dbrepo.AuthAccountRepository) *UserEventUsecase {
+func NewUserEventUsecase(authAccountRepo *dbrepo.AuthAccountRepository, totpCredentialRepo *dbrepo.TotpCredentialRepository, webauthnCredRepo *dbrepo.WebauthnCredentialRepository, recoveryCodeRepo *dbrepo.RecoveryCodeRepository, providerTokenRepo *dbrepo.ProviderTokenRepository, sessionRepo *dbrepo.SessionRepository, authActivityRepo *dbrepo.AuthActivityRepository) *UserEventUsecase {
 	return &UserEventUsecase{
-		authAccountRepo: authAccountRepo,
+		authAccountRepo:    authAccountRepo,
+		totpCredentialRepo: totpCredentialRepo,
+		webauthnCredRepo:   webauthnCredRepo,
+		recoveryCodeRepo:   recoveryCodeRepo,
+		providerTokenRepo:  providerTokenRepo,
+		sessionRepo:        sessionRepo,
+		authActivityRepo:   authActivityRepo,
 	}
 }
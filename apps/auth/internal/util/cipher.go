@@ -0,0 +1,105 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+// Cipher encrypts short secrets with AES-GCM for storage at rest.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a Cipher from a 16, 24 or 32 byte key.
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt encrypts the plaintext and returns the nonce and ciphertext as base64.
+//
+// The ciphertext is bound to additionalData, which Decrypt must be given as
+// well, so that it cannot be moved to another record.
+func (c *Cipher) Encrypt(plaintext string, additionalData string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), []byte(additionalData))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt with the same additionalData.
+func (c *Cipher) Decrypt(ciphertext string, additionalData string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(additionalData))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Keyring encrypts with its current key and decrypts with any of its keys,
+// so that keys can be rotated while older ciphertexts remain readable.
+type Keyring struct {
+	currentID string
+	ciphers   map[string]*Cipher
+}
+
+// NewKeyring creates a Keyring from keys by ID, encrypting with currentID.
+func NewKeyring(currentID string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[currentID]; !ok {
+		return nil, errors.New("current key " + currentID + " is not in the keyring")
+	}
+
+	ciphers := make(map[string]*Cipher, len(keys))
+	for id, key := range keys {
+		c, err := NewCipher(key)
+		if err != nil {
+			return nil, errors.New("key " + id + ": " + err.Error())
+		}
+		ciphers[id] = c
+	}
+	return &Keyring{currentID: currentID, ciphers: ciphers}, nil
+}
+
+// CurrentKeyID returns the ID of the key Encrypt uses.
+func (k *Keyring) CurrentKeyID() string {
+	return k.currentID
+}
+
+// Encrypt encrypts the plaintext with the current key and returns the key ID
+// along with the ciphertext.
+//
+// The ciphertext is bound to additionalData and the key ID.
+func (k *Keyring) Encrypt(plaintext string, additionalData string) (keyID string, ciphertext string, err error) {
+	ciphertext, err = k.ciphers[k.currentID].Encrypt(plaintext, additionalData+"|"+k.currentID)
+	return k.currentID, ciphertext, err
+}
+
+// Decrypt decrypts a ciphertext of Encrypt with the key it was encrypted with
+// and the same additionalData.
+func (k *Keyring) Decrypt(keyID string, ciphertext string, additionalData string) (string, error) {
+	c, ok := k.ciphers[keyID]
+	if !ok {
+		return "", errors.New("key " + keyID + " is not in the keyring")
+	}
+	return c.Decrypt(ciphertext, additionalData+"|"+keyID)
+}
@@ -0,0 +1,213 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordAlgorithm hashes passwords with one algorithm and verifies hashes it
+// produced, including hashes made with other parameters.
+type PasswordAlgorithm interface {
+	// Identifies reports whether the hash was produced by this algorithm.
+	Identifies(hash string) bool
+	// Hash hashes the password with the current parameters.
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the hash.
+	Verify(hash string, password string) (bool, error)
+	// NeedsRehash reports whether the hash uses weaker parameters than the current ones.
+	NeedsRehash(hash string) bool
+}
+
+// PasswordHasher hashes new passwords with a preferred algorithm and still
+// verifies hashes of the other known algorithms, so stored hashes can be
+// upgraded as users log in.
+type PasswordHasher struct {
+	preferred  PasswordAlgorithm
+	algorithms []PasswordAlgorithm
+}
+
+// Hash hashes the password with the preferred algorithm.
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify reports whether the password matches a hash of any known algorithm.
+func (h *PasswordHasher) Verify(hash string, password string) (bool, error) {
+	for _, algorithm := range h.algorithms {
+		if algorithm.Identifies(hash) {
+			return algorithm.Verify(hash, password)
+		}
+	}
+	return false, errors.New("unknown password hash format")
+}
+
+// NeedsRehash reports whether the hash should be replaced by a hash of the
+// preferred algorithm with the current parameters.
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	return !h.preferred.Identifies(hash) || h.preferred.NeedsRehash(hash)
+}
+
+// NewPasswordHasher creates a PasswordHasher hashing with preferred and
+// verifying with preferred and legacy.
+func NewPasswordHasher(preferred PasswordAlgorithm, legacy ...PasswordAlgorithm) *PasswordHasher {
+	return &PasswordHasher{
+		preferred:  preferred,
+		algorithms: append([]PasswordAlgorithm{preferred}, legacy...),
+	}
+}
+
+// Argon2Params are the Argon2id cost parameters. Memory is in KiB.
+type Argon2Params struct {
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// Argon2idAlgorithm hashes passwords with Argon2id into PHC strings like
+// "$argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>".
+type Argon2idAlgorithm struct {
+	params Argon2Params
+}
+
+const argon2idPrefix = "$argon2id$"
+
+// decode parses a PHC string into its parameters, salt and key.
+func (a *Argon2idAlgorithm) decode(hash string) (Argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2Params{}, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	if version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var params Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+	params.SaltLen = uint32(len(salt))
+	params.KeyLen = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+// Identifies implements PasswordAlgorithm.
+func (a *Argon2idAlgorithm) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, argon2idPrefix)
+}
+
+// Hash implements PasswordAlgorithm.
+func (a *Argon2idAlgorithm) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, a.params.Time, a.params.Memory, a.params.Threads, a.params.KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		a.params.Memory,
+		a.params.Time,
+		a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements PasswordAlgorithm.
+func (a *Argon2idAlgorithm) Verify(hash string, password string) (bool, error) {
+	params, salt, key, err := a.decode(hash)
+	if err != nil {
+		return false, err
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+	return subtle.ConstantTimeCompare(key, candidate) == 1, nil
+}
+
+// NeedsRehash implements PasswordAlgorithm.
+func (a *Argon2idAlgorithm) NeedsRehash(hash string) bool {
+	params, _, _, err := a.decode(hash)
+	if err != nil {
+		return true
+	}
+	return params.Memory < a.params.Memory ||
+		params.Time < a.params.Time ||
+		params.SaltLen < a.params.SaltLen ||
+		params.KeyLen < a.params.KeyLen
+}
+
+// NewArgon2idAlgorithm creates an Argon2idAlgorithm hashing with the given parameters.
+func NewArgon2idAlgorithm(params Argon2Params) *Argon2idAlgorithm {
+	return &Argon2idAlgorithm{
+		params: params,
+	}
+}
+
+// BcryptAlgorithm hashes passwords with bcrypt in its modular crypt format.
+type BcryptAlgorithm struct {
+	cost int
+}
+
+// Identifies implements PasswordAlgorithm.
+func (b *BcryptAlgorithm) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
+
+// Hash implements PasswordAlgorithm.
+func (b *BcryptAlgorithm) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify implements PasswordAlgorithm.
+func (b *BcryptAlgorithm) Verify(hash string, password string) (bool, error) {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// NeedsRehash implements PasswordAlgorithm.
+func (b *BcryptAlgorithm) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < b.cost
+}
+
+// NewBcryptAlgorithm creates a BcryptAlgorithm hashing with the given cost.
+func NewBcryptAlgorithm(cost int) *BcryptAlgorithm {
+	return &BcryptAlgorithm{
+		cost: cost,
+	}
+}
@@ -1,20 +1,23 @@
 package util
 
 import (
+	"regexp"
+
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
-	"mandacode.com/accounts/auth/ent/authaccount"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
 )
 
-func ConvertToEnt(provider string) (authaccount.Provider, error) {
-	switch provider {
-	case "google":
-		return authaccount.ProviderGoogle, nil
-	case "kakao":
-		return authaccount.ProviderKakao, nil
-	case "naver":
-		return authaccount.ProviderNaver, nil
-	default:
+// providerNamePattern matches provider names usable in routes and as config keys.
+var providerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// ParseProvider converts the provider of a route to an OAuth provider.
+//
+// Whether the provider is registered is left to the usecases, which know the
+// configured providers.
+func ParseProvider(provider string) (providermodels.Provider, error) {
+	if !providerNamePattern.MatchString(provider) || provider == string(providermodels.ProviderLocal) {
 		return "", errors.New("unsupported provider", "UnsupportedProvider", errcode.ErrInvalidInput)
 	}
+	return providermodels.Provider(provider), nil
 }
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// WaitUntil blocks until the deadline or until the context is done.
+//
+// Deferred at the start of a request, it makes the request take at least as
+// long as the deadline allows, whichever path it returns through.
+func WaitUntil(ctx context.Context, deadline time.Time) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
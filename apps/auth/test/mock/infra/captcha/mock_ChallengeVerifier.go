@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mandacode.com/accounts/auth/internal/infra/captcha (interfaces: ChallengeVerifier)
+//
+// Generated by this command:
+//
+//	mockgen mandacode.com/accounts/auth/internal/infra/captcha ChallengeVerifier
+//
+
+// Package mock_captcha is a generated GoMock package.
+package mock_captcha
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChallengeVerifier is a mock of ChallengeVerifier interface.
+type MockChallengeVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockChallengeVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockChallengeVerifierMockRecorder is the mock recorder for MockChallengeVerifier.
+type MockChallengeVerifierMockRecorder struct {
+	mock *MockChallengeVerifier
+}
+
+// NewMockChallengeVerifier creates a new mock instance.
+func NewMockChallengeVerifier(ctrl *gomock.Controller) *MockChallengeVerifier {
+	mock := &MockChallengeVerifier{ctrl: ctrl}
+	mock.recorder = &MockChallengeVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChallengeVerifier) EXPECT() *MockChallengeVerifierMockRecorder {
+	return m.recorder
+}
+
+// Provider mocks base method.
+func (m *MockChallengeVerifier) Provider() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Provider")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Provider indicates an expected call of Provider.
+func (mr *MockChallengeVerifierMockRecorder) Provider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Provider", reflect.TypeOf((*MockChallengeVerifier)(nil).Provider))
+}
+
+// SiteKey mocks base method.
+func (m *MockChallengeVerifier) SiteKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SiteKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SiteKey indicates an expected call of SiteKey.
+func (mr *MockChallengeVerifierMockRecorder) SiteKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SiteKey", reflect.TypeOf((*MockChallengeVerifier)(nil).SiteKey))
+}
+
+// Verify mocks base method.
+func (m *MockChallengeVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token, remoteIP)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockChallengeVerifierMockRecorder) Verify(ctx, token, remoteIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockChallengeVerifier)(nil).Verify), ctx, token, remoteIP)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mandacode.com/accounts/auth/internal/infra/oauthapi (interfaces: OAuthAPI)
+//
+// Generated by this command:
+//
+//	mockgen mandacode.com/accounts/auth/internal/infra/oauthapi OAuthAPI
+//
+
+// Package mock_oauthapi is a generated GoMock package.
+package mock_oauthapi
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	oauthapi "mandacode.com/accounts/auth/internal/infra/oauthapi"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+)
+
+// MockOAuthAPI is a mock of OAuthAPI interface.
+type MockOAuthAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockOAuthAPIMockRecorder is the mock recorder for MockOAuthAPI.
+type MockOAuthAPIMockRecorder struct {
+	mock *MockOAuthAPI
+}
+
+// NewMockOAuthAPI creates a new mock instance.
+func NewMockOAuthAPI(ctrl *gomock.Controller) *MockOAuthAPI {
+	mock := &MockOAuthAPI{ctrl: ctrl}
+	mock.recorder = &MockOAuthAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthAPI) EXPECT() *MockOAuthAPIMockRecorder {
+	return m.recorder
+}
+
+// GetAccessToken mocks base method.
+func (m *MockOAuthAPI) GetAccessToken(code, codeVerifier string) (*oauthmodels.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessToken", code, codeVerifier)
+	ret0, _ := ret[0].(*oauthmodels.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessToken indicates an expected call of GetAccessToken.
+func (mr *MockOAuthAPIMockRecorder) GetAccessToken(code, codeVerifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessToken", reflect.TypeOf((*MockOAuthAPI)(nil).GetAccessToken), code, codeVerifier)
+}
+
+// GetLoginURL mocks base method.
+func (m *MockOAuthAPI) GetLoginURL(req oauthapi.AuthRequest) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginURL", req)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetLoginURL indicates an expected call of GetLoginURL.
+func (mr *MockOAuthAPIMockRecorder) GetLoginURL(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginURL", reflect.TypeOf((*MockOAuthAPI)(nil).GetLoginURL), req)
+}
+
+// GetNativeUserInfo mocks base method.
+func (m *MockOAuthAPI) GetNativeUserInfo(token oauthapi.NativeToken) (*oauthmodels.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNativeUserInfo", token)
+	ret0, _ := ret[0].(*oauthmodels.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNativeUserInfo indicates an expected call of GetNativeUserInfo.
+func (mr *MockOAuthAPIMockRecorder) GetNativeUserInfo(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNativeUserInfo", reflect.TypeOf((*MockOAuthAPI)(nil).GetNativeUserInfo), token)
+}
+
+// GetUserInfo mocks base method.
+func (m *MockOAuthAPI) GetUserInfo(token *oauthmodels.Token, nonce string) (*oauthmodels.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInfo", token, nonce)
+	ret0, _ := ret[0].(*oauthmodels.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInfo indicates an expected call of GetUserInfo.
+func (mr *MockOAuthAPIMockRecorder) GetUserInfo(token, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockOAuthAPI)(nil).GetUserInfo), token, nonce)
+}
+
+// RefreshAccessToken mocks base method.
+func (m *MockOAuthAPI) RefreshAccessToken(refreshToken string) (*oauthmodels.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAccessToken", refreshToken)
+	ret0, _ := ret[0].(*oauthmodels.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshAccessToken indicates an expected call of RefreshAccessToken.
+func (mr *MockOAuthAPIMockRecorder) RefreshAccessToken(refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAccessToken", reflect.TypeOf((*MockOAuthAPI)(nil).RefreshAccessToken), refreshToken)
+}
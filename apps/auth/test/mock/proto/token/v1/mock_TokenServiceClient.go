@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mandacode-com/accounts-proto/go/token/v1 (interfaces: TokenServiceClient)
+//
+// Generated by this command:
+//
+//	mockgen github.com/mandacode-com/accounts-proto/go/token/v1 TokenServiceClient
+//
+
+// Package mock_tokenv1 is a generated GoMock package.
+package mock_tokenv1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockTokenServiceClient is a mock of TokenServiceClient interface.
+type MockTokenServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenServiceClientMockRecorder is the mock recorder for MockTokenServiceClient.
+type MockTokenServiceClientMockRecorder struct {
+	mock *MockTokenServiceClient
+}
+
+// NewMockTokenServiceClient creates a new mock instance.
+func NewMockTokenServiceClient(ctrl *gomock.Controller) *MockTokenServiceClient {
+	mock := &MockTokenServiceClient{ctrl: ctrl}
+	mock.recorder = &MockTokenServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenServiceClient) EXPECT() *MockTokenServiceClientMockRecorder {
+	return m.recorder
+}
+
+// GenerateAccessToken mocks base method.
+func (m *MockTokenServiceClient) GenerateAccessToken(ctx context.Context, in *tokenv1.GenerateAccessTokenRequest, opts ...grpc.CallOption) (*tokenv1.GenerateAccessTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateAccessToken", varargs...)
+	ret0, _ := ret[0].(*tokenv1.GenerateAccessTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateAccessToken indicates an expected call of GenerateAccessToken.
+func (mr *MockTokenServiceClientMockRecorder) GenerateAccessToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAccessToken", reflect.TypeOf((*MockTokenServiceClient)(nil).GenerateAccessToken), varargs...)
+}
+
+// GenerateEmailVerificationToken mocks base method.
+func (m *MockTokenServiceClient) GenerateEmailVerificationToken(ctx context.Context, in *tokenv1.GenerateEmailVerificationTokenRequest, opts ...grpc.CallOption) (*tokenv1.GenerateEmailVerificationTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateEmailVerificationToken", varargs...)
+	ret0, _ := ret[0].(*tokenv1.GenerateEmailVerificationTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateEmailVerificationToken indicates an expected call of GenerateEmailVerificationToken.
+func (mr *MockTokenServiceClientMockRecorder) GenerateEmailVerificationToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateEmailVerificationToken", reflect.TypeOf((*MockTokenServiceClient)(nil).GenerateEmailVerificationToken), varargs...)
+}
+
+// GenerateRefreshToken mocks base method.
+func (m *MockTokenServiceClient) GenerateRefreshToken(ctx context.Context, in *tokenv1.GenerateRefreshTokenRequest, opts ...grpc.CallOption) (*tokenv1.GenerateRefreshTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateRefreshToken", varargs...)
+	ret0, _ := ret[0].(*tokenv1.GenerateRefreshTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRefreshToken indicates an expected call of GenerateRefreshToken.
+func (mr *MockTokenServiceClientMockRecorder) GenerateRefreshToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockTokenServiceClient)(nil).GenerateRefreshToken), varargs...)
+}
+
+// VerifyAccessToken mocks base method.
+func (m *MockTokenServiceClient) VerifyAccessToken(ctx context.Context, in *tokenv1.VerifyAccessTokenRequest, opts ...grpc.CallOption) (*tokenv1.VerifyAccessTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyAccessToken", varargs...)
+	ret0, _ := ret[0].(*tokenv1.VerifyAccessTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAccessToken indicates an expected call of VerifyAccessToken.
+func (mr *MockTokenServiceClientMockRecorder) VerifyAccessToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAccessToken", reflect.TypeOf((*MockTokenServiceClient)(nil).VerifyAccessToken), varargs...)
+}
+
+// VerifyEmailVerificationToken mocks base method.
+func (m *MockTokenServiceClient) VerifyEmailVerificationToken(ctx context.Context, in *tokenv1.VerifyEmailVerificationTokenRequest, opts ...grpc.CallOption) (*tokenv1.VerifyEmailVerificationTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyEmailVerificationToken", varargs...)
+	ret0, _ := ret[0].(*tokenv1.VerifyEmailVerificationTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailVerificationToken indicates an expected call of VerifyEmailVerificationToken.
+func (mr *MockTokenServiceClientMockRecorder) VerifyEmailVerificationToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailVerificationToken", reflect.TypeOf((*MockTokenServiceClient)(nil).VerifyEmailVerificationToken), varargs...)
+}
+
+// VerifyRefreshToken mocks base method.
+func (m *MockTokenServiceClient) VerifyRefreshToken(ctx context.Context, in *tokenv1.VerifyRefreshTokenRequest, opts ...grpc.CallOption) (*tokenv1.VerifyRefreshTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyRefreshToken", varargs...)
+	ret0, _ := ret[0].(*tokenv1.VerifyRefreshTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyRefreshToken indicates an expected call of VerifyRefreshToken.
+func (mr *MockTokenServiceClientMockRecorder) VerifyRefreshToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRefreshToken", reflect.TypeOf((*MockTokenServiceClient)(nil).VerifyRefreshToken), varargs...)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mandacode-com/accounts-proto/go/user/user/v1 (interfaces: UserServiceClient)
+//
+// Generated by this command:
+//
+//	mockgen github.com/mandacode-com/accounts-proto/go/user/user/v1 UserServiceClient
+//
+
+// Package mock_userv1 is a generated GoMock package.
+package mock_userv1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	userv1 "github.com/mandacode-com/accounts-proto/go/user/user/v1"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockUserServiceClient is a mock of UserServiceClient interface.
+type MockUserServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceClientMockRecorder is the mock recorder for MockUserServiceClient.
+type MockUserServiceClientMockRecorder struct {
+	mock *MockUserServiceClient
+}
+
+// NewMockUserServiceClient creates a new mock instance.
+func NewMockUserServiceClient(ctrl *gomock.Controller) *MockUserServiceClient {
+	mock := &MockUserServiceClient{ctrl: ctrl}
+	mock.recorder = &MockUserServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServiceClient) EXPECT() *MockUserServiceClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserServiceClient) DeleteUser(ctx context.Context, in *userv1.DeleteUserRequest, opts ...grpc.CallOption) (*userv1.DeleteUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUser", varargs...)
+	ret0, _ := ret[0].(*userv1.DeleteUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceClientMockRecorder) DeleteUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceClient)(nil).DeleteUser), varargs...)
+}
+
+// InitUser mocks base method.
+func (m *MockUserServiceClient) InitUser(ctx context.Context, in *userv1.InitUserRequest, opts ...grpc.CallOption) (*userv1.InitUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InitUser", varargs...)
+	ret0, _ := ret[0].(*userv1.InitUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitUser indicates an expected call of InitUser.
+func (mr *MockUserServiceClientMockRecorder) InitUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitUser", reflect.TypeOf((*MockUserServiceClient)(nil).InitUser), varargs...)
+}
+
+// IsActive mocks base method.
+func (m *MockUserServiceClient) IsActive(ctx context.Context, in *userv1.IsActiveRequest, opts ...grpc.CallOption) (*userv1.IsActiveResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IsActive", varargs...)
+	ret0, _ := ret[0].(*userv1.IsActiveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MockUserServiceClientMockRecorder) IsActive(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockUserServiceClient)(nil).IsActive), varargs...)
+}
+
+// IsBlocked mocks base method.
+func (m *MockUserServiceClient) IsBlocked(ctx context.Context, in *userv1.IsBlockedRequest, opts ...grpc.CallOption) (*userv1.IsBlockedResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IsBlocked", varargs...)
+	ret0, _ := ret[0].(*userv1.IsBlockedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockUserServiceClientMockRecorder) IsBlocked(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockUserServiceClient)(nil).IsBlocked), varargs...)
+}
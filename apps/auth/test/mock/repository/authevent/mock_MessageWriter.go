@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mandacode.com/accounts/auth/internal/repository/authevent (interfaces: MessageWriter)
+//
+// Generated by this command:
+//
+//	mockgen mandacode.com/accounts/auth/internal/repository/authevent MessageWriter
+//
+
+// Package mock_autheventrepo is a generated GoMock package.
+package mock_autheventrepo
+
+import (
+	context "context"
+	reflect "reflect"
+
+	kafka "github.com/segmentio/kafka-go"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessageWriter is a mock of MessageWriter interface.
+type MockMessageWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageWriterMockRecorder is the mock recorder for MockMessageWriter.
+type MockMessageWriterMockRecorder struct {
+	mock *MockMessageWriter
+}
+
+// NewMockMessageWriter creates a new mock instance.
+func NewMockMessageWriter(ctrl *gomock.Controller) *MockMessageWriter {
+	mock := &MockMessageWriter{ctrl: ctrl}
+	mock.recorder = &MockMessageWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageWriter) EXPECT() *MockMessageWriterMockRecorder {
+	return m.recorder
+}
+
+// WriteMessages mocks base method.
+func (m *MockMessageWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range msgs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WriteMessages", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteMessages indicates an expected call of WriteMessages.
+func (mr *MockMessageWriterMockRecorder) WriteMessages(ctx any, msgs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, msgs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteMessages", reflect.TypeOf((*MockMessageWriter)(nil).WriteMessages), varargs...)
+}
@@ -0,0 +1,154 @@
+package infra_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mandacode.com/accounts/auth/internal/infra/captcha"
+)
+
+const (
+	testCaptchaSiteKey = "site-key"
+	testCaptchaSecret  = "secret"
+	testCaptchaToken   = "solved-token"
+	testCaptchaIP      = "203.0.113.7"
+)
+
+// newSiteVerifyServer serves a siteverify endpoint accepting testCaptchaToken
+// and answering with score when it is not nil.
+func newSiteVerifyServer(t *testing.T, score *float64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		if got := r.PostForm.Get("secret"); got != testCaptchaSecret {
+			t.Errorf("expected secret %q, got %q", testCaptchaSecret, got)
+		}
+		if got := r.PostForm.Get("remoteip"); got != testCaptchaIP {
+			t.Errorf("expected remoteip %q, got %q", testCaptchaIP, got)
+		}
+
+		response := map[string]any{"success": r.PostForm.Get("response") == testCaptchaToken}
+		if score != nil {
+			response["score"] = *score
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestSiteVerifyVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Verify_Success", func(t *testing.T) {
+		server := newSiteVerifyServer(t, nil)
+		defer server.Close()
+
+		verifier, err := captcha.NewHCaptchaVerifier(server.URL, testCaptchaSiteKey, testCaptchaSecret, server.Client())
+		if err != nil {
+			t.Fatalf("failed to create verifier: %v", err)
+		}
+		solved, err := verifier.Verify(ctx, testCaptchaToken, testCaptchaIP)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !solved {
+			t.Error("expected the challenge to be solved")
+		}
+		if verifier.Provider() != captcha.ProviderHCaptcha || verifier.SiteKey() != testCaptchaSiteKey {
+			t.Errorf("unexpected provider %q and site key %q", verifier.Provider(), verifier.SiteKey())
+		}
+	})
+
+	t.Run("Verify_WrongToken", func(t *testing.T) {
+		server := newSiteVerifyServer(t, nil)
+		defer server.Close()
+
+		verifier, _ := captcha.NewTurnstileVerifier(server.URL, testCaptchaSiteKey, testCaptchaSecret, server.Client())
+		solved, err := verifier.Verify(ctx, "wrong-token", testCaptchaIP)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if solved {
+			t.Error("expected the challenge to be unsolved")
+		}
+	})
+
+	t.Run("Verify_EmptyToken", func(t *testing.T) {
+		verifier, _ := captcha.NewTurnstileVerifier("http://127.0.0.1:0", testCaptchaSiteKey, testCaptchaSecret, nil)
+		solved, err := verifier.Verify(ctx, "", testCaptchaIP)
+		if err != nil || solved {
+			t.Errorf("expected an unsolved challenge without calling the provider, got %v, %v", solved, err)
+		}
+	})
+
+	t.Run("Verify_ScoreBelowMinimum", func(t *testing.T) {
+		score := 0.3
+		server := newSiteVerifyServer(t, &score)
+		defer server.Close()
+
+		verifier, _ := captcha.NewRecaptchaVerifier(server.URL, testCaptchaSiteKey, testCaptchaSecret, 0.5, server.Client())
+		solved, err := verifier.Verify(ctx, testCaptchaToken, testCaptchaIP)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if solved {
+			t.Error("expected a low score to fail the challenge")
+		}
+	})
+
+	t.Run("Verify_ScoreAboveMinimum", func(t *testing.T) {
+		score := 0.9
+		server := newSiteVerifyServer(t, &score)
+		defer server.Close()
+
+		verifier, _ := captcha.NewRecaptchaVerifier(server.URL, testCaptchaSiteKey, testCaptchaSecret, 0.5, server.Client())
+		solved, err := verifier.Verify(ctx, testCaptchaToken, testCaptchaIP)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !solved {
+			t.Error("expected the challenge to be solved")
+		}
+	})
+
+	t.Run("Verify_ProviderError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		verifier, _ := captcha.NewHCaptchaVerifier(server.URL, testCaptchaSiteKey, testCaptchaSecret, server.Client())
+		if _, err := verifier.Verify(ctx, testCaptchaToken, testCaptchaIP); err == nil {
+			t.Error("expected an error when the provider fails")
+		}
+	})
+}
+
+func TestNewSiteVerifyVerifier_MissingSecret(t *testing.T) {
+	if _, err := captcha.NewHCaptchaVerifier(captcha.HCaptchaEndpoint, testCaptchaSiteKey, "", nil); err == nil {
+		t.Error("expected an error without a secret")
+	}
+}
+
+func TestStubVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	verifier := captcha.NewStubVerifier(testCaptchaToken)
+	if solved, _ := verifier.Verify(ctx, testCaptchaToken, testCaptchaIP); !solved {
+		t.Error("expected the pass token to solve the challenge")
+	}
+	if solved, _ := verifier.Verify(ctx, "wrong-token", testCaptchaIP); solved {
+		t.Error("expected another token to fail the challenge")
+	}
+
+	empty := captcha.NewStubVerifier("")
+	if solved, _ := empty.Verify(ctx, "", testCaptchaIP); solved {
+		t.Error("expected a stub without pass token to solve nothing")
+	}
+}
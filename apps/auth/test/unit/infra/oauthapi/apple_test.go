@@ -0,0 +1,320 @@
+package infra_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+)
+
+const (
+	testAppleClientID = "com.example.accounts"
+	testAppleTeamID   = "TEAM123456"
+	testAppleKeyID    = "KEY1234567"
+	testAppleIssuer   = "https://appleid.apple.com"
+	testAppleBundleID = "com.example.accounts.ios"
+)
+
+// fakeApple serves a JWKS and a token endpoint like Apple's.
+type fakeApple struct {
+	t          *testing.T
+	server     *httptest.Server
+	signingKey *rsa.PrivateKey
+	clientKey  *ecdsa.PrivateKey
+	idToken    string
+}
+
+func newFakeApple(t *testing.T) *fakeApple {
+	t.Helper()
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate client key: %v", err)
+	}
+
+	f := &fakeApple{t: t, signingKey: signingKey, clientKey: clientKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/keys", f.serveKeys)
+	mux.HandleFunc("/auth/token", f.serveToken)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeApple) endpoints() oauthapi.AppleEndpoints {
+	return oauthapi.AppleEndpoints{
+		Auth:  f.server.URL + "/auth/authorize",
+		Token: f.server.URL + "/auth/token",
+		Keys:  f.server.URL + "/auth/keys",
+	}
+}
+
+// privateKeyPEM returns the client key as the contents of a .p8 file.
+func (f *fakeApple) privateKeyPEM() []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(f.clientKey)
+	if err != nil {
+		f.t.Fatalf("failed to marshal client key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func (f *fakeApple) serveKeys(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "apple-key",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(f.signingKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(f.signingKey.E)).Bytes()),
+		}},
+	})
+}
+
+func (f *fakeApple) serveToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "valid-code" {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	if r.PostForm.Get("client_id") != testAppleClientID {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusBadRequest)
+		return
+	}
+	if err := f.checkClientSecret(r.PostForm.Get("client_secret")); err != nil {
+		f.t.Errorf("invalid client secret: %v", err)
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "opaque-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     f.idToken,
+	})
+}
+
+// checkClientSecret verifies the ES256 client secret JWT against the client key.
+func (f *fakeApple) checkClientSecret(secret string) error {
+	parts := strings.Split(secret, ".")
+	if len(parts) != 3 {
+		return errString("malformed client secret")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return errString("malformed signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&f.clientKey.PublicKey, digest[:], r, s) {
+		return errString("bad signature")
+	}
+
+	var header map[string]string
+	decodeJSON(f.t, parts[0], &header)
+	if header["alg"] != "ES256" || header["kid"] != testAppleKeyID {
+		return errString("unexpected header")
+	}
+	var claims map[string]any
+	decodeJSON(f.t, parts[1], &claims)
+	if claims["iss"] != testAppleTeamID || claims["sub"] != testAppleClientID || claims["aud"] != testAppleIssuer {
+		return errString("unexpected claims")
+	}
+	return nil
+}
+
+// signIDToken signs claims as an RS256 ID token with the key served in the JWKS.
+func (f *fakeApple) signIDToken(claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "apple-key"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, f.signingKey, crypto.SHA256, digest[:])
+	if err != nil {
+		f.t.Fatalf("failed to sign ID token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (f *fakeApple) claims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":              testAppleIssuer,
+		"aud":              testAppleClientID,
+		"sub":              "001234.abcdef.1234",
+		"iat":              now.Unix(),
+		"exp":              now.Add(10 * time.Minute).Unix(),
+		"email":            "x7k2m9@privaterelay.appleid.com",
+		"email_verified":   "true",
+		"is_private_email": "true",
+	}
+}
+
+type errString string
+
+func (e errString) Error() string { return string(e) }
+
+func decodeJSON(t *testing.T, segment string, v any) {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		t.Fatalf("failed to decode segment: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to unmarshal segment: %v", err)
+	}
+}
+
+func newTestAppleAPI(t *testing.T, f *fakeApple) oauthapi.OAuthAPI {
+	t.Helper()
+	api, err := oauthapi.NewAppleAPI(testAppleClientID, testAppleTeamID, testAppleKeyID, f.privateKeyPEM(), "https://accounts.example.com/callback/apple", []string{testAppleBundleID}, f.endpoints(), validator.New())
+	if err != nil {
+		t.Fatalf("failed to create Apple API: %v", err)
+	}
+	return api
+}
+
+func TestAppleAPI_CodeExchange(t *testing.T) {
+	f := newFakeApple(t)
+	api := newTestAppleAPI(t, f)
+	f.idToken = f.signIDToken(f.claims())
+
+	token, err := api.GetAccessToken("valid-code", "")
+	if err != nil {
+		t.Fatalf("failed to exchange code: %v", err)
+	}
+
+	userInfo, err := api.GetUserInfo(token, "")
+	if err != nil {
+		t.Fatalf("failed to get user info: %v", err)
+	}
+	if userInfo.ProviderID != "001234.abcdef.1234" {
+		t.Errorf("unexpected provider ID %q", userInfo.ProviderID)
+	}
+	if userInfo.Email != "x7k2m9@privaterelay.appleid.com" || !userInfo.EmailVerified {
+		t.Errorf("expected a verified relay email, got %q, %v", userInfo.Email, userInfo.EmailVerified)
+	}
+	if userInfo.Name != "" {
+		t.Errorf("expected no name in the ID token, got %q", userInfo.Name)
+	}
+
+	if _, err := api.GetAccessToken("invalid-code", ""); err == nil {
+		t.Fatal("expected an invalid code to be rejected")
+	}
+}
+
+func TestAppleAPI_RejectsInvalidIDTokens(t *testing.T) {
+	f := newFakeApple(t)
+	api := newTestAppleAPI(t, f)
+
+	cases := []struct {
+		name   string
+		modify func(claims map[string]any)
+	}{
+		{"Wrong Audience", func(claims map[string]any) { claims["aud"] = "com.example.other" }},
+		{"Wrong Issuer", func(claims map[string]any) { claims["iss"] = "https://evil.example.com" }},
+		{"Expired", func(claims map[string]any) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := f.claims()
+			tc.modify(claims)
+			if _, err := api.GetUserInfo(&oauthmodels.Token{IDToken: f.signIDToken(claims)}, ""); err == nil {
+				t.Fatal("expected the ID token to be rejected")
+			}
+		})
+	}
+
+	t.Run("Tampered Payload", func(t *testing.T) {
+		parts := strings.Split(f.signIDToken(f.claims()), ".")
+		claims := f.claims()
+		claims["sub"] = "someone-else"
+		payload, _ := json.Marshal(claims)
+		parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+		if _, err := api.GetUserInfo(&oauthmodels.Token{IDToken: strings.Join(parts, ".")}, ""); err == nil {
+			t.Fatal("expected the ID token to be rejected")
+		}
+	})
+
+	t.Run("Nonce", func(t *testing.T) {
+		claims := f.claims()
+		claims["nonce"] = "nonce-of-attempt"
+		idToken := f.signIDToken(claims)
+		if _, err := api.GetUserInfo(&oauthmodels.Token{IDToken: idToken}, "nonce-of-attempt"); err != nil {
+			t.Fatalf("expected the nonce of the attempt to be accepted: %v", err)
+		}
+		if _, err := api.GetUserInfo(&oauthmodels.Token{IDToken: idToken}, "nonce-of-other-attempt"); err == nil {
+			t.Fatal("expected a mismatched nonce to be rejected")
+		}
+	})
+}
+
+func TestAppleAPI_GetNativeUserInfo(t *testing.T) {
+	f := newFakeApple(t)
+	api := newTestAppleAPI(t, f)
+
+	claims := f.claims()
+	claims["aud"] = testAppleBundleID
+	claims["nonce"] = "nonce-of-app"
+	userInfo, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims), Nonce: "nonce-of-app"})
+	if err != nil {
+		t.Fatalf("expected the identity token of our app to be accepted: %v", err)
+	}
+	if userInfo.ProviderID != "001234.abcdef.1234" {
+		t.Errorf("unexpected provider ID %q", userInfo.ProviderID)
+	}
+
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims), Nonce: "nonce-of-other-app"}); err == nil {
+		t.Error("expected a mismatched nonce to be rejected")
+	}
+	if _, err := api.GetUserInfo(&oauthmodels.Token{IDToken: f.signIDToken(claims)}, ""); err == nil {
+		t.Error("expected the web flow to accept only the Services ID")
+	}
+
+	claims["aud"] = "com.other.app"
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims), Nonce: "nonce-of-app"}); err == nil {
+		t.Error("expected a token of another app to be rejected")
+	}
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{AccessToken: "opaque-access-token"}); err == nil {
+		t.Error("expected an opaque token to be rejected")
+	}
+}
+
+func TestAppleAPI_GetLoginURL(t *testing.T) {
+	f := newFakeApple(t)
+	loginURL := newTestAppleAPI(t, f).GetLoginURL(oauthapi.AuthRequest{State: "state-1", Nonce: "nonce-1", CodeChallenge: "challenge-1"})
+	for _, param := range []string{"response_mode=form_post", "scope=name%20email", "client_id=" + testAppleClientID, "state=state-1", "nonce=nonce-1"} {
+		if !strings.Contains(loginURL, param) {
+			t.Errorf("expected %q in login URL %s", param, loginURL)
+		}
+	}
+}
+
+func TestParseAppleUserName(t *testing.T) {
+	user := `{"name":{"firstName":"Jane","lastName":"Doe"},"email":"jane@example.com"}`
+	if got := oauthapi.ParseAppleUserName(user); got != "Jane Doe" {
+		t.Errorf("expected Jane Doe, got %q", got)
+	}
+	if got := oauthapi.ParseAppleUserName(""); got != "" {
+		t.Errorf("expected no name for later logins, got %q", got)
+	}
+}
@@ -0,0 +1,283 @@
+package infra_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+)
+
+const (
+	testOIDCClientID     = "accounts-client"
+	testOIDCClientSecret = "accounts-secret"
+	testOIDCRedirectURL  = "https://accounts.example.com/callback/corp"
+)
+
+// fakeOIDC serves a discovery document, a JWKS and a token endpoint.
+type fakeOIDC struct {
+	t          *testing.T
+	server     *httptest.Server
+	signingKey *rsa.PrivateKey
+	issuer     string
+	idToken    string
+}
+
+func newFakeOIDC(t *testing.T) *fakeOIDC {
+	t.Helper()
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	f := &fakeOIDC{t: t, signingKey: signingKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", f.serveDiscovery)
+	mux.HandleFunc("/keys", f.serveKeys)
+	mux.HandleFunc("/token", f.serveToken)
+	f.server = httptest.NewServer(mux)
+	f.issuer = f.server.URL
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeOIDC) serveDiscovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                 f.issuer,
+		"authorization_endpoint": f.server.URL + "/authorize",
+		"token_endpoint":         f.server.URL + "/token",
+		"jwks_uri":               f.server.URL + "/keys",
+	})
+}
+
+func (f *fakeOIDC) serveKeys(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "oidc-key",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(f.signingKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(f.signingKey.E)).Bytes()),
+		}},
+	})
+}
+
+func (f *fakeOIDC) serveToken(w http.ResponseWriter, r *http.Request) {
+	// Without token_endpoint_auth_methods_supported, client_secret_basic is expected
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != testOIDCClientID || clientSecret != testOIDCClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+	if r.PostForm.Get("grant_type") == "refresh_token" {
+		if r.PostForm.Get("refresh_token") != "valid-refresh-token" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "refreshed-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"scope":        "openid email",
+		})
+		return
+	}
+	if r.PostForm.Get("code") != "valid-code" {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	if r.PostForm.Get("code_verifier") != "verifier-of-attempt" {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  "opaque-access-token",
+		"refresh_token": "valid-refresh-token",
+		"token_type":    "Bearer",
+		"id_token":      f.idToken,
+	})
+}
+
+func (f *fakeOIDC) signIDToken(claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "oidc-key"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, f.signingKey, crypto.SHA256, digest[:])
+	if err != nil {
+		f.t.Fatalf("failed to sign ID token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (f *fakeOIDC) claims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss": f.issuer,
+		"aud": []string{testOIDCClientID, "other-client"},
+		"sub": "oidc-user-1",
+		"iat": now.Unix(),
+		"exp": now.Add(10 * time.Minute).Unix(),
+		"profile": map[string]any{
+			"mail":     "jane@corp.example.com",
+			"verified": "true",
+		},
+		"name":  "Jane Doe",
+		"nonce": "nonce-of-attempt",
+	}
+}
+
+func newTestOIDCAPI(t *testing.T, f *fakeOIDC) oauthapi.OAuthAPI {
+	t.Helper()
+	api, err := oauthapi.NewOIDCAPI(f.issuer, testOIDCClientID, testOIDCClientSecret, testOIDCRedirectURL,
+		[]string{"openid", "email", "profile"}, []string{"accounts-android"},
+		oauthapi.OIDCClaimPaths{Email: "profile.mail", EmailVerified: "profile.verified"},
+		nil, validator.New())
+	if err != nil {
+		t.Fatalf("failed to create OIDC API: %v", err)
+	}
+	return api
+}
+
+func TestOIDCAPI_CodeExchange(t *testing.T) {
+	f := newFakeOIDC(t)
+	api := newTestOIDCAPI(t, f)
+	f.idToken = f.signIDToken(f.claims())
+
+	token, err := api.GetAccessToken("valid-code", "verifier-of-attempt")
+	if err != nil {
+		t.Fatalf("failed to exchange code: %v", err)
+	}
+	if token.AccessToken != "opaque-access-token" || token.RefreshToken != "valid-refresh-token" {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	userInfo, err := api.GetUserInfo(token, "nonce-of-attempt")
+	if err != nil {
+		t.Fatalf("failed to get user info: %v", err)
+	}
+	if userInfo.ProviderID != "oidc-user-1" || userInfo.Name != "Jane Doe" {
+		t.Errorf("unexpected user info %+v", userInfo)
+	}
+	if userInfo.Email != "jane@corp.example.com" || !userInfo.EmailVerified {
+		t.Errorf("expected the verified email of the nested claims, got %q, %v", userInfo.Email, userInfo.EmailVerified)
+	}
+
+	if _, err := api.GetAccessToken("invalid-code", "verifier-of-attempt"); err == nil {
+		t.Fatal("expected an invalid code to be rejected")
+	}
+	if _, err := api.GetAccessToken("valid-code", "verifier-of-other-attempt"); err == nil {
+		t.Fatal("expected a code with the wrong verifier to be rejected")
+	}
+}
+
+func TestOIDCAPI_RefreshAccessToken(t *testing.T) {
+	f := newFakeOIDC(t)
+	api := newTestOIDCAPI(t, f)
+
+	token, err := api.RefreshAccessToken("valid-refresh-token")
+	if err != nil {
+		t.Fatalf("failed to refresh token: %v", err)
+	}
+	if token.AccessToken != "refreshed-access-token" || token.Scope != "openid email" {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if token.RefreshToken != "" {
+		t.Errorf("expected no new refresh token, got %q", token.RefreshToken)
+	}
+	if token.ExpiresAt.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("expected the token to expire in an hour, got %v", token.ExpiresAt)
+	}
+
+	if _, err := api.RefreshAccessToken("revoked-refresh-token"); err == nil {
+		t.Fatal("expected a revoked refresh token to be rejected")
+	}
+}
+
+func TestOIDCAPI_RejectsInvalidIDTokens(t *testing.T) {
+	f := newFakeOIDC(t)
+	api := newTestOIDCAPI(t, f)
+
+	cases := []struct {
+		name   string
+		modify func(claims map[string]any)
+	}{
+		{"Wrong Audience", func(claims map[string]any) { claims["aud"] = "other-client" }},
+		{"Wrong Issuer", func(claims map[string]any) { claims["iss"] = "https://evil.example.com" }},
+		{"Expired", func(claims map[string]any) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"Missing Email", func(claims map[string]any) { delete(claims, "profile") }},
+		{"Wrong Nonce", func(claims map[string]any) { claims["nonce"] = "nonce-of-other-attempt" }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := f.claims()
+			tc.modify(claims)
+			if _, err := api.GetUserInfo(&oauthmodels.Token{IDToken: f.signIDToken(claims)}, "nonce-of-attempt"); err == nil {
+				t.Fatal("expected the ID token to be rejected")
+			}
+		})
+	}
+}
+
+func TestOIDCAPI_GetNativeUserInfo(t *testing.T) {
+	f := newFakeOIDC(t)
+	api := newTestOIDCAPI(t, f)
+
+	claims := f.claims()
+	claims["aud"] = "accounts-android"
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims), Nonce: "nonce-of-attempt"}); err != nil {
+		t.Fatalf("expected the ID token of our app to be accepted: %v", err)
+	}
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims)}); err == nil {
+		t.Error("expected an ID token with an unexpected nonce to be rejected")
+	}
+	delete(claims, "nonce")
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims)}); !errors.Is(err, oauthapi.ErrNonceRequired) {
+		t.Errorf("expected an ID token without a nonce to be rejected, got %v", err)
+	}
+	claims["nonce"] = "nonce-of-attempt"
+
+	claims["aud"] = "other-app"
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{IDToken: f.signIDToken(claims), Nonce: "nonce-of-attempt"}); err == nil {
+		t.Error("expected a token of another app to be rejected")
+	}
+	if _, err := api.GetNativeUserInfo(oauthapi.NativeToken{AccessToken: "opaque-access-token"}); err == nil {
+		t.Error("expected an opaque token to be rejected")
+	}
+}
+
+func TestOIDCAPI_Discovery(t *testing.T) {
+	f := newFakeOIDC(t)
+
+	loginURL := newTestOIDCAPI(t, f).GetLoginURL(oauthapi.AuthRequest{State: "state-1", Nonce: "nonce-1", CodeChallenge: "challenge-1"})
+	if !strings.HasPrefix(loginURL, f.server.URL+"/authorize?") {
+		t.Errorf("expected the discovered authorization endpoint, got %s", loginURL)
+	}
+	for _, param := range []string{"scope=openid%20email%20profile", "state=state-1", "nonce=nonce-1", "code_challenge=challenge-1", "code_challenge_method=S256"} {
+		if !strings.Contains(loginURL, param) {
+			t.Errorf("expected %q in login URL %s", param, loginURL)
+		}
+	}
+
+	// A document describing another issuer must not be trusted
+	f.issuer = "https://evil.example.com"
+	if _, err := oauthapi.NewOIDCAPI(f.server.URL, testOIDCClientID, testOIDCClientSecret, testOIDCRedirectURL, []string{"openid"}, nil, oauthapi.OIDCClaimPaths{}, nil, validator.New()); err == nil {
+		t.Fatal("expected a mismatched issuer to be rejected")
+	}
+}
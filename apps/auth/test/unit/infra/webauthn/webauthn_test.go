@@ -0,0 +1,339 @@
+package infra_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+)
+
+const (
+	testRPID   = "accounts.example.com"
+	testOrigin = "https://accounts.example.com"
+)
+
+// softAuthenticator is a minimal software authenticator holding one ES256 credential.
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	aaguid       []byte
+	signCount    uint32
+	flags        byte
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	credentialID := make([]byte, 32)
+	aaguid := make([]byte, 16)
+	rand.Read(credentialID)
+	rand.Read(aaguid)
+	return &softAuthenticator{
+		key:          key,
+		credentialID: credentialID,
+		aaguid:       aaguid,
+		flags:        0x01 | 0x04, // user present, user verified
+	}
+}
+
+// CBOR encoding helpers, enough for COSE keys and attestation objects.
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 1<<8:
+		return []byte{major<<5 | 24, byte(n)}
+	default:
+		return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+	}
+}
+
+func cborInt(n int64) []byte {
+	if n < 0 {
+		return cborHead(1, uint64(-1-n))
+	}
+	return cborHead(0, uint64(n))
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, uint64(len(b))), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, uint64(len(s))), s...)
+}
+
+func cborMap(pairs ...[]byte) []byte {
+	out := cborHead(5, uint64(len(pairs)/2))
+	for _, p := range pairs {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func (a *softAuthenticator) coseKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	return cborMap(
+		cborInt(1), cborInt(2), // kty: EC2
+		cborInt(3), cborInt(webauthn.AlgES256),
+		cborInt(-1), cborInt(1), // crv: P-256
+		cborInt(-2), cborBytes(x),
+		cborInt(-3), cborBytes(y),
+	)
+}
+
+func (a *softAuthenticator) authData(rpID string, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte(nil), rpIDHash[:]...)
+	flags := a.flags
+	if attested {
+		flags |= 0x40
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		data = append(data, a.aaguid...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremony string, challenge []byte, origin string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": webauthn.EncodeBase64URL(challenge),
+		"origin":    origin,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode client data: %v", err)
+	}
+	return data
+}
+
+func (a *softAuthenticator) create(t *testing.T, rpID string, challenge []byte, origin string) (clientData []byte, attestationObject []byte) {
+	t.Helper()
+	clientData = clientDataJSON(t, "webauthn.create", challenge, origin)
+	attestationObject = cborMap(
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborMap(),
+		cborText("authData"), cborBytes(a.authData(rpID, true)),
+	)
+	return clientData, attestationObject
+}
+
+func (a *softAuthenticator) get(t *testing.T, rpID string, challenge []byte, origin string) (clientData []byte, authData []byte, signature []byte) {
+	t.Helper()
+	a.signCount++
+	clientData = clientDataJSON(t, "webauthn.get", challenge, origin)
+	authData = a.authData(rpID, false)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign assertion: %v", err)
+	}
+	return clientData, authData, signature
+}
+
+func newRelyingParty() *webauthn.RelyingParty {
+	return &webauthn.RelyingParty{
+		ID:                      testRPID,
+		Name:                    "Example",
+		Origins:                 []string{testOrigin},
+		RequireUserVerification: true,
+		Timeout:                 5 * time.Minute,
+	}
+}
+
+func register(t *testing.T, rp *webauthn.RelyingParty, authenticator *softAuthenticator) *webauthn.Credential {
+	t.Helper()
+	challenge := []byte("registration-challenge")
+	clientData, attestationObject := authenticator.create(t, testRPID, challenge, testOrigin)
+	credential, err := rp.VerifyRegistration(challenge, clientData, attestationObject, []string{"internal"})
+	if err != nil {
+		t.Fatalf("expected registration to succeed, got %v", err)
+	}
+	return credential
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	rp := newRelyingParty()
+
+	t.Run("VerifyRegistration_Success", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+		if !bytes.Equal(credential.ID, authenticator.credentialID) {
+			t.Error("expected credential ID to match the authenticator")
+		}
+		if !bytes.Equal(credential.AAGUID, authenticator.aaguid) {
+			t.Error("expected AAGUID to match the authenticator")
+		}
+		if !bytes.Equal(credential.PublicKey, authenticator.coseKey()) {
+			t.Error("expected public key to match the authenticator")
+		}
+		if len(credential.Transports) != 1 || credential.Transports[0] != "internal" {
+			t.Errorf("unexpected transports %v", credential.Transports)
+		}
+	})
+
+	t.Run("VerifyRegistration_ParseChallenge", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		clientData, _ := authenticator.create(t, testRPID, []byte("challenge"), testOrigin)
+		challenge, err := webauthn.ParseChallenge(clientData)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if string(challenge) != "challenge" {
+			t.Errorf("expected challenge %q, got %q", "challenge", challenge)
+		}
+	})
+
+	t.Run("VerifyRegistration_WrongChallenge", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		clientData, attestationObject := authenticator.create(t, testRPID, []byte("challenge"), testOrigin)
+		_, err := rp.VerifyRegistration([]byte("other"), clientData, attestationObject, nil)
+		if !errors.Is(err, webauthn.ErrInvalidClientData) {
+			t.Errorf("expected ErrInvalidClientData, got %v", err)
+		}
+	})
+
+	t.Run("VerifyRegistration_WrongOrigin", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		challenge := []byte("challenge")
+		clientData, attestationObject := authenticator.create(t, testRPID, challenge, "https://evil.example.com")
+		_, err := rp.VerifyRegistration(challenge, clientData, attestationObject, nil)
+		if !errors.Is(err, webauthn.ErrInvalidClientData) {
+			t.Errorf("expected ErrInvalidClientData, got %v", err)
+		}
+	})
+
+	t.Run("VerifyRegistration_WrongRPID", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		challenge := []byte("challenge")
+		clientData, attestationObject := authenticator.create(t, "evil.example.com", challenge, testOrigin)
+		_, err := rp.VerifyRegistration(challenge, clientData, attestationObject, nil)
+		if !errors.Is(err, webauthn.ErrInvalidAuthenticatorData) {
+			t.Errorf("expected ErrInvalidAuthenticatorData, got %v", err)
+		}
+	})
+
+	t.Run("VerifyRegistration_UserNotVerified", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		authenticator.flags = 0x01
+		challenge := []byte("challenge")
+		clientData, attestationObject := authenticator.create(t, testRPID, challenge, testOrigin)
+		_, err := rp.VerifyRegistration(challenge, clientData, attestationObject, nil)
+		if !errors.Is(err, webauthn.ErrUserNotVerified) {
+			t.Errorf("expected ErrUserNotVerified, got %v", err)
+		}
+	})
+
+	t.Run("VerifyRegistration_MalformedAttestation", func(t *testing.T) {
+		challenge := []byte("challenge")
+		clientData := clientDataJSON(t, "webauthn.create", challenge, testOrigin)
+		_, err := rp.VerifyRegistration(challenge, clientData, []byte{0xff, 0x00}, nil)
+		if !errors.Is(err, webauthn.ErrInvalidAttestation) {
+			t.Errorf("expected ErrInvalidAttestation, got %v", err)
+		}
+	})
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	rp := newRelyingParty()
+
+	t.Run("VerifyAssertion_Success", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+
+		challenge := []byte("assertion-challenge")
+		clientData, authData, signature := authenticator.get(t, testRPID, challenge, testOrigin)
+		signCount, err := rp.VerifyAssertion(challenge, clientData, authData, signature, credential.PublicKey, credential.SignCount)
+		if err != nil {
+			t.Fatalf("expected assertion to succeed, got %v", err)
+		}
+		if signCount != authenticator.signCount {
+			t.Errorf("expected sign count %d, got %d", authenticator.signCount, signCount)
+		}
+	})
+
+	t.Run("VerifyAssertion_WrongCeremony", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+
+		challenge := []byte("challenge")
+		clientData, attestationObject := authenticator.create(t, testRPID, challenge, testOrigin)
+		_, err := rp.VerifyAssertion(challenge, clientData, attestationObject, nil, credential.PublicKey, 0)
+		if !errors.Is(err, webauthn.ErrInvalidClientData) {
+			t.Errorf("expected ErrInvalidClientData, got %v", err)
+		}
+	})
+
+	t.Run("VerifyAssertion_InvalidSignature", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+
+		challenge := []byte("challenge")
+		clientData, authData, signature := authenticator.get(t, testRPID, challenge, testOrigin)
+		signature[len(signature)-1] ^= 0xff
+		_, err := rp.VerifyAssertion(challenge, clientData, authData, signature, credential.PublicKey, credential.SignCount)
+		if !errors.Is(err, webauthn.ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("VerifyAssertion_OtherKey", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		other := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+
+		challenge := []byte("challenge")
+		clientData, authData, signature := other.get(t, testRPID, challenge, testOrigin)
+		_, err := rp.VerifyAssertion(challenge, clientData, authData, signature, credential.PublicKey, credential.SignCount)
+		if !errors.Is(err, webauthn.ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("VerifyAssertion_SignCountReplay", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+
+		challenge := []byte("challenge")
+		clientData, authData, signature := authenticator.get(t, testRPID, challenge, testOrigin)
+		_, err := rp.VerifyAssertion(challenge, clientData, authData, signature, credential.PublicKey, authenticator.signCount)
+		if !errors.Is(err, webauthn.ErrSignCount) {
+			t.Errorf("expected ErrSignCount, got %v", err)
+		}
+	})
+
+	t.Run("VerifyAssertion_UserNotPresent", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t)
+		credential := register(t, rp, authenticator)
+
+		authenticator.flags = 0x04
+		challenge := []byte("challenge")
+		clientData, authData, signature := authenticator.get(t, testRPID, challenge, testOrigin)
+		_, err := rp.VerifyAssertion(challenge, clientData, authData, signature, credential.PublicKey, credential.SignCount)
+		if !errors.Is(err, webauthn.ErrUserNotPresent) {
+			t.Errorf("expected ErrUserNotPresent, got %v", err)
+		}
+	})
+}
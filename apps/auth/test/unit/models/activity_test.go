@@ -0,0 +1,102 @@
+package models_test
+
+import (
+	stdErrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+)
+
+func TestNewEntry(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		entry := activitymodels.NewEntry(activitymodels.MethodOAuth, "google", userID, nil)
+		if entry.Outcome != activitymodels.OutcomeSuccess || entry.FailureReason != "" {
+			t.Fatalf("expected a success without reason, got %q, %q", entry.Outcome, entry.FailureReason)
+		}
+		if entry.UserID != userID || entry.Provider != "google" {
+			t.Fatalf("expected the user and provider of the attempt, got %v, %q", entry.UserID, entry.Provider)
+		}
+	})
+
+	t.Run("Failure Keeps First Line", func(t *testing.T) {
+		err := errors.Join(errors.New("invalid email or password", "Unauthorized", errcode.ErrUnauthorized), "login failed")
+		entry := activitymodels.NewEntry(activitymodels.MethodPassword, "", userID, err)
+		if entry.Outcome != activitymodels.OutcomeFailure || entry.FailureReason != "login failed" {
+			t.Fatalf("expected a failure with the outer message, got %q, %q", entry.Outcome, entry.FailureReason)
+		}
+	})
+
+	t.Run("Failure Bounds Reason", func(t *testing.T) {
+		err := errors.New(strings.Repeat("x", 1000), "Unauthorized", errcode.ErrUnauthorized)
+		entry := activitymodels.NewEntry(activitymodels.MethodPassword, "", userID, err)
+		if len(entry.FailureReason) != 255 {
+			t.Fatalf("expected the reason to be truncated, got %d bytes", len(entry.FailureReason))
+		}
+	})
+
+	t.Run("Internal Failure Hides Message", func(t *testing.T) {
+		for _, err := range []error{
+			errors.New("pq: connection refused", "Internal Error", errcode.ErrInternalFailure),
+			stdErrors.New("dial tcp: timeout"),
+		} {
+			entry := activitymodels.NewEntry(activitymodels.MethodRefresh, "", uuid.Nil, err)
+			if entry.FailureReason != "internal error" {
+				t.Fatalf("expected the internal message to be hidden, got %q", entry.FailureReason)
+			}
+		}
+	})
+}
+
+func TestCursor(t *testing.T) {
+	cursor := activitymodels.Cursor{
+		CreatedAt: time.Date(2026, 10, 17, 12, 30, 0, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	t.Run("Round Trip", func(t *testing.T) {
+		parsed, err := activitymodels.ParseCursor(cursor.Encode())
+		if err != nil {
+			t.Fatalf("failed to parse cursor: %v", err)
+		}
+		if !parsed.CreatedAt.Equal(cursor.CreatedAt) || parsed.ID != cursor.ID {
+			t.Fatalf("expected %v, got %v", cursor, parsed)
+		}
+	})
+
+	t.Run("Rejects Malformed", func(t *testing.T) {
+		for _, value := range []string{"", "not base64!", "bm8tc2VwYXJhdG9y", "MTIzLm5vdC1hLXV1aWQ"} {
+			if _, err := activitymodels.ParseCursor(value); !errors.Is(err, errcode.ErrInvalidInput) {
+				t.Fatalf("expected %q to be rejected as invalid input, got %v", value, err)
+			}
+		}
+	})
+}
+
+func TestNetworkPrefix(t *testing.T) {
+	tests := []struct {
+		ip     string
+		prefix string
+		ok     bool
+	}{
+		{ip: "203.0.113.42", prefix: "203.0.113.", ok: true},
+		{ip: "::ffff:203.0.113.42", prefix: "203.0.113.", ok: true},
+		{ip: "2001:db8::1", ok: false},
+		{ip: "", ok: false},
+		{ip: "not-an-ip", ok: false},
+	}
+
+	for _, tt := range tests {
+		prefix, ok := activitymodels.NetworkPrefix(tt.ip)
+		if prefix != tt.prefix || ok != tt.ok {
+			t.Errorf("NetworkPrefix(%q) = %q, %v; want %q, %v", tt.ip, prefix, ok, tt.prefix, tt.ok)
+		}
+	}
+}
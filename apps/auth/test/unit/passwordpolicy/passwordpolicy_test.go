@@ -0,0 +1,118 @@
+package passwordpolicy_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
+)
+
+var testRules = passwordpolicy.Rules{
+	MinLength:    8,
+	RequireLower: true,
+	RequireDigit: true,
+	MinStrength:  passwordpolicy.StrengthFair,
+}
+
+// writeBreachedList writes a range file list holding the given passwords.
+func writeBreachedList(t *testing.T, passwords ...string) *passwordpolicy.BreachedList {
+	t.Helper()
+	dir := t.TempDir()
+	for _, password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		file, err := os.OpenFile(filepath.Join(dir, hash[:5]+".txt"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatalf("failed to open range file: %v", err)
+		}
+		file.WriteString(hash[5:] + ":42\r\n")
+		file.Close()
+	}
+	list, err := passwordpolicy.NewBreachedList(dir)
+	if err != nil {
+		t.Fatalf("failed to load breached list: %v", err)
+	}
+	return list
+}
+
+// reasons returns the violation reasons of a policy error.
+func reasons(t *testing.T, err error) []string {
+	t.Helper()
+	var policyErr *passwordpolicy.PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected a policy error, got %v", err)
+	}
+	result := make([]string, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		if violation.Field != "password" {
+			t.Errorf("expected field password, got %q", violation.Field)
+		}
+		result = append(result, violation.Reason)
+	}
+	return result
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	policy := passwordpolicy.NewPolicy(testRules, writeBreachedList(t, "Summer2024x!"))
+
+	t.Run("Accepts Strong Password", func(t *testing.T) {
+		if err := policy.Validate("password", "correct7horse-battery", "jane.doe@example.com"); err != nil {
+			t.Fatalf("expected password to be accepted, got %v", err)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		password string
+		email    string
+		reason   string
+	}{
+		{"Too Short", "ab3!", "user@example.com", passwordpolicy.ReasonTooShort},
+		{"Missing Digit", "correct-horse-battery", "user@example.com", passwordpolicy.ReasonMissingDigit},
+		{"Missing Lowercase", "CORRECT7HORSE-BATTERY", "user@example.com", passwordpolicy.ReasonMissingLowercase},
+		{"Contains Email", "xJane.Doe4-staple", "jane.doe@example.com", passwordpolicy.ReasonContainsEmail},
+		{"Too Weak", "aaaaaaaaaaa1", "user@example.com", passwordpolicy.ReasonTooWeak},
+		{"Breached", "Summer2024x!", "user@example.com", passwordpolicy.ReasonBreached},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := reasons(t, policy.Validate("password", tc.password, tc.email))
+			found := false
+			for _, reason := range got {
+				found = found || reason == tc.reason
+			}
+			if !found {
+				t.Fatalf("expected reason %q, got %v", tc.reason, got)
+			}
+		})
+	}
+}
+
+func TestBreachedList_Contains(t *testing.T) {
+	list := writeBreachedList(t, "hunter22")
+
+	breached, err := list.Contains("hunter22")
+	if err != nil || !breached {
+		t.Fatalf("expected password to be breached, got %v, %v", breached, err)
+	}
+
+	// No range file exists for this prefix
+	breached, err = list.Contains("not-in-the-list")
+	if err != nil || breached {
+		t.Fatalf("expected password not to be breached, got %v, %v", breached, err)
+	}
+}
+
+func TestEstimateStrength(t *testing.T) {
+	if got := passwordpolicy.EstimateStrength("abcdefgh"); got != passwordpolicy.StrengthVeryWeak {
+		t.Errorf("expected a sequence to be very weak, got %d", got)
+	}
+	if got := passwordpolicy.EstimateStrength("x7#Kp!2qLm@9vR"); got < passwordpolicy.StrengthStrong {
+		t.Errorf("expected a random password to be strong, got %d", got)
+	}
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+const testLoginCodeTTL = time.Minute
+
+func newTestLoginCodeManager(t *testing.T) (*miniredis.Miniredis, *coderepo.LoginCodeManager) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	return server, coderepo.NewLoginCodeManager(util.NewRandomGenerator(32), testLoginCodeTTL, store, "login_code:")
+}
+
+func TestLoginCodeManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Consumes Code Once With Request Info", func(t *testing.T) {
+		server, manager := newTestLoginCodeManager(t)
+		userID := uuid.New()
+		info := reqmodels.RequestInfo{IP: "203.0.113.7", UserAgent: "Browser", DeviceName: "Laptop", IsWeb: true}
+
+		code, err := manager.IssueCode(reqmodels.WithRequestInfo(ctx, info), userID)
+		if err != nil {
+			t.Fatalf("failed to issue code: %v", err)
+		}
+		if ttl := server.TTL("login_code:" + code); ttl != testLoginCodeTTL {
+			t.Fatalf("expected the code to expire after %s, got %s", testLoginCodeTTL, ttl)
+		}
+		consumed, valid, err := manager.ConsumeCode(ctx, userID, code)
+		if err != nil || !valid {
+			t.Fatalf("expected the code to be valid, got %v, %v", valid, err)
+		}
+		if consumed != info {
+			t.Fatalf("expected the request info of the issuing client, got %+v", consumed)
+		}
+		if _, valid, err := manager.ConsumeCode(ctx, userID, code); err != nil || valid {
+			t.Fatalf("expected the code to be consumed, got %v, %v", valid, err)
+		}
+	})
+
+	t.Run("Keeps Code Of Another User", func(t *testing.T) {
+		_, manager := newTestLoginCodeManager(t)
+		userID := uuid.New()
+
+		code, err := manager.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue code: %v", err)
+		}
+		if _, valid, err := manager.ConsumeCode(ctx, uuid.New(), code); err != nil || valid {
+			t.Fatalf("expected the code to be rejected for another user, got %v, %v", valid, err)
+		}
+		if _, valid, err := manager.ConsumeCode(ctx, userID, code); err != nil || !valid {
+			t.Fatalf("expected the code to be kept for its user, got %v, %v", valid, err)
+		}
+	})
+
+	t.Run("Rejects Unknown Code", func(t *testing.T) {
+		_, manager := newTestLoginCodeManager(t)
+
+		if _, valid, err := manager.ConsumeCode(ctx, uuid.New(), "unknown-code"); err != nil || valid {
+			t.Fatalf("expected an unknown code to be rejected, got %v, %v", valid, err)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+)
+
+const (
+	testOTPTTL         = 10 * time.Minute
+	testOTPMaxAttempts = 3
+	testOTPCooldown    = time.Minute
+)
+
+func newTestOTPManager(t *testing.T) (*miniredis.Miniredis, *coderepo.OTPManager) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	return server, coderepo.NewOTPManager(store, "otp:", testOTPTTL, "otp-hash-key", testOTPMaxAttempts, testOTPCooldown)
+}
+
+// wrongCode returns a well-formed code other than code.
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
+
+func issueOTP(t *testing.T, manager *coderepo.OTPManager, userID uuid.UUID) string {
+	t.Helper()
+	code, err := manager.IssueOTP(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to issue code: %v", err)
+	}
+	if len(code) != 6 {
+		t.Fatalf("expected a 6-digit code, got %q", code)
+	}
+	return code
+}
+
+func validateOTP(t *testing.T, manager *coderepo.OTPManager, userID uuid.UUID, code string) bool {
+	t.Helper()
+	valid, err := manager.ValidateOTP(context.Background(), userID, code)
+	if err != nil {
+		t.Fatalf("failed to validate code: %v", err)
+	}
+	return valid
+}
+
+func TestOTPManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Consumes Valid Code", func(t *testing.T) {
+		_, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		code := issueOTP(t, manager, userID)
+
+		if validateOTP(t, manager, uuid.New(), code) {
+			t.Fatal("expected the code to be rejected for another user")
+		}
+		if !validateOTP(t, manager, userID, code) {
+			t.Fatal("expected the code to be valid")
+		}
+		if validateOTP(t, manager, userID, code) {
+			t.Fatal("expected the code to be consumed")
+		}
+	})
+
+	t.Run("Expires Code", func(t *testing.T) {
+		server, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		code := issueOTP(t, manager, userID)
+
+		server.FastForward(testOTPTTL)
+		if validateOTP(t, manager, userID, code) {
+			t.Fatal("expected the code to expire")
+		}
+	})
+
+	t.Run("Replaces Earlier Code", func(t *testing.T) {
+		_, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		first := issueOTP(t, manager, userID)
+		second := issueOTP(t, manager, userID)
+
+		if first != second && validateOTP(t, manager, userID, first) {
+			t.Fatal("expected the earlier code to be replaced")
+		}
+		if !validateOTP(t, manager, userID, second) {
+			t.Fatal("expected the latest code to be valid")
+		}
+	})
+
+	t.Run("Counts Attempts", func(t *testing.T) {
+		_, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		code := issueOTP(t, manager, userID)
+
+		for range testOTPMaxAttempts - 1 {
+			if validateOTP(t, manager, userID, wrongCode(code)) {
+				t.Fatal("expected a wrong code to be rejected")
+			}
+		}
+		if !validateOTP(t, manager, userID, code) {
+			t.Fatal("expected the code to be valid on the last attempt")
+		}
+	})
+
+	t.Run("Consumes Code After Max Attempts", func(t *testing.T) {
+		_, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		code := issueOTP(t, manager, userID)
+
+		for range testOTPMaxAttempts {
+			if validateOTP(t, manager, userID, wrongCode(code)) {
+				t.Fatal("expected a wrong code to be rejected")
+			}
+		}
+		if validateOTP(t, manager, userID, code) {
+			t.Fatal("expected the code to be consumed once the attempts ran out")
+		}
+	})
+
+	t.Run("Keeps Attempts Across Codes", func(t *testing.T) {
+		_, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		first := issueOTP(t, manager, userID)
+
+		for range testOTPMaxAttempts - 1 {
+			if validateOTP(t, manager, userID, wrongCode(first)) {
+				t.Fatal("expected a wrong code to be rejected")
+			}
+		}
+		second := issueOTP(t, manager, userID)
+		if validateOTP(t, manager, userID, wrongCode(second)) {
+			t.Fatal("expected a wrong code to be rejected")
+		}
+		if validateOTP(t, manager, userID, second) {
+			t.Fatal("expected a new code not to reset the attempts")
+		}
+		if _, err := manager.IssueOTP(ctx, userID); !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected no code while the attempts are used up, got %v", err)
+		}
+	})
+
+	t.Run("Resets Attempts After Code TTL", func(t *testing.T) {
+		server, manager := newTestOTPManager(t)
+		userID := uuid.New()
+		code := issueOTP(t, manager, userID)
+
+		for range testOTPMaxAttempts {
+			validateOTP(t, manager, userID, wrongCode(code))
+		}
+		server.FastForward(testOTPTTL)
+		code = issueOTP(t, manager, userID)
+		if !validateOTP(t, manager, userID, code) {
+			t.Fatal("expected the attempts to be reset once they expire")
+		}
+	})
+
+	t.Run("Limits Resends", func(t *testing.T) {
+		server, manager := newTestOTPManager(t)
+
+		left, err := manager.ClaimResend(ctx, "User@Example.com")
+		if err != nil || left != 0 {
+			t.Fatalf("expected the first resend to be claimed, got %s, %v", left, err)
+		}
+		left, err = manager.ClaimResend(ctx, "user@example.com")
+		if err != nil || left <= 0 || left > testOTPCooldown {
+			t.Fatalf("expected the cooldown of the email to be running, got %s, %v", left, err)
+		}
+		if left, err := manager.ClaimResend(ctx, "other@example.com"); err != nil || left != 0 {
+			t.Fatalf("expected another email to be unaffected, got %s, %v", left, err)
+		}
+
+		server.FastForward(testOTPCooldown)
+		if left, err := manager.ClaimResend(ctx, "user@example.com"); err != nil || left != 0 {
+			t.Fatalf("expected a resend after the cooldown, got %s, %v", left, err)
+		}
+	})
+}
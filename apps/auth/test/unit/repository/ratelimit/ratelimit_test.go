@@ -0,0 +1,191 @@
+package repository_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+)
+
+func newTestStore(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	return server, store
+}
+
+// retryAfter returns the wait of a rejected attempt, failing the test if the
+// attempt was allowed.
+func retryAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+	if !errors.Is(err, errcode.ErrTooManyRequests) {
+		t.Fatalf("expected the attempt to be rejected, got %v", err)
+	}
+	var limitErr *ratelimitrepo.LimitError
+	if !stdErrors.As(err, &limitErr) {
+		t.Fatalf("expected a LimitError, got %v", err)
+	}
+	return limitErr.RetryAfter
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	key := ratelimitrepo.EmailKey("User@Example.com")
+
+	t.Run("Delays After Free Attempts", func(t *testing.T) {
+		_, store := newTestStore(t)
+		limiter := ratelimitrepo.NewLimiter(store, "login:", ratelimitrepo.Policy{
+			Window:       time.Hour,
+			FreeAttempts: 2,
+			MaxAttempts:  10,
+			BaseDelay:    time.Minute,
+			MaxDelay:     3 * time.Minute,
+			Lockout:      time.Hour,
+		})
+
+		for range 2 {
+			if err := limiter.RecordFailure(ctx, key); err != nil {
+				t.Fatalf("failed to record failure: %v", err)
+			}
+		}
+		if err := limiter.Check(ctx, key); err != nil {
+			t.Fatalf("expected the free attempts not to be delayed, got %v", err)
+		}
+
+		// The delay doubles with each further failure, up to MaxDelay
+		for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+			if err := limiter.RecordFailure(ctx, key); err != nil {
+				t.Fatalf("failed to record failure: %v", err)
+			}
+			wait := retryAfter(t, limiter.Check(ctx, key))
+			if wait > want || wait < want-time.Second {
+				t.Fatalf("expected a wait of about %s, got %s", want, wait)
+			}
+		}
+	})
+
+	t.Run("Forgets Failures Outside Window", func(t *testing.T) {
+		_, store := newTestStore(t)
+		limiter := ratelimitrepo.NewLimiter(store, "login:", ratelimitrepo.Policy{
+			Window:       200 * time.Millisecond,
+			FreeAttempts: 1,
+			MaxAttempts:  10,
+			BaseDelay:    time.Hour,
+			MaxDelay:     time.Hour,
+			Lockout:      time.Hour,
+		})
+
+		for range 2 {
+			if err := limiter.RecordFailure(ctx, key); err != nil {
+				t.Fatalf("failed to record failure: %v", err)
+			}
+		}
+		retryAfter(t, limiter.Check(ctx, key))
+
+		time.Sleep(300 * time.Millisecond)
+		if err := limiter.Check(ctx, key); err != nil {
+			t.Fatalf("expected failures outside the window to be forgotten, got %v", err)
+		}
+	})
+
+	t.Run("Locks Out After Max Attempts", func(t *testing.T) {
+		server, store := newTestStore(t)
+		limiter := ratelimitrepo.NewLimiter(store, "login:", ratelimitrepo.Policy{
+			Window:       time.Hour,
+			FreeAttempts: 10,
+			MaxAttempts:  3,
+			Lockout:      15 * time.Minute,
+		})
+
+		for i := range 3 {
+			locked, err := limiter.IsLockedOut(ctx, key)
+			if err != nil || locked {
+				t.Fatalf("expected no lockout after %d failures, got %v, %v", i, locked, err)
+			}
+			if err := limiter.RecordFailure(ctx, key); err != nil {
+				t.Fatalf("failed to record failure: %v", err)
+			}
+		}
+		locked, err := limiter.IsLockedOut(ctx, key)
+		if err != nil || !locked {
+			t.Fatalf("expected a lockout, got %v, %v", locked, err)
+		}
+		if wait := retryAfter(t, limiter.Check(ctx, key)); wait > 15*time.Minute || wait < 14*time.Minute {
+			t.Fatalf("expected a wait of the lockout, got %s", wait)
+		}
+
+		// A success does not lift the lockout
+		if err := limiter.Reset(ctx, key); err != nil {
+			t.Fatalf("failed to reset: %v", err)
+		}
+		retryAfter(t, limiter.Check(ctx, key))
+
+		// The failures start over once the lockout ends
+		server.FastForward(15 * time.Minute)
+		if err := limiter.Check(ctx, key); err != nil {
+			t.Fatalf("expected attempts after the lockout, got %v", err)
+		}
+		if err := limiter.RecordFailure(ctx, key); err != nil {
+			t.Fatalf("failed to record failure: %v", err)
+		}
+		if locked, _ := limiter.IsLockedOut(ctx, key); locked {
+			t.Fatal("expected a clean window after the lockout")
+		}
+	})
+
+	t.Run("Reset Clears Failures", func(t *testing.T) {
+		_, store := newTestStore(t)
+		limiter := ratelimitrepo.NewLimiter(store, "login:", ratelimitrepo.Policy{
+			Window:       time.Hour,
+			FreeAttempts: 1,
+			MaxAttempts:  10,
+			BaseDelay:    time.Hour,
+			MaxDelay:     time.Hour,
+			Lockout:      time.Hour,
+		})
+
+		for range 2 {
+			if err := limiter.RecordFailure(ctx, key); err != nil {
+				t.Fatalf("failed to record failure: %v", err)
+			}
+		}
+		retryAfter(t, limiter.Check(ctx, key))
+		if err := limiter.Reset(ctx, key); err != nil {
+			t.Fatalf("failed to reset: %v", err)
+		}
+		if err := limiter.Check(ctx, key); err != nil {
+			t.Fatalf("expected the failures to be cleared, got %v", err)
+		}
+	})
+
+	t.Run("Checks Every Key", func(t *testing.T) {
+		_, store := newTestStore(t)
+		limiter := ratelimitrepo.NewLimiter(store, "login:", ratelimitrepo.Policy{
+			Window:       time.Hour,
+			FreeAttempts: 10,
+			MaxAttempts:  1,
+			Lockout:      time.Hour,
+		})
+		ipKey := ratelimitrepo.IPKey("203.0.113.7")
+
+		if err := limiter.RecordFailure(ctx, ipKey); err != nil {
+			t.Fatalf("failed to record failure: %v", err)
+		}
+		if err := limiter.Check(ctx, key); err != nil {
+			t.Fatalf("expected another key to be unaffected, got %v", err)
+		}
+		retryAfter(t, limiter.Check(ctx, key, ipKey))
+
+		if err := limiter.RecordFailure(ctx, key); err != nil {
+			t.Fatalf("failed to record failure: %v", err)
+		}
+		retryAfter(t, limiter.Check(ctx, ratelimitrepo.EmailKey("user@example.com")))
+	})
+}
@@ -0,0 +1,146 @@
+package repository_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+)
+
+const testRevocationTTL = 720 * time.Hour
+
+func newTestRevocationRepository(t *testing.T) (*miniredis.Miniredis, *revocationrepo.RevocationRepository) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	return server, revocationrepo.NewRevocationRepository(store, "revoked:", testRevocationTTL)
+}
+
+// tokenKey returns the key the token service looks up for a revoked token.
+func tokenKey(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return "revoked:token:" + hex.EncodeToString(digest[:])
+}
+
+func TestRevocationRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Revokes Token By Digest", func(t *testing.T) {
+		server, repo := newTestRevocationRepository(t)
+
+		if err := repo.RevokeToken(ctx, "refresh-token"); err != nil {
+			t.Fatalf("failed to revoke the token: %v", err)
+		}
+		if !server.Exists(tokenKey("refresh-token")) {
+			t.Fatal("expected the token to be revoked")
+		}
+		if server.Exists("revoked:token:refresh-token") {
+			t.Fatal("expected the store never to hold the token itself")
+		}
+	})
+
+	t.Run("Revokes User Tokens At Store Time", func(t *testing.T) {
+		server, repo := newTestRevocationRepository(t)
+		userID := uuid.New()
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		server.SetTime(now)
+
+		if err := repo.RevokeUserTokens(ctx, userID); err != nil {
+			t.Fatalf("failed to revoke the tokens of the user: %v", err)
+		}
+		value, err := server.Get("revoked:user:" + userID.String())
+		if err != nil {
+			t.Fatalf("expected the tokens of the user to be revoked: %v", err)
+		}
+		if revokedAt, err := strconv.ParseInt(value, 10, 64); err != nil || revokedAt != now.UnixMilli() {
+			t.Fatalf("expected the store time in milliseconds, got %q", value)
+		}
+	})
+
+	t.Run("Revokes For Token Lifetime", func(t *testing.T) {
+		server, repo := newTestRevocationRepository(t)
+		userID := uuid.New()
+
+		if err := repo.RevokeToken(ctx, "refresh-token"); err != nil {
+			t.Fatalf("failed to revoke the token: %v", err)
+		}
+		if err := repo.RevokeTokenFamily(ctx, "family"); err != nil {
+			t.Fatalf("failed to revoke the family: %v", err)
+		}
+		if err := repo.RevokeUserTokens(ctx, userID); err != nil {
+			t.Fatalf("failed to revoke the tokens of the user: %v", err)
+		}
+		for _, key := range []string{tokenKey("refresh-token"), "revoked:family:family", "revoked:user:" + userID.String()} {
+			if ttl := server.TTL(key); ttl != testRevocationTTL {
+				t.Errorf("expected %s to outlive the token, got %s", key, ttl)
+			}
+		}
+	})
+
+	t.Run("Claims Token Once", func(t *testing.T) {
+		server, repo := newTestRevocationRepository(t)
+
+		claimed, err := repo.ClaimToken(ctx, "legacy-refresh-token")
+		if err != nil || !claimed {
+			t.Fatalf("expected the token to be claimed, got %v, %v", claimed, err)
+		}
+		if !server.Exists(tokenKey("legacy-refresh-token")) {
+			t.Fatal("expected the claimed token to be revoked")
+		}
+		if ttl := server.TTL(tokenKey("legacy-refresh-token")); ttl != testRevocationTTL {
+			t.Fatalf("expected the revocation to outlive the token, got %s", ttl)
+		}
+		claimed, err = repo.ClaimToken(ctx, "legacy-refresh-token")
+		if err != nil || claimed {
+			t.Fatalf("expected a claimed token not to be claimed again, got %v, %v", claimed, err)
+		}
+	})
+
+	t.Run("Claims Token Once Concurrently", func(t *testing.T) {
+		_, repo := newTestRevocationRepository(t)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		winners := 0
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				claimed, err := repo.ClaimToken(ctx, "legacy-refresh-token")
+				if err != nil {
+					t.Errorf("failed to claim: %v", err)
+					return
+				}
+				if claimed {
+					mu.Lock()
+					winners++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if winners != 1 {
+			t.Fatalf("expected exactly one concurrent claim to win, got %d", winners)
+		}
+	})
+
+	t.Run("Does Not Claim Revoked Token", func(t *testing.T) {
+		_, repo := newTestRevocationRepository(t)
+
+		if err := repo.RevokeToken(ctx, "legacy-refresh-token"); err != nil {
+			t.Fatalf("failed to revoke: %v", err)
+		}
+		if claimed, err := repo.ClaimToken(ctx, "legacy-refresh-token"); err != nil || claimed {
+			t.Fatalf("expected a revoked token not to be claimed, got %v, %v", claimed, err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	rotationrepo "mandacode.com/accounts/auth/internal/repository/rotation"
+)
+
+const testRotationTTL = 720 * time.Hour
+
+func newTestRotationRepository(t *testing.T) (*miniredis.Miniredis, *rotationrepo.RotationRepository) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	return server, rotationrepo.NewRotationRepository(store, "rotation:", testRotationTTL)
+}
+
+func rotate(t *testing.T, repo *rotationrepo.RotationRepository, familyID, previousTokenID, nextTokenID string) bool {
+	t.Helper()
+	rotated, err := repo.Rotate(context.Background(), familyID, previousTokenID, nextTokenID)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	return rotated
+}
+
+func TestRotationRepository(t *testing.T) {
+	t.Run("Rotates Current Token", func(t *testing.T) {
+		server, repo := newTestRotationRepository(t)
+
+		// The token issued at login has no entry yet
+		if !rotate(t, repo, "family", "token-0", "token-1") {
+			t.Fatal("expected the login token to be rotated")
+		}
+		if !rotate(t, repo, "family", "token-1", "token-2") {
+			t.Fatal("expected the current token to be rotated")
+		}
+		if current, _ := server.Get("rotation:family"); current != "token-2" {
+			t.Fatalf("expected token-2 to be current, got %q", current)
+		}
+		if ttl := server.TTL("rotation:family"); ttl != testRotationTTL {
+			t.Fatalf("expected the entry to live as long as refresh tokens, got %s", ttl)
+		}
+	})
+
+	t.Run("Rejects Rotated Token", func(t *testing.T) {
+		server, repo := newTestRotationRepository(t)
+		rotate(t, repo, "family", "token-0", "token-1")
+		rotate(t, repo, "family", "token-1", "token-2")
+
+		for _, reused := range []string{"token-0", "token-1"} {
+			if rotate(t, repo, "family", reused, "token-3") {
+				t.Fatalf("expected the rotated %s to be detected as reused", reused)
+			}
+		}
+		if current, _ := server.Get("rotation:family"); current != "token-2" {
+			t.Fatalf("expected a reused token to leave token-2 current, got %q", current)
+		}
+		if !rotate(t, repo, "other-family", "token-1", "token-4") {
+			t.Fatal("expected families to be tracked apart")
+		}
+	})
+
+	t.Run("Rotates Once Concurrently", func(t *testing.T) {
+		_, repo := newTestRotationRepository(t)
+		rotate(t, repo, "family", "token-0", "token-1")
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		winners := 0
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rotated, err := repo.Rotate(context.Background(), "family", "token-1", "token-next-"+strconv.Itoa(i))
+				if err != nil {
+					t.Errorf("failed to rotate: %v", err)
+					return
+				}
+				if rotated {
+					mu.Lock()
+					winners++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if winners != 1 {
+			t.Fatalf("expected exactly one concurrent rotation to win, got %d", winners)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package signuppolicy_test
+
+import (
+	"testing"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+)
+
+func newPolicy(t *testing.T, rules signuppolicy.Rules) *signuppolicy.Policy {
+	policy, err := signuppolicy.NewPolicy(rules)
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	return policy
+}
+
+func TestPolicy_Check(t *testing.T) {
+	allowlist := newPolicy(t, signuppolicy.Rules{
+		Mode:           signuppolicy.ModeDomain,
+		AllowedDomains: []string{"Example.com", "@corp.example.org"},
+		DeniedDomains:  []string{"contractors.example.com"},
+	})
+	denylist := newPolicy(t, signuppolicy.Rules{
+		Mode:          signuppolicy.ModeDomain,
+		DeniedDomains: []string{"mailinator.com"},
+	})
+
+	cases := []struct {
+		name     string
+		policy   *signuppolicy.Policy
+		email    string
+		verified bool
+		allowed  bool
+	}{
+		{"Open", newPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeOpen}), "user@anywhere.net", false, true},
+		{"Closed", newPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeClosed}), "user@example.com", true, false},
+		{"Invite Leaves Codes To Gate", newPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeInvite}), "user@example.com", true, true},
+		{"Allowed Domain", allowlist, "user@EXAMPLE.com", true, true},
+		{"Allowed Subdomain", allowlist, "user@eng.example.com", true, true},
+		{"Denied Subdomain Of Allowed Domain", allowlist, "user@contractors.example.com", true, false},
+		{"Domain Not Allowed", allowlist, "user@example.net", true, false},
+		{"Lookalike Domain", allowlist, "user@badexample.com", true, false},
+		{"Unverified Email", allowlist, "user@example.com", false, false},
+		{"Domain Not Denied", denylist, "user@example.net", true, true},
+		{"Denied Domain", denylist, "user@mailinator.com", true, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Check(tc.email, tc.verified)
+			if tc.allowed && err != nil {
+				t.Fatalf("expected %s to be allowed, got %v", tc.email, err)
+			}
+			if !tc.allowed && !errors.Is(err, errcode.ErrForbidden) {
+				t.Fatalf("expected %s to be forbidden, got %v", tc.email, err)
+			}
+		})
+	}
+}
+
+func TestPolicy_InviteOnly(t *testing.T) {
+	if !newPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeInvite}).InviteOnly() {
+		t.Error("expected invite mode to be invite-only")
+	}
+	if newPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeOpen}).InviteOnly() {
+		t.Error("expected open mode not to be invite-only")
+	}
+}
+
+func TestNewPolicy_Invalid(t *testing.T) {
+	if _, err := signuppolicy.NewPolicy(signuppolicy.Rules{Mode: signuppolicy.ModeDomain}); err == nil {
+		t.Error("expected domain mode without domains to be rejected")
+	}
+	if _, err := signuppolicy.NewPolicy(signuppolicy.Rules{Mode: "everyone"}); err == nil {
+		t.Error("expected an unknown mode to be rejected")
+	}
+}
+
+func TestPolicy_CheckDomain(t *testing.T) {
+	denylist := newPolicy(t, signuppolicy.Rules{
+		Mode:          signuppolicy.ModeDomain,
+		DeniedDomains: []string{"mailinator.com"},
+	})
+	closed := newPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeClosed})
+
+	if err := denylist.CheckDomain("user@mailinator.com"); !errors.Is(err, errcode.ErrForbidden) {
+		t.Fatalf("expected the denied domain to be forbidden, got %v", err)
+	}
+	if err := denylist.CheckDomain("user@example.com"); err != nil {
+		t.Fatalf("expected the domain to be allowed, got %v", err)
+	}
+	// Closing signup does not keep existing users from changing their email
+	if err := closed.CheckDomain("user@mailinator.com"); err != nil {
+		t.Fatalf("expected any domain to be allowed while signup is closed, got %v", err)
+	}
+}
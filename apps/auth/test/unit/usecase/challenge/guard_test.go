@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+	"mandacode.com/accounts/auth/internal/infra/captcha"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
+	mock_captcha "mandacode.com/accounts/auth/test/mock/infra/captcha"
+)
+
+const (
+	testRiskyIP = "203.0.113.7"
+	testOtherIP = "198.51.100.7"
+)
+
+type guardTest struct {
+	guard    *challenge.Guard
+	verifier *mock_captcha.MockChallengeVerifier
+}
+
+// newGuardTest creates a guard asking for a challenge once an IP has
+// threshold risky events.
+func newGuardTest(t *testing.T, threshold int64) *guardTest {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+
+	verifier := mock_captcha.NewMockChallengeVerifier(gomock.NewController(t))
+	verifier.EXPECT().Provider().Return(captcha.ProviderTurnstile).AnyTimes()
+	verifier.EXPECT().SiteKey().Return("site-key").AnyTimes()
+	return &guardTest{
+		guard:    challenge.NewGuard(verifier, ratelimitrepo.NewCounter(store, "risk:", time.Hour), threshold),
+		verifier: verifier,
+	}
+}
+
+func (g *guardTest) recordRisk(t *testing.T, ip string, times int) {
+	t.Helper()
+	for range times {
+		if err := g.guard.RecordRisk(context.Background(), ip); err != nil {
+			t.Fatalf("failed to record risk: %v", err)
+		}
+	}
+}
+
+func TestGuard(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Requires Nothing Without Verifier", func(t *testing.T) {
+		guard := challenge.NewGuard(nil, nil, 0)
+
+		if err := guard.RecordRisk(ctx, testRiskyIP); err != nil {
+			t.Fatalf("expected risk to be ignored, got %v", err)
+		}
+		if err := guard.Check(ctx, testRiskyIP, ""); err != nil {
+			t.Fatalf("expected no challenge, got %v", err)
+		}
+	})
+
+	t.Run("Requires Nothing Below Threshold", func(t *testing.T) {
+		test := newGuardTest(t, 2)
+		test.recordRisk(t, testRiskyIP, 1)
+
+		if err := test.guard.Check(ctx, testRiskyIP, ""); err != nil {
+			t.Fatalf("expected no challenge, got %v", err)
+		}
+	})
+
+	t.Run("Tells Risky Client Which Challenge To Solve", func(t *testing.T) {
+		test := newGuardTest(t, 2)
+		test.recordRisk(t, testRiskyIP, 2)
+
+		requirement, err := test.guard.Requirement(ctx, testRiskyIP)
+		if err != nil || requirement == nil || requirement.Provider != captcha.ProviderTurnstile || requirement.SiteKey != "site-key" {
+			t.Fatalf("expected a turnstile challenge, got %+v, %v", requirement, err)
+		}
+		if requirement, err := test.guard.Requirement(ctx, testOtherIP); err != nil || requirement != nil {
+			t.Fatalf("expected other clients to need no challenge, got %+v, %v", requirement, err)
+		}
+	})
+
+	t.Run("Rejects Risky Client Without Token", func(t *testing.T) {
+		test := newGuardTest(t, 1)
+		test.recordRisk(t, testRiskyIP, 1)
+
+		err := test.guard.Check(ctx, testRiskyIP, "")
+		var requiredErr *challenge.RequiredError
+		if !errors.Is(err, errcode.ErrForbidden) || !stdErrors.As(err, &requiredErr) {
+			t.Fatalf("expected the challenge to be required, got %v", err)
+		}
+	})
+
+	t.Run("Accepts Solved Challenge", func(t *testing.T) {
+		test := newGuardTest(t, 1)
+		test.recordRisk(t, testRiskyIP, 1)
+		test.verifier.EXPECT().Verify(gomock.Any(), "solved-token", testRiskyIP).Return(true, nil)
+
+		if err := test.guard.Check(ctx, testRiskyIP, "solved-token"); err != nil {
+			t.Fatalf("expected the solved challenge to pass, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Unsolved Challenge", func(t *testing.T) {
+		test := newGuardTest(t, 1)
+		test.recordRisk(t, testRiskyIP, 1)
+		test.verifier.EXPECT().Verify(gomock.Any(), "wrong-token", testRiskyIP).Return(false, nil)
+
+		if err := test.guard.Check(ctx, testRiskyIP, "wrong-token"); !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the unsolved challenge to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Fails When Provider Is Unreachable", func(t *testing.T) {
+		test := newGuardTest(t, 0)
+		test.verifier.EXPECT().Verify(gomock.Any(), "solved-token", testRiskyIP).Return(false, stdErrors.New("connection refused"))
+
+		if err := test.guard.Check(ctx, testRiskyIP, "solved-token"); !errors.Is(err, errcode.ErrInternalFailure) {
+			t.Fatalf("expected an internal failure, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/emailauth"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+	mock_autheventrepo "mandacode.com/accounts/auth/test/mock/repository/authevent"
+)
+
+// testMagicLinkRequestDuration is the least time RequestMagicLink takes.
+const testMagicLinkRequestDuration = 500 * time.Millisecond
+
+type magicLinkTest struct {
+	usecase     *emailauth.MagicLinkUsecase
+	client      *ent.Client
+	authAccount *dbrepo.AuthAccountRepository
+	totp        *dbrepo.TotpCredentialRepository
+	linkCodes   *coderepo.CodeManager
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	writer      *mock_mailer.MockMessageWriter
+	server      *miniredis.Miniredis
+}
+
+func newMagicLinkTest(t *testing.T) *magicLinkTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	cipher, err := util.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	hasher := util.NewPasswordHasher(util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  8192,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	}))
+
+	test := &magicLinkTest{
+		client:      client,
+		authAccount: dbrepo.NewAuthAccountRepository(client, hasher, zap.NewNop()),
+		totp:        dbrepo.NewTotpCredentialRepository(client, cipher),
+		linkCodes:   coderepo.NewCodeManager(util.NewRandomGenerator(32), 15*time.Minute, store, "magic_link:"),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+		server:      server,
+	}
+	mail := mailer.NewMailer(test.writer, "https://accounts.example.com/sessions")
+	notifier := notice.NewSecurityNotifier(test.authAccount, dbrepo.NewAuthActivityRepository(client), mail, zap.NewNop())
+	issuer := session.NewIssuer(tokenrepo.NewTokenRepository(test.tokenClient), dbrepo.NewSessionRepository(client), dbrepo.NewAuthActivityRepository(client), notifier)
+	webauthnCredential := dbrepo.NewWebauthnCredentialRepository(client)
+	challenge := mfa.NewChallengeUsecase(
+		test.totp,
+		webauthnCredential,
+		dbrepo.NewRecoveryCodeRepository(client),
+		autheventrepo.NewAuthEventEmitter(mock_autheventrepo.NewMockMessageWriter(ctrl)),
+		notifier,
+		passkeyauth.NewAssertionVerifier(webauthnCredential, coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "webauthn:"), &webauthn.RelyingParty{}),
+		coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "mfa_challenge:"),
+		ratelimitrepo.NewLimiter(store, "mfa:", ratelimitrepo.Policy{Window: time.Hour, FreeAttempts: 3, MaxAttempts: 3, Lockout: time.Hour}),
+	)
+	test.usecase = emailauth.NewMagicLinkUsecase(
+		test.authAccount,
+		tokenrepo.NewTokenRepository(test.tokenClient),
+		issuer,
+		mail,
+		test.linkCodes,
+		coderepo.NewCooldown(store, "magic_link:cooldown:", "magic-link-hash-key", time.Minute),
+		challenge,
+		"https://accounts.example.com/login/link",
+	)
+	return test
+}
+
+func (m *magicLinkTest) createAccount(t *testing.T, email string, verified bool) uuid.UUID {
+	t.Helper()
+	auth, err := m.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      email,
+		Password:   "password",
+		IsVerified: verified,
+	})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return auth.UserID
+}
+
+// expectLinkToken makes the token service accept token as a login link
+// carrying the code.
+func (m *magicLinkTest) expectLinkToken(token string, userID uuid.UUID, email string, code string) {
+	m.tokenClient.EXPECT().
+		VerifyEmailVerificationToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *tokenv1.VerifyEmailVerificationTokenRequest, _ ...any) (*tokenv1.VerifyEmailVerificationTokenResponse, error) {
+			valid := req.Token == token && req.Purpose == string(tokenmodels.EmailTokenPurposeMagicLink)
+			id := userID.String()
+			return &tokenv1.VerifyEmailVerificationTokenResponse{Valid: valid, UserId: &id, Email: &email, Code: &code}, nil
+		})
+}
+
+func (m *magicLinkTest) expectTokenPair() {
+	m.tokenClient.EXPECT().
+		GenerateAccessToken(gomock.Any(), gomock.Any()).
+		Return(&tokenv1.GenerateAccessTokenResponse{Token: "access-token"}, nil)
+	m.tokenClient.EXPECT().
+		GenerateRefreshToken(gomock.Any(), gomock.Any()).
+		Return(&tokenv1.GenerateRefreshTokenResponse{Token: "refresh-token", FamilyId: "family", ExpiresAt: time.Now().Add(time.Hour).Unix()}, nil)
+}
+
+func TestMagicLinkUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sends Login Link", func(t *testing.T) {
+		test := newMagicLinkTest(t)
+		userID := test.createAccount(t, "user@example.com", true)
+
+		test.tokenClient.EXPECT().
+			GenerateEmailVerificationToken(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *tokenv1.GenerateEmailVerificationTokenRequest, _ ...any) (*tokenv1.GenerateEmailVerificationTokenResponse, error) {
+				if req.UserId != userID.String() || req.Purpose != string(tokenmodels.EmailTokenPurposeMagicLink) {
+					t.Errorf("expected a login link token for the user, got %+v", req)
+				}
+				return &tokenv1.GenerateEmailVerificationTokenResponse{Token: "link-token"}, nil
+			})
+		test.writer.EXPECT().
+			WriteMessages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+				if len(msgs) != 1 || string(msgs[0].Key) != "user@example.com" {
+					t.Errorf("expected one mail to the user, got %+v", msgs)
+				}
+				return nil
+			})
+
+		if err := test.usecase.RequestMagicLink(ctx, "user@example.com"); err != nil {
+			t.Fatalf("failed to request login link: %v", err)
+		}
+	})
+
+	t.Run("Ignores Unknown Email As Slowly As Known", func(t *testing.T) {
+		test := newMagicLinkTest(t)
+
+		start := time.Now()
+		if err := test.usecase.RequestMagicLink(ctx, "nobody@example.com"); err != nil {
+			t.Fatalf("expected unknown emails to be ignored, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < testMagicLinkRequestDuration {
+			t.Fatalf("expected the request to take at least %s, took %s", testMagicLinkRequestDuration, elapsed)
+		}
+	})
+
+	t.Run("Limits Requests Per Email", func(t *testing.T) {
+		test := newMagicLinkTest(t)
+
+		if err := test.usecase.RequestMagicLink(ctx, "nobody@example.com"); err != nil {
+			t.Fatalf("failed to request login link: %v", err)
+		}
+		err := test.usecase.RequestMagicLink(ctx, "Nobody@Example.com")
+		if !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected the second request to be limited, got %v", err)
+		}
+
+		test.server.FastForward(time.Minute)
+		if err := test.usecase.RequestMagicLink(ctx, "nobody@example.com"); err != nil {
+			t.Fatalf("expected a request after the cooldown to pass, got %v", err)
+		}
+	})
+
+	t.Run("Logs In Once And Verifies Email", func(t *testing.T) {
+		test := newMagicLinkTest(t)
+		userID := test.createAccount(t, "user@example.com", false)
+		code, err := test.linkCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue link code: %v", err)
+		}
+		test.expectLinkToken("link-token", userID, "user@example.com", code)
+		test.expectLinkToken("link-token", userID, "user@example.com", code)
+		test.expectTokenPair()
+
+		accessToken, refreshToken, mfaToken, err := test.usecase.LoginWithMagicLink(ctx, "link-token")
+		if err != nil || accessToken != "access-token" || refreshToken != "refresh-token" || mfaToken != "" {
+			t.Fatalf("expected a token pair, got %q, %q, %q, %v", accessToken, refreshToken, mfaToken, err)
+		}
+		auth, err := test.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+		if err != nil || !auth.IsVerified {
+			t.Fatalf("expected the email to be verified, got %+v, %v", auth, err)
+		}
+
+		if _, _, _, err := test.usecase.LoginWithMagicLink(ctx, "link-token"); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the link to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Asks For Second Factor", func(t *testing.T) {
+		test := newMagicLinkTest(t)
+		userID := test.createAccount(t, "user@example.com", true)
+		secret, err := util.GenerateTotpSecret()
+		if err != nil {
+			t.Fatalf("failed to generate TOTP secret: %v", err)
+		}
+		if _, err := test.totp.SetPendingTotpSecret(ctx, userID, secret); err != nil {
+			t.Fatalf("failed to enroll TOTP: %v", err)
+		}
+		if err := test.client.TotpCredential.Update().Where(totpcredential.UserID(userID)).SetIsConfirmed(true).Exec(ctx); err != nil {
+			t.Fatalf("failed to confirm TOTP: %v", err)
+		}
+		code, err := test.linkCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue link code: %v", err)
+		}
+		test.expectLinkToken("link-token", userID, "user@example.com", code)
+
+		accessToken, _, mfaToken, err := test.usecase.LoginWithMagicLink(ctx, "link-token")
+		if err != nil || accessToken != "" || mfaToken == "" {
+			t.Fatalf("expected an MFA challenge instead of tokens, got %q, %q, %v", accessToken, mfaToken, err)
+		}
+	})
+
+	t.Run("Rejects Token Of Another Flow", func(t *testing.T) {
+		test := newMagicLinkTest(t)
+		userID := test.createAccount(t, "user@example.com", true)
+		code, err := test.linkCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue link code: %v", err)
+		}
+		test.expectLinkToken("reset-token", userID, "user@example.com", code)
+
+		if _, _, _, err := test.usecase.LoginWithMagicLink(ctx, "link-token"); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the token to be rejected, got %v", err)
+		}
+	})
+}
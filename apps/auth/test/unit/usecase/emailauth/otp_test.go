@@ -0,0 +1,224 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/emailauth"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+	mock_autheventrepo "mandacode.com/accounts/auth/test/mock/repository/authevent"
+)
+
+// testOTPRequestDuration is the least time RequestLoginOTP takes.
+const testOTPRequestDuration = 500 * time.Millisecond
+
+type loginOTPTest struct {
+	usecase     *emailauth.OTPUsecase
+	authAccount *dbrepo.AuthAccountRepository
+	otpCodes    *coderepo.OTPManager
+	activity    *dbrepo.AuthActivityRepository
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	writer      *mock_mailer.MockMessageWriter
+}
+
+func newLoginOTPTest(t *testing.T) *loginOTPTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	cipher, err := util.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	hasher := util.NewPasswordHasher(util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  8192,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	}))
+
+	test := &loginOTPTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, hasher, zap.NewNop()),
+		otpCodes:    coderepo.NewOTPManager(store, "login_otp:", 10*time.Minute, "login-otp-hash-key", 5, time.Minute),
+		activity:    dbrepo.NewAuthActivityRepository(client),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+	}
+	mail := mailer.NewMailer(test.writer, "https://accounts.example.com/sessions")
+	notifier := notice.NewSecurityNotifier(test.authAccount, test.activity, mail, zap.NewNop())
+	issuer := session.NewIssuer(tokenrepo.NewTokenRepository(test.tokenClient), dbrepo.NewSessionRepository(client), test.activity, notifier)
+	webauthnCredential := dbrepo.NewWebauthnCredentialRepository(client)
+	challenge := mfa.NewChallengeUsecase(
+		dbrepo.NewTotpCredentialRepository(client, cipher),
+		webauthnCredential,
+		dbrepo.NewRecoveryCodeRepository(client),
+		autheventrepo.NewAuthEventEmitter(mock_autheventrepo.NewMockMessageWriter(ctrl)),
+		notifier,
+		passkeyauth.NewAssertionVerifier(webauthnCredential, coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "webauthn:"), &webauthn.RelyingParty{}),
+		coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "mfa_challenge:"),
+		ratelimitrepo.NewLimiter(store, "mfa:", ratelimitrepo.Policy{Window: time.Hour, FreeAttempts: 3, MaxAttempts: 3, Lockout: time.Hour}),
+	)
+	test.usecase = emailauth.NewOTPUsecase(
+		test.authAccount,
+		issuer,
+		mail,
+		test.otpCodes,
+		challenge,
+		ratelimitrepo.NewLimiter(store, "login:", ratelimitrepo.Policy{Window: time.Hour, FreeAttempts: 3, MaxAttempts: 3, Lockout: time.Hour}),
+	)
+	return test
+}
+
+func (o *loginOTPTest) createAccount(t *testing.T, email string, verified bool) uuid.UUID {
+	t.Helper()
+	auth, err := o.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      email,
+		Password:   "password",
+		IsVerified: verified,
+	})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return auth.UserID
+}
+
+func (o *loginOTPTest) issueOTP(t *testing.T, userID uuid.UUID) string {
+	t.Helper()
+	code, err := o.otpCodes.IssueOTP(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to issue code: %v", err)
+	}
+	return code
+}
+
+// wrongOTP returns a well-formed code other than code.
+func wrongOTP(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
+
+func TestOTPUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sends Login Code Once Per Cooldown", func(t *testing.T) {
+		test := newLoginOTPTest(t)
+		test.createAccount(t, "user@example.com", true)
+		test.writer.EXPECT().
+			WriteMessages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+				if len(msgs) != 1 || string(msgs[0].Key) != "user@example.com" {
+					t.Errorf("expected one mail to the user, got %+v", msgs)
+				}
+				return nil
+			})
+
+		if err := test.usecase.RequestLoginOTP(ctx, "user@example.com", "203.0.113.1"); err != nil {
+			t.Fatalf("failed to request login code: %v", err)
+		}
+		err := test.usecase.RequestLoginOTP(ctx, "User@Example.com", "203.0.113.1")
+		if !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected the second request to be limited, got %v", err)
+		}
+	})
+
+	t.Run("Ignores Unknown Email As Slowly As Known", func(t *testing.T) {
+		test := newLoginOTPTest(t)
+
+		start := time.Now()
+		if err := test.usecase.RequestLoginOTP(ctx, "nobody@example.com", "203.0.113.1"); err != nil {
+			t.Fatalf("expected unknown emails to be ignored, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < testOTPRequestDuration {
+			t.Fatalf("expected the request to take at least %s, took %s", testOTPRequestDuration, elapsed)
+		}
+	})
+
+	t.Run("Logs In Once And Verifies Email", func(t *testing.T) {
+		test := newLoginOTPTest(t)
+		userID := test.createAccount(t, "user@example.com", false)
+		code := test.issueOTP(t, userID)
+		test.tokenClient.EXPECT().
+			GenerateAccessToken(gomock.Any(), gomock.Any()).
+			Return(&tokenv1.GenerateAccessTokenResponse{Token: "access-token"}, nil)
+		test.tokenClient.EXPECT().
+			GenerateRefreshToken(gomock.Any(), gomock.Any()).
+			Return(&tokenv1.GenerateRefreshTokenResponse{Token: "refresh-token", FamilyId: "family", ExpiresAt: time.Now().Add(time.Hour).Unix()}, nil)
+
+		accessToken, refreshToken, mfaToken, err := test.usecase.LoginWithOTP(ctx, "user@example.com", code, "203.0.113.1")
+		if err != nil || accessToken != "access-token" || refreshToken != "refresh-token" || mfaToken != "" {
+			t.Fatalf("expected a token pair, got %q, %q, %q, %v", accessToken, refreshToken, mfaToken, err)
+		}
+		auth, err := test.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+		if err != nil || !auth.IsVerified {
+			t.Fatalf("expected the email to be verified, got %+v, %v", auth, err)
+		}
+
+		if _, _, _, err := test.usecase.LoginWithOTP(ctx, "user@example.com", code, "203.0.113.1"); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the code to be consumed, got %v", err)
+		}
+		activities, err := test.activity.ListAuthActivities(ctx, activitymodels.Filter{UserID: userID})
+		if err != nil || len(activities) != 2 {
+			t.Fatalf("expected both attempts to be recorded, got %+v, %v", activities, err)
+		}
+		outcomes := map[activitymodels.Outcome]bool{}
+		for _, activity := range activities {
+			if activity.Method != activitymodels.MethodEmailOTP {
+				t.Fatalf("expected an email OTP attempt, got %+v", activity)
+			}
+			outcomes[activity.Outcome] = true
+		}
+		if !outcomes[activitymodels.OutcomeSuccess] || !outcomes[activitymodels.OutcomeFailure] {
+			t.Fatalf("expected a success and a failure, got %+v", activities)
+		}
+	})
+
+	t.Run("Limits Wrong Codes Per Client IP", func(t *testing.T) {
+		test := newLoginOTPTest(t)
+		userID := test.createAccount(t, "user@example.com", true)
+		code := test.issueOTP(t, userID)
+
+		for _, email := range []string{"user@example.com", "nobody@example.com", "other@example.com"} {
+			_, _, _, err := test.usecase.LoginWithOTP(ctx, email, wrongOTP(code), "203.0.113.1")
+			if !errors.Is(err, errcode.ErrUnauthorized) {
+				t.Fatalf("expected the wrong code to be rejected, got %v", err)
+			}
+		}
+		_, _, _, err := test.usecase.LoginWithOTP(ctx, "user@example.com", code, "203.0.113.1")
+		if !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected the client IP to be locked out, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+	"mandacode.com/accounts/auth/internal/usecase/localauth"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+)
+
+type emailChangeTest struct {
+	usecase     *localauth.EmailChangeUsecase
+	authAccount *dbrepo.AuthAccountRepository
+	changeCodes *coderepo.CodeManager
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	writer      *mock_mailer.MockMessageWriter
+}
+
+func newEmailChangeTest(t *testing.T) *emailChangeTest {
+	t.Helper()
+	return newEmailChangeTestWithPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeOpen})
+}
+
+// newEmailChangeTestWithPolicy creates an email change usecase following the
+// signup policy of rules.
+func newEmailChangeTestWithPolicy(t *testing.T, rules signuppolicy.Rules) *emailChangeTest {
+	t.Helper()
+	policy, err := signuppolicy.NewPolicy(rules)
+	if err != nil {
+		t.Fatalf("failed to create signup policy: %v", err)
+	}
+	ctrl := gomock.NewController(t)
+	_, store := newTestStore(t)
+	client := newTestClient(t)
+
+	test := &emailChangeTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, newTestPasswordHasher(), zap.NewNop()),
+		changeCodes: coderepo.NewCodeManager(util.NewRandomGenerator(16), time.Hour, store, "change_code:"),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+	}
+	mail := mailer.NewMailer(test.writer, "https://accounts.example.com/sessions")
+	test.usecase = localauth.NewEmailChangeUsecase(
+		test.authAccount,
+		tokenrepo.NewTokenRepository(test.tokenClient),
+		mail,
+		newTestNotifier(client, test.authAccount, mail),
+		test.changeCodes,
+		policy,
+		"https://accounts.example.com/change-email",
+	)
+	return test
+}
+
+// expectChangeToken makes the token service accept token as an email change
+// link to email carrying the code.
+func (e *emailChangeTest) expectChangeToken(token string, userID uuid.UUID, email string, code string) {
+	e.tokenClient.EXPECT().
+		VerifyEmailVerificationToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *tokenv1.VerifyEmailVerificationTokenRequest, _ ...any) (*tokenv1.VerifyEmailVerificationTokenResponse, error) {
+			valid := req.Token == token && req.Purpose == string(tokenmodels.EmailTokenPurposeEmailChange)
+			id := userID.String()
+			return &tokenv1.VerifyEmailVerificationTokenResponse{Valid: valid, UserId: &id, Email: &email, Code: &code}, nil
+		})
+}
+
+func TestEmailChangeUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Mails New And Current Address", func(t *testing.T) {
+		test := newEmailChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+
+		test.tokenClient.EXPECT().
+			GenerateEmailVerificationToken(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *tokenv1.GenerateEmailVerificationTokenRequest, _ ...any) (*tokenv1.GenerateEmailVerificationTokenResponse, error) {
+				if req.Email != "new@example.com" || req.Purpose != string(tokenmodels.EmailTokenPurposeEmailChange) {
+					t.Errorf("expected an email change token for the new address, got %+v", req)
+				}
+				return &tokenv1.GenerateEmailVerificationTokenResponse{Token: "change-token"}, nil
+			})
+		var recipients []string
+		test.writer.EXPECT().
+			WriteMessages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+				for _, msg := range msgs {
+					recipients = append(recipients, string(msg.Key))
+				}
+				return nil
+			}).
+			Times(2)
+
+		err := test.usecase.RequestEmailChange(ctx, localauthdto.ChangeEmailInput{
+			UserID:   userID,
+			Password: "password",
+			NewEmail: "new@example.com",
+		})
+		if err != nil {
+			t.Fatalf("failed to request email change: %v", err)
+		}
+		if len(recipients) != 2 || recipients[0] != "new@example.com" || recipients[1] != "old@example.com" {
+			t.Fatalf("expected a link to the new address and a notice to the current one, got %v", recipients)
+		}
+		auth, err := test.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+		if err != nil || auth.Email != "old@example.com" {
+			t.Fatalf("expected the email to be kept until confirmed, got %+v, %v", auth, err)
+		}
+	})
+
+	t.Run("Rejects Wrong Password", func(t *testing.T) {
+		test := newEmailChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+
+		err := test.usecase.RequestEmailChange(ctx, localauthdto.ChangeEmailInput{
+			UserID:   userID,
+			Password: "wrong-password",
+			NewEmail: "new@example.com",
+		})
+		if !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the wrong password to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Taken Email", func(t *testing.T) {
+		test := newEmailChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+		createLocalAccount(t, test.authAccount, "new@example.com", "password")
+
+		err := test.usecase.RequestEmailChange(ctx, localauthdto.ChangeEmailInput{
+			UserID:   userID,
+			Password: "password",
+			NewEmail: "new@example.com",
+		})
+		if !errors.Is(err, errcode.ErrConflict) {
+			t.Fatalf("expected the taken email to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Changes Email Once", func(t *testing.T) {
+		test := newEmailChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+		code, err := test.changeCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue change code: %v", err)
+		}
+		test.expectChangeToken("change-token", userID, "new@example.com", code)
+		test.expectChangeToken("change-token", userID, "new@example.com", code)
+		expectMail(t, test.writer, "old@example.com", mailer.EventTypeEmailChanged)
+
+		email, err := test.usecase.ConfirmEmailChange(ctx, "change-token")
+		if err != nil || email != "new@example.com" {
+			t.Fatalf("expected the email to be changed, got %q, %v", email, err)
+		}
+		auth, err := test.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+		if err != nil || auth.Email != "new@example.com" || !auth.IsVerified {
+			t.Fatalf("expected the verified new email to be stored, got %+v, %v", auth, err)
+		}
+
+		if _, err := test.usecase.ConfirmEmailChange(ctx, "change-token"); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the link to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Email Taken Since Request", func(t *testing.T) {
+		test := newEmailChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+		code, err := test.changeCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue change code: %v", err)
+		}
+		test.expectChangeToken("change-token", userID, "new@example.com", code)
+		createLocalAccount(t, test.authAccount, "new@example.com", "password")
+
+		if _, err := test.usecase.ConfirmEmailChange(ctx, "change-token"); !errors.Is(err, errcode.ErrConflict) {
+			t.Fatalf("expected the taken email to be rejected, got %v", err)
+		}
+	})
+	t.Run("Rejects Email Of Denied Domain", func(t *testing.T) {
+		test := newEmailChangeTestWithPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeDomain, DeniedDomains: []string{"mailinator.com"}})
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+
+		err := test.usecase.RequestEmailChange(ctx, localauthdto.ChangeEmailInput{
+			UserID:   userID,
+			Password: "password",
+			NewEmail: "new@mailinator.com",
+		})
+		if !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the denied domain to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Domain Disallowed Since Request", func(t *testing.T) {
+		test := newEmailChangeTestWithPolicy(t, signuppolicy.Rules{Mode: signuppolicy.ModeDomain, AllowedDomains: []string{"example.com"}})
+		userID := createLocalAccount(t, test.authAccount, "old@example.com", "password")
+		code, err := test.changeCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue change code: %v", err)
+		}
+		test.expectChangeToken("change-token", userID, "new@example.net", code)
+
+		if _, err := test.usecase.ConfirmEmailChange(ctx, "change-token"); !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the disallowed domain to be rejected, got %v", err)
+		}
+		auth, err := test.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+		if err != nil || auth.Email != "old@example.com" {
+			t.Fatalf("expected the email to be kept, got %+v, %v", auth, err)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package usecase_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/localauth"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+)
+
+type passwordChangeTest struct {
+	usecase     *localauth.PasswordChangeUsecase
+	authAccount *dbrepo.AuthAccountRepository
+	sessions    *dbrepo.SessionRepository
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	writer      *mock_mailer.MockMessageWriter
+	server      *miniredis.Miniredis
+}
+
+func newPasswordChangeTest(t *testing.T) *passwordChangeTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	server, store := newTestStore(t)
+	client := newTestClient(t)
+
+	test := &passwordChangeTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, newTestPasswordHasher(), zap.NewNop()),
+		sessions:    dbrepo.NewSessionRepository(client),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+		server:      server,
+	}
+	notifier := newTestNotifier(client, test.authAccount, mailer.NewMailer(test.writer, "https://accounts.example.com/sessions"))
+	test.usecase = localauth.NewPasswordChangeUsecase(
+		test.authAccount,
+		session.NewIssuer(tokenrepo.NewTokenRepository(test.tokenClient), test.sessions, dbrepo.NewAuthActivityRepository(client), notifier),
+		revocationrepo.NewRevocationRepository(store, "revoked:", time.Hour),
+		test.sessions,
+		newTestPasswordPolicy(),
+		notifier,
+	)
+	return test
+}
+
+func TestPasswordChangeUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Changes Password", func(t *testing.T) {
+		test := newPasswordChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+		expectMail(t, test.writer, "user@example.com", mailer.EventTypePasswordChanged)
+
+		accessToken, refreshToken, err := test.usecase.ChangePassword(ctx, localauthdto.ChangePasswordInput{
+			UserID:          userID,
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+		})
+		if err != nil {
+			t.Fatalf("failed to change password: %v", err)
+		}
+		if accessToken != "" || refreshToken != "" {
+			t.Fatal("expected no tokens without signing out other sessions")
+		}
+		valid, _, err := test.authAccount.ComparePassword(ctx, "user@example.com", "new-password")
+		if err != nil || !valid {
+			t.Fatalf("expected the new password to be set, got %v, %v", valid, err)
+		}
+		if test.server.Exists("revoked:user:" + userID.String()) {
+			t.Fatal("expected other sessions to be kept")
+		}
+	})
+
+	t.Run("Rejects Wrong Current Password", func(t *testing.T) {
+		test := newPasswordChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+
+		_, _, err := test.usecase.ChangePassword(ctx, localauthdto.ChangePasswordInput{
+			UserID:          userID,
+			CurrentPassword: "wrong-password",
+			NewPassword:     "new-password",
+		})
+		if !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the wrong password to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Unchanged Password", func(t *testing.T) {
+		test := newPasswordChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+
+		_, _, err := test.usecase.ChangePassword(ctx, localauthdto.ChangePasswordInput{
+			UserID:          userID,
+			CurrentPassword: "old-password",
+			NewPassword:     "old-password",
+		})
+		if !errors.Is(err, errcode.ErrInvalidInput) {
+			t.Fatalf("expected the unchanged password to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Rejects User Without Password", func(t *testing.T) {
+		test := newPasswordChangeTest(t)
+
+		_, _, err := test.usecase.ChangePassword(ctx, localauthdto.ChangePasswordInput{
+			UserID:          uuid.New(),
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+		})
+		if !errors.Is(err, errcode.ErrNotFound) {
+			t.Fatalf("expected a user without local account to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Signs Out Others With Store Clock", func(t *testing.T) {
+		test := newPasswordChangeTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+		expectMail(t, test.writer, "user@example.com", mailer.EventTypePasswordChanged)
+		// The store clock runs ahead of the host, as the token service reads it
+		storeTime := time.Now().Add(time.Hour)
+		test.server.SetTime(storeTime)
+		if _, err := test.sessions.CreateSession(ctx, userID, "old-family", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+
+		test.tokenClient.EXPECT().
+			GenerateAccessToken(gomock.Any(), gomock.Any()).
+			Return(&tokenv1.GenerateAccessTokenResponse{Token: "access-token"}, nil)
+		test.tokenClient.EXPECT().
+			GenerateRefreshToken(gomock.Any(), gomock.Any()).
+			Return(&tokenv1.GenerateRefreshTokenResponse{Token: "refresh-token", FamilyId: "new-family", ExpiresAt: time.Now().Add(time.Hour).Unix()}, nil)
+
+		accessToken, refreshToken, err := test.usecase.ChangePassword(ctx, localauthdto.ChangePasswordInput{
+			UserID:          userID,
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+			SignOutOthers:   true,
+		})
+		if err != nil {
+			t.Fatalf("failed to change password: %v", err)
+		}
+		if accessToken != "access-token" || refreshToken != "refresh-token" {
+			t.Fatalf("expected a new token pair, got %q, %q", accessToken, refreshToken)
+		}
+		revokedAt, err := test.server.Get("revoked:user:" + userID.String())
+		if err != nil {
+			t.Fatalf("expected the refresh tokens of the user to be revoked: %v", err)
+		}
+		if revokedAt != strconv.FormatInt(storeTime.UnixMilli(), 10) {
+			t.Fatalf("expected the revocation to be stamped with the store clock, got %s", revokedAt)
+		}
+		sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID)
+		if err != nil || len(sessions) != 1 || sessions[0].FamilyID != "new-family" {
+			t.Fatalf("expected only the session of the new token pair, got %v, %v", sessions, err)
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	tokenmodels "mandacode.com/accounts/auth/internal/models/token"
+	"mandacode.com/accounts/auth/internal/passwordpolicy"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/localauth"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+)
+
+// testResetRequestDuration is the least time RequestPasswordReset takes.
+const testResetRequestDuration = 500 * time.Millisecond
+
+func newTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func newTestAuthAccountRepository(t *testing.T) *dbrepo.AuthAccountRepository {
+	t.Helper()
+	return dbrepo.NewAuthAccountRepository(newTestClient(t), newTestPasswordHasher(), zap.NewNop())
+}
+
+func newTestNotifier(client *ent.Client, authAccount *dbrepo.AuthAccountRepository, mail *mailer.Mailer) *notice.SecurityNotifier {
+	return notice.NewSecurityNotifier(authAccount, dbrepo.NewAuthActivityRepository(client), mail, zap.NewNop())
+}
+
+func newTestPasswordHasher() *util.PasswordHasher {
+	return util.NewPasswordHasher(util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  8192,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	}))
+}
+
+func newTestStore(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	return server, store
+}
+
+func newTestPasswordPolicy() *passwordpolicy.Policy {
+	return passwordpolicy.NewPolicy(passwordpolicy.Rules{MinLength: 8}, nil)
+}
+
+// expectMail makes writer accept one mail of eventType to email.
+func expectMail(t *testing.T, writer *mock_mailer.MockMessageWriter, email string, eventType string) {
+	t.Helper()
+	writer.EXPECT().
+		WriteMessages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+			if len(msgs) != 1 || string(msgs[0].Key) != email || string(msgs[0].Headers[0].Value) != eventType {
+				t.Errorf("expected a %s mail to %s, got %+v", eventType, email, msgs)
+			}
+			return nil
+		})
+}
+
+func createLocalAccount(t *testing.T, authAccount *dbrepo.AuthAccountRepository, email string, password string) uuid.UUID {
+	t.Helper()
+	auth, err := authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      email,
+		Password:   password,
+		IsVerified: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return auth.UserID
+}
+
+type passwordResetTest struct {
+	usecase     *localauth.PasswordResetUsecase
+	authAccount *dbrepo.AuthAccountRepository
+	sessions    *dbrepo.SessionRepository
+	resetCodes  *coderepo.CodeManager
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	writer      *mock_mailer.MockMessageWriter
+	server      *miniredis.Miniredis
+}
+
+func newPasswordResetTest(t *testing.T) *passwordResetTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	server, store := newTestStore(t)
+	client := newTestClient(t)
+
+	test := &passwordResetTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, newTestPasswordHasher(), zap.NewNop()),
+		sessions:    dbrepo.NewSessionRepository(client),
+		resetCodes:  coderepo.NewCodeManager(util.NewRandomGenerator(16), 15*time.Minute, store, "reset_code:"),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+		server:      server,
+	}
+	mail := mailer.NewMailer(test.writer, "https://accounts.example.com/sessions")
+	test.usecase = localauth.NewPasswordResetUsecase(
+		test.authAccount,
+		tokenrepo.NewTokenRepository(test.tokenClient),
+		revocationrepo.NewRevocationRepository(store, "revoked:", time.Hour),
+		test.sessions,
+		mail,
+		newTestNotifier(client, test.authAccount, mail),
+		test.resetCodes,
+		coderepo.NewCooldown(store, "reset_code:cooldown:", "reset-hash-key", time.Minute),
+		newTestPasswordPolicy(),
+		"https://accounts.example.com/reset-password",
+	)
+	return test
+}
+
+// expectResetToken makes the token service accept token as a reset link
+// carrying the code.
+func (p *passwordResetTest) expectResetToken(token string, userID uuid.UUID, email string, code string) {
+	p.tokenClient.EXPECT().
+		VerifyEmailVerificationToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *tokenv1.VerifyEmailVerificationTokenRequest, _ ...any) (*tokenv1.VerifyEmailVerificationTokenResponse, error) {
+			valid := req.Token == token && req.Purpose == string(tokenmodels.EmailTokenPurposePasswordReset)
+			id := userID.String()
+			return &tokenv1.VerifyEmailVerificationTokenResponse{Valid: valid, UserId: &id, Email: &email, Code: &code}, nil
+		})
+}
+
+func TestPasswordResetUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sends Reset Link", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+
+		test.tokenClient.EXPECT().
+			GenerateEmailVerificationToken(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *tokenv1.GenerateEmailVerificationTokenRequest, _ ...any) (*tokenv1.GenerateEmailVerificationTokenResponse, error) {
+				if req.UserId != userID.String() || req.Purpose != string(tokenmodels.EmailTokenPurposePasswordReset) {
+					t.Errorf("expected a reset token for the user, got %+v", req)
+				}
+				return &tokenv1.GenerateEmailVerificationTokenResponse{Token: "reset-token"}, nil
+			})
+		test.writer.EXPECT().
+			WriteMessages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+				if len(msgs) != 1 || string(msgs[0].Key) != "user@example.com" {
+					t.Errorf("expected one mail to the user, got %+v", msgs)
+				}
+				return nil
+			})
+
+		if err := test.usecase.RequestPasswordReset(ctx, "user@example.com"); err != nil {
+			t.Fatalf("failed to request password reset: %v", err)
+		}
+	})
+
+	t.Run("Ignores Unknown Email As Slowly As Known", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+
+		start := time.Now()
+		if err := test.usecase.RequestPasswordReset(ctx, "nobody@example.com"); err != nil {
+			t.Fatalf("expected unknown emails to be ignored, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < testResetRequestDuration {
+			t.Fatalf("expected the request to take at least %s, took %s", testResetRequestDuration, elapsed)
+		}
+	})
+
+	t.Run("Limits Requests Per Email", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+
+		if err := test.usecase.RequestPasswordReset(ctx, "nobody@example.com"); err != nil {
+			t.Fatalf("failed to request password reset: %v", err)
+		}
+		err := test.usecase.RequestPasswordReset(ctx, "Nobody@Example.com")
+		if !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected the second request to be limited, got %v", err)
+		}
+
+		test.server.FastForward(time.Minute)
+		if err := test.usecase.RequestPasswordReset(ctx, "nobody@example.com"); err != nil {
+			t.Fatalf("expected a request after the cooldown to pass, got %v", err)
+		}
+	})
+
+	t.Run("Resets Password Once", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+		if _, err := test.sessions.CreateSession(ctx, userID, "family", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		code, err := test.resetCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue reset code: %v", err)
+		}
+		test.expectResetToken("reset-token", userID, "user@example.com", code)
+		test.expectResetToken("reset-token", userID, "user@example.com", code)
+		expectMail(t, test.writer, "user@example.com", mailer.EventTypePasswordChanged)
+
+		input := localauthdto.ResetPasswordInput{Token: "reset-token", Password: "new-password"}
+		if err := test.usecase.ResetPassword(ctx, input); err != nil {
+			t.Fatalf("failed to reset password: %v", err)
+		}
+		valid, _, err := test.authAccount.ComparePassword(ctx, "user@example.com", "new-password")
+		if err != nil || !valid {
+			t.Fatalf("expected the new password to be set, got %v, %v", valid, err)
+		}
+		if !test.server.Exists("revoked:user:" + userID.String()) {
+			t.Fatal("expected the refresh tokens of the user to be revoked")
+		}
+		if sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID); err != nil || len(sessions) != 0 {
+			t.Fatalf("expected the sessions of the user to be deleted, got %v, %v", sessions, err)
+		}
+
+		input.Password = "other-password"
+		if err := test.usecase.ResetPassword(ctx, input); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the reset link to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Token Of Another Flow", func(t *testing.T) {
+		test := newPasswordResetTest(t)
+		userID := createLocalAccount(t, test.authAccount, "user@example.com", "old-password")
+		code, err := test.resetCodes.IssueCode(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue reset code: %v", err)
+		}
+		test.expectResetToken("verification-token", userID, "user@example.com", code)
+
+		input := localauthdto.ResetPasswordInput{Token: "reset-token", Password: "new-password"}
+		if err := test.usecase.ResetPassword(ctx, input); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the token to be rejected, got %v", err)
+		}
+	})
+}
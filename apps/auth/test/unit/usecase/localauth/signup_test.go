@@ -0,0 +1,283 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	userv1 "github.com/mandacode-com/accounts-proto/go/user/user/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	userrepo "mandacode.com/accounts/auth/internal/repository/user"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+	"mandacode.com/accounts/auth/internal/usecase/challenge"
+	"mandacode.com/accounts/auth/internal/usecase/localauth"
+	localauthdto "mandacode.com/accounts/auth/internal/usecase/localauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+	mock_userv1 "mandacode.com/accounts/auth/test/mock/proto/user/v1"
+)
+
+// testOTPRequestDuration is the least time ResendVerificationOTP takes.
+const testOTPRequestDuration = 500 * time.Millisecond
+
+type signupTest struct {
+	usecase     *localauth.SignupUsecase
+	authAccount *dbrepo.AuthAccountRepository
+	invites     *dbrepo.SignupInviteRepository
+	otpCodes    *coderepo.OTPManager
+	userClient  *mock_userv1.MockUserServiceClient
+	writer      *mock_mailer.MockMessageWriter
+}
+
+func newSignupTest(t *testing.T) *signupTest {
+	t.Helper()
+	return newSignupTestWithPolicy(t, signuppolicy.ModeOpen)
+}
+
+// newSignupTestWithPolicy creates a signup usecase whose signup policy is mode.
+func newSignupTestWithPolicy(t *testing.T, mode signuppolicy.Mode) *signupTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	_, store := newTestStore(t)
+	client := newTestClient(t)
+	policy, err := signuppolicy.NewPolicy(signuppolicy.Rules{Mode: mode})
+	if err != nil {
+		t.Fatalf("failed to create signup policy: %v", err)
+	}
+
+	test := &signupTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, newTestPasswordHasher(), zap.NewNop()),
+		invites:     dbrepo.NewSignupInviteRepository(client),
+		otpCodes:    coderepo.NewOTPManager(store, "verify_otp:", 10*time.Minute, "verify-otp-hash-key", 5, time.Minute),
+		userClient:  mock_userv1.NewMockUserServiceClient(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+	}
+	test.usecase = localauth.NewSignupUsecase(
+		test.authAccount,
+		userrepo.NewUserServiceRepository(test.userClient),
+		tokenrepo.NewTokenRepository(mock_tokenv1.NewMockTokenServiceClient(ctrl)),
+		dbrepo.NewAuthActivityRepository(client),
+		mailer.NewMailer(test.writer, "https://accounts.example.com/sessions"),
+		coderepo.NewCodeManager(util.NewRandomGenerator(16), 15*time.Minute, store, "email_code:"),
+		test.otpCodes,
+		ratelimitrepo.NewLimiter(store, "otp:", ratelimitrepo.Policy{Window: time.Hour, FreeAttempts: 3, MaxAttempts: 3, Lockout: time.Hour}),
+		newTestPasswordPolicy(),
+		challenge.NewGuard(nil, nil, 0),
+		signup.NewGate(policy, test.invites),
+		"https://accounts.example.com/verify-email",
+	)
+	return test
+}
+
+func (s *signupTest) createAccount(t *testing.T, email string, verified bool) uuid.UUID {
+	t.Helper()
+	auth, err := s.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      email,
+		Password:   "password",
+		IsVerified: verified,
+	})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return auth.UserID
+}
+
+func (s *signupTest) createInvite(t *testing.T, code string) {
+	t.Helper()
+	if _, err := s.invites.CreateSignupInvite(context.Background(), code, "", "admin", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to create invite: %v", err)
+	}
+}
+
+// invite returns the only invite.
+func (s *signupTest) invite(t *testing.T) *dbmodels.SignupInvite {
+	t.Helper()
+	invites, err := s.invites.GetSignupInvites(context.Background())
+	if err != nil || len(invites) != 1 {
+		t.Fatalf("expected one invite, got %v, %v", invites, err)
+	}
+	return invites[0]
+}
+
+// expectInitUser makes the user service create whichever user it is asked to.
+func (s *signupTest) expectInitUser() {
+	s.userClient.EXPECT().
+		InitUser(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *userv1.InitUserRequest, _ ...any) (*userv1.InitUserResponse, error) {
+			return &userv1.InitUserResponse{UserId: req.UserId}, nil
+		})
+}
+
+func (s *signupTest) issueOTP(t *testing.T, userID uuid.UUID) string {
+	t.Helper()
+	code, err := s.otpCodes.IssueOTP(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to issue code: %v", err)
+	}
+	return code
+}
+
+// wrongOTP returns a well-formed code other than code.
+func wrongOTP(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
+
+func TestSignupUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Resends Code Once Per Cooldown", func(t *testing.T) {
+		test := newSignupTest(t)
+		test.createAccount(t, "user@example.com", false)
+		test.writer.EXPECT().
+			WriteMessages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+				if len(msgs) != 1 || string(msgs[0].Key) != "user@example.com" {
+					t.Errorf("expected one mail to the user, got %+v", msgs)
+				}
+				return nil
+			})
+
+		if err := test.usecase.ResendVerificationOTP(ctx, localauthdto.ResendVerificationInput{Email: "user@example.com"}); err != nil {
+			t.Fatalf("failed to resend code: %v", err)
+		}
+		if err := test.usecase.ResendVerificationOTP(ctx, localauthdto.ResendVerificationInput{Email: "User@Example.com"}); !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected the second resend to be limited, got %v", err)
+		}
+	})
+
+	t.Run("Ignores Unknown Email As Slowly As Known", func(t *testing.T) {
+		test := newSignupTest(t)
+
+		start := time.Now()
+		if err := test.usecase.ResendVerificationOTP(ctx, localauthdto.ResendVerificationInput{Email: "nobody@example.com"}); err != nil {
+			t.Fatalf("expected unknown emails to be ignored, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < testOTPRequestDuration {
+			t.Fatalf("expected the request to take at least %s, took %s", testOTPRequestDuration, elapsed)
+		}
+	})
+
+	t.Run("Verifies Email Once", func(t *testing.T) {
+		test := newSignupTest(t)
+		userID := test.createAccount(t, "user@example.com", false)
+		code := test.issueOTP(t, userID)
+
+		if err := test.usecase.VerifyEmailWithOTP(ctx, "user@example.com", code, "203.0.113.1"); err != nil {
+			t.Fatalf("failed to verify email: %v", err)
+		}
+		auth, err := test.authAccount.GetLocalAuthAccountByUserID(ctx, userID)
+		if err != nil || !auth.IsVerified {
+			t.Fatalf("expected the email to be verified, got %+v, %v", auth, err)
+		}
+		if err := test.usecase.VerifyEmailWithOTP(ctx, "user@example.com", code, "203.0.113.1"); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the code to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Fails Alike For Unknown And Verified Emails", func(t *testing.T) {
+		test := newSignupTest(t)
+		userID := test.createAccount(t, "verified@example.com", true)
+		code := test.issueOTP(t, userID)
+
+		for _, email := range []string{"verified@example.com", "nobody@example.com"} {
+			err := test.usecase.VerifyEmailWithOTP(ctx, email, code, "203.0.113.1")
+			if !errors.Is(err, errcode.ErrUnauthorized) {
+				t.Fatalf("expected %s to fail as a wrong code, got %v", email, err)
+			}
+		}
+	})
+
+	t.Run("Limits Wrong Codes Per Email", func(t *testing.T) {
+		test := newSignupTest(t)
+		userID := test.createAccount(t, "user@example.com", false)
+		code := test.issueOTP(t, userID)
+
+		for i := range 3 {
+			// A new client IP each time, so only the email is limited
+			ip := fmt.Sprintf("203.0.113.%d", i+1)
+			if err := test.usecase.VerifyEmailWithOTP(ctx, "user@example.com", wrongOTP(code), ip); !errors.Is(err, errcode.ErrUnauthorized) {
+				t.Fatalf("expected the wrong code to be rejected, got %v", err)
+			}
+		}
+		err := test.usecase.VerifyEmailWithOTP(ctx, "user@example.com", code, "198.51.100.1")
+		if !errors.Is(err, errcode.ErrTooManyRequests) {
+			t.Fatalf("expected the email to be locked out, got %v", err)
+		}
+	})
+	t.Run("Refuses Signup Without Invite", func(t *testing.T) {
+		test := newSignupTestWithPolicy(t, signuppolicy.ModeInvite)
+
+		_, err := test.usecase.Signup(ctx, localauthdto.SignupInput{Email: "user@example.com", Password: "long-password"})
+		if !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the signup to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Refuses Closed Signup", func(t *testing.T) {
+		test := newSignupTestWithPolicy(t, signuppolicy.ModeClosed)
+
+		_, err := test.usecase.Signup(ctx, localauthdto.SignupInput{Email: "user@example.com", Password: "long-password"})
+		if !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the signup to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Redeems Invite For New User", func(t *testing.T) {
+		test := newSignupTestWithPolicy(t, signuppolicy.ModeInvite)
+		test.createInvite(t, "INVITE-CODE")
+		test.expectInitUser()
+		expectMail(t, test.writer, "user@example.com", mailer.EventTypeEmailOTP)
+
+		userID, err := test.usecase.Signup(ctx, localauthdto.SignupInput{
+			Email:              "user@example.com",
+			Password:           "long-password",
+			VerificationMethod: localauthdto.VerificationOTP,
+			InviteCode:         "invite-code",
+		})
+		if err != nil {
+			t.Fatalf("expected the signup to succeed, got %v", err)
+		}
+		if invite := test.invite(t); invite.UsedAt == nil || invite.UsedBy == nil || *invite.UsedBy != userID {
+			t.Fatalf("expected the invite to be used by the new user, got %+v", invite)
+		}
+
+		_, err = test.usecase.Signup(ctx, localauthdto.SignupInput{Email: "other@example.com", Password: "long-password", InviteCode: "invite-code"})
+		if !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the used invite to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Gives Back Invite When Account Creation Fails", func(t *testing.T) {
+		test := newSignupTestWithPolicy(t, signuppolicy.ModeInvite)
+		test.createAccount(t, "user@example.com", true)
+		test.createInvite(t, "invite-code")
+		test.expectInitUser()
+		test.userClient.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(&userv1.DeleteUserResponse{}, nil)
+
+		_, err := test.usecase.Signup(ctx, localauthdto.SignupInput{Email: "user@example.com", Password: "long-password", InviteCode: "invite-code"})
+		if err == nil {
+			t.Fatal("expected the signup of a taken email to fail")
+		}
+		if invite := test.invite(t); invite.UsedAt != nil || invite.UsedBy != nil {
+			t.Fatalf("expected the invite to be usable again, got %+v", invite)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+)
+
+// totpCode returns the current RFC 6238 code for the secret.
+func totpCode(t *testing.T, secret string) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("failed to decode TOTP secret: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(time.Now().Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// wrongTotpCode returns a code that differs from the current one.
+func wrongTotpCode(t *testing.T, secret string) string {
+	t.Helper()
+	if code := totpCode(t, secret); code != "000000" {
+		return "000000"
+	}
+	return "111111"
+}
+
+func TestChallengeUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Skips Users Without Second Factor", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, false)
+
+		mfaToken, err := test.challenge.IssueChallenge(ctx, userID)
+		if err != nil || mfaToken != "" {
+			t.Fatalf("expected no challenge for a pending enrollment, got %q, %v", mfaToken, err)
+		}
+	})
+
+	t.Run("Passes With Totp Code Once", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		secret := test.enrollTotp(t, userID, true)
+		mfaToken, err := test.challenge.IssueChallenge(ctx, userID)
+		if err != nil || mfaToken == "" {
+			t.Fatalf("expected a challenge, got %q, %v", mfaToken, err)
+		}
+
+		input := mfadto.ChallengeInput{MFAToken: mfaToken, Code: totpCode(t, secret)}
+		passedID, err := test.challenge.CompleteChallenge(ctx, input)
+		if err != nil || passedID != userID {
+			t.Fatalf("expected the challenge to pass for the user, got %v, %v", passedID, err)
+		}
+		if _, err := test.challenge.CompleteChallenge(ctx, input); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the challenge to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Spends Recovery Code With Notice", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(2)
+		codes, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+		mfaToken, err := test.challenge.IssueChallenge(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue challenge: %v", err)
+		}
+
+		passedID, err := test.challenge.CompleteChallenge(ctx, mfadto.ChallengeInput{MFAToken: mfaToken, RecoveryCode: codes[0]})
+		if err != nil || passedID != userID {
+			t.Fatalf("expected the challenge to pass for the user, got %v, %v", passedID, err)
+		}
+		if test.consume(t, userID, codes[0]) {
+			t.Fatal("expected the recovery code to be spent")
+		}
+	})
+
+	t.Run("Keeps Recovery Code For Wrong Challenge", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(1)
+		codes, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+
+		_, err = test.challenge.CompleteChallenge(ctx, mfadto.ChallengeInput{MFAToken: "unknown", RecoveryCode: codes[0]})
+		if !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the unknown challenge to be rejected, got %v", err)
+		}
+		if !test.consume(t, userID, codes[0]) {
+			t.Fatal("expected the recovery code to be kept")
+		}
+	})
+
+	t.Run("Revokes Challenge After Lockout", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		secret := test.enrollTotp(t, userID, true)
+		mfaToken, err := test.challenge.IssueChallenge(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue challenge: %v", err)
+		}
+
+		wrong := mfadto.ChallengeInput{MFAToken: mfaToken, Code: wrongTotpCode(t, secret)}
+		for range 3 {
+			if _, err := test.challenge.CompleteChallenge(ctx, wrong); !errors.Is(err, errcode.ErrUnauthorized) {
+				t.Fatalf("expected the wrong code to be rejected, got %v", err)
+			}
+		}
+		_, err = test.challenge.CompleteChallenge(ctx, mfadto.ChallengeInput{MFAToken: mfaToken, Code: totpCode(t, secret)})
+		if !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the challenge to be revoked, got %v", err)
+		}
+	})
+
+	t.Run("Passes With Passkey Once", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		passkey := test.registerPasskey(t, userID)
+		mfaToken, err := test.challenge.IssueChallenge(ctx, userID)
+		if err != nil || mfaToken == "" {
+			t.Fatalf("expected a challenge for a passkey user, got %q, %v", mfaToken, err)
+		}
+
+		options, err := test.challenge.PasskeyOptions(ctx, mfaToken)
+		if err != nil || len(options.AllowCredentials) != 1 {
+			t.Fatalf("expected the passkey of the user to be allowed, got %+v, %v", options, err)
+		}
+		input := mfadto.ChallengeInput{MFAToken: mfaToken, Passkey: passkey.assert(t, options)}
+		passedID, err := test.challenge.CompleteChallenge(ctx, input)
+		if err != nil || passedID != userID {
+			t.Fatalf("expected the challenge to pass for the user, got %v, %v", passedID, err)
+		}
+		if _, err := test.challenge.CompleteChallenge(ctx, input); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the challenge to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Passkey Of Another User", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.registerPasskey(t, userID)
+		other := test.registerPasskey(t, uuid.New())
+		mfaToken, err := test.challenge.IssueChallenge(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to issue challenge: %v", err)
+		}
+		options, err := test.challenge.PasskeyOptions(ctx, mfaToken)
+		if err != nil {
+			t.Fatalf("failed to start passkey assertion: %v", err)
+		}
+
+		_, err = test.challenge.CompleteChallenge(ctx, mfadto.ChallengeInput{MFAToken: mfaToken, Passkey: other.assert(t, options)})
+		if !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the passkey of another user to be rejected, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+)
+
+func TestFactorUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Lists Confirmed Factors", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, false)
+
+		factors, err := test.factor.ListFactors(ctx, userID)
+		if err != nil || len(factors) != 0 {
+			t.Fatalf("expected a pending enrollment not to be listed, got %+v, %v", factors, err)
+		}
+
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(1)
+		codes, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+		test.consume(t, userID, codes[0])
+
+		factors, err = test.factor.ListFactors(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to list factors: %v", err)
+		}
+		if len(factors) != 2 || factors[0].Type != mfadto.FactorTypeTotp || factors[1].Type != mfadto.FactorTypeRecoveryCodes {
+			t.Fatalf("expected the authenticator and the recovery codes, got %+v", factors)
+		}
+		if factors[1].Remaining != 9 || factors[1].LastUsedAt == nil {
+			t.Fatalf("expected 9 unused codes and the last use, got %+v", factors[1])
+		}
+	})
+
+	t.Run("Removes Pending Totp Silently", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, false)
+
+		if err := test.factor.RemoveTotp(ctx, userID); err != nil {
+			t.Fatalf("failed to remove TOTP: %v", err)
+		}
+		if _, err := test.totp.GetTotpCredentialByUserID(ctx, userID); !errors.Is(err, errcode.ErrNotFound) {
+			t.Fatalf("expected the enrollment to be removed, got %v", err)
+		}
+	})
+
+	t.Run("Removes Totp With Notice", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(2)
+
+		if err := test.factor.RenameTotp(ctx, userID, "Phone"); err != nil {
+			t.Fatalf("failed to rename TOTP: %v", err)
+		}
+		credential, err := test.totp.GetTotpCredentialByUserID(ctx, userID)
+		if err != nil || credential.Name != "Phone" {
+			t.Fatalf("expected the authenticator to be renamed, got %+v, %v", credential, err)
+		}
+		if err := test.factor.RemoveTotp(ctx, userID); err != nil {
+			t.Fatalf("failed to remove TOTP: %v", err)
+		}
+	})
+
+	t.Run("Removes Recovery Codes", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+
+		if err := test.factor.RemoveRecoveryCodes(ctx, userID); !errors.Is(err, errcode.ErrNotFound) {
+			t.Fatalf("expected missing recovery codes to be reported, got %v", err)
+		}
+
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(2)
+		codes, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+		if err := test.factor.RemoveRecoveryCodes(ctx, userID); err != nil {
+			t.Fatalf("failed to remove recovery codes: %v", err)
+		}
+		if test.consume(t, userID, codes[0]) {
+			t.Fatal("expected removed codes to stop working")
+		}
+	})
+}
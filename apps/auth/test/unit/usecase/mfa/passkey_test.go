@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	passkeydto "mandacode.com/accounts/auth/internal/usecase/passkeyauth/dto"
+)
+
+const (
+	testRPID   = "accounts.example.com"
+	testOrigin = "https://accounts.example.com"
+)
+
+func newTestRelyingParty() *webauthn.RelyingParty {
+	return &webauthn.RelyingParty{
+		ID:                      testRPID,
+		Name:                    "Example",
+		Origins:                 []string{testOrigin},
+		RequireUserVerification: true,
+		Timeout:                 5 * time.Minute,
+	}
+}
+
+// softPasskey is a minimal software authenticator holding one ES256 credential.
+type softPasskey struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+}
+
+// coseKey encodes the public key as a COSE EC2 key.
+func (p *softPasskey) coseKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	p.key.X.FillBytes(x)
+	p.key.Y.FillBytes(y)
+	key := []byte{
+		0xa5,       // map(5)
+		0x01, 0x02, // kty: EC2
+		0x03, 0x26, // alg: ES256
+		0x20, 0x01, // crv: P-256
+		0x21, 0x58, 0x20, // x: bytes(32)
+	}
+	key = append(key, x...)
+	key = append(key, 0x22, 0x58, 0x20) // y: bytes(32)
+	return append(key, y...)
+}
+
+// registerPasskey gives the user a passkey.
+func (m *mfaTest) registerPasskey(t *testing.T, userID uuid.UUID) *softPasskey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	passkey := &softPasskey{key: key, credentialID: []byte(uuid.NewString())}
+	if _, err := m.webauthn.CreateWebauthnCredential(context.Background(), &dbmodels.CreateWebauthnCredentialInput{
+		UserID:       userID,
+		CredentialID: passkey.credentialID,
+		PublicKey:    passkey.coseKey(),
+		Name:         "Laptop",
+	}); err != nil {
+		t.Fatalf("failed to create passkey: %v", err)
+	}
+	return passkey
+}
+
+// assert answers the challenge of options with the passkey.
+func (p *softPasskey) assert(t *testing.T, options *webauthn.RequestOptions) *passkeydto.AssertionInput {
+	t.Helper()
+	clientData, err := json.Marshal(map[string]string{
+		"type":      "webauthn.get",
+		"challenge": options.Challenge,
+		"origin":    testOrigin,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode client data: %v", err)
+	}
+
+	p.signCount++
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	authData := append(rpIDHash[:], 0x01|0x04) // user present, user verified
+	authData = binary.BigEndian.AppendUint32(authData, p.signCount)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, p.key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign assertion: %v", err)
+	}
+
+	return &passkeydto.AssertionInput{
+		CredentialID:      webauthn.EncodeBase64URL(p.credentialID),
+		ClientDataJSON:    webauthn.EncodeBase64URL(clientData),
+		AuthenticatorData: webauthn.EncodeBase64URL(authData),
+		Signature:         webauthn.EncodeBase64URL(signature),
+	}
+}
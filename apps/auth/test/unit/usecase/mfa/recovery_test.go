@@ -0,0 +1,241 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_autheventrepo "mandacode.com/accounts/auth/test/mock/repository/authevent"
+)
+
+var testArgon2Params = util.Argon2Params{
+	Memory:  8192,
+	Time:    1,
+	Threads: 1,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+type mfaTest struct {
+	client       *ent.Client
+	authAccount  *dbrepo.AuthAccountRepository
+	totp         *dbrepo.TotpCredentialRepository
+	recoveryCode *dbrepo.RecoveryCodeRepository
+	webauthn     *dbrepo.WebauthnCredentialRepository
+	mailWriter   *mock_mailer.MockMessageWriter
+	eventWriter  *mock_autheventrepo.MockMessageWriter
+	recovery     *mfa.RecoveryCodeUsecase
+	factor       *mfa.FactorUsecase
+	challenge    *mfa.ChallengeUsecase
+	server       *miniredis.Miniredis
+}
+
+func newMFATest(t *testing.T) *mfaTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	cipher, err := util.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+
+	test := &mfaTest{
+		client:       client,
+		authAccount:  dbrepo.NewAuthAccountRepository(client, util.NewPasswordHasher(util.NewArgon2idAlgorithm(testArgon2Params)), zap.NewNop()),
+		totp:         dbrepo.NewTotpCredentialRepository(client, cipher),
+		recoveryCode: dbrepo.NewRecoveryCodeRepository(client),
+		webauthn:     dbrepo.NewWebauthnCredentialRepository(client),
+		mailWriter:   mock_mailer.NewMockMessageWriter(ctrl),
+		eventWriter:  mock_autheventrepo.NewMockMessageWriter(ctrl),
+		server:       server,
+	}
+	authEvent := autheventrepo.NewAuthEventEmitter(test.eventWriter)
+	mail := mailer.NewMailer(test.mailWriter, "https://accounts.example.com/sessions")
+	notifier := notice.NewSecurityNotifier(test.authAccount, dbrepo.NewAuthActivityRepository(client), mail, zap.NewNop())
+	test.recovery = mfa.NewRecoveryCodeUsecase(test.totp, test.webauthn, test.recoveryCode, authEvent, notifier, util.NewRandomGenerator(8), zap.NewNop())
+	test.factor = mfa.NewFactorUsecase(test.totp, test.webauthn, test.recoveryCode, authEvent, notifier)
+	test.challenge = mfa.NewChallengeUsecase(
+		test.totp,
+		test.webauthn,
+		test.recoveryCode,
+		authEvent,
+		notifier,
+		passkeyauth.NewAssertionVerifier(
+			test.webauthn,
+			coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "webauthn:"),
+			newTestRelyingParty(),
+		),
+		coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "mfa_challenge:"),
+		ratelimitrepo.NewLimiter(store, "mfa:", ratelimitrepo.Policy{Window: time.Hour, FreeAttempts: 3, MaxAttempts: 3, Lockout: time.Hour}),
+	)
+	return test
+}
+
+func (m *mfaTest) createUser(t *testing.T) uuid.UUID {
+	t.Helper()
+	auth, err := m.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      "user@example.com",
+		Password:   "password",
+		IsVerified: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return auth.UserID
+}
+
+// enrollTotp gives the user a TOTP authenticator, confirmed if confirmed is
+// set, and returns its secret.
+func (m *mfaTest) enrollTotp(t *testing.T, userID uuid.UUID, confirmed bool) string {
+	t.Helper()
+	ctx := context.Background()
+	secret, err := util.GenerateTotpSecret()
+	if err != nil {
+		t.Fatalf("failed to generate TOTP secret: %v", err)
+	}
+	if _, err := m.totp.SetPendingTotpSecret(ctx, userID, secret); err != nil {
+		t.Fatalf("failed to enroll TOTP: %v", err)
+	}
+	if confirmed {
+		if err := m.client.TotpCredential.Update().
+			Where(totpcredential.UserID(userID)).
+			SetIsConfirmed(true).
+			Exec(ctx); err != nil {
+			t.Fatalf("failed to confirm TOTP: %v", err)
+		}
+	}
+	return secret
+}
+
+// expectNotice expects an auth event and a security notice mail for each of
+// times changes.
+func (m *mfaTest) expectNotice(times int) {
+	m.eventWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil).Times(times)
+	m.mailWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil).Times(times)
+}
+
+func (m *mfaTest) consume(t *testing.T, userID uuid.UUID, code string) bool {
+	t.Helper()
+	valid, err := m.recoveryCode.ConsumeRecoveryCode(context.Background(), userID, code, func() error { return nil })
+	if err != nil {
+		t.Fatalf("failed to consume recovery code: %v", err)
+	}
+	return valid
+}
+
+func TestRecoveryCodeUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Requires Second Factor", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, false)
+
+		if _, err := test.recovery.GenerateRecoveryCodes(ctx, userID); !errors.Is(err, errcode.ErrInvalidInput) {
+			t.Fatalf("expected codes to require a confirmed second factor, got %v", err)
+		}
+	})
+
+	t.Run("Replaces Earlier Codes", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(2)
+
+		first, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+		if len(first) != 10 {
+			t.Fatalf("expected 10 recovery codes, got %d", len(first))
+		}
+		seen := map[string]bool{}
+		for _, code := range first {
+			if seen[code] || !strings.Contains(code, "-") {
+				t.Fatalf("expected distinct grouped codes, got %v", first)
+			}
+			seen[code] = true
+		}
+
+		second, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+		if test.consume(t, userID, first[0]) {
+			t.Fatal("expected earlier codes to stop working")
+		}
+		// Codes are accepted without their separators and in any case
+		typed := strings.ToUpper(strings.ReplaceAll(second[0], "-", ""))
+		if !test.consume(t, userID, typed) {
+			t.Fatal("expected the new code to be accepted")
+		}
+		if test.consume(t, userID, second[0]) {
+			t.Fatal("expected the code to be used once")
+		}
+	})
+
+	t.Run("Returns Codes When Notice Fails", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, true)
+		test.eventWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil)
+		test.mailWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(errors.New("broker down", "Internal Server Error", errcode.ErrInternalFailure))
+
+		codes, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil || len(codes) != 10 {
+			t.Fatalf("expected the codes despite the failed notice, got %v, %v", codes, err)
+		}
+		if !test.consume(t, userID, codes[0]) {
+			t.Fatal("expected the returned codes to be stored")
+		}
+	})
+
+	t.Run("Keeps Code When Redeem Fails", func(t *testing.T) {
+		test := newMFATest(t)
+		userID := test.createUser(t)
+		test.enrollTotp(t, userID, true)
+		test.expectNotice(1)
+		codes, err := test.recovery.GenerateRecoveryCodes(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to generate recovery codes: %v", err)
+		}
+
+		redeemErr := errors.New("challenge already used", "Unauthorized", errcode.ErrUnauthorized)
+		valid, err := test.recoveryCode.ConsumeRecoveryCode(ctx, userID, codes[0], func() error { return redeemErr })
+		if valid || !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the redeem error, got %v, %v", valid, err)
+		}
+		if !test.consume(t, userID, codes[0]) {
+			t.Fatal("expected the code to be kept")
+		}
+	})
+}
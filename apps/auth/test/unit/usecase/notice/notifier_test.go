@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	activitymodels "mandacode.com/accounts/auth/internal/models/activity"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+)
+
+type notifierTest struct {
+	notifier    *notice.SecurityNotifier
+	authAccount *dbrepo.AuthAccountRepository
+	activity    *dbrepo.AuthActivityRepository
+	writer      *mock_mailer.MockMessageWriter
+}
+
+func newNotifierTest(t *testing.T) *notifierTest {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	hasher := util.NewPasswordHasher(util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  8192,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	}))
+
+	test := &notifierTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, hasher, zap.NewNop()),
+		activity:    dbrepo.NewAuthActivityRepository(client),
+		writer:      mock_mailer.NewMockMessageWriter(gomock.NewController(t)),
+	}
+	test.notifier = notice.NewSecurityNotifier(
+		test.authAccount,
+		test.activity,
+		mailer.NewMailer(test.writer, "https://accounts.example.com/sessions"),
+		zap.NewNop(),
+	)
+	return test
+}
+
+func (n *notifierTest) createUser(t *testing.T) uuid.UUID {
+	t.Helper()
+	auth, err := n.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      "user@example.com",
+		Password:   "password",
+		IsVerified: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return auth.UserID
+}
+
+// signIn records a successful sign-in of the user from the client.
+func (n *notifierTest) signIn(t *testing.T, userID uuid.UUID, userAgent string, ip string) {
+	t.Helper()
+	ctx := reqmodels.WithRequestInfo(context.Background(), reqmodels.RequestInfo{UserAgent: userAgent, IP: ip})
+	if err := n.activity.RecordAuthActivity(ctx, activitymodels.NewEntry(activitymodels.MethodPassword, "", userID, nil)); err != nil {
+		t.Fatalf("failed to record sign-in: %v", err)
+	}
+}
+
+// expectSignInNotice makes the writer accept one new sign-in notice to the user.
+func (n *notifierTest) expectSignInNotice(t *testing.T) {
+	t.Helper()
+	n.writer.EXPECT().
+		WriteMessages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+			if len(msgs) != 1 || string(msgs[0].Key) != "user@example.com" || string(msgs[0].Headers[0].Value) != mailer.EventTypeNewSignIn {
+				t.Errorf("expected a new sign-in notice to the user, got %+v", msgs)
+			}
+			return nil
+		})
+}
+
+func clientContext(userAgent string, ip string) context.Context {
+	return reqmodels.WithRequestInfo(context.Background(), reqmodels.RequestInfo{UserAgent: userAgent, IP: ip})
+}
+
+func TestSecurityNotifier(t *testing.T) {
+	t.Run("Skips First Sign-In", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+
+		test.notifier.NotifySignIn(clientContext("Firefox", "203.0.113.10"), userID)
+	})
+
+	t.Run("Skips Known Client On Same Network", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+		test.signIn(t, userID, "Firefox", "203.0.113.10")
+
+		test.notifier.NotifySignIn(clientContext("Firefox", "203.0.113.20"), userID)
+	})
+
+	t.Run("Mails Sign-In From New User Agent", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+		test.signIn(t, userID, "Firefox", "203.0.113.10")
+		test.expectSignInNotice(t)
+
+		test.notifier.NotifySignIn(clientContext("Chrome", "203.0.113.10"), userID)
+	})
+
+	t.Run("Mails Known User Agent From New Network", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+		test.signIn(t, userID, "Firefox", "203.0.113.10")
+		test.expectSignInNotice(t)
+
+		test.notifier.NotifySignIn(clientContext("Firefox", "198.51.100.10"), userID)
+	})
+
+	t.Run("Matches IPv6 Addresses Exactly", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+		test.signIn(t, userID, "Firefox", "2001:db8::1")
+		test.expectSignInNotice(t)
+
+		test.notifier.NotifySignIn(clientContext("Firefox", "2001:db8::1"), userID)
+		test.notifier.NotifySignIn(clientContext("Firefox", "2001:db8::2"), userID)
+	})
+
+	t.Run("Does Not Fail On Mail Error", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+		test.writer.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(fmt.Errorf("broker down"))
+
+		test.notifier.NotifyPasswordChanged(context.Background(), userID, false)
+	})
+
+	t.Run("Mails Previous Address Of Email Change", func(t *testing.T) {
+		test := newNotifierTest(t)
+		userID := test.createUser(t)
+		test.writer.EXPECT().
+			WriteMessages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+				if len(msgs) != 1 || string(msgs[0].Key) != "old@example.com" || string(msgs[0].Headers[0].Value) != mailer.EventTypeEmailChanged {
+					t.Errorf("expected an email change notice to the previous address, got %+v", msgs)
+				}
+				return nil
+			})
+
+		test.notifier.NotifyEmailChanged(context.Background(), userID, "old@example.com", "user@example.com")
+	})
+}
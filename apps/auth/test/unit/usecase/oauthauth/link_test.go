@@ -0,0 +1,214 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/oauthauth"
+	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_oauthapi "mandacode.com/accounts/auth/test/mock/infra/oauthapi"
+)
+
+type linkTest struct {
+	usecase     *oauthauth.LinkUsecase
+	authAccount *dbrepo.AuthAccountRepository
+	webauthn    *dbrepo.WebauthnCredentialRepository
+	tokens      *dbrepo.ProviderTokenRepository
+	google      *mock_oauthapi.MockOAuthAPI
+	writer      *mock_mailer.MockMessageWriter
+}
+
+func newLinkTest(t *testing.T) *linkTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	hasher := util.NewPasswordHasher(util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  8192,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	}))
+
+	test := &linkTest{
+		authAccount: dbrepo.NewAuthAccountRepository(client, hasher, zap.NewNop()),
+		webauthn:    dbrepo.NewWebauthnCredentialRepository(client),
+		tokens:      newTestProviderTokenRepository(t, client),
+		google:      mock_oauthapi.NewMockOAuthAPI(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+	}
+	test.usecase = oauthauth.NewLinkUsecase(
+		test.authAccount,
+		test.webauthn,
+		test.tokens,
+		notice.NewSecurityNotifier(test.authAccount, dbrepo.NewAuthActivityRepository(client), mailer.NewMailer(test.writer, "https://accounts.example.com/sessions"), zap.NewNop()),
+		[]providermodels.Provider{providermodels.ProviderGoogle},
+		map[providermodels.Provider]oauthapi.OAuthAPI{providermodels.ProviderGoogle: test.google},
+	)
+	return test
+}
+
+// expectGoogleUser makes Google return the identity for the native access token.
+func (l *linkTest) expectGoogleUser(accessToken string, providerID string, verified bool) {
+	l.google.EXPECT().
+		GetNativeUserInfo(oauthapi.NativeToken{AccessToken: accessToken}).
+		Return(oauthmodels.NewUserInfo(providerID, providerID+"@gmail.com", "User", verified), nil)
+}
+
+// expectLinkNotice expects the notice mailed to user@example.com when a
+// provider is linked or unlinked.
+func (l *linkTest) expectLinkNotice(t *testing.T) {
+	t.Helper()
+	l.writer.EXPECT().
+		WriteMessages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+			if len(msgs) != 1 || string(msgs[0].Key) != "user@example.com" || string(msgs[0].Headers[0].Value) != mailer.EventTypeProviderLinkChanged {
+				t.Errorf("expected a provider link notice to the user, got %+v", msgs)
+			}
+			return nil
+		})
+}
+
+func (l *linkTest) createLocal(t *testing.T, userID uuid.UUID) {
+	t.Helper()
+	if _, err := l.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     userID,
+		Email:      "user@example.com",
+		Password:   "password",
+		IsVerified: true,
+	}); err != nil {
+		t.Fatalf("failed to create local account: %v", err)
+	}
+}
+
+func (l *linkTest) createOAuth(t *testing.T, userID uuid.UUID, provider providermodels.Provider, providerID string, verified bool) {
+	t.Helper()
+	if _, err := l.authAccount.CreateOAuthAuthAccount(context.Background(), &dbmodels.CreateOAuthAuthAccountInput{
+		UserID:     userID,
+		Provider:   provider,
+		ProviderID: providerID,
+		Email:      providerID + "@example.com",
+		IsVerified: verified,
+	}); err != nil {
+		t.Fatalf("failed to create OAuth account: %v", err)
+	}
+}
+
+func TestLinkUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Links And Lists Identity", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createLocal(t, userID)
+		test.expectGoogleUser("google-token", "google-1", true)
+		test.expectLinkNotice(t)
+
+		account, err := test.usecase.LinkProvider(ctx, oauthdto.LinkInput{UserID: userID, Provider: providermodels.ProviderGoogle, AccessToken: "google-token"})
+		if err != nil || account.UserID != userID || account.ProviderID != "google-1" {
+			t.Fatalf("expected the identity to be linked to the user, got %+v, %v", account, err)
+		}
+		accounts, err := test.usecase.ListLinkedAccounts(ctx, userID)
+		if err != nil || len(accounts) != 2 {
+			t.Fatalf("expected the local account and the identity, got %v, %v", accounts, err)
+		}
+	})
+
+	t.Run("Rejects Identity Of Another User", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createLocal(t, userID)
+		test.createOAuth(t, uuid.New(), providermodels.ProviderGoogle, "google-1", true)
+		test.expectGoogleUser("google-token", "google-1", true)
+
+		_, err := test.usecase.LinkProvider(ctx, oauthdto.LinkInput{UserID: userID, Provider: providermodels.ProviderGoogle, AccessToken: "google-token"})
+		if !errors.Is(err, errcode.ErrConflict) {
+			t.Fatalf("expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Second Identity Of Provider", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createOAuth(t, userID, providermodels.ProviderGoogle, "google-1", true)
+		test.expectGoogleUser("google-token", "google-2", true)
+
+		_, err := test.usecase.LinkProvider(ctx, oauthdto.LinkInput{UserID: userID, Provider: providermodels.ProviderGoogle, AccessToken: "google-token"})
+		if !errors.Is(err, errcode.ErrConflict) {
+			t.Fatalf("expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("Unlinks Identity Beside Local Account", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createLocal(t, userID)
+		test.createOAuth(t, userID, providermodels.ProviderGoogle, "google-1", true)
+		test.expectLinkNotice(t)
+
+		if err := test.usecase.UnlinkProvider(ctx, userID, providermodels.ProviderGoogle); err != nil {
+			t.Fatalf("failed to unlink identity: %v", err)
+		}
+		if err := test.usecase.UnlinkProvider(ctx, userID, providermodels.ProviderGoogle); !errors.Is(err, errcode.ErrNotFound) {
+			t.Fatalf("expected the identity to be gone, got %v", err)
+		}
+	})
+
+	t.Run("Keeps Last Sign-In Method", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createOAuth(t, userID, providermodels.ProviderGoogle, "google-1", true)
+		// An unverified identity does not count as a way to sign in
+		test.createOAuth(t, userID, providermodels.ProviderKakao, "kakao-1", false)
+
+		if err := test.usecase.UnlinkProvider(ctx, userID, providermodels.ProviderGoogle); !errors.Is(err, errcode.ErrConflict) {
+			t.Fatalf("expected the last sign-in method to be kept, got %v", err)
+		}
+	})
+
+	t.Run("Unlinks Last Identity Beside Passkey", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createOAuth(t, userID, providermodels.ProviderGoogle, "google-1", true)
+		if _, err := test.webauthn.CreateWebauthnCredential(ctx, &dbmodels.CreateWebauthnCredentialInput{
+			UserID:       userID,
+			CredentialID: []byte("credential"),
+			PublicKey:    []byte("public-key"),
+			Name:         "Laptop",
+		}); err != nil {
+			t.Fatalf("failed to create passkey: %v", err)
+		}
+
+		if err := test.usecase.UnlinkProvider(ctx, userID, providermodels.ProviderGoogle); err != nil {
+			t.Fatalf("expected the passkey to keep a way to sign in, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Local Provider", func(t *testing.T) {
+		test := newLinkTest(t)
+		userID := uuid.New()
+		test.createLocal(t, userID)
+
+		if err := test.usecase.UnlinkProvider(ctx, userID, providermodels.ProviderLocal); !errors.Is(err, errcode.ErrInvalidInput) {
+			t.Fatalf("expected the local account to stay, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,470 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"mandacode.com/accounts/auth/ent"
+	"mandacode.com/accounts/auth/ent/enttest"
+	"mandacode.com/accounts/auth/ent/totpcredential"
+	"mandacode.com/accounts/auth/internal/infra/mailer"
+	"mandacode.com/accounts/auth/internal/infra/oauthapi"
+	"mandacode.com/accounts/auth/internal/infra/webauthn"
+	dbmodels "mandacode.com/accounts/auth/internal/models/database"
+	oauthmodels "mandacode.com/accounts/auth/internal/models/oauth"
+	providermodels "mandacode.com/accounts/auth/internal/models/provider"
+	reqmodels "mandacode.com/accounts/auth/internal/models/request"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	coderepo "mandacode.com/accounts/auth/internal/repository/code"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	ratelimitrepo "mandacode.com/accounts/auth/internal/repository/ratelimit"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	userrepo "mandacode.com/accounts/auth/internal/repository/user"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+	"mandacode.com/accounts/auth/internal/usecase/mfa"
+	mfadto "mandacode.com/accounts/auth/internal/usecase/mfa/dto"
+	"mandacode.com/accounts/auth/internal/usecase/notice"
+	"mandacode.com/accounts/auth/internal/usecase/oauthauth"
+	oauthdto "mandacode.com/accounts/auth/internal/usecase/oauthauth/dto"
+	"mandacode.com/accounts/auth/internal/usecase/passkeyauth"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
+	"mandacode.com/accounts/auth/internal/util"
+	mock_mailer "mandacode.com/accounts/auth/test/mock/infra/mailer"
+	mock_oauthapi "mandacode.com/accounts/auth/test/mock/infra/oauthapi"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+	mock_userv1 "mandacode.com/accounts/auth/test/mock/proto/user/v1"
+	mock_autheventrepo "mandacode.com/accounts/auth/test/mock/repository/authevent"
+)
+
+type oauthLoginTest struct {
+	usecase     *oauthauth.LoginUsecase
+	client      *ent.Client
+	authAccount *dbrepo.AuthAccountRepository
+	totp        *dbrepo.TotpCredentialRepository
+	tokens      *dbrepo.ProviderTokenRepository
+	sessions    *dbrepo.SessionRepository
+	google      *mock_oauthapi.MockOAuthAPI
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	eventWriter *mock_autheventrepo.MockMessageWriter
+	writer      *mock_mailer.MockMessageWriter
+}
+
+func newOAuthLoginTest(t *testing.T, linkPolicy oauthauth.LinkPolicy) *oauthLoginTest {
+	t.Helper()
+	return newOAuthLoginTestWithSignup(t, linkPolicy, signuppolicy.ModeOpen)
+}
+
+// newOAuthLoginTestWithSignup creates an OAuth login usecase creating new
+// users as the signup policy of mode admits.
+func newOAuthLoginTestWithSignup(t *testing.T, linkPolicy oauthauth.LinkPolicy, signupMode signuppolicy.Mode) *oauthLoginTest {
+	t.Helper()
+	signupPolicy, err := signuppolicy.NewPolicy(signuppolicy.Rules{Mode: signupMode})
+	if err != nil {
+		t.Fatalf("failed to create signup policy: %v", err)
+	}
+	ctrl := gomock.NewController(t)
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	cipher, err := util.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	hasher := util.NewPasswordHasher(util.NewArgon2idAlgorithm(util.Argon2Params{
+		Memory:  8192,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	}))
+
+	test := &oauthLoginTest{
+		client:      client,
+		authAccount: dbrepo.NewAuthAccountRepository(client, hasher, zap.NewNop()),
+		totp:        dbrepo.NewTotpCredentialRepository(client, cipher),
+		tokens:      newTestProviderTokenRepository(t, client),
+		sessions:    dbrepo.NewSessionRepository(client),
+		google:      mock_oauthapi.NewMockOAuthAPI(ctrl),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		eventWriter: mock_autheventrepo.NewMockMessageWriter(ctrl),
+		writer:      mock_mailer.NewMockMessageWriter(ctrl),
+	}
+	webauthnCredential := dbrepo.NewWebauthnCredentialRepository(client)
+	authEvent := autheventrepo.NewAuthEventEmitter(test.eventWriter)
+	policy := ratelimitrepo.Policy{Window: time.Hour, FreeAttempts: 3, MaxAttempts: 3, Lockout: time.Hour}
+	activity := dbrepo.NewAuthActivityRepository(client)
+	notifier := notice.NewSecurityNotifier(test.authAccount, activity, mailer.NewMailer(test.writer, "https://accounts.example.com/sessions"), zap.NewNop())
+	challenge := mfa.NewChallengeUsecase(
+		test.totp,
+		webauthnCredential,
+		dbrepo.NewRecoveryCodeRepository(client),
+		authEvent,
+		notifier,
+		passkeyauth.NewAssertionVerifier(webauthnCredential, coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "webauthn:"), &webauthn.RelyingParty{}),
+		coderepo.NewCodeManager(util.NewRandomGenerator(32), 5*time.Minute, store, "mfa_challenge:"),
+		ratelimitrepo.NewLimiter(store, "mfa:", policy),
+	)
+	test.usecase = oauthauth.NewLoginUsecase(
+		test.authAccount,
+		userrepo.NewUserServiceRepository(mock_userv1.NewMockUserServiceClient(ctrl)),
+		session.NewIssuer(tokenrepo.NewTokenRepository(test.tokenClient), test.sessions, activity, notifier),
+		challenge,
+		authEvent,
+		notifier,
+		signup.NewGate(signupPolicy, dbrepo.NewSignupInviteRepository(client)),
+		coderepo.NewLoginCodeManager(util.NewRandomGenerator(32), time.Minute, store, "login_code:"),
+		coderepo.NewPendingLinkManager(util.NewRandomGenerator(32), 10*time.Minute, store, "pending_link:"),
+		coderepo.NewLoginAttemptManager(util.NewRandomGenerator(32), 10*time.Minute, store, "oauth_state:"),
+		ratelimitrepo.NewLimiter(store, "login:", policy),
+		ratelimitrepo.NewLimiter(store, "verify_code:", policy),
+		test.tokens,
+		[]providermodels.Provider{providermodels.ProviderGoogle},
+		linkPolicy,
+		[]string{"https://app.example.com/"},
+		map[providermodels.Provider]oauthapi.OAuthAPI{providermodels.ProviderGoogle: test.google},
+	)
+	return test
+}
+
+func newTestProviderTokenRepository(t *testing.T, client *ent.Client) *dbrepo.ProviderTokenRepository {
+	t.Helper()
+	keyring, err := util.NewKeyring("key-1", map[string][]byte{"key-1": []byte("0123456789abcdef0123456789abcdef")})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+	return dbrepo.NewProviderTokenRepository(client, keyring)
+}
+
+// expectGoogleUser makes Google return a verified identity with the email for
+// the native access token.
+func (o *oauthLoginTest) expectGoogleUser(accessToken string, email string) {
+	o.google.EXPECT().
+		GetNativeUserInfo(oauthapi.NativeToken{AccessToken: accessToken}).
+		Return(oauthmodels.NewUserInfo("google-1", email, "User", true), nil)
+}
+
+// startWebLogin starts a web login with Google and returns its state.
+func (o *oauthLoginTest) startWebLogin(t *testing.T, returnTo string) string {
+	t.Helper()
+	o.google.EXPECT().
+		GetLoginURL(gomock.Any()).
+		Return("https://accounts.google.com/o/oauth2/v2/auth")
+	_, state, err := o.usecase.GetLoginURL(context.Background(), providermodels.ProviderGoogle, returnTo, "")
+	if err != nil {
+		t.Fatalf("failed to start login: %v", err)
+	}
+	return state
+}
+
+// expectTokens makes the token service issue a token pair.
+func (o *oauthLoginTest) expectTokens() {
+	o.tokenClient.EXPECT().
+		GenerateAccessToken(gomock.Any(), gomock.Any()).
+		Return(&tokenv1.GenerateAccessTokenResponse{Token: "access-token"}, nil)
+	o.tokenClient.EXPECT().
+		GenerateRefreshToken(gomock.Any(), gomock.Any()).
+		Return(&tokenv1.GenerateRefreshTokenResponse{Token: "refresh-token", FamilyId: "family", ExpiresAt: time.Now().Add(time.Hour).Unix()}, nil)
+}
+
+// expectLinkedEvent expects the account linked event of one link and the
+// notice mailed to the user about it.
+func (o *oauthLoginTest) expectLinkedEvent() {
+	o.eventWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil)
+	o.writer.EXPECT().
+		WriteMessages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+			if len(msgs) != 1 || string(msgs[0].Headers[0].Value) != mailer.EventTypeProviderLinkChanged {
+				return fmt.Errorf("expected a provider link notice, got %+v", msgs)
+			}
+			return nil
+		})
+}
+
+func (o *oauthLoginTest) createLocal(t *testing.T, email string) uuid.UUID {
+	t.Helper()
+	auth, err := o.authAccount.CreateLocalAuthAccount(context.Background(), &dbmodels.CreateLocalAuthAccountInput{
+		UserID:     uuid.New(),
+		Email:      email,
+		Password:   "password",
+		IsVerified: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create local account: %v", err)
+	}
+	return auth.UserID
+}
+
+// enrollTotp gives the user a confirmed TOTP authenticator and returns its secret.
+func (o *oauthLoginTest) enrollTotp(t *testing.T, userID uuid.UUID) string {
+	t.Helper()
+	ctx := context.Background()
+	secret, err := util.GenerateTotpSecret()
+	if err != nil {
+		t.Fatalf("failed to generate TOTP secret: %v", err)
+	}
+	if _, err := o.totp.SetPendingTotpSecret(ctx, userID, secret); err != nil {
+		t.Fatalf("failed to enroll TOTP: %v", err)
+	}
+	if err := o.client.TotpCredential.Update().
+		Where(totpcredential.UserID(userID)).
+		SetIsConfirmed(true).
+		Exec(ctx); err != nil {
+		t.Fatalf("failed to confirm TOTP: %v", err)
+	}
+	return secret
+}
+
+// createOAuth links the Google identity to the user.
+func (o *oauthLoginTest) createOAuth(t *testing.T, userID uuid.UUID) {
+	t.Helper()
+	if _, err := o.authAccount.CreateOAuthAuthAccount(context.Background(), &dbmodels.CreateOAuthAuthAccountInput{
+		UserID:     userID,
+		Provider:   providermodels.ProviderGoogle,
+		ProviderID: "google-1",
+		Email:      "user@example.com",
+		IsVerified: true,
+	}); err != nil {
+		t.Fatalf("failed to create OAuth account: %v", err)
+	}
+}
+
+func (o *oauthLoginTest) linked(t *testing.T, userID uuid.UUID) bool {
+	t.Helper()
+	accounts, err := o.authAccount.GetAuthAccountsByUserID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to get accounts: %v", err)
+	}
+	return len(accounts) == 2
+}
+
+// totpCode returns the current RFC 6238 code for the secret.
+func totpCode(t *testing.T, secret string) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("failed to decode TOTP secret: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(time.Now().Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func TestOAuthLoginUsecase(t *testing.T) {
+	ctx := context.Background()
+	login := oauthdto.LoginInput{Provider: providermodels.ProviderGoogle, AccessToken: "google-token"}
+
+	t.Run("Links Verified Local Account", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyVerified)
+		userID := test.createLocal(t, "user@example.com")
+		test.expectGoogleUser("google-token", "user@example.com")
+		test.expectLinkedEvent()
+		test.expectTokens()
+
+		accessToken, _, linkToken, mfaToken, err := test.usecase.Login(ctx, login)
+		if err != nil || accessToken != "access-token" || linkToken != "" || mfaToken != "" {
+			t.Fatalf("expected a token pair, got %q, %q, %q, %v", accessToken, linkToken, mfaToken, err)
+		}
+		if !test.linked(t, userID) {
+			t.Fatal("expected the identity to be linked to the local account")
+		}
+	})
+
+	t.Run("Keeps Link Pending For MFA User", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyVerified)
+		userID := test.createLocal(t, "user@example.com")
+		test.enrollTotp(t, userID)
+		test.expectGoogleUser("google-token", "user@example.com")
+
+		_, _, linkToken, _, err := test.usecase.Login(ctx, login)
+		if err != nil || linkToken == "" {
+			t.Fatalf("expected the link to wait for the user, got %q, %v", linkToken, err)
+		}
+		if test.linked(t, userID) {
+			t.Fatal("expected the identity not to be linked yet")
+		}
+	})
+
+	t.Run("Confirms Link With Password", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyConfirm)
+		userID := test.createLocal(t, "user@example.com")
+		test.expectGoogleUser("google-token", "user@example.com")
+		_, _, linkToken, _, err := test.usecase.Login(ctx, login)
+		if err != nil || linkToken == "" {
+			t.Fatalf("expected a pending link, got %q, %v", linkToken, err)
+		}
+
+		wrong := oauthdto.ConfirmLinkInput{LinkToken: linkToken, Password: "wrong", ClientIP: "203.0.113.1"}
+		if _, _, _, err := test.usecase.ConfirmLink(ctx, wrong); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the wrong password to be rejected, got %v", err)
+		}
+		test.expectLinkedEvent()
+		test.expectTokens()
+		right := oauthdto.ConfirmLinkInput{LinkToken: linkToken, Password: "password", ClientIP: "203.0.113.1"}
+		accessToken, _, mfaToken, err := test.usecase.ConfirmLink(ctx, right)
+		if err != nil || accessToken != "access-token" || mfaToken != "" {
+			t.Fatalf("expected a token pair, got %q, %q, %v", accessToken, mfaToken, err)
+		}
+		if !test.linked(t, userID) {
+			t.Fatal("expected the identity to be linked to the local account")
+		}
+		if _, _, _, err := test.usecase.ConfirmLink(ctx, right); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the link token to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Confirms Link With MFA", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyConfirm)
+		userID := test.createLocal(t, "user@example.com")
+		secret := test.enrollTotp(t, userID)
+		test.expectGoogleUser("google-token", "user@example.com")
+		_, _, linkToken, _, err := test.usecase.Login(ctx, login)
+		if err != nil {
+			t.Fatalf("failed to log in: %v", err)
+		}
+
+		_, _, mfaToken, err := test.usecase.ConfirmLink(ctx, oauthdto.ConfirmLinkInput{LinkToken: linkToken, Password: "password", ClientIP: "203.0.113.1"})
+		if err != nil || mfaToken == "" {
+			t.Fatalf("expected an MFA challenge, got %q, %v", mfaToken, err)
+		}
+		if test.linked(t, userID) {
+			t.Fatal("expected the identity not to be linked before the challenge")
+		}
+
+		test.expectLinkedEvent()
+		test.expectTokens()
+		accessToken, _, err := test.usecase.ConfirmLinkWithMFA(ctx, oauthdto.ConfirmLinkMFAInput{
+			LinkToken: linkToken,
+			Challenge: mfadto.ChallengeInput{MFAToken: mfaToken, Code: totpCode(t, secret)},
+		})
+		if err != nil || accessToken != "access-token" {
+			t.Fatalf("expected a token pair, got %q, %v", accessToken, err)
+		}
+		if !test.linked(t, userID) {
+			t.Fatal("expected the identity to be linked to the local account")
+		}
+	})
+
+	t.Run("Keeps Provider Tokens And Rejects Reused State", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyVerified)
+		userID := test.createLocal(t, "user@example.com")
+		test.createOAuth(t, userID)
+		state := test.startWebLogin(t, "https://app.example.com/done")
+		issued := oauthmodels.NewToken("google-token", "google-refresh", "", "Bearer", "openid email", 3600)
+		test.google.EXPECT().GetAccessToken("google-code", gomock.Any()).Return(issued, nil)
+		test.google.EXPECT().
+			GetUserInfo(issued, gomock.Not("")).
+			Return(oauthmodels.NewUserInfo("google-1", "user@example.com", "User", true), nil)
+
+		callback := oauthdto.CallbackInput{Provider: providermodels.ProviderGoogle, Code: "google-code", State: state}
+		output, err := test.usecase.IssueLoginCode(ctx, callback)
+		if err != nil || output.Code == "" || output.UserID != userID || output.ReturnTo != "https://app.example.com/done" {
+			t.Fatalf("expected a login code for the user, got %+v, %v", output, err)
+		}
+		stored, err := test.tokens.GetProviderToken(ctx, userID, providermodels.ProviderGoogle)
+		if err != nil || stored.AccessToken != "google-token" || stored.RefreshToken != "google-refresh" {
+			t.Fatalf("expected the provider tokens to be stored, got %+v, %v", stored, err)
+		}
+		if _, err := test.usecase.IssueLoginCode(ctx, callback); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the state to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Refuses New User While Signup Is Closed", func(t *testing.T) {
+		test := newOAuthLoginTestWithSignup(t, oauthauth.LinkPolicyVerified, signuppolicy.ModeClosed)
+		state := test.startWebLogin(t, "https://app.example.com/done")
+		issued := oauthmodels.NewToken("google-token", "", "", "Bearer", "openid email", 3600)
+		test.google.EXPECT().GetAccessToken("google-code", gomock.Any()).Return(issued, nil)
+		test.google.EXPECT().
+			GetUserInfo(issued, gomock.Not("")).
+			Return(oauthmodels.NewUserInfo("google-1", "new@example.com", "User", true), nil)
+
+		callback := oauthdto.CallbackInput{Provider: providermodels.ProviderGoogle, Code: "google-code", State: state}
+		if _, err := test.usecase.IssueLoginCode(ctx, callback); !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the new user to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Starts Session For Client Of Login Code", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyVerified)
+		userID := test.createLocal(t, "user@example.com")
+		test.createOAuth(t, userID)
+		state := test.startWebLogin(t, "")
+		issued := oauthmodels.NewToken("google-token", "", "", "Bearer", "openid email", 3600)
+		test.google.EXPECT().GetAccessToken("google-code", gomock.Any()).Return(issued, nil)
+		test.google.EXPECT().
+			GetUserInfo(issued, gomock.Any()).
+			Return(oauthmodels.NewUserInfo("google-1", "user@example.com", "User", true), nil)
+		test.expectTokens()
+
+		browser := reqmodels.WithRequestInfo(ctx, reqmodels.RequestInfo{IP: "203.0.113.7", UserAgent: "Browser", DeviceName: "Laptop"})
+		output, err := test.usecase.IssueLoginCode(browser, oauthdto.CallbackInput{Provider: providermodels.ProviderGoogle, Code: "google-code", State: state})
+		if err != nil || output.Code == "" {
+			t.Fatalf("expected a login code, got %+v, %v", output, err)
+		}
+		backend := reqmodels.WithRequestInfo(ctx, reqmodels.RequestInfo{IP: "10.0.0.2", UserAgent: "Backend"})
+		if _, _, err := test.usecase.VerifyLoginCode(backend, userID, output.Code, "10.0.0.2"); err != nil {
+			t.Fatalf("failed to verify login code: %v", err)
+		}
+		sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID)
+		if err != nil || len(sessions) != 1 {
+			t.Fatalf("expected a session, got %v, %v", sessions, err)
+		}
+		if session := sessions[0]; session.IPAddress != "203.0.113.7" || session.UserAgent != "Browser" || session.DeviceName != "Laptop" {
+			t.Fatalf("expected the session to describe the browser, got %+v", session)
+		}
+	})
+
+	t.Run("Rejects Return URL Not Allowed", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyVerified)
+
+		_, _, err := test.usecase.GetLoginURL(ctx, providermodels.ProviderGoogle, "https://evil.example.com/", "")
+		if !errors.Is(err, errcode.ErrInvalidInput) {
+			t.Fatalf("expected the return URL to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Challenges Linked MFA User", func(t *testing.T) {
+		test := newOAuthLoginTest(t, oauthauth.LinkPolicyVerified)
+		userID := test.createLocal(t, "user@example.com")
+		secret := test.enrollTotp(t, userID)
+		test.createOAuth(t, userID)
+		state := test.startWebLogin(t, "")
+		issued := oauthmodels.NewToken("google-token", "", "", "Bearer", "openid email", 3600)
+		test.google.EXPECT().GetAccessToken("google-code", gomock.Any()).Return(issued, nil)
+		test.google.EXPECT().
+			GetUserInfo(issued, gomock.Any()).
+			Return(oauthmodels.NewUserInfo("google-1", "user@example.com", "User", true), nil)
+
+		output, err := test.usecase.IssueLoginCode(ctx, oauthdto.CallbackInput{Provider: providermodels.ProviderGoogle, Code: "google-code", State: state})
+		if err != nil || output.Code != "" || output.MFAToken == "" {
+			t.Fatalf("expected an MFA challenge instead of a code, got %+v, %v", output, err)
+		}
+		code, passedID, err := test.usecase.IssueLoginCodeWithMFA(ctx, mfadto.ChallengeInput{MFAToken: output.MFAToken, Code: totpCode(t, secret)})
+		if err != nil || code == "" || passedID != userID {
+			t.Fatalf("expected a login code for the user, got %q, %v, %v", code, passedID, err)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+	"mandacode.com/accounts/auth/ent/enttest"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/session"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+)
+
+type manageTest struct {
+	usecase     *session.ManageUsecase
+	store       *miniredis.Miniredis
+	sessions    *dbrepo.SessionRepository
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+}
+
+func newManageTest(t *testing.T) *manageTest {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	test := &manageTest{
+		store:       server,
+		sessions:    dbrepo.NewSessionRepository(client),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(gomock.NewController(t)),
+	}
+	test.usecase = session.NewManageUsecase(
+		test.sessions,
+		revocationrepo.NewRevocationRepository(store, "revoked:", 720*time.Hour),
+		tokenrepo.NewTokenRepository(test.tokenClient),
+	)
+	return test
+}
+
+// expectVerify makes the token service verify refresh tokens, accepting the
+// one given as a token of the user in the family.
+func (m *manageTest) expectVerify(refreshToken string, userID uuid.UUID, familyID string) {
+	m.tokenClient.EXPECT().
+		VerifyRefreshToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *tokenv1.VerifyRefreshTokenRequest, _ ...any) (*tokenv1.VerifyRefreshTokenResponse, error) {
+			if req.Token != refreshToken {
+				return &tokenv1.VerifyRefreshTokenResponse{}, nil
+			}
+			sub, tokenID := userID.String(), "token"
+			return &tokenv1.VerifyRefreshTokenResponse{Valid: true, UserId: &sub, FamilyId: &familyID, TokenId: &tokenID}, nil
+		}).
+		AnyTimes()
+}
+
+func (m *manageTest) createSession(t *testing.T, userID uuid.UUID, familyID string) uuid.UUID {
+	t.Helper()
+	created, err := m.sessions.CreateSession(context.Background(), userID, familyID, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	return created.ID
+}
+
+func TestManageUsecase(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Lists Sessions With Current", func(t *testing.T) {
+		test := newManageTest(t)
+		test.expectVerify("current-refresh-token", userID, "family-current")
+		test.createSession(t, userID, "family-current")
+		test.createSession(t, userID, "family-other")
+		test.createSession(t, uuid.New(), "family-another-user")
+
+		sessions, err := test.usecase.ListSessions(ctx, userID, "current-refresh-token")
+		if err != nil || len(sessions) != 2 {
+			t.Fatalf("expected the sessions of the user, got %v, %v", sessions, err)
+		}
+		current := 0
+		for _, item := range sessions {
+			if item.Current {
+				current++
+			}
+		}
+		if current != 1 {
+			t.Fatalf("expected a single current session, got %d", current)
+		}
+	})
+
+	t.Run("Revokes Session", func(t *testing.T) {
+		test := newManageTest(t)
+		sessionID := test.createSession(t, userID, "family")
+
+		if err := test.usecase.RevokeSession(ctx, userID, sessionID); err != nil {
+			t.Fatalf("failed to revoke session: %v", err)
+		}
+		if !test.store.Exists("revoked:family:family") {
+			t.Fatal("expected the token family of the session to be revoked")
+		}
+		if err := test.usecase.RevokeSession(ctx, userID, sessionID); !errors.Is(err, errcode.ErrNotFound) {
+			t.Fatalf("expected the session to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("Rejects Session Of Another User", func(t *testing.T) {
+		test := newManageTest(t)
+		sessionID := test.createSession(t, uuid.New(), "family")
+
+		if err := test.usecase.RevokeSession(ctx, userID, sessionID); !errors.Is(err, errcode.ErrNotFound) {
+			t.Fatalf("expected a session of another user to be not found, got %v", err)
+		}
+		if keys := test.store.Keys(); len(keys) != 0 {
+			t.Fatalf("expected nothing to be revoked, got %v", keys)
+		}
+	})
+
+	t.Run("Revokes Other Sessions", func(t *testing.T) {
+		test := newManageTest(t)
+		test.expectVerify("current-refresh-token", userID, "family-current")
+		test.createSession(t, userID, "family-current")
+		test.createSession(t, userID, "family-other")
+
+		revoked, err := test.usecase.RevokeOtherSessions(ctx, userID, "current-refresh-token")
+		if err != nil || revoked != 1 {
+			t.Fatalf("expected one session to be revoked, got %d, %v", revoked, err)
+		}
+		if !test.store.Exists("revoked:family:family-other") || test.store.Exists("revoked:family:family-current") {
+			t.Fatalf("expected only the other family to be revoked, got %v", test.store.Keys())
+		}
+		sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID)
+		if err != nil || len(sessions) != 1 || sessions[0].FamilyID != "family-current" {
+			t.Fatalf("expected the current session to be kept, got %v, %v", sessions, err)
+		}
+	})
+
+	t.Run("Requires Current Session To Revoke Others", func(t *testing.T) {
+		test := newManageTest(t)
+		test.expectVerify("other-user-refresh-token", uuid.New(), "family-other")
+		test.createSession(t, userID, "family")
+
+		for _, refreshToken := range []string{"", "unknown-refresh-token", "other-user-refresh-token"} {
+			if _, err := test.usecase.RevokeOtherSessions(ctx, userID, refreshToken); !errors.Is(err, errcode.ErrInvalidInput) {
+				t.Errorf("expected %q not to identify the current session, got %v", refreshToken, err)
+			}
+		}
+		if keys := test.store.Keys(); len(keys) != 0 {
+			t.Fatalf("expected nothing to be revoked, got %v", keys)
+		}
+	})
+
+	t.Run("Revokes All Sessions", func(t *testing.T) {
+		test := newManageTest(t)
+		test.createSession(t, userID, "family-1")
+		test.createSession(t, userID, "family-2")
+
+		revoked, err := test.usecase.RevokeAllSessions(ctx, userID)
+		if err != nil || revoked != 2 {
+			t.Fatalf("expected both sessions to be revoked, got %d, %v", revoked, err)
+		}
+		if !test.store.Exists("revoked:user:" + userID.String()) {
+			t.Fatal("expected the refresh tokens of the user to be revoked")
+		}
+		if sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID); err != nil || len(sessions) != 0 {
+			t.Fatalf("expected the sessions of the user to be deleted, got %v, %v", sessions, err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"mandacode.com/accounts/auth/ent/enttest"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	"mandacode.com/accounts/auth/internal/signuppolicy"
+	"mandacode.com/accounts/auth/internal/usecase/signup"
+)
+
+type gateTest struct {
+	gate    *signup.Gate
+	invites *dbrepo.SignupInviteRepository
+}
+
+func newGateTest(t *testing.T, mode signuppolicy.Mode) *gateTest {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	policy, err := signuppolicy.NewPolicy(signuppolicy.Rules{Mode: mode})
+	if err != nil {
+		t.Fatalf("failed to create signup policy: %v", err)
+	}
+
+	invites := dbrepo.NewSignupInviteRepository(client)
+	return &gateTest{
+		gate:    signup.NewGate(policy, invites),
+		invites: invites,
+	}
+}
+
+func (g *gateTest) createInvite(t *testing.T, code string, email string, expiresAt time.Time) {
+	t.Helper()
+	if _, err := g.invites.CreateSignupInvite(context.Background(), code, email, "admin", expiresAt); err != nil {
+		t.Fatalf("failed to create invite: %v", err)
+	}
+}
+
+func TestGate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Admits Anyone While Signup Is Open", func(t *testing.T) {
+		test := newGateTest(t, signuppolicy.ModeOpen)
+
+		inviteID, err := test.gate.Admit(ctx, "user@example.com", false, "", uuid.New())
+		if err != nil || inviteID != uuid.Nil {
+			t.Fatalf("expected the signup to be admitted without invite, got %v, %v", inviteID, err)
+		}
+	})
+
+	t.Run("Admits Invite Only For Its Email", func(t *testing.T) {
+		test := newGateTest(t, signuppolicy.ModeInvite)
+		test.createInvite(t, "invite-code", "user@example.com", time.Now().Add(time.Hour))
+
+		if _, err := test.gate.Admit(ctx, "other@example.com", true, "invite-code", uuid.New()); !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the invite to be refused for another email, got %v", err)
+		}
+		if _, err := test.gate.Admit(ctx, "User@Example.com", true, "invite-code", uuid.New()); err != nil {
+			t.Fatalf("expected the invite to admit its email, got %v", err)
+		}
+	})
+
+	t.Run("Refuses Expired Invite", func(t *testing.T) {
+		test := newGateTest(t, signuppolicy.ModeInvite)
+		test.createInvite(t, "invite-code", "", time.Now().Add(-time.Minute))
+
+		if _, err := test.gate.Admit(ctx, "user@example.com", true, "invite-code", uuid.New()); !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the expired invite to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Gives Back Invite Of Aborted Signup", func(t *testing.T) {
+		test := newGateTest(t, signuppolicy.ModeInvite)
+		test.createInvite(t, "invite-code", "", time.Now().Add(time.Hour))
+		inviteID, err := test.gate.Admit(ctx, "user@example.com", true, "invite-code", uuid.New())
+		if err != nil {
+			t.Fatalf("failed to admit signup: %v", err)
+		}
+		if _, err := test.gate.Admit(ctx, "other@example.com", true, "invite-code", uuid.New()); !errors.Is(err, errcode.ErrForbidden) {
+			t.Fatalf("expected the redeemed invite to be refused, got %v", err)
+		}
+
+		cause := errors.New("account creation failed", "Internal Error", errcode.ErrInternalFailure)
+		if err := test.gate.Abort(ctx, inviteID, cause); err != cause {
+			t.Fatalf("expected the cause to be returned, got %v", err)
+		}
+		if _, err := test.gate.Admit(ctx, "other@example.com", true, "invite-code", uuid.New()); err != nil {
+			t.Fatalf("expected the given back invite to admit a signup, got %v", err)
+		}
+	})
+}
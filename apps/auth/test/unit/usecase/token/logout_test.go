@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+	"mandacode.com/accounts/auth/ent/enttest"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/token"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+)
+
+type logoutTest struct {
+	usecase     *token.LogoutUsecase
+	store       *miniredis.Miniredis
+	sessions    *dbrepo.SessionRepository
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+}
+
+func newLogoutTest(t *testing.T) *logoutTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	test := &logoutTest{
+		store:       server,
+		sessions:    dbrepo.NewSessionRepository(client),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+	}
+	test.usecase = token.NewLogoutUsecase(
+		tokenrepo.NewTokenRepository(test.tokenClient),
+		revocationrepo.NewRevocationRepository(store, "revoked:", 720*time.Hour),
+		test.sessions,
+	)
+	return test
+}
+
+// expectVerify makes the token service verify the refresh token, as issued to
+// the user if userID is not nil or as invalid otherwise. familyID is empty for
+// tokens issued before rotation.
+func (l *logoutTest) expectVerify(refreshToken string, userID *uuid.UUID, familyID string) {
+	resp := &tokenv1.VerifyRefreshTokenResponse{}
+	if userID != nil {
+		sub := userID.String()
+		resp.Valid = true
+		resp.UserId = &sub
+	}
+	if familyID != "" {
+		tokenID := "token"
+		resp.FamilyId = &familyID
+		resp.TokenId = &tokenID
+	}
+	l.tokenClient.EXPECT().
+		VerifyRefreshToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *tokenv1.VerifyRefreshTokenRequest, _ ...any) (*tokenv1.VerifyRefreshTokenResponse, error) {
+			if req.Token != refreshToken {
+				return &tokenv1.VerifyRefreshTokenResponse{}, nil
+			}
+			return resp, nil
+		})
+}
+
+func (l *logoutTest) createSession(t *testing.T, userID uuid.UUID, familyID string) {
+	t.Helper()
+	if _, err := l.sessions.CreateSession(context.Background(), userID, familyID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+}
+
+func TestLogoutUsecase(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Revokes Family Of Session", func(t *testing.T) {
+		test := newLogoutTest(t)
+		test.expectVerify("refresh-token", &userID, "family")
+		test.createSession(t, userID, "family")
+		test.createSession(t, userID, "other-family")
+
+		if err := test.usecase.Logout(ctx, "refresh-token", false); err != nil {
+			t.Fatalf("failed to log out: %v", err)
+		}
+		if keys := test.store.Keys(); len(keys) != 1 || !test.store.Exists("revoked:family:family") {
+			t.Fatalf("expected only the family to be revoked, got %v", keys)
+		}
+		sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID)
+		if err != nil || len(sessions) != 1 || sessions[0].FamilyID != "other-family" {
+			t.Fatalf("expected only the session signed out to be deleted, got %v, %v", sessions, err)
+		}
+	})
+
+	t.Run("Revokes Token Issued Before Rotation", func(t *testing.T) {
+		test := newLogoutTest(t)
+		test.expectVerify("refresh-token", &userID, "")
+
+		if err := test.usecase.Logout(ctx, "refresh-token", false); err != nil {
+			t.Fatalf("failed to log out: %v", err)
+		}
+		if keys := test.store.Keys(); len(keys) != 1 || test.store.Exists("revoked:user:"+userID.String()) {
+			t.Fatalf("expected only the token to be revoked, got %v", keys)
+		}
+	})
+
+	t.Run("Revokes Every Token Of User", func(t *testing.T) {
+		test := newLogoutTest(t)
+		test.expectVerify("refresh-token", &userID, "family")
+		test.createSession(t, userID, "family")
+		test.createSession(t, userID, "other-family")
+
+		if err := test.usecase.Logout(ctx, "refresh-token", true); err != nil {
+			t.Fatalf("failed to log out: %v", err)
+		}
+		if !test.store.Exists("revoked:user:" + userID.String()) {
+			t.Fatal("expected the tokens of the user to be revoked")
+		}
+		if sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID); err != nil || len(sessions) != 0 {
+			t.Fatalf("expected every session to be deleted, got %v, %v", sessions, err)
+		}
+	})
+
+	t.Run("Ignores Invalid Token", func(t *testing.T) {
+		test := newLogoutTest(t)
+		test.expectVerify("unknown-refresh-token", nil, "")
+		test.expectVerify("unknown-refresh-token", nil, "")
+
+		if err := test.usecase.Logout(ctx, "unknown-refresh-token", false); err != nil {
+			t.Fatalf("expected signing out twice to succeed, got %v", err)
+		}
+		if keys := test.store.Keys(); len(keys) != 0 {
+			t.Fatalf("expected nothing to be revoked, got %v", keys)
+		}
+		if err := test.usecase.Logout(ctx, "unknown-refresh-token", true); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected signing out everywhere to require a valid token, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
+	"github.com/mandacode-com/golib/errors"
+	"github.com/mandacode-com/golib/errors/errcode"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+	"mandacode.com/accounts/auth/ent/enttest"
+	autheventrepo "mandacode.com/accounts/auth/internal/repository/authevent"
+	dbrepo "mandacode.com/accounts/auth/internal/repository/database"
+	revocationrepo "mandacode.com/accounts/auth/internal/repository/revocation"
+	rotationrepo "mandacode.com/accounts/auth/internal/repository/rotation"
+	tokenrepo "mandacode.com/accounts/auth/internal/repository/token"
+	"mandacode.com/accounts/auth/internal/usecase/token"
+	mock_tokenv1 "mandacode.com/accounts/auth/test/mock/proto/token/v1"
+	mock_autheventrepo "mandacode.com/accounts/auth/test/mock/repository/authevent"
+)
+
+type refreshTest struct {
+	usecase     *token.RefreshUsecase
+	store       *miniredis.Miniredis
+	rotation    *rotationrepo.RotationRepository
+	sessions    *dbrepo.SessionRepository
+	tokenClient *mock_tokenv1.MockTokenServiceClient
+	eventWriter *mock_autheventrepo.MockMessageWriter
+}
+
+func newRefreshTest(t *testing.T) *refreshTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	server := miniredis.RunT(t)
+	store := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { store.Close() })
+	client := enttest.Open(t, "sqlite3", "file:"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	test := &refreshTest{
+		store:       server,
+		rotation:    rotationrepo.NewRotationRepository(store, "rotation:", 720*time.Hour),
+		sessions:    dbrepo.NewSessionRepository(client),
+		tokenClient: mock_tokenv1.NewMockTokenServiceClient(ctrl),
+		eventWriter: mock_autheventrepo.NewMockMessageWriter(ctrl),
+	}
+	test.usecase = token.NewRefreshUsecase(
+		tokenrepo.NewTokenRepository(test.tokenClient),
+		revocationrepo.NewRevocationRepository(store, "revoked:", 720*time.Hour),
+		test.rotation,
+		test.sessions,
+		dbrepo.NewAuthActivityRepository(client),
+		autheventrepo.NewAuthEventEmitter(test.eventWriter),
+	)
+	return test
+}
+
+// expectVerify makes the token service accept the refresh token of the user
+// as often as it is presented. familyID and tokenID are empty for tokens
+// issued before rotation.
+func (r *refreshTest) expectVerify(userID uuid.UUID, familyID string, tokenID string) {
+	sub := userID.String()
+	resp := &tokenv1.VerifyRefreshTokenResponse{Valid: true, UserId: &sub}
+	if familyID != "" {
+		resp.FamilyId = &familyID
+		resp.TokenId = &tokenID
+	}
+	r.tokenClient.EXPECT().
+		VerifyRefreshToken(gomock.Any(), gomock.Any()).
+		Return(resp, nil).
+		AnyTimes()
+}
+
+// expectIssue makes the token service issue one token pair, the refresh token
+// having the ID and family given.
+func (r *refreshTest) expectIssue(tokenID string, familyID string) {
+	r.tokenClient.EXPECT().
+		GenerateRefreshToken(gomock.Any(), gomock.Any()).
+		Return(&tokenv1.GenerateRefreshTokenResponse{Token: "refresh-token", TokenId: tokenID, FamilyId: familyID, ExpiresAt: time.Now().Add(720 * time.Hour).Unix()}, nil)
+	r.tokenClient.EXPECT().
+		GenerateAccessToken(gomock.Any(), gomock.Any()).
+		Return(&tokenv1.GenerateAccessTokenResponse{Token: "access-token"}, nil)
+}
+
+func TestRefreshUsecase(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Rotates Token Within Family", func(t *testing.T) {
+		test := newRefreshTest(t)
+		test.expectVerify(userID, "family", "token-0")
+		test.expectIssue("token-1", "family")
+		if _, err := test.sessions.CreateSession(ctx, userID, "family", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+
+		accessToken, refreshToken, err := test.usecase.Refresh(ctx, "login-refresh-token")
+		if err != nil || accessToken != "access-token" || refreshToken != "refresh-token" {
+			t.Fatalf("expected a new token pair, got %q, %q, %v", accessToken, refreshToken, err)
+		}
+		// The presented token is no longer the current one of its family
+		rotated, err := test.rotation.Rotate(ctx, "family", "token-0", "token-2")
+		if err != nil || rotated {
+			t.Fatalf("expected the presented token to be rotated out, got %v, %v", rotated, err)
+		}
+		sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID)
+		if err != nil || len(sessions) != 1 || time.Until(sessions[0].ExpiresAt) < 719*time.Hour {
+			t.Fatalf("expected the session to follow the new token, got %v, %v", sessions, err)
+		}
+	})
+
+	t.Run("Revokes Family On Reuse", func(t *testing.T) {
+		test := newRefreshTest(t)
+		test.expectVerify(userID, "family", "token-0")
+		if _, err := test.rotation.Rotate(ctx, "family", "token-0", "token-1"); err != nil {
+			t.Fatalf("failed to rotate: %v", err)
+		}
+		if _, err := test.sessions.CreateSession(ctx, userID, "family", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		test.tokenClient.EXPECT().
+			GenerateRefreshToken(gomock.Any(), gomock.Any()).
+			Return(&tokenv1.GenerateRefreshTokenResponse{Token: "refresh-token", TokenId: "token-2", FamilyId: "family"}, nil)
+		test.eventWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, _, err := test.usecase.Refresh(ctx, "login-refresh-token")
+		if !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected the reused token to be rejected, got %v", err)
+		}
+		if !test.store.Exists("revoked:family:family") {
+			t.Fatal("expected the token family to be revoked")
+		}
+		if sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID); err != nil || len(sessions) != 0 {
+			t.Fatalf("expected the session to be deleted, got %v, %v", sessions, err)
+		}
+	})
+
+	t.Run("Claims Legacy Token Once", func(t *testing.T) {
+		test := newRefreshTest(t)
+		test.expectVerify(userID, "", "")
+		test.expectIssue("token-1", "family")
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded, rejected := 0, 0
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, err := test.usecase.Refresh(ctx, "legacy-refresh-token")
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					succeeded++
+				case errors.Is(err, errcode.ErrUnauthorized):
+					rejected++
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if succeeded != 1 || rejected != 9 {
+			t.Fatalf("expected a single refresh to start a family, got %d succeeded and %d rejected", succeeded, rejected)
+		}
+		sessions, err := test.sessions.GetActiveSessionsByUserID(ctx, userID)
+		if err != nil || len(sessions) != 1 || sessions[0].FamilyID != "family" {
+			t.Fatalf("expected a session for the new family, got %v, %v", sessions, err)
+		}
+	})
+
+	t.Run("Rejects Invalid Token", func(t *testing.T) {
+		test := newRefreshTest(t)
+		test.tokenClient.EXPECT().
+			VerifyRefreshToken(gomock.Any(), gomock.Any()).
+			Return(&tokenv1.VerifyRefreshTokenResponse{Valid: false}, nil)
+
+		if _, _, err := test.usecase.Refresh(ctx, "unknown-refresh-token"); !errors.Is(err, errcode.ErrUnauthorized) {
+			t.Fatalf("expected an unknown token to be rejected, got %v", err)
+		}
+	})
+}
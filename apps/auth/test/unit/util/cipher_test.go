@@ -0,0 +1,76 @@
+package util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+const testAssociatedData = "0b9c1f9e-4a35-4d8e-9d2c-7f0f4f1f2a10|google"
+
+func TestKeyring(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+
+	oldKeyring, err := util.NewKeyring("2025-01", map[string][]byte{"2025-01": oldKey})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+	keyID, ciphertext, err := oldKeyring.Encrypt("provider-access-token", testAssociatedData)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+	if keyID != "2025-01" {
+		t.Fatalf("expected the current key ID, got %q", keyID)
+	}
+
+	t.Run("Decrypts With Retired Key", func(t *testing.T) {
+		keyring, err := util.NewKeyring("2025-06", map[string][]byte{"2025-01": oldKey, "2025-06": newKey})
+		if err != nil {
+			t.Fatalf("failed to create keyring: %v", err)
+		}
+		plaintext, err := keyring.Decrypt(keyID, ciphertext, testAssociatedData)
+		if err != nil || plaintext != "provider-access-token" {
+			t.Fatalf("expected the token of the retired key, got %q, %v", plaintext, err)
+		}
+		if rotatedKeyID, _, _ := keyring.Encrypt(plaintext, testAssociatedData); rotatedKeyID != "2025-06" {
+			t.Fatalf("expected encryption with the current key, got %q", rotatedKeyID)
+		}
+	})
+
+	t.Run("Rejects Unknown Key", func(t *testing.T) {
+		keyring, err := util.NewKeyring("2025-06", map[string][]byte{"2025-06": newKey})
+		if err != nil {
+			t.Fatalf("failed to create keyring: %v", err)
+		}
+		if _, err := keyring.Decrypt(keyID, ciphertext, testAssociatedData); err == nil {
+			t.Fatal("expected a ciphertext of a removed key to be rejected")
+		}
+	})
+
+	t.Run("Rejects Other Associated Data", func(t *testing.T) {
+		if _, err := oldKeyring.Decrypt(keyID, ciphertext, "6f1d2c3b-8e7a-4b5c-9d0e-1a2b3c4d5e6f|google"); err == nil {
+			t.Fatal("expected a ciphertext moved to another user to be rejected")
+		}
+		if _, err := oldKeyring.Decrypt(keyID, ciphertext, "0b9c1f9e-4a35-4d8e-9d2c-7f0f4f1f2a10|kakao"); err == nil {
+			t.Fatal("expected a ciphertext moved to another provider to be rejected")
+		}
+	})
+
+	t.Run("Binds Key ID", func(t *testing.T) {
+		keyring, err := util.NewKeyring("2025-06", map[string][]byte{"2025-01": oldKey, "2025-06": oldKey})
+		if err != nil {
+			t.Fatalf("failed to create keyring: %v", err)
+		}
+		if _, err := keyring.Decrypt("2025-06", ciphertext, testAssociatedData); err == nil {
+			t.Fatal("expected a ciphertext relabelled with another key ID to be rejected")
+		}
+	})
+
+	t.Run("Requires Current Key", func(t *testing.T) {
+		if _, err := util.NewKeyring("2025-06", map[string][]byte{"2025-01": oldKey}); err == nil {
+			t.Fatal("expected a missing current key to be rejected")
+		}
+	})
+}
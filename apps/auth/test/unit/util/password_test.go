@@ -0,0 +1,82 @@
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+var testArgon2Params = util.Argon2Params{
+	Memory:  8192,
+	Time:    2,
+	Threads: 1,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+func TestPasswordHasher(t *testing.T) {
+	argon2id := util.NewArgon2idAlgorithm(testArgon2Params)
+	bcrypt := util.NewBcryptAlgorithm(10)
+	hasher := util.NewPasswordHasher(argon2id, bcrypt)
+
+	t.Run("Hashes With Argon2id", func(t *testing.T) {
+		hash, err := hasher.Hash("correct horse")
+		if err != nil {
+			t.Fatalf("failed to hash password: %v", err)
+		}
+		if !strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=2,p=1$") {
+			t.Fatalf("unexpected hash format: %s", hash)
+		}
+
+		matched, err := hasher.Verify(hash, "correct horse")
+		if err != nil || !matched {
+			t.Fatalf("expected password to match, got %v, %v", matched, err)
+		}
+		matched, err = hasher.Verify(hash, "wrong horse")
+		if err != nil || matched {
+			t.Fatalf("expected password not to match, got %v, %v", matched, err)
+		}
+		if hasher.NeedsRehash(hash) {
+			t.Fatal("expected current hash not to need a rehash")
+		}
+	})
+
+	t.Run("Verifies Legacy Bcrypt", func(t *testing.T) {
+		hash, err := bcrypt.Hash("correct horse")
+		if err != nil {
+			t.Fatalf("failed to hash password: %v", err)
+		}
+
+		matched, err := hasher.Verify(hash, "correct horse")
+		if err != nil || !matched {
+			t.Fatalf("expected password to match, got %v, %v", matched, err)
+		}
+		if !hasher.NeedsRehash(hash) {
+			t.Fatal("expected bcrypt hash to need a rehash")
+		}
+	})
+
+	t.Run("Rehashes Weaker Parameters", func(t *testing.T) {
+		weaker := testArgon2Params
+		weaker.Time = 1
+		hash, err := util.NewArgon2idAlgorithm(weaker).Hash("correct horse")
+		if err != nil {
+			t.Fatalf("failed to hash password: %v", err)
+		}
+
+		matched, err := hasher.Verify(hash, "correct horse")
+		if err != nil || !matched {
+			t.Fatalf("expected password to match, got %v, %v", matched, err)
+		}
+		if !hasher.NeedsRehash(hash) {
+			t.Fatal("expected weaker hash to need a rehash")
+		}
+	})
+
+	t.Run("Rejects Unknown Format", func(t *testing.T) {
+		if _, err := hasher.Verify("$unknown$hash", "correct horse"); err == nil {
+			t.Fatal("expected an error for an unknown hash format")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package util_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mandacode.com/accounts/auth/internal/util"
+)
+
+// testTotpSecret is the base32 encoding of the RFC 6238 SHA-1 test secret,
+// "12345678901234567890".
+const testTotpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTotp(t *testing.T) {
+	t.Run("RFC 6238 Vectors", func(t *testing.T) {
+		// The RFC lists 8 digit codes; ours are their last 6 digits
+		vectors := []struct {
+			unix int64
+			code string
+		}{
+			{59, "287082"},
+			{1111111109, "081804"},
+			{1111111111, "050471"},
+			{1234567890, "005924"},
+			{2000000000, "279037"},
+			{20000000000, "353130"},
+		}
+		for _, v := range vectors {
+			now := time.Unix(v.unix, 0)
+			step, ok := util.ValidateTotp(testTotpSecret, v.code, now)
+			if !ok {
+				t.Errorf("expected %s to be valid at %d", v.code, v.unix)
+				continue
+			}
+			if step != util.TotpStep(now) {
+				t.Errorf("expected step %d at %d, got %d", util.TotpStep(now), v.unix, step)
+			}
+		}
+	})
+
+	t.Run("Accepts Lowercase Secret", func(t *testing.T) {
+		if _, ok := util.ValidateTotp(strings.ToLower(testTotpSecret), "287082", time.Unix(59, 0)); !ok {
+			t.Fatal("expected a lowercase secret to be accepted")
+		}
+	})
+
+	t.Run("Allows One Step Of Skew", func(t *testing.T) {
+		issued := time.Unix(1111111109, 0)
+		for _, now := range []time.Time{issued.Add(-30 * time.Second), issued.Add(30 * time.Second)} {
+			if _, ok := util.ValidateTotp(testTotpSecret, "081804", now); !ok {
+				t.Errorf("expected the code to be valid at %d", now.Unix())
+			}
+		}
+		for _, now := range []time.Time{issued.Add(-60 * time.Second), issued.Add(60 * time.Second)} {
+			if _, ok := util.ValidateTotp(testTotpSecret, "081804", now); ok {
+				t.Errorf("expected the code to be expired at %d", now.Unix())
+			}
+		}
+	})
+
+	t.Run("Reports Step Of Code For Replay Check", func(t *testing.T) {
+		// A code replayed in the next step matches the step it was used in,
+		// which the credential rejects as not after its last used step
+		issued := time.Unix(1111111109, 0)
+		used, ok := util.ValidateTotp(testTotpSecret, "081804", issued)
+		if !ok {
+			t.Fatal("expected the code to be valid")
+		}
+		replayed, ok := util.ValidateTotp(testTotpSecret, "081804", issued.Add(30*time.Second))
+		if !ok {
+			t.Fatal("expected the code to still match within the skew")
+		}
+		if replayed > used {
+			t.Fatalf("expected the replayed code to match step %d, got %d", used, replayed)
+		}
+	})
+
+	t.Run("Rejects Malformed Codes", func(t *testing.T) {
+		now := time.Unix(59, 0)
+		for _, code := range []string{"", "28708", "2870820", "94287082", "abcdef"} {
+			if _, ok := util.ValidateTotp(testTotpSecret, code, now); ok {
+				t.Errorf("expected %q to be rejected", code)
+			}
+		}
+		if _, ok := util.ValidateTotp("not base32!", "287082", now); ok {
+			t.Error("expected an invalid secret to be rejected")
+		}
+	})
+}
@@ -2,9 +2,10 @@ package mailhandler
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-playground/validator/v10"
-	mailerv1 "github.com/mandacode-com/accounts-proto/mailer/v1"
+	mailerv1 "github.com/mandacode-com/accounts-proto/go/mailer/v1"
 	kafka "github.com/segmentio/kafka-go"
 	"google.golang.org/protobuf/proto"
 	kafkaserver "mandacode.com/accounts/mailer/cmd/server/kafka"
@@ -16,16 +17,113 @@ type MailHandler struct {
 	validator *validator.Validate
 }
 
+// eventTypeHeader is the Kafka header carrying the type of a mail event.
+const eventTypeHeader = "event_type"
+
+const (
+	eventTypeEmailVerification   = "email_verification"
+	eventTypePasswordReset       = "password_reset"
+	eventTypeEmailChange         = "email_change"
+	eventTypeEmailChangeNotice   = "email_change_notice"
+	eventTypeSecurityNotice      = "security_notice"
+	eventTypeMagicLink           = "magic_link"
+	eventTypeEmailOTP            = "email_otp"
+	eventTypeNewSignIn           = "new_sign_in"
+	eventTypePasswordChanged     = "password_changed"
+	eventTypeEmailChanged        = "email_changed"
+	eventTypeProviderLinkChanged = "provider_link_changed"
+	eventTypeMFAChanged          = "mfa_changed"
+)
+
+// eventType returns the mail event type of the message.
+// Messages without the header are treated as email verification events.
+func eventType(m kafka.Message) string {
+	for _, header := range m.Headers {
+		if header.Key == eventTypeHeader {
+			return string(header.Value)
+		}
+	}
+	return eventTypeEmailVerification
+}
+
 // HandleMessage implements kafkaserver.KafkaHandler.
 func (h *MailHandler) HandleMessage(ctx context.Context, m kafka.Message) error {
-	event := &mailerv1.EmailVerificationEvent{}
-	if err := proto.Unmarshal(m.Value, event); err != nil {
-		return err
-	}
-	if err := h.MailApp.SendEmailVerificationMail(event.Email, event.VerificationLink); err != nil {
-		return err
+	switch eventType(m) {
+	case eventTypeEmailVerification:
+		event := &mailerv1.EmailVerificationEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendEmailVerificationMail(event.Email, event.VerificationLink)
+	case eventTypePasswordReset:
+		event := &mailerv1.PasswordResetEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendPasswordResetMail(event.Email, event.ResetLink)
+	case eventTypeEmailChange:
+		event := &mailerv1.EmailChangeEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendEmailChangeVerificationMail(event.Email, event.VerificationLink)
+	case eventTypeEmailChangeNotice:
+		event := &mailerv1.EmailChangeNoticeEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendEmailChangeNoticeMail(event.Email, event.NewEmail)
+	case eventTypeSecurityNotice:
+		event := &mailerv1.SecurityNoticeEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendSecurityNoticeMail(event.Email, event.NoticeType)
+	case eventTypeMagicLink:
+		event := &mailerv1.MagicLinkEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendMagicLinkMail(event.Email, event.LoginLink)
+	case eventTypeEmailOTP:
+		event := &mailerv1.EmailOTPEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendEmailOTPMail(event.Email, event.Code, event.Purpose)
+	case eventTypeNewSignIn:
+		event := &mailerv1.NewSignInEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendNewSignInMail(event.Email, event.DeviceName, event.UserAgent, event.IpAddress, event.EventTime.AsTime(), event.RevokeSessionLink)
+	case eventTypePasswordChanged:
+		event := &mailerv1.PasswordChangedEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendPasswordChangedMail(event.Email, event.Reset, event.RevokeSessionLink)
+	case eventTypeEmailChanged:
+		event := &mailerv1.EmailChangedEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendEmailChangedMail(event.Email, event.NewEmail, event.RevokeSessionLink)
+	case eventTypeProviderLinkChanged:
+		event := &mailerv1.ProviderLinkChangedEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendProviderLinkChangedMail(event.Email, event.Provider, event.Linked, event.RevokeSessionLink)
+	case eventTypeMFAChanged:
+		event := &mailerv1.MFAChangedEvent{}
+		if err := proto.Unmarshal(m.Value, event); err != nil {
+			return err
+		}
+		return h.MailApp.SendMFAChangedMail(event.Email, event.Change, event.RevokeSessionLink)
+	default:
+		return errors.New("unsupported mail event type: " + eventType(m))
 	}
-	return nil
 }
 
 func NewMailHandler(mail *mail.MailUsecase, validator *validator.Validate) kafkaserver.KafkaHandler {
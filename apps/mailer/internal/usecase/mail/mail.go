@@ -2,36 +2,36 @@ package mail
 
 import (
 	"bytes"
+	"errors"
 	"html/template"
 	"os"
 	"path/filepath"
+	"time"
 
 	"go.uber.org/zap"
 	"gopkg.in/gomail.v2"
 )
 
 type MailUsecase struct {
-	dialer              *gomail.Dialer
-	verifyEmailTemplate *template.Template
-	logger              *zap.Logger
-	username            string
-	sender              string
+	dialer                    *gomail.Dialer
+	verifyEmailTemplate       *template.Template
+	passwordResetTemplate     *template.Template
+	emailChangeTemplate       *template.Template
+	emailChangeNoticeTemplate *template.Template
+	securityNoticeTemplate    *template.Template
+	magicLinkTemplate         *template.Template
+	emailOTPTemplate          *template.Template
+	newSignInTemplate         *template.Template
+	accountChangeTemplate     *template.Template
+	logger                    *zap.Logger
+	username                  string
+	sender                    string
 }
 
-// SendEmailVerificationMail sends an email verification mail to the user.
-//
-// Parameters:
-//   - email: The email address of the user to send the verification mail to.
-//   - verificationLink: The link to be included in the email for verification.
-func (m *MailUsecase) SendEmailVerificationMail(email string, link string) error {
-	data := struct {
-		Link string
-	}{
-		Link: link,
-	}
-
+// send renders the template with data and sends the result to email.
+func (m *MailUsecase) send(email string, subject string, tmpl *template.Template, data any) error {
 	var body bytes.Buffer
-	if err := m.verifyEmailTemplate.Execute(&body, data); err != nil {
+	if err := tmpl.Execute(&body, data); err != nil {
 		m.logger.Error("failed to execute email template", zap.Error(err), zap.String("to", email))
 		return err
 	}
@@ -39,7 +39,7 @@ func (m *MailUsecase) SendEmailVerificationMail(email string, link string) error
 	msg := gomail.NewMessage()
 	msg.SetHeader("From", msg.FormatAddress(m.username, m.sender))
 	msg.SetHeader("To", email)
-	msg.SetHeader("Subject", "[Mandacode] Email Verification")
+	msg.SetHeader("Subject", subject)
 	msg.SetBody("text/html", body.String())
 
 	if err := m.dialer.DialAndSend(msg); err != nil {
@@ -51,22 +51,363 @@ func (m *MailUsecase) SendEmailVerificationMail(email string, link string) error
 	return nil
 }
 
+// SendEmailVerificationMail sends an email verification mail to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the verification mail to.
+//   - verificationLink: The link to be included in the email for verification.
+func (m *MailUsecase) SendEmailVerificationMail(email string, link string) error {
+	data := struct {
+		Link string
+	}{
+		Link: link,
+	}
+	return m.send(email, "[Mandacode] Email Verification", m.verifyEmailTemplate, data)
+}
+
+// SendPasswordResetMail sends a password reset mail to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the reset mail to.
+//   - link: The link to be included in the email for resetting the password.
+func (m *MailUsecase) SendPasswordResetMail(email string, link string) error {
+	data := struct {
+		Link string
+	}{
+		Link: link,
+	}
+	return m.send(email, "[Mandacode] Password Reset", m.passwordResetTemplate, data)
+}
+
+// SendEmailChangeVerificationMail sends a confirmation mail to the new address of an email change.
+//
+// Parameters:
+//   - email: The new email address to confirm.
+//   - link: The link to be included in the email for confirming the change.
+func (m *MailUsecase) SendEmailChangeVerificationMail(email string, link string) error {
+	data := struct {
+		Link string
+	}{
+		Link: link,
+	}
+	return m.send(email, "[Mandacode] Confirm Your New Email", m.emailChangeTemplate, data)
+}
+
+// SendEmailChangeNoticeMail notifies the current address that an email change was requested.
+//
+// Parameters:
+//   - email: The current email address of the user.
+//   - newEmail: The email address the user asked to change to.
+func (m *MailUsecase) SendEmailChangeNoticeMail(email string, newEmail string) error {
+	data := struct {
+		NewEmail string
+	}{
+		NewEmail: newEmail,
+	}
+	return m.send(email, "[Mandacode] Email Change Requested", m.emailChangeNoticeTemplate, data)
+}
+
+// securityNotice is the text of a security notice mail.
+type securityNotice struct {
+	Subject string
+	Title   string
+	Message string
+}
+
+// securityNotices maps the notice types sent by the auth service to their text.
+var securityNotices = map[string]securityNotice{
+	"recovery_code_used": {
+		Subject: "[Mandacode] Recovery Code Used",
+		Title:   "Recovery Code Used",
+		Message: "A recovery code was used to sign in to your account in place of your second factor.",
+	},
+	"recovery_codes_generated": {
+		Subject: "[Mandacode] New Recovery Codes",
+		Title:   "New Recovery Codes Generated",
+		Message: "A new set of recovery codes was generated for your account. Your previous recovery codes no longer work.",
+	},
+	"mfa_factor_renamed": {
+		Subject: "[Mandacode] Sign-in Method Renamed",
+		Title:   "Sign-in Method Renamed",
+		Message: "One of the two-step verification methods of your account was renamed.",
+	},
+	"mfa_factor_removed": {
+		Subject: "[Mandacode] Sign-in Method Removed",
+		Title:   "Sign-in Method Removed",
+		Message: "One of the two-step verification methods of your account was removed.",
+	},
+}
+
+// SendSecurityNoticeMail notifies the user of a sensitive change to their account.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - noticeType: The kind of change, as sent by the auth service.
+func (m *MailUsecase) SendSecurityNoticeMail(email string, noticeType string) error {
+	notice, ok := securityNotices[noticeType]
+	if !ok {
+		return errors.New("unsupported security notice type: " + noticeType)
+	}
+	return m.send(email, notice.Subject, m.securityNoticeTemplate, notice)
+}
+
+// SendMagicLinkMail sends a passwordless login link to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the login link to.
+//   - link: The link to be included in the email for logging in.
+func (m *MailUsecase) SendMagicLinkMail(email string, link string) error {
+	data := struct {
+		Link string
+	}{
+		Link: link,
+	}
+	return m.send(email, "[Mandacode] Your Login Link", m.magicLinkTemplate, data)
+}
+
+// emailOTP is the text of a one-time passcode mail.
+type emailOTP struct {
+	Subject string
+	Title   string
+	Message string
+	Code    string
+}
+
+// emailOTPs maps the passcode purposes sent by the auth service to their text.
+var emailOTPs = map[string]emailOTP{
+	"email_verification": {
+		Subject: "[Mandacode] Your Verification Code",
+		Title:   "Verify Your Email",
+		Message: "Enter the code below to verify the email address of your account.",
+	},
+	"login": {
+		Subject: "[Mandacode] Your Login Code",
+		Title:   "Log In to Your Account",
+		Message: "Enter the code below to log in to your account.",
+	},
+}
+
+// SendEmailOTPMail sends a one-time passcode to the user.
+//
+// Parameters:
+//   - email: The email address of the user to send the code to.
+//   - code: The one-time passcode.
+//   - purpose: What the code is for, as sent by the auth service.
+func (m *MailUsecase) SendEmailOTPMail(email string, code string, purpose string) error {
+	otp, ok := emailOTPs[purpose]
+	if !ok {
+		return errors.New("unsupported passcode purpose: " + purpose)
+	}
+	otp.Code = code
+	return m.send(email, otp.Subject, m.emailOTPTemplate, otp)
+}
+
+// SendNewSignInMail warns the user of a sign-in from a device or location
+// their account was not used from before.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - deviceName: The device name sent by the client, empty if none.
+//   - userAgent: The user agent of the client.
+//   - ipAddress: The IP address of the client.
+//   - signedInAt: The time of the sign-in.
+//   - revokeLink: The link to the page revoking sessions.
+func (m *MailUsecase) SendNewSignInMail(email string, deviceName string, userAgent string, ipAddress string, signedInAt time.Time, revokeLink string) error {
+	data := struct {
+		DeviceName string
+		UserAgent  string
+		IPAddress  string
+		Time       string
+		RevokeLink string
+	}{
+		DeviceName: deviceName,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		Time:       signedInAt.UTC().Format("January 2, 2006 15:04 MST"),
+		RevokeLink: revokeLink,
+	}
+	return m.send(email, "[Mandacode] New Sign-in to Your Account", m.newSignInTemplate, data)
+}
+
+// accountChange is the text of a mail about a change to the account.
+type accountChange struct {
+	Subject    string
+	Title      string
+	Message    string
+	RevokeLink string
+}
+
+// SendPasswordChangedMail notifies the user that their password was changed.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - reset: Whether the password was reset through a reset link.
+//   - revokeLink: The link to the page revoking sessions.
+func (m *MailUsecase) SendPasswordChangedMail(email string, reset bool, revokeLink string) error {
+	change := accountChange{
+		Subject:    "[Mandacode] Password Changed",
+		Title:      "Password Changed",
+		Message:    "The password of your account was changed.",
+		RevokeLink: revokeLink,
+	}
+	if reset {
+		change.Subject = "[Mandacode] Password Reset"
+		change.Title = "Password Reset"
+		change.Message = "The password of your account was reset with a reset link. Your sessions were signed out."
+	}
+	return m.send(email, change.Subject, m.accountChangeTemplate, change)
+}
+
+// SendEmailChangedMail notifies the previous address that the email of the account was changed.
+//
+// Parameters:
+//   - email: The previous email address of the user.
+//   - newEmail: The email address the account was changed to.
+//   - revokeLink: The link to the page revoking sessions.
+func (m *MailUsecase) SendEmailChangedMail(email string, newEmail string, revokeLink string) error {
+	change := accountChange{
+		Subject:    "[Mandacode] Email Changed",
+		Title:      "Email Changed",
+		Message:    "The email of your account was changed to " + newEmail + ". This address will no longer receive mail about your account.",
+		RevokeLink: revokeLink,
+	}
+	return m.send(email, change.Subject, m.accountChangeTemplate, change)
+}
+
+// SendProviderLinkChangedMail notifies the user that a sign-in provider was linked to or unlinked from their account.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - provider: The name of the provider.
+//   - linked: Whether the provider was linked, or unlinked otherwise.
+//   - revokeLink: The link to the page revoking sessions.
+func (m *MailUsecase) SendProviderLinkChangedMail(email string, provider string, linked bool, revokeLink string) error {
+	change := accountChange{
+		Subject:    "[Mandacode] Sign-in Provider Linked",
+		Title:      "Sign-in Provider Linked",
+		Message:    "Your " + provider + " account was linked to your account and can now be used to sign in.",
+		RevokeLink: revokeLink,
+	}
+	if !linked {
+		change.Subject = "[Mandacode] Sign-in Provider Unlinked"
+		change.Title = "Sign-in Provider Unlinked"
+		change.Message = "Your " + provider + " account was unlinked from your account and can no longer be used to sign in."
+	}
+	return m.send(email, change.Subject, m.accountChangeTemplate, change)
+}
+
+// mfaChanges maps the MFA changes sent by the auth service to their text.
+var mfaChanges = map[string]accountChange{
+	"totp_enabled": {
+		Subject: "[Mandacode] Authenticator App Added",
+		Title:   "Authenticator App Added",
+		Message: "An authenticator app was added to the two-step verification methods of your account.",
+	},
+	"passkey_added": {
+		Subject: "[Mandacode] Passkey Added",
+		Title:   "Passkey Added",
+		Message: "A passkey was added to your account and can now be used to sign in.",
+	},
+	"factor_renamed": {
+		Subject: "[Mandacode] Sign-in Method Renamed",
+		Title:   "Sign-in Method Renamed",
+		Message: "One of the two-step verification methods of your account was renamed.",
+	},
+	"factor_removed": {
+		Subject: "[Mandacode] Sign-in Method Removed",
+		Title:   "Sign-in Method Removed",
+		Message: "One of the two-step verification methods of your account was removed.",
+	},
+	"recovery_codes_generated": {
+		Subject: "[Mandacode] New Recovery Codes",
+		Title:   "New Recovery Codes Generated",
+		Message: "A new set of recovery codes was generated for your account. Your previous recovery codes no longer work.",
+	},
+}
+
+// SendMFAChangedMail notifies the user of a change to their two-step verification methods.
+//
+// Parameters:
+//   - email: The email address of the user.
+//   - change: The kind of change, as sent by the auth service.
+//   - revokeLink: The link to the page revoking sessions.
+func (m *MailUsecase) SendMFAChangedMail(email string, change string, revokeLink string) error {
+	mfaChange, ok := mfaChanges[change]
+	if !ok {
+		return errors.New("unsupported MFA change: " + change)
+	}
+	mfaChange.RevokeLink = revokeLink
+	return m.send(email, mfaChange.Subject, m.accountChangeTemplate, mfaChange)
+}
+
 // NewMailApp creates a new instance of MailApp with the provided SMTP configuration.
 func NewMailApp(host string, port int, username, password, sender string, logger *zap.Logger) (*MailUsecase, error) {
 	dialer := gomail.NewDialer(host, port, username, password)
 	cwd, err := os.Getwd()
-	tmplPath := filepath.Join(cwd, "internal", "template", "verify_email.html")
-	tmpl, err := template.ParseFiles(tmplPath)
+	if err != nil {
+		logger.Error("failed to get working directory", zap.Error(err))
+		return nil, err
+	}
+	tmplDir := filepath.Join(cwd, "internal", "template")
+	verifyEmailTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "verify_email.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	passwordResetTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "reset_password.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	emailChangeTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "change_email.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	emailChangeNoticeTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "change_email_notice.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	securityNoticeTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "security_notice.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	magicLinkTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "magic_link.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	emailOTPTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "email_otp.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	newSignInTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "new_sign_in.html"))
+	if err != nil {
+		logger.Error("failed to parse email template", zap.Error(err))
+		return nil, err
+	}
+	accountChangeTmpl, err := template.ParseFiles(filepath.Join(tmplDir, "account_change.html"))
 	if err != nil {
 		logger.Error("failed to parse email template", zap.Error(err))
 		return nil, err
 	}
 
 	return &MailUsecase{
-		dialer:              dialer,
-		verifyEmailTemplate: tmpl,
-		logger:              logger,
-		username:            username,
-		sender:              sender,
+		dialer:                    dialer,
+		verifyEmailTemplate:       verifyEmailTmpl,
+		passwordResetTemplate:     passwordResetTmpl,
+		emailChangeTemplate:       emailChangeTmpl,
+		emailChangeNoticeTemplate: emailChangeNoticeTmpl,
+		securityNoticeTemplate:    securityNoticeTmpl,
+		magicLinkTemplate:         magicLinkTmpl,
+		emailOTPTemplate:          emailOTPTmpl,
+		newSignInTemplate:         newSignInTmpl,
+		accountChangeTemplate:     accountChangeTmpl,
+		logger:                    logger,
+		username:                  username,
+		sender:                    sender,
 	}, nil
 }
@@ -5,7 +5,7 @@ import (
 	"net"
 	"strconv"
 
-	tokenv1 "github.com/mandacode-com/accounts-proto/token/v1"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
 	"github.com/mandacode-com/golib/server"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
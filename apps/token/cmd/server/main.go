@@ -7,11 +7,13 @@ import (
 	"os/signal"
 
 	"github.com/mandacode-com/golib/server"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	grpcserver "mandacode.com/accounts/token/cmd/server/grpc"
 	"mandacode.com/accounts/token/config"
 	handlerv1 "mandacode.com/accounts/token/internal/handler/v1"
 	tokengen "mandacode.com/accounts/token/internal/infra/token"
+	revocationrepo "mandacode.com/accounts/token/internal/repository/revocation"
 	token "mandacode.com/accounts/token/internal/usecase/token"
 )
 
@@ -50,10 +52,19 @@ func main() {
 		logger.Fatal("failed to create email verification token generator", zap.Error(err))
 	}
 
+	// Revocations are written by the auth service and checked on refresh token verification
+	revocationStore := redis.NewClient(&redis.Options{
+		Addr:     cfg.RevocationStore.Address,
+		Password: cfg.RevocationStore.Password,
+		DB:       cfg.RevocationStore.DB,
+	})
+	revocationRepo := revocationrepo.NewRevocationRepository(revocationStore, cfg.RevocationStore.Prefix)
+
 	tokenUsecase := token.NewTokenUsecase(
 		accesTokenGen,
 		refreshTokenGen,
 		emailVerificationTokenGen,
+		revocationRepo,
 	)
 
 	tokenHandler, err := handlerv1.NewTokenHandler(tokenUsecase, logger)
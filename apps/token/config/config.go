@@ -17,6 +17,14 @@ type Config struct {
 	RefreshTokenDuration           time.Duration
 	EmailVerificationPrivateKey    string
 	EmailVerificationTokenDuration time.Duration
+	RevocationStore                RedisStoreConfig
+}
+
+type RedisStoreConfig struct {
+	Address  string
+	Password string
+	DB       int
+	Prefix   string
 }
 
 // LoadConfig loads env vars from .env (if exists) and returns structured config
@@ -39,6 +47,13 @@ func LoadConfig() (*Config, error) {
 	}
 
 	port, err := strconv.Atoi(getEnv("PORT", "50051"))
+	if err != nil {
+		return nil, err
+	}
+	revocationStoreDB, err := strconv.Atoi(getEnv("REVOCATION_STORE_DB", "0"))
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
 		Env:                            getEnv("ENV", "local"),
@@ -49,6 +64,12 @@ func LoadConfig() (*Config, error) {
 		RefreshTokenDuration:           refreshTokenDuration,
 		EmailVerificationPrivateKey:    getEnv("EMAIL_VERIFICATION_PRIVATE_KEY", ""),
 		EmailVerificationTokenDuration: emailVerificationTokenDuration,
+		RevocationStore: RedisStoreConfig{
+			Address:  getEnv("REVOCATION_STORE_ADDRESS", ""),
+			Password: getEnv("REVOCATION_STORE_PASSWORD", ""),
+			DB:       revocationStoreDB,
+			Prefix:   getEnv("REVOCATION_STORE_PREFIX", "revoked:"),
+		},
 	}, nil
 }
 
@@ -3,7 +3,7 @@ package handlerv1
 import (
 	"context"
 
-	tokenv1 "github.com/mandacode-com/accounts-proto/token/v1"
+	tokenv1 "github.com/mandacode-com/accounts-proto/go/token/v1"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
 	"go.uber.org/zap"
@@ -88,7 +88,11 @@ func (h *TokenHandler) GenerateRefreshToken(ctx context.Context, req *tokenv1.Ge
 		return nil, util.NewGRPCError(err)
 	}
 
-	token, expiresAt, err := h.token.GenerateRefreshToken(req.UserId)
+	familyID := ""
+	if req.FamilyId != nil {
+		familyID = *req.FamilyId
+	}
+	token, tokenID, familyID, expiresAt, err := h.token.GenerateRefreshToken(ctx, req.UserId, familyID)
 	if err != nil {
 		h.logError(err)
 		return nil, util.NewGRPCError(err)
@@ -97,6 +101,8 @@ func (h *TokenHandler) GenerateRefreshToken(ctx context.Context, req *tokenv1.Ge
 	return &tokenv1.GenerateRefreshTokenResponse{
 		Token:     token,
 		ExpiresAt: expiresAt,
+		TokenId:   tokenID,
+		FamilyId:  familyID,
 	}, nil
 }
 
@@ -107,16 +113,21 @@ func (h *TokenHandler) VerifyRefreshToken(ctx context.Context, req *tokenv1.Veri
 		return nil, util.NewGRPCError(err)
 	}
 
-	userId, err := h.token.VerifyRefreshToken(req.Token)
+	claims, err := h.token.VerifyRefreshToken(ctx, req.Token)
 	if err != nil {
 		h.logError(err)
 		return nil, util.NewGRPCError(err)
 	}
 
-	return &tokenv1.VerifyRefreshTokenResponse{
+	resp := &tokenv1.VerifyRefreshTokenResponse{
 		Valid:  true,
-		UserId: userId,
-	}, nil
+		UserId: &claims.UserID,
+	}
+	if claims.FamilyID != "" {
+		resp.TokenId = &claims.TokenID
+		resp.FamilyId = &claims.FamilyID
+	}
+	return resp, nil
 }
 
 func (h *TokenHandler) GenerateEmailVerificationToken(ctx context.Context, req *tokenv1.GenerateEmailVerificationTokenRequest) (*tokenv1.GenerateEmailVerificationTokenResponse, error) {
@@ -126,7 +137,7 @@ func (h *TokenHandler) GenerateEmailVerificationToken(ctx context.Context, req *
 		return nil, util.NewGRPCError(err)
 	}
 
-	token, expiresAt, err := h.token.GenerateEmailVerificationToken(req.UserId, req.Email, req.Code)
+	token, expiresAt, err := h.token.GenerateEmailVerificationToken(req.UserId, req.Email, req.Code, req.Purpose)
 	if err != nil {
 		h.logError(err)
 		return nil, util.NewGRPCError(err)
@@ -145,7 +156,7 @@ func (h *TokenHandler) VerifyEmailVerificationToken(ctx context.Context, req *to
 		return nil, util.NewGRPCError(err)
 	}
 
-	userID, email, code, err := h.token.VerifyEmailVerificationToken(req.Token)
+	userID, email, code, err := h.token.VerifyEmailVerificationToken(req.Token, req.Purpose)
 	if err != nil {
 		h.logError(err)
 		return nil, util.NewGRPCError(err)
@@ -2,6 +2,7 @@ package tokengen
 
 import (
 	"crypto/rsa"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -115,8 +116,12 @@ func (j *TokenGenerator) VerifyToken(
 	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
 		result := make(map[string]string)
 		for key, value := range claims {
-			if strValue, ok := value.(string); ok {
-				result[key] = strValue
+			switch v := value.(type) {
+			case string:
+				result[key] = v
+			case float64:
+				// Numeric claims such as "iat" and "exp" are kept as integer strings
+				result[key] = strconv.FormatInt(int64(v), 10)
 			}
 		}
 		return result, nil
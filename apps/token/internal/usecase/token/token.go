@@ -1,15 +1,21 @@
 package token
 
 import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
 	"github.com/mandacode-com/golib/errors"
 	"github.com/mandacode-com/golib/errors/errcode"
 	tokengen "mandacode.com/accounts/token/internal/infra/token"
+	revocationrepo "mandacode.com/accounts/token/internal/repository/revocation"
 )
 
 type TokenUsecase struct {
 	accessTokenGenerator            *tokengen.TokenGenerator
 	refreshTokenGenerator           *tokengen.TokenGenerator
 	emailVerificationTokenGenerator *tokengen.TokenGenerator
+	revocation                      *revocationrepo.RevocationRepository
 }
 
 // GenerateAccessToken generates an access token for a user.
@@ -34,34 +40,63 @@ func (t *TokenUsecase) GenerateAccessToken(userID string) (string, int64, error)
 //   - userID: The unique identifier of the user for whom the email verification token is generated.
 //   - email: The email address to be verified.
 //   - code: The verification code to be included in the token.
+//   - purpose: The flow the token is issued for, kept in the "aud" claim.
 //
 // Returns:
 //   - string: The generated JWT email verification token.
 //   - int64: The expiration time of the token in seconds since epoch.
 //   - error: An error if the token generation fails.
-func (t *TokenUsecase) GenerateEmailVerificationToken(userID string, email string, code string) (string, int64, error) {
+func (t *TokenUsecase) GenerateEmailVerificationToken(userID string, email string, code string, purpose string) (string, int64, error) {
 	claims := map[string]string{
 		"sub":   userID,
 		"email": email,
 		"code":  code,
 	}
+	if purpose != "" {
+		claims["aud"] = purpose
+	}
 	return t.emailVerificationTokenGenerator.GenerateToken(claims)
 }
 
 // GenerateRefreshToken generates a refresh token for a user.
 //
+// Every refresh token has its own ID ("jti") and belongs to a family ("fam")
+// started at login and kept across rotations, so that a whole family can be
+// revoked when a rotated token is reused. Its issue time is also kept in
+// milliseconds ("iat_ms"), read from the clock revocations are stamped with,
+// to compare it against revocations made in the same second.
+//
 // Parameters:
+//   - ctx: The context for the operation.
 //   - userID: The unique identifier of the user for whom the refresh token is generated.
+//   - familyID: The family of the rotated token, or empty to start a new family.
 //
 // Returns:
 //   - string: The generated JWT refresh token.
+//   - string: The ID of the token.
+//   - string: The family ID of the token.
 //   - int64: The expiration time of the token in seconds since epoch.
 //   - error: An error if the token generation fails.
-func (t *TokenUsecase) GenerateRefreshToken(userID string) (string, int64, error) {
+func (t *TokenUsecase) GenerateRefreshToken(ctx context.Context, userID string, familyID string) (string, string, string, int64, error) {
+	issuedAt, err := t.revocation.Now(ctx)
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	if familyID == "" {
+		familyID = uuid.NewString()
+	}
+	tokenID := uuid.NewString()
 	claims := map[string]string{
-		"sub": userID, // Use "sub" claim for user ID
+		"sub":    userID, // Use "sub" claim for user ID
+		"jti":    tokenID,
+		"fam":    familyID,
+		"iat_ms": strconv.FormatInt(issuedAt, 10),
+	}
+	token, expiresAt, err := t.refreshTokenGenerator.GenerateToken(claims)
+	if err != nil {
+		return "", "", "", 0, err
 	}
-	return t.refreshTokenGenerator.GenerateToken(claims)
+	return token, tokenID, familyID, expiresAt, nil
 }
 
 // VerifyAccessToken verifies the provided access token and returns the user ID if valid.
@@ -90,19 +125,25 @@ func (t *TokenUsecase) VerifyAccessToken(token string) (*string, error) {
 // VerifyEmailVerificationToken verifies the provided email verification token and returns the user ID, email, and code if valid.
 // Parameters:
 //   - token: The JWT email verification token to be verified.
+//   - purpose: The flow the token must have been issued for.
 //
 // Returns:
 //   - *string: The user ID extracted from the token claims if verification is successful.
 //   - *string: The email extracted from the token claims if verification is successful.
 //   - *string: The verification code extracted from the token claims if verification is successful.
 //   - error: An error if the token verification fails or if any required claims are missing.
-func (t *TokenUsecase) VerifyEmailVerificationToken(token string) (*string, *string, *string, error) {
+func (t *TokenUsecase) VerifyEmailVerificationToken(token string, purpose string) (*string, *string, *string, error) {
 	claims, err := t.emailVerificationTokenGenerator.VerifyToken(token)
 	if err != nil {
 		joinedErr := errors.Join(err, "failed to verify email verification token")
 		return nil, nil, nil, errors.Upgrade(joinedErr, errcode.ErrInvalidToken, "Token Verification Error")
 	}
 
+	// A token issued for one flow (e.g. a magic link) must not be accepted by another (e.g. a password reset)
+	if claims["aud"] != purpose {
+		return nil, nil, nil, errors.New("email verification token was issued for another purpose", "Token Verification Error", errcode.ErrInvalidToken)
+	}
+
 	userID, ok := claims["sub"]
 	if !ok {
 		return nil, nil, nil, errors.New("email verification token does not contain user ID claim", "Token Verification Error", errcode.ErrInvalidToken)
@@ -121,15 +162,23 @@ func (t *TokenUsecase) VerifyEmailVerificationToken(token string) (*string, *str
 	return &userID, &email, &code, nil
 }
 
-// VerifyRefreshToken verifies the provided refresh token and returns the user ID if valid.
+// RefreshTokenClaims identify a verified refresh token.
+type RefreshTokenClaims struct {
+	UserID   string
+	TokenID  string // Empty for tokens issued before rotation
+	FamilyID string // Empty for tokens issued before rotation
+}
+
+// VerifyRefreshToken verifies the provided refresh token and returns its claims if valid.
 //
 // Parameters:
+//   - ctx: The context for the operation.
 //   - token: The JWT refresh token to be verified.
 //
 // Returns:
-//   - *string: The user ID extracted from the token claims if verification is successful.
+//   - *RefreshTokenClaims: The claims of the token if verification is successful.
 //   - error: An error if the token verification fails or if the user ID claim is missing.
-func (t *TokenUsecase) VerifyRefreshToken(token string) (*string, error) {
+func (t *TokenUsecase) VerifyRefreshToken(ctx context.Context, token string) (*RefreshTokenClaims, error) {
 	claims, err := t.refreshTokenGenerator.VerifyToken(token)
 	if err != nil {
 		joinedErr := errors.Join(err, "failed to verify refresh token")
@@ -141,7 +190,62 @@ func (t *TokenUsecase) VerifyRefreshToken(token string) (*string, error) {
 		return nil, errors.New("refresh token does not contain user ID claim", "Token Verification Error", errcode.ErrInvalidToken)
 	}
 
-	return &userID, nil
+	issuedAt, err := refreshTokenIssuedAt(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject tokens revoked by the auth service (password reset, logout, ...)
+	revoked, err := t.revocation.IsRevoked(ctx, userID, issuedAt)
+	if err != nil {
+		return nil, errors.Join(err, "failed to check refresh token revocation")
+	}
+	if revoked {
+		return nil, errors.New("refresh token has been revoked", "Token Verification Error", errcode.ErrInvalidToken)
+	}
+	revoked, err = t.revocation.IsTokenRevoked(ctx, token)
+	if err != nil {
+		return nil, errors.Join(err, "failed to check refresh token revocation")
+	}
+	if revoked {
+		return nil, errors.New("refresh token has been revoked", "Token Verification Error", errcode.ErrInvalidToken)
+	}
+
+	familyID := claims["fam"]
+	if familyID != "" {
+		revoked, err = t.revocation.IsFamilyRevoked(ctx, familyID)
+		if err != nil {
+			return nil, errors.Join(err, "failed to check refresh token revocation")
+		}
+		if revoked {
+			return nil, errors.New("refresh token family has been revoked", "Token Verification Error", errcode.ErrInvalidToken)
+		}
+	}
+
+	return &RefreshTokenClaims{
+		UserID:   userID,
+		TokenID:  claims["jti"],
+		FamilyID: familyID,
+	}, nil
+}
+
+// refreshTokenIssuedAt returns the issue time of a refresh token in
+// milliseconds since epoch. Tokens issued before "iat_ms" was added fall back
+// to their "iat" claim.
+func refreshTokenIssuedAt(claims map[string]string) (int64, error) {
+	if value, ok := claims["iat_ms"]; ok {
+		issuedAt, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, errors.New("refresh token does not contain a valid issued at claim", "Token Verification Error", errcode.ErrInvalidToken)
+		}
+		return issuedAt, nil
+	}
+
+	issuedAt, err := strconv.ParseInt(claims["iat"], 10, 64)
+	if err != nil {
+		return 0, errors.New("refresh token does not contain a valid issued at claim", "Token Verification Error", errcode.ErrInvalidToken)
+	}
+	return issuedAt * 1000, nil
 }
 
 // NewTokenUsecase creates a new instance of tokenUsecase with the provided TokenGenerators.
@@ -149,10 +253,12 @@ func NewTokenUsecase(
 	accessTokenGenerator *tokengen.TokenGenerator,
 	refreshTokenGenerator *tokengen.TokenGenerator,
 	emailVerificationTokenGenerator *tokengen.TokenGenerator,
+	revocation *revocationrepo.RevocationRepository,
 ) *TokenUsecase {
 	return &TokenUsecase{
 		accessTokenGenerator:            accessTokenGenerator,
 		refreshTokenGenerator:           refreshTokenGenerator,
 		emailVerificationTokenGenerator: emailVerificationTokenGenerator,
+		revocation:                      revocation,
 	}
 }